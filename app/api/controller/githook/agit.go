@@ -0,0 +1,206 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// gitReferenceNamePrefixAGit is the prefix of references used to create or update pull requests
+	// without pushing a branch (AGit flow): git push origin HEAD:refs/for/<target-branch> -o topic=<topic>.
+	gitReferenceNamePrefixAGit = "refs/for/"
+)
+
+func isAGitRef(ref string) bool {
+	return strings.HasPrefix(ref, gitReferenceNamePrefixAGit)
+}
+
+// checkAGitRefUpdates verifies pushes to refs/for/<target-branch> references.
+// Such references are never kept in the repository, post-receive converts them into pull requests.
+func (c *Controller) checkAGitRefUpdates(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+	opts pushOptions,
+	output *hook.Output,
+) error {
+	for _, refUpdate := range in.RefUpdates {
+		if !isAGitRef(refUpdate.Ref) {
+			continue
+		}
+
+		if refUpdate.New.IsNil() {
+			output.Error = ptr.String(fmt.Sprintf("Reference %q can't be deleted", refUpdate.Ref))
+			return nil
+		}
+
+		targetBranch := refUpdate.Ref[len(gitReferenceNamePrefixAGit):]
+		if targetBranch == "" {
+			output.Error = ptr.String("Target branch is missing, push to refs/for/<target-branch>")
+			return nil
+		}
+
+		if opts.Topic == "" {
+			output.Error = ptr.String(fmt.Sprintf(
+				"Pushing to %q requires a topic, use 'git push -o %s=<topic>'", refUpdate.Ref, pushOptionAGitTopic))
+			return nil
+		}
+
+		_, err := rgit.GetBranch(ctx, &git.GetBranchParams{
+			ReadParams: git.ReadParams{
+				RepoUID:             repo.GitUID,
+				AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+			},
+			BranchName: targetBranch,
+		})
+		if errors.IsNotFound(err) {
+			output.Error = ptr.String(fmt.Sprintf("Target branch %q doesn't exist", targetBranch))
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get target branch %q: %w", targetBranch, err)
+		}
+	}
+
+	return nil
+}
+
+// handleAGitPush creates or updates pull requests for all pushes to refs/for/<target-branch>
+// and removes the pushed references afterwards - best effort.
+func (c *Controller) handleAGitPush(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+	opts pushOptions,
+	out *hook.Output,
+) {
+	var session *auth.Session
+	for _, refUpdate := range in.RefUpdates {
+		if !isAGitRef(refUpdate.Ref) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		if session == nil {
+			principal, err := c.principalStore.Find(ctx, principalID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to find principal for AGit push")
+				return
+			}
+
+			session = &auth.Session{Principal: *principal, Metadata: nil}
+		}
+
+		c.handleAGitRefUpdate(ctx, session, repo, refUpdate, opts, out)
+	}
+}
+
+func (c *Controller) handleAGitRefUpdate(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	refUpdate hook.ReferenceUpdate,
+	opts pushOptions,
+	out *hook.Output,
+) {
+	defer c.deleteAGitRef(ctx, session, repo, refUpdate)
+
+	title := opts.Title
+	if title == "" {
+		title = opts.PRTitle
+	}
+
+	agitOut, err := c.pullreqCtrl.AGitPush(ctx, session, repo.Path, &pullreq.AGitPushInput{
+		TargetBranch: refUpdate.Ref[len(gitReferenceNamePrefixAGit):],
+		Topic:        opts.Topic,
+		SHA:          refUpdate.New,
+		Title:        title,
+		Description:  opts.Description,
+		IsDraft:      opts.PRDraft,
+		SkipCI:       opts.CISkip,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("ref", refUpdate.Ref).
+			Msg("failed to create or update pull request for AGit push")
+
+		out.Messages = append(out.Messages, fmt.Sprintf("Failed to process push to %q: %s",
+			refUpdate.Ref, usererror.Translate(ctx, err).Message))
+		return
+	}
+
+	pr := agitOut.PullReq
+	switch {
+	case agitOut.Created:
+		out.Messages = append(out.Messages, fmt.Sprintf("Created a pull request for topic %q:", opts.Topic))
+	case agitOut.Updated:
+		out.Messages = append(out.Messages, fmt.Sprintf("Updated the pull request for topic %q:", opts.Topic))
+	default:
+		out.Messages = append(out.Messages, fmt.Sprintf("The pull request for topic %q is up to date:", opts.Topic))
+	}
+
+	out.Messages = append(out.Messages,
+		fmt.Sprintf("  (#%d) %s", pr.Number, pr.Title),
+		"    "+c.urlProvider.GenerateUIPRURL(ctx, repo.Path, pr.Number),
+	)
+
+	if opts.AutoMerge {
+		c.enableAutoMerge(ctx, session, repo, pr, opts.AutoMergeMethod, out)
+	}
+}
+
+// deleteAGitRef removes the pushed refs/for/<target-branch> reference,
+// the commit is kept in the pull request's head reference.
+func (c *Controller) deleteAGitRef(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	refUpdate hook.ReferenceUpdate,
+) {
+	writeParams, err := controller.CreateRPCSystemReferencesWriteParams(ctx, c.urlProvider, session, repo.Core())
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to create RPC write params for AGit reference removal")
+		return
+	}
+
+	err = c.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        refUpdate.Ref,
+		Type:        gitenum.RefTypeRaw,
+		NewValue:    sha.Nil,
+		OldValue:    refUpdate.New,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("ref", refUpdate.Ref).
+			Msg("failed to delete AGit reference")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	agitTestSHA = sha.Must("1111111111111111111111111111111111111111")
+	agitTestOld = sha.Must("2222222222222222222222222222222222222222")
+)
+
+type agitRestrictedGit struct {
+	RestrictedGIT
+	branches map[string]bool
+}
+
+func (g agitRestrictedGit) GetBranch(_ context.Context, params *git.GetBranchParams) (*git.GetBranchOutput, error) {
+	if !g.branches[params.BranchName] {
+		return nil, errors.NotFoundf("branch %q not found", params.BranchName)
+	}
+	return &git.GetBranchOutput{Branch: git.Branch{Name: params.BranchName, SHA: agitTestOld}}, nil
+}
+
+func TestCheckAGitRefUpdates(t *testing.T) {
+	tests := []struct {
+		name      string
+		refUpdate hook.ReferenceUpdate
+		opts      pushOptions
+		wantErr   string
+	}{
+		{
+			name:      "branch push is ignored",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/heads/feature", Old: sha.Nil, New: agitTestSHA},
+		},
+		{
+			name:      "push to existing target branch",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/main", Old: sha.Nil, New: agitTestSHA},
+			opts:      pushOptions{Topic: "feature"},
+		},
+		{
+			name:      "target branch with slashes",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/release/1.0", Old: sha.Nil, New: agitTestSHA},
+			opts:      pushOptions{Topic: "feature"},
+		},
+		{
+			name:      "deletion",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/main", Old: agitTestOld, New: sha.Nil},
+			opts:      pushOptions{Topic: "feature"},
+			wantErr:   `Reference "refs/for/main" can't be deleted`,
+		},
+		{
+			name:      "missing target branch",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/", Old: sha.Nil, New: agitTestSHA},
+			opts:      pushOptions{Topic: "feature"},
+			wantErr:   "Target branch is missing, push to refs/for/<target-branch>",
+		},
+		{
+			name:      "missing topic",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/main", Old: sha.Nil, New: agitTestSHA},
+			wantErr:   `Pushing to "refs/for/main" requires a topic, use 'git push -o topic=<topic>'`,
+		},
+		{
+			name:      "unknown target branch",
+			refUpdate: hook.ReferenceUpdate{Ref: "refs/for/develop", Old: sha.Nil, New: agitTestSHA},
+			opts:      pushOptions{Topic: "feature"},
+			wantErr:   `Target branch "develop" doesn't exist`,
+		},
+	}
+
+	rgit := agitRestrictedGit{branches: map[string]bool{"main": true, "release/1.0": true}}
+	repo := &types.RepositoryCore{ID: 1, GitUID: "repo-uid"}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := &Controller{}
+			in := types.GithookPreReceiveInput{
+				PreReceiveInput: hook.PreReceiveInput{RefUpdates: []hook.ReferenceUpdate{test.refUpdate}},
+			}
+			output := hook.Output{}
+
+			err := c.checkAGitRefUpdates(context.Background(), rgit, repo, in, test.opts, &output)
+			require.NoError(t, err)
+
+			if test.wantErr == "" {
+				assert.Nil(t, output.Error)
+				return
+			}
+
+			require.NotNil(t, output.Error)
+			assert.Equal(t, test.wantErr, *output.Error)
+		})
+	}
+}
+
+type agitPrincipalStore struct {
+	store.PrincipalStore
+	principals map[int64]*types.Principal
+	calls      int
+}
+
+func (s *agitPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	s.calls++
+	if p, ok := s.principals[id]; ok {
+		return p, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type agitGit struct {
+	git.Interface
+	updates []git.UpdateRefParams
+}
+
+func (g *agitGit) UpdateRef(_ context.Context, params git.UpdateRefParams) error {
+	g.updates = append(g.updates, params)
+	return nil
+}
+
+type agitURLProvider struct {
+	url.Provider
+}
+
+func (agitURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000"
+}
+
+func TestHandleAGitPush(t *testing.T) {
+	const principalID = 7
+
+	tests := []struct {
+		name           string
+		principalID    int64
+		refUpdates     []hook.ReferenceUpdate
+		opts           pushOptions
+		wantLookups    int
+		wantMessages   []string
+		wantDeletedRef string
+	}{
+		{
+			name:        "branch pushes and deletions are ignored",
+			principalID: principalID,
+			refUpdates: []hook.ReferenceUpdate{
+				{Ref: "refs/heads/feature", Old: sha.Nil, New: agitTestSHA},
+				{Ref: "refs/for/main", Old: agitTestOld, New: sha.Nil},
+			},
+			opts: pushOptions{Topic: "feature"},
+		},
+		{
+			name:        "unknown principal",
+			principalID: principalID + 1,
+			refUpdates:  []hook.ReferenceUpdate{{Ref: "refs/for/main", Old: sha.Nil, New: agitTestSHA}},
+			opts:        pushOptions{Topic: "feature"},
+			wantLookups: 1,
+		},
+		{
+			name:        "failed pull request update is reported and the reference removed",
+			principalID: principalID,
+			refUpdates:  []hook.ReferenceUpdate{{Ref: "refs/for/main", Old: sha.Nil, New: agitTestSHA}},
+			wantLookups: 1,
+			wantMessages: []string{
+				`Failed to process push to "refs/for/main": Topic is required.`,
+			},
+			wantDeletedRef: "refs/for/main",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			principalStore := &agitPrincipalStore{principals: map[int64]*types.Principal{
+				principalID: {ID: principalID, UID: "user", DisplayName: "User", Email: "user@example.com"},
+			}}
+			gitFake := &agitGit{}
+
+			c := &Controller{
+				principalStore: principalStore,
+				urlProvider:    agitURLProvider{},
+				git:            gitFake,
+				pullreqCtrl:    &pullreq.Controller{},
+			}
+
+			repo := &types.Repository{ID: 1, GitUID: "repo-uid", Path: "space/repo"}
+			out := hook.Output{}
+
+			c.handleAGitPush(context.Background(), repo, test.principalID,
+				hook.PostReceiveInput{RefUpdates: test.refUpdates}, test.opts, &out)
+
+			assert.Equal(t, test.wantLookups, principalStore.calls, "the principal is looked up once")
+			assert.Equal(t, test.wantMessages, out.Messages)
+
+			if test.wantDeletedRef == "" {
+				assert.Empty(t, gitFake.updates)
+				return
+			}
+
+			require.Len(t, gitFake.updates, 1)
+			assert.Equal(t, test.wantDeletedRef, gitFake.updates[0].Name)
+			assert.Equal(t, sha.Nil, gitFake.updates[0].NewValue)
+			assert.Equal(t, agitTestSHA, gitFake.updates[0].OldValue)
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
@@ -62,6 +63,8 @@ type Controller struct {
 	lfsStore            store.LFSObjectStore
 	auditService        audit.Service
 	userGroupService    usergroup.Service
+	git                 git.Interface
+	pullreqCtrl         *pullreq.Controller
 }
 
 func NewController(
@@ -83,6 +86,8 @@ func NewController(
 	lfsStore store.LFSObjectStore,
 	auditService audit.Service,
 	userGroupService usergroup.Service,
+	git git.Interface,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	return &Controller{
 		authorizer:          authorizer,
@@ -103,6 +108,8 @@ func NewController(
 		lfsStore:            lfsStore,
 		auditService:        auditService,
 		userGroupService:    userGroupService,
+		git:                 git,
+		pullreqCtrl:         pullreqCtrl,
 	}
 }
 
@@ -78,6 +78,12 @@ func (c *Controller) PostReceive(
 		logOutputFor(ctx, "post-receive", out)
 	}()
 
+	// push options are verified in pre-receive, ignore them in case they are invalid.
+	opts, err := parsePushOptions(in.PushOptions)
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to parse push options")
+	}
+
 	// update default branch based on ref update info on empty repos.
 	// as the branch could be different than the configured default value.
 	c.handleEmptyRepoPush(ctx, repo, in.PostReceiveInput, &out)
@@ -88,11 +94,20 @@ func (c *Controller) PostReceive(
 	// report ref events if repo is in an active state - best effort
 	forcePushStatus := make(refForcePushMap)
 	if repo.State == enum.RepoStateActive {
-		forcePushStatus = c.reportReferenceEvents(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput)
+		forcePushStatus = c.reportReferenceEvents(ctx, rgit, repo, in.PrincipalID, in.PostReceiveInput, opts.CISkip)
 	}
 
 	// handle branch updates related to PRs - best effort
-	c.handlePRMessaging(ctx, rgit, repo, in.PostReceiveInput, &out)
+	if opts.wantsPullReq() && repo.State == enum.RepoStateActive {
+		c.handlePushOptionsPullReq(ctx, repo, in.PrincipalID, in.PostReceiveInput, opts, &out)
+	} else {
+		c.handlePRMessaging(ctx, rgit, repo, in.PostReceiveInput, &out)
+	}
+
+	// create or update PRs for pushes to refs/for/<target-branch> - best effort
+	if repo.State == enum.RepoStateActive {
+		c.handleAGitPush(ctx, repo, in.PrincipalID, in.PostReceiveInput, opts, &out)
+	}
 
 	err = c.postReceiveExtender.Extend(ctx, rgit, session, repo.Core(), in, &out)
 	if err != nil {
@@ -120,17 +135,18 @@ func (c *Controller) reportReferenceEvents(
 	repo *types.Repository,
 	principalID int64,
 	in hook.PostReceiveInput,
+	skipCI bool,
 ) refForcePushMap {
 	forcePushStatus := make(refForcePushMap)
 
 	for _, refUpdate := range in.RefUpdates {
 		switch {
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch):
-			if forced := c.reportBranchEvent(ctx, rgit, repo, principalID, in.Environment, refUpdate); forced {
+			if forced := c.reportBranchEvent(ctx, rgit, repo, principalID, in.Environment, refUpdate, skipCI); forced {
 				forcePushStatus[refUpdate.Ref] = struct{}{}
 			}
 		case strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixTag):
-			c.reportTagEvent(ctx, repo, principalID, refUpdate)
+			c.reportTagEvent(ctx, repo, principalID, refUpdate, skipCI)
 		default:
 			// Ignore any other references in post-receive
 		}
@@ -146,6 +162,7 @@ func (c *Controller) reportBranchEvent(
 	principalID int64,
 	env hook.Environment,
 	branchUpdate hook.ReferenceUpdate,
+	skipCI bool,
 ) bool {
 	var forced bool
 
@@ -156,6 +173,7 @@ func (c *Controller) reportBranchEvent(
 			PrincipalID: principalID,
 			Ref:         branchUpdate.Ref,
 			SHA:         branchUpdate.New.String(),
+			SkipCI:      skipCI,
 		}
 
 		c.gitReporter.BranchCreated(ctx, payload)
@@ -195,6 +213,7 @@ func (c *Controller) reportBranchEvent(
 			OldSHA:      branchUpdate.Old.String(),
 			NewSHA:      branchUpdate.New.String(),
 			Forced:      forced,
+			SkipCI:      skipCI,
 		}
 
 		c.gitReporter.BranchUpdated(ctx, payload)
@@ -210,6 +229,7 @@ func (c *Controller) reportTagEvent(
 	repo *types.Repository,
 	principalID int64,
 	tagUpdate hook.ReferenceUpdate,
+	skipCI bool,
 ) {
 	switch {
 	case tagUpdate.Old.IsNil():
@@ -218,6 +238,7 @@ func (c *Controller) reportTagEvent(
 			PrincipalID: principalID,
 			Ref:         tagUpdate.Ref,
 			SHA:         tagUpdate.New.String(),
+			SkipCI:      skipCI,
 		}
 
 		c.gitReporter.TagCreated(ctx, payload)
@@ -245,6 +266,7 @@ func (c *Controller) reportTagEvent(
 			NewSHA:      tagUpdate.New.String(),
 			// tags can only be force updated!
 			Forced: true,
+			SkipCI: skipCI,
 		}
 
 		c.gitReporter.TagUpdated(ctx, payload)
@@ -94,6 +94,25 @@ func (c *Controller) PreReceive(
 		return output, nil
 	}
 
+	// For external calls (git pushes) verify the push options
+	// and pushes to refs/for/<target-branch> used for creating pull requests.
+	if !in.Internal {
+		opts, err := c.checkPushOptions(ctx, rgit, repo, in, &output)
+		if err != nil {
+			return hook.Output{}, fmt.Errorf("failed to check push options: %w", err)
+		}
+		if output.Error != nil {
+			return output, nil
+		}
+
+		if err := c.checkAGitRefUpdates(ctx, rgit, repo, in, opts, &output); err != nil {
+			return hook.Output{}, fmt.Errorf("failed to check AGit reference updates: %w", err)
+		}
+		if output.Error != nil {
+			return output, nil
+		}
+	}
+
 	err = c.preReceiveExtender.Extend(ctx, rgit, session, repo, in, &output)
 	if err != nil {
 		return hook.Output{}, fmt.Errorf("failed to extend pre-receive hook: %w", err)
@@ -0,0 +1,349 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// Supported push options, provided with 'git push -o <option>'.
+const (
+	pushOptionCISkip    = "ci.skip"
+	pushOptionPRCreate  = "pr.create"
+	pushOptionPRTarget  = "pr.target"
+	pushOptionPRTitle   = "pr.title"
+	pushOptionPRDraft   = "pr.draft"
+	pushOptionAutoMerge = "automerge"
+
+	pushOptionAGitTopic       = "topic"
+	pushOptionAGitTitle       = "title"
+	pushOptionAGitDescription = "description"
+)
+
+// pushOptions holds the parsed push options.
+type pushOptions struct {
+	// CISkip suppresses pipeline triggers for the pushed references.
+	CISkip bool
+
+	// PRCreate requests creation of a pull request for the pushed branch.
+	PRCreate bool
+	// PRTarget is the target branch of the pull request, the default branch is used if empty.
+	PRTarget string
+	// PRTitle is the title of the pull request, the title of the pushed commit is used if empty.
+	PRTitle string
+	// PRDraft marks the created pull request as draft.
+	PRDraft bool
+
+	// AutoMerge enables auto merge of the pull request using the AutoMergeMethod.
+	AutoMerge       bool
+	AutoMergeMethod enum.MergeMethod
+
+	// Topic, Title and Description are used for pushes to refs/for/<target-branch> (AGit flow).
+	Topic       string
+	Title       string
+	Description string
+}
+
+// parsePushOptions parses the push options provided as "key" or "key=value".
+// Boolean options are enabled when provided without a value.
+func parsePushOptions(options []string) (pushOptions, error) {
+	var opts pushOptions
+	var err error
+
+	for _, option := range options {
+		key, value, hasValue := strings.Cut(option, "=")
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case pushOptionCISkip:
+			opts.CISkip, err = parsePushOptionBool(key, value, hasValue)
+		case pushOptionPRCreate:
+			opts.PRCreate, err = parsePushOptionBool(key, value, hasValue)
+		case pushOptionPRTarget:
+			opts.PRTarget = value
+		case pushOptionPRTitle:
+			opts.PRTitle = value
+		case pushOptionPRDraft:
+			opts.PRDraft, err = parsePushOptionBool(key, value, hasValue)
+		case pushOptionAutoMerge:
+			opts.AutoMerge, opts.AutoMergeMethod, err = parsePushOptionAutoMerge(value, hasValue)
+		case pushOptionAGitTopic:
+			opts.Topic = value
+		case pushOptionAGitTitle:
+			opts.Title = value
+		case pushOptionAGitDescription:
+			opts.Description = value
+		default:
+			// Ignore unknown push options, they might be used by the extenders.
+		}
+		if err != nil {
+			return pushOptions{}, err
+		}
+	}
+
+	return opts, nil
+}
+
+// wantsPullReq returns true if the push options request creation or an update of a pull request.
+func (o pushOptions) wantsPullReq() bool {
+	return o.PRCreate || o.AutoMerge
+}
+
+func parsePushOptionBool(key, value string, hasValue bool) (bool, error) {
+	if !hasValue || value == "" {
+		return true, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid value %q of push option %q, expected a boolean", value, key)
+	}
+
+	return b, nil
+}
+
+// parsePushOptionAutoMerge parses the automerge push option.
+// It's either a boolean or the merge method to use, the default method is merge.
+func parsePushOptionAutoMerge(value string, hasValue bool) (bool, enum.MergeMethod, error) {
+	if !hasValue || value == "" {
+		return true, enum.MergeMethodMerge, nil
+	}
+
+	if method, ok := enum.MergeMethod(value).Sanitize(); ok {
+		return true, method, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, "", fmt.Errorf(
+			"invalid value %q of push option %q, expected a boolean or one of the merge methods: %v",
+			value, pushOptionAutoMerge, enum.MergeMethods)
+	}
+
+	return b, enum.MergeMethodMerge, nil
+}
+
+// checkPushOptions verifies the push options of an external push.
+func (c *Controller) checkPushOptions(
+	ctx context.Context,
+	rgit RestrictedGIT,
+	repo *types.RepositoryCore,
+	in types.GithookPreReceiveInput,
+	output *hook.Output,
+) (pushOptions, error) {
+	opts, err := parsePushOptions(in.PushOptions)
+	if err != nil {
+		output.Error = ptr.String(err.Error())
+		return pushOptions{}, nil
+	}
+
+	if !opts.wantsPullReq() || opts.PRTarget == "" {
+		return opts, nil
+	}
+
+	_, err = rgit.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.ReadParams{
+			RepoUID:             repo.GitUID,
+			AlternateObjectDirs: in.Environment.AlternateObjectDirs,
+		},
+		BranchName: opts.PRTarget,
+	})
+	if errors.IsNotFound(err) {
+		output.Error = ptr.String(fmt.Sprintf("Pull request target branch %q doesn't exist", opts.PRTarget))
+		return pushOptions{}, nil
+	}
+	if err != nil {
+		return pushOptions{}, fmt.Errorf("failed to get pull request target branch %q: %w", opts.PRTarget, err)
+	}
+
+	return opts, nil
+}
+
+// handlePushOptionsPullReq creates pull requests and enables auto merge
+// for the pushed branches as requested with the push options - best effort.
+func (c *Controller) handlePushOptionsPullReq(
+	ctx context.Context,
+	repo *types.Repository,
+	principalID int64,
+	in hook.PostReceiveInput,
+	opts pushOptions,
+	out *hook.Output,
+) {
+	targetBranch := opts.PRTarget
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	var session *auth.Session
+	for _, refUpdate := range in.RefUpdates {
+		if !strings.HasPrefix(refUpdate.Ref, gitReferenceNamePrefixBranch) || refUpdate.New.IsNil() {
+			continue
+		}
+
+		branchName := refUpdate.Ref[len(gitReferenceNamePrefixBranch):]
+		if branchName == targetBranch {
+			continue
+		}
+
+		if session == nil {
+			principal, err := c.principalStore.Find(ctx, principalID)
+			if err != nil {
+				log.Ctx(ctx).Warn().Err(err).Msg("failed to find principal for push options")
+				return
+			}
+
+			session = &auth.Session{Principal: *principal, Metadata: nil}
+		}
+
+		c.handlePushOptionsPullReqForBranch(ctx, session, repo, branchName, targetBranch, refUpdate, opts, out)
+	}
+}
+
+func (c *Controller) handlePushOptionsPullReqForBranch(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	branchName string,
+	targetBranch string,
+	refUpdate hook.ReferenceUpdate,
+	opts pushOptions,
+	out *hook.Output,
+) {
+	prs, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+		SourceRepoID:       repo.ID,
+		SourceBranch:       branchName,
+		TargetRepoID:       repo.ID,
+		TargetBranch:       targetBranch,
+		States:             []enum.PullReqState{enum.PullReqStateOpen},
+		Flow:               enum.PullReqFlowGithub,
+		Size:               1,
+		ExcludeDescription: true,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Str("branch", branchName).
+			Msg("failed to find open pull requests for push options")
+		return
+	}
+
+	var pr *types.PullReq
+	switch {
+	case len(prs) > 0:
+		pr = prs[0]
+		out.Messages = append(out.Messages, fmt.Sprintf("Branch %q has an open PR:", branchName))
+	case opts.PRCreate:
+		pr, err = c.createPullReqFromPush(ctx, session, repo, branchName, targetBranch, refUpdate, opts)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).Str("branch", branchName).
+				Msg("failed to create pull request requested with push options")
+
+			out.Messages = append(out.Messages, fmt.Sprintf("Failed to create a pull request for branch %q: %s",
+				branchName, usererror.Translate(ctx, err).Message))
+			return
+		}
+		out.Messages = append(out.Messages, fmt.Sprintf("Created a pull request for branch %q:", branchName))
+	default:
+		out.Messages = append(out.Messages, fmt.Sprintf(
+			"Branch %q has no open pull request to %q, use 'git push -o %s' to create one",
+			branchName, targetBranch, pushOptionPRCreate))
+		return
+	}
+
+	out.Messages = append(out.Messages,
+		fmt.Sprintf("  (#%d) %s", pr.Number, pr.Title),
+		"    "+c.urlProvider.GenerateUIPRURL(ctx, repo.Path, pr.Number),
+	)
+
+	if opts.AutoMerge {
+		c.enableAutoMerge(ctx, session, repo, pr, opts.AutoMergeMethod, out)
+	}
+}
+
+func (c *Controller) createPullReqFromPush(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	branchName string,
+	targetBranch string,
+	refUpdate hook.ReferenceUpdate,
+	opts pushOptions,
+) (*types.PullReq, error) {
+	title := opts.PRTitle
+	if title == "" {
+		commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+			ReadParams: git.CreateReadParams(repo),
+			Revision:   refUpdate.New.String(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pushed commit: %w", err)
+		}
+
+		title = commit.Commit.Title
+	}
+
+	return c.pullreqCtrl.Create(ctx, session, repo.Path, &pullreq.CreateInput{
+		IsDraft:      opts.PRDraft,
+		Title:        title,
+		SourceBranch: branchName,
+		TargetBranch: targetBranch,
+		SkipCI:       opts.CISkip,
+	})
+}
+
+// enableAutoMerge enables auto merge for the pull request, or merges it right away if possible.
+func (c *Controller) enableAutoMerge(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.Repository,
+	pr *types.PullReq,
+	method enum.MergeMethod,
+	out *hook.Output,
+) {
+	resp, err := c.pullreqCtrl.AutoMergeEnable(ctx, session, repo.Path, pr.Number, &pullreq.AutoMergeEnableInput{
+		Method: method,
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Int64("pullreq_number", pr.Number).
+			Msg("failed to enable auto merge requested with push options")
+
+		out.Messages = append(out.Messages, fmt.Sprintf("Failed to enable auto merge for pull request #%d: %s",
+			pr.Number, usererror.Translate(ctx, err).Message))
+		return
+	}
+
+	if resp.MergeResponse != nil {
+		out.Messages = append(out.Messages, fmt.Sprintf("Pull request #%d has been merged.", pr.Number))
+		return
+	}
+
+	out.Messages = append(out.Messages, fmt.Sprintf(
+		"Auto merge enabled for pull request #%d, it will be merged using %q once all requirements are met.",
+		pr.Number, method))
+}
@@ -0,0 +1,136 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package githook
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:funlen // table test
+func TestParsePushOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []string
+		want    pushOptions
+		wantErr bool
+	}{
+		{
+			name: "none",
+			want: pushOptions{},
+		},
+		{
+			name:    "ci skip without value",
+			options: []string{"ci.skip"},
+			want:    pushOptions{CISkip: true},
+		},
+		{
+			name:    "ci skip with boolean value",
+			options: []string{"ci.skip=false"},
+			want:    pushOptions{CISkip: false},
+		},
+		{
+			name:    "ci skip with invalid value",
+			options: []string{"ci.skip=sometimes"},
+			wantErr: true,
+		},
+		{
+			name:    "pull request with target, title and draft",
+			options: []string{"pr.create", "pr.target=release/1.0", "pr.title= Fix the build ", "pr.draft"},
+			want: pushOptions{
+				PRCreate: true,
+				PRTarget: "release/1.0",
+				PRTitle:  "Fix the build",
+				PRDraft:  true,
+			},
+		},
+		{
+			name:    "title containing the separator",
+			options: []string{"pr.title=a=b"},
+			want:    pushOptions{PRTitle: "a=b"},
+		},
+		{
+			name:    "empty target and title",
+			options: []string{"pr.target=", "pr.title"},
+			want:    pushOptions{},
+		},
+		{
+			name:    "pull request creation with auto merge",
+			options: []string{"pr.create", "automerge"},
+			want: pushOptions{
+				PRCreate:        true,
+				AutoMerge:       true,
+				AutoMergeMethod: enum.MergeMethodMerge,
+			},
+		},
+		{
+			name:    "auto merge with merge method",
+			options: []string{"pr.create=true", "automerge=squash"},
+			want: pushOptions{
+				PRCreate:        true,
+				AutoMerge:       true,
+				AutoMergeMethod: enum.MergeMethodSquash,
+			},
+		},
+		{
+			name:    "auto merge disabled",
+			options: []string{"automerge=false"},
+			want:    pushOptions{AutoMerge: false, AutoMergeMethod: enum.MergeMethodMerge},
+		},
+		{
+			name:    "auto merge with invalid value",
+			options: []string{"automerge=octopus"},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate options, the last one wins",
+			options: []string{"pr.target=main", "pr.target=develop", "ci.skip", "ci.skip=false"},
+			want:    pushOptions{PRTarget: "develop"},
+		},
+		{
+			name:    "unknown options are ignored",
+			options: []string{"merge_request.create", "custom=value", "ci.skip"},
+			want:    pushOptions{CISkip: true},
+		},
+		{
+			name:    "agit options",
+			options: []string{"topic=feature", "title=Feature", "description=Adds a feature"},
+			want:    pushOptions{Topic: "feature", Title: "Feature", Description: "Adds a feature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parsePushOptions(test.options)
+			if test.wantErr {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestPushOptions_WantsPullReq(t *testing.T) {
+	assert.False(t, pushOptions{CISkip: true, PRTarget: "main"}.wantsPullReq())
+	assert.True(t, pushOptions{PRCreate: true}.wantsPullReq())
+	assert.True(t, pushOptions{AutoMerge: true}.wantsPullReq())
+}
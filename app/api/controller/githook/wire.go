@@ -16,6 +16,7 @@ package githook
 
 import (
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/auth/authz"
 	eventsgit "github.com/harness/gitness/app/events/git"
 	eventsrepo "github.com/harness/gitness/app/events/repo"
@@ -67,6 +68,7 @@ func ProvideController(
 	lfsStore store.LFSObjectStore,
 	auditService audit.Service,
 	userGroupService usergroup.Service,
+	pullreqCtrl *pullreq.Controller,
 ) *Controller {
 	ctrl := NewController(
 		authorizer,
@@ -87,6 +89,8 @@ func ProvideController(
 		lfsStore,
 		auditService,
 		userGroupService,
+		git,
+		pullreqCtrl,
 	)
 
 	// TODO: improve wiring if possible
@@ -0,0 +1,337 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+// maxChannelPatterns is the maximum number of repository or branch patterns of a notification channel.
+const maxChannelPatterns = 50
+
+// ListChannels lists the notification channels of the space.
+func (c *Controller) ListChannels(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) ([]*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	channels, err := c.channelStore.List(ctx, space.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	return channels, nil
+}
+
+// FindChannel finds a notification channel of the space.
+func (c *Controller) FindChannel(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	channel, err := c.channelStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// CreateChannel creates a new notification channel for the space.
+func (c *Controller) CreateChannel(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.NotificationChannelCreateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckEditAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	if err = c.sanitizeChannelCreateInput(in); err != nil {
+		return nil, err
+	}
+
+	encryptedURL, err := c.encrypter.Encrypt(in.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	channel := &types.NotificationChannel{
+		SpaceID:        space.ID,
+		Identifier:     in.Identifier,
+		DisplayName:    in.DisplayName,
+		Type:           in.Type,
+		URL:            string(encryptedURL),
+		Enabled:        in.Enabled,
+		Events:         in.Events,
+		RepoPatterns:   in.RepoPatterns,
+		BranchPatterns: in.BranchPatterns,
+		CreatedBy:      session.Principal.ID,
+		Created:        now,
+		Updated:        now,
+	}
+
+	if err = c.channelStore.Create(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// UpdateChannel updates a notification channel of the space.
+func (c *Controller) UpdateChannel(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+	in *types.NotificationChannelUpdateInput,
+) (*types.NotificationChannel, error) {
+	space, err := c.getSpaceCheckEditAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	if err = c.sanitizeChannelUpdateInput(in); err != nil {
+		return nil, err
+	}
+
+	channel, err := c.channelStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	if in.Identifier != nil {
+		channel.Identifier = *in.Identifier
+	}
+	if in.DisplayName != nil {
+		channel.DisplayName = *in.DisplayName
+	}
+	if in.Type != nil {
+		channel.Type = *in.Type
+	}
+	if in.URL != nil {
+		encryptedURL, err := c.encrypter.Encrypt(*in.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt notification channel url: %w", err)
+		}
+		channel.URL = string(encryptedURL)
+	}
+	if in.Enabled != nil {
+		channel.Enabled = *in.Enabled
+	}
+	if in.Events != nil {
+		channel.Events = in.Events
+	}
+	if in.RepoPatterns != nil {
+		channel.RepoPatterns = in.RepoPatterns
+	}
+	if in.BranchPatterns != nil {
+		channel.BranchPatterns = in.BranchPatterns
+	}
+
+	channel.Updated = time.Now().UnixMilli()
+
+	if err = c.channelStore.Update(ctx, channel); err != nil {
+		return nil, fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	return channel, nil
+}
+
+// DeleteChannel deletes a notification channel of the space.
+func (c *Controller) DeleteChannel(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	identifier string,
+) error {
+	space, err := c.getSpaceCheckEditAccess(ctx, session, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	channel, err := c.channelStore.FindByIdentifier(ctx, space.ID, identifier)
+	if err != nil {
+		return fmt.Errorf("failed to find notification channel: %w", err)
+	}
+
+	if err = c.channelStore.Delete(ctx, channel.ID); err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	return nil
+}
+
+func (c *Controller) sanitizeChannelCreateInput(in *types.NotificationChannelCreateInput) error {
+	if err := check.Identifier(in.Identifier); err != nil {
+		return err
+	}
+
+	if in.DisplayName == "" {
+		in.DisplayName = in.Identifier
+	}
+	if err := check.DisplayName(in.DisplayName); err != nil {
+		return err
+	}
+
+	channelType, ok := in.Type.Sanitize()
+	if !ok {
+		return errors.InvalidArgumentf("Notification channel type %q is not supported.", in.Type)
+	}
+	in.Type = channelType
+
+	if err := webhook.CheckURL(in.URL, c.webhookConfig.AllowLoopback, c.webhookConfig.AllowPrivateNetwork,
+		false); err != nil {
+		return err
+	}
+
+	var err error
+	if in.Events, err = sanitizeChannelEvents(in.Events); err != nil {
+		return err
+	}
+	if in.RepoPatterns, err = sanitizeChannelPatterns(in.RepoPatterns); err != nil {
+		return err
+	}
+	if in.BranchPatterns, err = sanitizeChannelPatterns(in.BranchPatterns); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Controller) sanitizeChannelUpdateInput(in *types.NotificationChannelUpdateInput) error {
+	if in.Identifier != nil {
+		if err := check.Identifier(*in.Identifier); err != nil {
+			return err
+		}
+	}
+
+	if in.DisplayName != nil {
+		if err := check.DisplayName(*in.DisplayName); err != nil {
+			return err
+		}
+	}
+
+	if in.Type != nil {
+		channelType, ok := in.Type.Sanitize()
+		if !ok {
+			return errors.InvalidArgumentf("Notification channel type %q is not supported.", *in.Type)
+		}
+		in.Type = &channelType
+	}
+
+	if in.URL != nil {
+		if err := webhook.CheckURL(*in.URL, c.webhookConfig.AllowLoopback, c.webhookConfig.AllowPrivateNetwork,
+			false); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if in.Events != nil {
+		if in.Events, err = sanitizeChannelEvents(in.Events); err != nil {
+			return err
+		}
+	}
+	if in.RepoPatterns != nil {
+		if in.RepoPatterns, err = sanitizeChannelPatterns(in.RepoPatterns); err != nil {
+			return err
+		}
+	}
+	if in.BranchPatterns != nil {
+		if in.BranchPatterns, err = sanitizeChannelPatterns(in.BranchPatterns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sanitizeChannelEvents validates the events of a notification channel and removes duplicates.
+func sanitizeChannelEvents(events []enum.NotificationEvent) ([]enum.NotificationEvent, error) {
+	seen := make(map[enum.NotificationEvent]bool, len(events))
+	result := make([]enum.NotificationEvent, 0, len(events))
+	for _, event := range events {
+		sanitized, ok := event.Sanitize()
+		if !ok {
+			return nil, errors.InvalidArgumentf("Notification event %q is not supported.", event)
+		}
+
+		if !sanitized.SupportsChannels() {
+			return nil, errors.InvalidArgumentf("Notification event %q can't be posted to notification channels.",
+				event)
+		}
+
+		if seen[sanitized] {
+			continue
+		}
+		seen[sanitized] = true
+
+		result = append(result, sanitized)
+	}
+
+	return result, nil
+}
+
+// sanitizeChannelPatterns validates the repository or branch patterns of a notification channel
+// and removes empty patterns.
+func sanitizeChannelPatterns(patterns []string) ([]string, error) {
+	if len(patterns) > maxChannelPatterns {
+		return nil, errors.InvalidArgumentf("A notification channel can have at most %d patterns.",
+			maxChannelPatterns)
+	}
+
+	result := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		if !doublestar.ValidatePattern(pattern) {
+			return nil, errors.InvalidArgumentf("Pattern %q is not a valid glob pattern.", pattern)
+		}
+
+		result = append(result, pattern)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/controller/space"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	notificationsvc "github.com/harness/gitness/app/services/notification"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type Controller struct {
+	tx                dbtx.Transactor
+	authorizer        authz.Authorizer
+	spaceFinder       refcache.SpaceFinder
+	repoFinder        refcache.RepoFinder
+	pullreqStore      store.PullReqStore
+	preferenceStore   store.NotificationPreferenceStore
+	subscriptionStore store.NotificationSubscriptionStore
+	notificationStore store.NotificationStore
+	channelStore      store.NotificationChannelStore
+	templateStore     store.NotificationTemplateStore
+	sseStreamer       sse.Streamer
+	encrypter         encrypt.Encrypter
+	webhookConfig     webhook.Config
+	templateRenderer  *notificationsvc.TemplateRenderer
+}
+
+func NewController(
+	tx dbtx.Transactor,
+	authorizer authz.Authorizer,
+	spaceFinder refcache.SpaceFinder,
+	repoFinder refcache.RepoFinder,
+	pullreqStore store.PullReqStore,
+	preferenceStore store.NotificationPreferenceStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	notificationStore store.NotificationStore,
+	channelStore store.NotificationChannelStore,
+	templateStore store.NotificationTemplateStore,
+	sseStreamer sse.Streamer,
+	encrypter encrypt.Encrypter,
+	webhookConfig webhook.Config,
+	templateRenderer *notificationsvc.TemplateRenderer,
+) *Controller {
+	return &Controller{
+		tx:                tx,
+		authorizer:        authorizer,
+		spaceFinder:       spaceFinder,
+		repoFinder:        repoFinder,
+		pullreqStore:      pullreqStore,
+		preferenceStore:   preferenceStore,
+		subscriptionStore: subscriptionStore,
+		notificationStore: notificationStore,
+		channelStore:      channelStore,
+		templateStore:     templateStore,
+		sseStreamer:       sseStreamer,
+		encrypter:         encrypter,
+		webhookConfig:     webhookConfig,
+		templateRenderer:  templateRenderer,
+	}
+}
+
+func (c *Controller) getRepoCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.RepositoryCore, error) {
+	return repo.GetRepoCheckAccess(ctx, c.repoFinder, c.authorizer, session, repoRef, enum.PermissionRepoView, true)
+}
+
+func (c *Controller) getSpaceCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceCore, error) {
+	return space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, enum.PermissionSpaceView)
+}
+
+func (c *Controller) getSpaceCheckEditAccess(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.SpaceCore, error) {
+	return space.GetSpaceCheckAuth(ctx, c.spaceFinder, c.authorizer, session, spaceRef, enum.PermissionSpaceEdit)
+}
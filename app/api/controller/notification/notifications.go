@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+)
+
+// ListNotifications lists the in-app notifications of the current user, the newest first.
+func (c *Controller) ListNotifications(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, int64, error) {
+	var (
+		list  []*types.Notification
+		count int64
+	)
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) (err error) {
+		list, err = c.notificationStore.List(ctx, session.Principal.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to list notifications: %w", err)
+		}
+
+		if filter.Page == 1 && len(list) < filter.Size {
+			count = int64(len(list))
+			return nil
+		}
+
+		count, err = c.notificationStore.Count(ctx, session.Principal.ID, filter)
+		if err != nil {
+			return fmt.Errorf("failed to count notifications: %w", err)
+		}
+
+		return nil
+	}, dbtx.TxDefaultReadOnly)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return list, count, nil
+}
+
+// CountNotifications returns the number of unread in-app notifications of the current user.
+func (c *Controller) CountNotifications(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.NotificationCount, error) {
+	unread, err := c.notificationStore.Count(ctx, session.Principal.ID, &types.NotificationFilter{UnreadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &types.NotificationCount{Unread: unread}, nil
+}
+
+// MarkNotificationsRead marks the provided in-app notifications of the current user as read.
+func (c *Controller) MarkNotificationsRead(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.NotificationMarkReadInput,
+) (*types.NotificationCount, error) {
+	if len(in.IDs) == 0 {
+		return nil, errors.InvalidArgument("At least one notification ID must be provided.")
+	}
+
+	if _, err := c.notificationStore.MarkRead(ctx, session.Principal.ID, in.IDs); err != nil {
+		return nil, fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+
+	return c.CountNotifications(ctx, session)
+}
+
+// MarkAllNotificationsRead marks all in-app notifications of the current user as read.
+func (c *Controller) MarkAllNotificationsRead(
+	ctx context.Context,
+	session *auth.Session,
+) (*types.NotificationCount, error) {
+	if _, err := c.notificationStore.MarkRead(ctx, session.Principal.ID, nil); err != nil {
+		return nil, fmt.Errorf("failed to mark all notifications as read: %w", err)
+	}
+
+	return c.CountNotifications(ctx, session)
+}
+
+// NotificationEvents streams the in-app notifications of the current user as they are created.
+func (c *Controller) NotificationEvents(
+	ctx context.Context,
+	session *auth.Session,
+) (<-chan *sse.Event, <-chan error, func(context.Context) error) {
+	return c.sseStreamer.StreamUser(ctx, session.Principal.ID)
+}
@@ -0,0 +1,60 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListPreferences returns the notification delivery of the current user for all notification events.
+// Events the user didn't configure are returned with the default delivery.
+func (c *Controller) ListPreferences(
+	ctx context.Context,
+	session *auth.Session,
+) ([]types.NotificationPreference, error) {
+	configured, err := c.preferenceStore.List(ctx, session.Principal.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification preferences: %w", err)
+	}
+
+	configuredMap := make(map[enum.NotificationEvent]types.NotificationPreference, len(configured))
+	for _, pref := range configured {
+		configuredMap[pref.Event] = pref
+	}
+
+	events, _ := enum.GetAllNotificationEvents()
+	_, defaultDelivery := enum.GetAllNotificationDeliveries()
+
+	prefs := make([]types.NotificationPreference, len(events))
+	for i, event := range events {
+		pref, ok := configuredMap[event]
+		if !ok {
+			pref = types.NotificationPreference{
+				PrincipalID: session.Principal.ID,
+				Event:       event,
+				Delivery:    defaultDelivery,
+			}
+		}
+
+		prefs[i] = pref
+	}
+
+	return prefs, nil
+}
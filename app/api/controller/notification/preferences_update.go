@@ -0,0 +1,78 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+)
+
+// UpdatePreferences changes the notification delivery of the current user for the provided notification events.
+func (c *Controller) UpdatePreferences(
+	ctx context.Context,
+	session *auth.Session,
+	in *types.NotificationPreferencesUpdateInput,
+) ([]types.NotificationPreference, error) {
+	if err := c.sanitizeUpdatePreferencesInput(in); err != nil {
+		return nil, err
+	}
+
+	err := c.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, input := range in.Preferences {
+			err := c.preferenceStore.Upsert(ctx, &types.NotificationPreference{
+				PrincipalID: session.Principal.ID,
+				Event:       input.Event,
+				Delivery:    input.Delivery,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update notification preference for %s: %w", input.Event, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return c.ListPreferences(ctx, session)
+}
+
+func (c *Controller) sanitizeUpdatePreferencesInput(in *types.NotificationPreferencesUpdateInput) error {
+	if len(in.Preferences) == 0 {
+		return errors.InvalidArgument("At least one notification preference must be provided.")
+	}
+
+	for i := range in.Preferences {
+		event, ok := in.Preferences[i].Event.Sanitize()
+		if !ok {
+			return errors.InvalidArgumentf("Notification event %q is not supported.", in.Preferences[i].Event)
+		}
+
+		delivery, ok := in.Preferences[i].Delivery.Sanitize()
+		if !ok || in.Preferences[i].Delivery == "" {
+			return errors.InvalidArgumentf("Notification delivery %q is not supported.", in.Preferences[i].Delivery)
+		}
+
+		in.Preferences[i].Event = event
+		in.Preferences[i].Delivery = delivery
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListSubscriptions lists the spaces, repositories and pull requests the current user watches or muted.
+func (c *Controller) ListSubscriptions(
+	ctx context.Context,
+	session *auth.Session,
+	filter *types.NotificationSubscriptionFilter,
+) ([]types.NotificationSubscription, error) {
+	if filter.ResourceType != "" {
+		if _, ok := filter.ResourceType.Sanitize(); !ok {
+			return nil, errors.InvalidArgumentf("Notification resource type %q is not supported.", filter.ResourceType)
+		}
+	}
+
+	subscriptions, err := c.subscriptionStore.List(ctx, session.Principal.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (c *Controller) findSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.NotificationResourceType,
+	resourceID int64,
+) (*types.NotificationSubscription, error) {
+	sub, err := c.subscriptionStore.Find(ctx, session.Principal.ID, resourceType, resourceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (c *Controller) setSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.NotificationResourceType,
+	resourceID int64,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	state, ok := in.State.Sanitize()
+	if !ok {
+		return nil, errors.InvalidArgumentf("Notification subscription state %q is not supported.", in.State)
+	}
+
+	now := time.Now().UnixMilli()
+	sub := &types.NotificationSubscription{
+		PrincipalID:  session.Principal.ID,
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+		State:        state,
+		Created:      now,
+		Updated:      now,
+	}
+
+	if err := c.subscriptionStore.Upsert(ctx, sub); err != nil {
+		return nil, fmt.Errorf("failed to store notification subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (c *Controller) deleteSubscription(
+	ctx context.Context,
+	session *auth.Session,
+	resourceType enum.NotificationResourceType,
+	resourceID int64,
+) error {
+	if err := c.subscriptionStore.Delete(ctx, session.Principal.ID, resourceType, resourceID); err != nil {
+		return fmt.Errorf("failed to delete notification subscription: %w", err)
+	}
+
+	return nil
+}
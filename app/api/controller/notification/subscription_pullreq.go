@@ -0,0 +1,89 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindSubscriptionPullReq returns the notification subscription of the current user for the pull request.
+func (c *Controller) FindSubscriptionPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.NotificationSubscription, error) {
+	pr, err := c.getPullReqCheckAccess(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.findSubscription(ctx, session, enum.NotificationResourceTypePullReq, pr.ID)
+}
+
+// SetSubscriptionPullReq watches or mutes the pull request for the current user.
+func (c *Controller) SetSubscriptionPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	pr, err := c.getPullReqCheckAccess(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationResourceTypePullReq, pr.ID, in)
+}
+
+// DeleteSubscriptionPullReq removes the notification subscription of the current user for the pull request.
+func (c *Controller) DeleteSubscriptionPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) error {
+	pr, err := c.getPullReqCheckAccess(ctx, session, repoRef, pullreqNum)
+	if err != nil {
+		return err
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationResourceTypePullReq, pr.ID)
+}
+
+func (c *Controller) getPullReqCheckAccess(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	return pr, nil
+}
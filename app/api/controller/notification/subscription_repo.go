@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindSubscriptionRepo returns the notification subscription of the current user for the repository.
+func (c *Controller) FindSubscriptionRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) (*types.NotificationSubscription, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.findSubscription(ctx, session, enum.NotificationResourceTypeRepo, repo.ID)
+}
+
+// SetSubscriptionRepo watches or mutes the repository for the current user.
+func (c *Controller) SetSubscriptionRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationResourceTypeRepo, repo.ID, in)
+}
+
+// DeleteSubscriptionRepo removes the notification subscription of the current user for the repository.
+func (c *Controller) DeleteSubscriptionRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationResourceTypeRepo, repo.ID)
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// FindSubscriptionSpace returns the notification subscription of the current user for the space.
+func (c *Controller) FindSubscriptionSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) (*types.NotificationSubscription, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	return c.findSubscription(ctx, session, enum.NotificationResourceTypeSpace, space.ID)
+}
+
+// SetSubscriptionSpace watches or mutes the space for the current user.
+func (c *Controller) SetSubscriptionSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	in *types.NotificationSubscriptionInput,
+) (*types.NotificationSubscription, error) {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	return c.setSubscription(ctx, session, enum.NotificationResourceTypeSpace, space.ID, in)
+}
+
+// DeleteSubscriptionSpace removes the notification subscription of the current user for the space.
+func (c *Controller) DeleteSubscriptionSpace(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+) error {
+	space, err := c.getSpaceCheckAccess(ctx, session, spaceRef)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to the space: %w", err)
+	}
+
+	return c.deleteSubscription(ctx, session, enum.NotificationResourceTypeSpace, space.ID)
+}
@@ -0,0 +1,183 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ListTemplates lists the templates used for all notification emails.
+func (c *Controller) ListTemplates(
+	ctx context.Context,
+	session *auth.Session,
+) ([]*types.NotificationTemplate, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	templates, err := c.templateRenderer.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification templates: %w", err)
+	}
+
+	return templates, nil
+}
+
+// FindTemplate returns the template used for the notification email.
+func (c *Controller) FindTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	name enum.NotificationTemplate,
+) (*types.NotificationTemplate, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	name, err := sanitizeTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := c.templateRenderer.Template(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find notification template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// UpdateTemplate saves a custom template for the notification email.
+func (c *Controller) UpdateTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	name enum.NotificationTemplate,
+	in *types.NotificationTemplateInput,
+) (*types.NotificationTemplate, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := templateFromInput(name, in)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.templateRenderer.Validate(tmpl); err != nil {
+		return nil, err
+	}
+
+	tmpl.Source = enum.NotificationTemplateSourceCustom
+	tmpl.UpdatedBy = &session.Principal.ID
+	tmpl.Updated = time.Now().UnixMilli()
+
+	if err = c.templateStore.Upsert(ctx, tmpl); err != nil {
+		return nil, fmt.Errorf("failed to save notification template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// ResetTemplate removes the custom template of the notification email
+// and returns the template used for the email from now on.
+func (c *Controller) ResetTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	name enum.NotificationTemplate,
+) (*types.NotificationTemplate, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	name, err := sanitizeTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.templateStore.Delete(ctx, name); err != nil {
+		return nil, fmt.Errorf("failed to delete notification template: %w", err)
+	}
+
+	return c.templateRenderer.Default(name), nil
+}
+
+// PreviewTemplate renders the template of the notification email with a sample payload.
+func (c *Controller) PreviewTemplate(
+	ctx context.Context,
+	session *auth.Session,
+	name enum.NotificationTemplate,
+	in *types.NotificationTemplateInput,
+) (*types.NotificationEmail, error) {
+	if err := checkAdmin(session); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := templateFromInput(name, in)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := c.templateRenderer.Preview(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to preview notification template: %w", err)
+	}
+
+	return email, nil
+}
+
+func checkAdmin(session *auth.Session) error {
+	if !session.Principal.Admin {
+		return errors.Forbidden("Only admins can manage notification templates.")
+	}
+
+	return nil
+}
+
+func sanitizeTemplateName(name enum.NotificationTemplate) (enum.NotificationTemplate, error) {
+	sanitized, ok := name.Sanitize()
+	if !ok {
+		return "", errors.NotFoundf("Notification template %q doesn't exist.", name)
+	}
+
+	return sanitized, nil
+}
+
+func templateFromInput(
+	name enum.NotificationTemplate,
+	in *types.NotificationTemplateInput,
+) (*types.NotificationTemplate, error) {
+	name, err := sanitizeTemplateName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType, ok := in.ContentType.Sanitize()
+	if !ok {
+		return nil, errors.InvalidArgumentf("Notification content type %q is not supported.", in.ContentType)
+	}
+
+	return &types.NotificationTemplate{
+		Name:        name,
+		Subject:     in.Subject,
+		Body:        in.Body,
+		ContentType: contentType,
+	}, nil
+}
@@ -76,6 +76,10 @@ func (c *Controller) ActivityList(
 
 	list = removeDeletedComments(list)
 
+	if err := c.backfillReactions(ctx, session, pr, list); err != nil {
+		return nil, fmt.Errorf("failed to backfill reactions: %w", err)
+	}
+
 	return list, nil
 }
 
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type AssigneeAddInput struct {
+	AssigneeID int64 `json:"assignee_id"`
+}
+
+// AssigneeAdd assigns a principal to the pull request.
+func (c *Controller) AssigneeAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *AssigneeAddInput,
+) (*types.PullReqAssignee, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Assignees can be changed only for open pull requests")
+	}
+
+	if in.AssigneeID <= 0 {
+		return nil, usererror.BadRequest("Must specify assignee ID.")
+	}
+
+	assigneePrincipal, err := c.principalStore.Find(ctx, in.AssigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find assignee principal: %w", err)
+	}
+
+	// TODO: To check the assignee's access to the repo we create a dummy session object. Fix it.
+	if err = apiauth.CheckRepo(ctx, c.authorizer, &auth.Session{
+		Principal: *assigneePrincipal,
+		Metadata:  nil,
+	}, repo, enum.PermissionRepoReview); err != nil {
+		log.Ctx(ctx).Info().Msgf("Assignee principal: %s access error: %s", assigneePrincipal.UID, err)
+		return nil, usererror.BadRequest("The assignee doesn't have enough permissions for the repository.")
+	}
+
+	assignee := &types.PullReqAssignee{
+		PullReqID:   pr.ID,
+		PrincipalID: assigneePrincipal.ID,
+		CreatedBy:   session.Principal.ID,
+		Created:     time.Now().UnixMilli(),
+		Assignee:    *assigneePrincipal.ToPrincipalInfo(),
+		AddedBy:     *session.Principal.ToPrincipalInfo(),
+	}
+
+	added, err := c.assigneeStore.Create(ctx, assignee)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request assignee: %w", err)
+	}
+
+	if !added {
+		return assignee, nil
+	}
+
+	err = func() error {
+		payload := &types.PullRequestActivityPayloadAssigneeAdd{
+			PrincipalID: assignee.PrincipalID,
+		}
+
+		metadata := &types.PullReqActivityMetadata{
+			Mentions: &types.PullReqActivityMentionsMetadata{IDs: []int64{assignee.PrincipalID}},
+		}
+
+		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, metadata)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after adding an assignee")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return assignee, nil
+}
+
+// AssigneeDelete removes the principal from the assignees of the pull request.
+// It fails with a not found error if the principal isn't assigned to the pull request.
+func (c *Controller) AssigneeDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	assigneeID int64,
+) error {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return usererror.BadRequest("Assignees can be changed only for open pull requests")
+	}
+
+	if assigneeID <= 0 {
+		return usererror.BadRequest("Must specify assignee ID.")
+	}
+
+	deleted, err := c.assigneeStore.Delete(ctx, pr.ID, assigneeID)
+	if err != nil {
+		return fmt.Errorf("failed to delete pull request assignee: %w", err)
+	}
+
+	if !deleted {
+		return usererror.NotFoundf("Principal %d is not an assignee of the pull request", assigneeID)
+	}
+
+	err = func() error {
+		payload := &types.PullRequestActivityPayloadAssigneeDelete{
+			PrincipalID: assigneeID,
+		}
+
+		if pr, err = c.pullreqStore.UpdateActivitySeq(ctx, pr); err != nil {
+			return fmt.Errorf("failed to increment pull request activity sequence: %w", err)
+		}
+
+		_, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create pull request activity: %w", err)
+		}
+
+		return nil
+	}()
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after assignee removal")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return nil
+}
+
+// AssigneeList returns the assignees of the pull request.
+func (c *Controller) AssigneeList(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) ([]*types.PullReqAssignee, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	assignees, err := c.assigneeStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request assignees: %w", err)
+	}
+
+	return assignees, nil
+}
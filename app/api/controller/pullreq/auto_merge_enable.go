@@ -20,7 +20,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/harness/gitness/app/api/controller"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/services/merge"
@@ -78,12 +77,11 @@ func (c *Controller) AutoMergeEnable(
 		return nil, fmt.Errorf("failed to acquire access to target repo: %w", err)
 	}
 
-	var autoMergeEnabled bool
-	explicitlySet, err := c.settings.RepoGet(ctx, targetRepo.ID, settings.KeyAutoMergeEnabled, &autoMergeEnabled)
+	autoMergeEnabled, err := settings.RepoGet(ctx, c.settings, targetRepo.ID, settings.KeyAutoMergeEnabled, false)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get repo auto-merge enabled setting: %w", err)
 	}
-	if !explicitlySet || !autoMergeEnabled {
+	if !autoMergeEnabled {
 		return nil, usererror.BadRequest("Auto merge setting is not enabled for the repository.")
 	}
 
@@ -107,32 +105,23 @@ func (c *Controller) AutoMergeEnable(
 		DeleteBranch: in.DeleteSourceBranch,
 	}
 
-	// Try to merge the pull request right now.
+	// Try to merge the pull request right now, otherwise add a new auto merge entry.
 
-	prMerged, branchDeleted, err := c.mergeService.Merge(ctx, pr, types.AutoMergeInput{
-		Principal:    session.Principal,
-		MergeMethod:  autoMerge.MergeMethod,
-		Title:        autoMerge.Title,
-		Message:      autoMerge.Message,
-		DeleteBranch: autoMerge.DeleteBranch,
-	})
+	prMerged, branchDeleted, err := c.mergeService.EnableAutoMerge(ctx, targetRepo, pr, &session.Principal, &autoMerge)
 	if errors.Is(err, merge.ErrMethodNotAllowed) {
 		return nil, usererror.BadRequest("The provided merge method is not allowed by the rules.")
 	}
-	if err != nil &&
-		!errors.Is(err, merge.ErrNotEligible) &&
-		!errors.Is(err, merge.ErrRuleViolation) &&
-		!errors.Is(err, merge.ErrConflict) {
-		return nil, fmt.Errorf("failed to merge pull request %d: %w", pr.ID, err)
+	if errors.Is(err, merge.ErrNotEligible) {
+		return nil, usererror.BadRequest("Pull request is no longer eligible for auto merge.")
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	// if merge succeeded we're done
-
-	if prMerged != nil && prMerged.MergeMethod != nil && prMerged.MergeSHA != nil {
-		pr = prMerged
+	if prMerged != nil {
 		return &types.AutoMergeResponse{
 			MergeResponse: &types.MergeResponse{
-				SHA:           *pr.MergeSHA,
+				SHA:           *prMerged.MergeSHA,
 				BranchDeleted: branchDeleted,
 			},
 			Requested:    autoMerge.Requested,
@@ -144,38 +133,6 @@ func (c *Controller) AutoMergeEnable(
 		}, nil
 	}
 
-	// otherwise add a new auto merge entry
-
-	err = controller.TxOptLock(ctx, c.tx, func(ctx context.Context) error {
-		pr, err = c.pullreqStore.Find(ctx, pr.ID)
-		if err != nil {
-			return fmt.Errorf("failed to find pull request by ID: %w", err)
-		}
-
-		err = verifyIfAutoMergeable(pr)
-		if err != nil {
-			return fmt.Errorf("pull request is not mergeable: %w", err)
-		}
-
-		pr.SubState = enum.PullReqSubStateAutoMerge
-		err = c.pullreqStore.Update(ctx, pr)
-		if err != nil {
-			return fmt.Errorf("failed to update pull request: %w", err)
-		}
-
-		err = c.autoMergeStore.Upsert(ctx, &autoMerge)
-		if err != nil {
-			return fmt.Errorf("failed to update auto merge state: %w", err)
-		}
-
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to enable auto merge for the pull request: %w", err)
-	}
-
-	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqAutoMergeEnabled, pr)
-
 	return &types.AutoMergeResponse{
 		MergeResponse: nil,
 		Requested:     autoMerge.Requested,
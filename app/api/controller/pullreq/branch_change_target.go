@@ -18,17 +18,12 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
-	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/errors"
-	"github.com/harness/gitness/git"
-	gitenum "github.com/harness/gitness/git/enum"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
-	"github.com/gotidy/ptr"
 	"github.com/rs/zerolog/log"
 )
 
@@ -70,76 +65,9 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 			errors.InvalidArgumentf("Source branch %q is same as new target branch", pr.SourceBranch)
 	}
 
-	readParams := git.CreateReadParams(repo)
-
-	targetRef, err := c.git.GetRef(ctx, git.GetRefParams{
-		ReadParams: readParams,
-		Name:       in.BranchName,
-		Type:       gitenum.RefTypeBranch,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to resolve target branch reference: %w", err)
-	}
-
-	targetSHA := targetRef.SHA
-
-	mergeBase, err := c.git.MergeBase(ctx, git.MergeBaseParams{
-		ReadParams: readParams,
-		Ref1:       pr.SourceSHA,
-		Ref2:       targetSHA.String(),
-	})
+	pr, err = c.pullreqService.ChangeTargetBranch(ctx, repo, pr, session.Principal.ID, in.BranchName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to find merge base: %w", err)
-	}
-
-	if mergeBase.MergeBaseSHA.String() == pr.SourceSHA {
-		return nil, usererror.BadRequest("The source branch doesn't contain any new commits")
-	}
-
-	diffStats, err := c.git.DiffStats(ctx, &git.DiffParams{
-		ReadParams: readParams,
-		BaseRef:    pr.MergeBaseSHA,
-		HeadRef:    pr.SourceSHA,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed get diff stats: %w", err)
-	}
-
-	oldTargetBranch := pr.TargetBranch
-	oldMergeBaseSHA := pr.MergeBaseSHA
-
-	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-		pr.MergeSHA = nil
-		pr.MarkAsMergeUnchecked()
-
-		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
-		pr.MergeTargetSHA = ptr.String(targetSHA.String())
-		pr.TargetBranch = in.BranchName
-		pr.Stats.DiffStats = types.NewDiffStats(
-			diffStats.Commits,
-			diffStats.FilesChanged,
-			diffStats.Additions,
-			diffStats.Deletions,
-		)
-
-		pr.ActivitySeq++
-
-		return nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to update PR target branch in db with error: %w", err)
-	}
-
-	_, err = c.activityStore.CreateWithPayload(
-		ctx, pr, session.Principal.ID,
-		&types.PullRequestActivityPayloadBranchChangeTarget{
-			Old: oldTargetBranch,
-			New: in.BranchName,
-		},
-		nil,
-	)
-	if err != nil {
-		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for successful branch restore")
+		return nil, fmt.Errorf("failed to change pull request target branch: %w", err)
 	}
 
 	err = c.instrumentation.Track(ctx, instrument.Event{
@@ -157,14 +85,5 @@ func (c *Controller) ChangeTargetBranch(ctx context.Context,
 		log.Ctx(ctx).Warn().Msgf("failed to insert instrumentation record for create branch operation: %s", err)
 	}
 
-	c.eventReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
-		Base:            eventBase(pr, &session.Principal),
-		SourceSHA:       pr.SourceSHA,
-		OldTargetBranch: oldTargetBranch,
-		NewTargetBranch: in.BranchName,
-		OldMergeBaseSHA: oldMergeBaseSHA,
-		NewMergeBaseSHA: mergeBase.MergeBaseSHA.String(),
-	})
-
 	return pr, nil
 }
@@ -49,6 +49,11 @@ func (c *Controller) DeleteBranch(ctx context.Context,
 	if err != nil {
 		return types.DeleteBranchOutput{}, nil, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
+
+	if pr.Flow == enum.PullReqFlowAGit {
+		return types.DeleteBranchOutput{}, nil, usererror.ErrPullReqNoSourceBranch
+	}
+
 	branchName := pr.SourceBranch
 
 	// make sure user isn't deleting the default branch
@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/instrument"
@@ -54,6 +55,9 @@ func (c *Controller) RestoreBranch(ctx context.Context,
 	if err != nil {
 		return types.CreateBranchOutput{}, nil, fmt.Errorf("failed to get pull request by number: %w", err)
 	}
+	if pr.Flow == enum.PullReqFlowAGit {
+		return types.CreateBranchOutput{}, nil, usererror.ErrPullReqNoSourceBranch
+	}
 	if pr.State == enum.PullReqStateOpen {
 		return types.CreateBranchOutput{}, nil, errors.Conflictf("source branch %q already exists", pr.SourceBranch)
 	}
@@ -53,6 +53,7 @@ func (c *Controller) CodeOwners(
 
 	return types.CodeOwnerEvaluation{
 		EvaluationEntries: mapCodeOwnerEvaluation(ownerEvaluation),
+		Sections:          mapCodeOwnerSections(ownerEvaluation.Sections),
 		FileSha:           ownerEvaluation.FileSha,
 	}, nil
 }
@@ -76,9 +77,14 @@ func mapCodeOwnerEvaluation(ownerEvaluation *codeowners.Evaluation) []types.Code
 				Evaluations: userGroupEvaluations,
 			}
 		}
+		var section string
+		if entry.Section != nil {
+			section = entry.Section.Name
+		}
 		codeOwnerEvaluationEntries[i] = types.CodeOwnerEvaluationEntry{
 			LineNumber:                entry.LineNumber,
 			Pattern:                   entry.Pattern,
+			Section:                   section,
 			OwnerEvaluations:          ownerEvaluations,
 			UserGroupOwnerEvaluations: userGroupOwnerEvaluations,
 		}
@@ -86,6 +92,29 @@ func mapCodeOwnerEvaluation(ownerEvaluation *codeowners.Evaluation) []types.Code
 	return codeOwnerEvaluationEntries
 }
 
+func mapCodeOwnerSections(sections []codeowners.SectionEvaluation) []types.CodeOwnerSectionEvaluation {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	result := make([]types.CodeOwnerSectionEvaluation, len(sections))
+	for i, section := range sections {
+		approvers := make([]types.OwnerEvaluation, len(section.Approvers))
+		for j, approver := range section.Approvers {
+			approvers[j] = mapOwner(approver)
+		}
+		result[i] = types.CodeOwnerSectionEvaluation{
+			Name:              section.Name,
+			Optional:          section.Optional,
+			RequiredApprovals: section.RequiredApprovals,
+			Approvers:         approvers,
+			ChangeRequested:   section.ChangeRequested,
+		}
+	}
+
+	return result
+}
+
 func mapOwner(owner codeowners.UserEvaluation) types.OwnerEvaluation {
 	return types.OwnerEvaluation{
 		Owner:          owner.Owner,
@@ -105,6 +105,10 @@ func (c *Controller) CommentApplySuggestions(
 		return CommentApplySuggestionsOutput{}, nil, fmt.Errorf("failed to find pull request by number: %w", err)
 	}
 
+	if pr.Flow == enum.PullReqFlowAGit {
+		return CommentApplySuggestionsOutput{}, nil, usererror.ErrPullReqNoSourceBranch
+	}
+
 	if err := in.sanitize(); err != nil {
 		return CommentApplySuggestionsOutput{}, nil, err
 	}
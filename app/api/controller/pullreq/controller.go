@@ -32,6 +32,7 @@ import (
 	locker "github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/merge"
 	"github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -66,6 +67,8 @@ type Controller struct {
 	userGroupStore         store.UserGroupStore
 	principalInfoCache     store.PrincipalInfoCache
 	fileViewStore          store.PullReqFileViewStore
+	reactionStore          store.PullReqReactionStore
+	assigneeStore          store.PullReqAssigneeStore
 	membershipStore        store.MembershipStore
 	checkStore             store.CheckStore
 	autoMergeStore         store.AutoMergeStore
@@ -84,6 +87,7 @@ type Controller struct {
 	settings               *settings.Service
 	importer               *migrate.PullReq
 	labelSvc               *label.Service
+	milestoneSvc           *milestone.Service
 	instrumentation        instrument.Service
 	userGroupService       usergroup.Service
 	branchStore            store.BranchStore
@@ -106,6 +110,8 @@ func NewController(
 	userGroupReviewerStore store.UserGroupReviewerStore,
 	principalInfoCache store.PrincipalInfoCache,
 	fileViewStore store.PullReqFileViewStore,
+	reactionStore store.PullReqReactionStore,
+	assigneeStore store.PullReqAssigneeStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
 	autoMergeStore store.AutoMergeStore,
@@ -124,6 +130,7 @@ func NewController(
 	settings *settings.Service,
 	importer *migrate.PullReq,
 	labelSvc *label.Service,
+	milestoneSvc *milestone.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
@@ -145,6 +152,8 @@ func NewController(
 		userGroupReviewerStore: userGroupReviewerStore,
 		principalInfoCache:     principalInfoCache,
 		fileViewStore:          fileViewStore,
+		reactionStore:          reactionStore,
+		assigneeStore:          assigneeStore,
 		membershipStore:        membershipStore,
 		checkStore:             checkStore,
 		autoMergeStore:         autoMergeStore,
@@ -163,6 +172,7 @@ func NewController(
 		settings:               settings,
 		importer:               importer,
 		labelSvc:               labelSvc,
+		milestoneSvc:           milestoneSvc,
 		instrumentation:        instrumentation,
 		userGroupService:       userGroupService,
 		branchStore:            branchStore,
@@ -323,6 +333,7 @@ func (c *Controller) checkIfAlreadyExists(ctx context.Context,
 		TargetRepoID:       targetRepoID,
 		TargetBranch:       targetBranch,
 		States:             []enum.PullReqState{enum.PullReqStateOpen},
+		Flow:               enum.PullReqFlowGithub,
 		Size:               1,
 		Sort:               enum.PullReqSortNumber,
 		Order:              enum.OrderAsc,
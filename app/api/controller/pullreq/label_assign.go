@@ -20,7 +20,6 @@ import (
 
 	"github.com/harness/gitness/app/auth"
 	events "github.com/harness/gitness/app/events/pullreq"
-	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -64,7 +63,7 @@ func (c *Controller) AssignLabel(
 		return nil, fmt.Errorf("failed to update pull request activity sequence: %w", err)
 	}
 
-	payload := activityPayload(out)
+	payload := out.ToActivityPayload()
 	if _, err := c.activityStore.CreateWithPayload(
 		ctx, pullreq, session.Principal.ID, payload, nil); err != nil {
 		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after label assign")
@@ -90,32 +89,3 @@ func (c *Controller) AssignLabel(
 
 	return out.PullReqLabel, nil
 }
-
-func activityPayload(out *label.AssignToPullReqOut) *types.PullRequestActivityLabel {
-	var oldValue *string
-	var oldValueColor *enum.LabelColor
-	if out.OldLabelValue != nil {
-		oldValue = &out.OldLabelValue.Value
-		oldValueColor = &out.OldLabelValue.Color
-	}
-
-	var value *string
-	var valueColor *enum.LabelColor
-	if out.NewLabelValue != nil {
-		value = &out.NewLabelValue.Value
-		valueColor = &out.NewLabelValue.Color
-	}
-
-	return &types.PullRequestActivityLabel{
-		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
-			Label:         out.Label.Key,
-			LabelColor:    out.Label.Color,
-			LabelScope:    out.Label.Scope,
-			Value:         value,
-			ValueColor:    valueColor,
-			OldValue:      oldValue,
-			OldValueColor: oldValueColor,
-		},
-		Type: out.ActivityType,
-	}
-}
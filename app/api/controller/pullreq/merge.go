@@ -264,6 +264,7 @@ func (c *Controller) Merge(
 		Method:              in.Method,
 		CheckResults:        checkResults,
 		CodeOwners:          codeOwnerWithApproval,
+		ChangedFiles:        c.mergeService.ChangedFiles(targetRepo, pr),
 	})
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -276,6 +277,7 @@ func (c *Controller) Merge(
 
 	// only delete the source branch if it's the source repository is the same as the target repository.
 	deleteSourceBranch := pr.SourceRepoID != nil && pr.TargetRepoID == *pr.SourceRepoID &&
+		pr.Flow != enum.PullReqFlowAGit &&
 		(in.DeleteSourceBranch || ruleOut.DeleteSourceBranch)
 
 	if in.DryRunRules {
@@ -298,6 +300,7 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			RuleWarnings:                        ruleOut.Warnings,
 		}, nil, nil
 	}
 
@@ -452,6 +455,7 @@ func (c *Controller) Merge(
 			MinimumRequiredApprovalsCount:       ruleOut.MinimumRequiredApprovalsCount,
 			MinimumRequiredApprovalsCountLatest: ruleOut.MinimumRequiredApprovalsCountLatest,
 			DefaultReviewerApprovals:            ruleOut.DefaultReviewerApprovals,
+			RuleWarnings:                        ruleOut.Warnings,
 		}
 
 		return out, nil, nil
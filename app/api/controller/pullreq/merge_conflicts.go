@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// mergeConflictFileSizeLimit is the max number of bytes returned per version of a conflicted file.
+const mergeConflictFileSizeLimit = 1 << 20 // 1 MiB
+
+// MergeConflicts returns the files that are in conflict when merging the target branch into the source branch,
+// with the content of the merge base, of both branches and the content with the conflict markers.
+func (c *Controller) MergeConflicts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+) (*types.PullReqMergeConflicts, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pull request source SHA: %w", err)
+	}
+
+	out, err := c.git.MergeConflicts(ctx, &git.MergeConflictsParams{
+		ReadParams:    git.CreateReadParams(repo),
+		SourceSHA:     sourceSHA,
+		TargetSHA:     targetBranch.Branch.SHA,
+		FileSizeLimit: mergeConflictFileSizeLimit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge conflicts: %w", err)
+	}
+
+	files := make([]types.MergeConflictFile, len(out.Files))
+	for i, file := range out.Files {
+		files[i] = types.MergeConflictFile{
+			Path:   file.Path,
+			Base:   mapMergeConflictFileVersion(file.Base),
+			Source: mapMergeConflictFileVersion(file.Source),
+			Target: mapMergeConflictFileVersion(file.Target),
+			Merged: mapMergeConflictFileVersion(file.Merged),
+		}
+	}
+
+	return &types.PullReqMergeConflicts{
+		SourceSHA:    sourceSHA.String(),
+		TargetSHA:    targetBranch.Branch.SHA.String(),
+		MergeBaseSHA: out.MergeBaseSHA.String(),
+		Files:        files,
+	}, nil
+}
+
+func mapMergeConflictFileVersion(v *git.MergeConflictFileVersion) *types.MergeConflictFileContent {
+	if v == nil {
+		return nil
+	}
+
+	return &types.MergeConflictFileContent{
+		SHA:      v.SHA.String(),
+		Encoding: enum.ContentEncodingTypeBase64,
+		Data:     base64.StdEncoding.EncodeToString(v.Content),
+		Size:     v.Size,
+		DataSize: int64(len(v.Content)),
+	}
+}
+
+type ResolvedConflictFile struct {
+	Path     string                   `json:"path"`
+	Encoding enum.ContentEncodingType `json:"encoding"`
+	Content  string                   `json:"content"`
+	// Delete removes the file instead of updating it with the content.
+	Delete bool `json:"delete"`
+}
+
+type ResolveConflictsInput struct {
+	// SourceSHA and TargetSHA must match the commits the conflicts have been resolved for.
+	SourceSHA string `json:"source_sha"`
+	TargetSHA string `json:"target_sha"`
+
+	Title   string `json:"title"`
+	Message string `json:"message"`
+
+	Files []ResolvedConflictFile `json:"files"`
+
+	BypassRules bool `json:"bypass_rules"`
+	DryRunRules bool `json:"dry_run_rules"`
+}
+
+func (in *ResolveConflictsInput) sanitize() error {
+	in.SourceSHA = strings.TrimSpace(in.SourceSHA)
+	in.TargetSHA = strings.TrimSpace(in.TargetSHA)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Message = strings.TrimSpace(in.Message)
+
+	if in.SourceSHA == "" {
+		return usererror.BadRequest("Source SHA must be provided")
+	}
+
+	if in.TargetSHA == "" {
+		return usererror.BadRequest("Target SHA must be provided")
+	}
+
+	if len(in.Files) == 0 {
+		return usererror.BadRequest("Resolved files must be provided")
+	}
+
+	paths := make(map[string]struct{}, len(in.Files))
+	for i := range in.Files {
+		in.Files[i].Path = strings.TrimSpace(in.Files[i].Path)
+		if in.Files[i].Path == "" {
+			return usererror.BadRequest("File path must be provided")
+		}
+
+		if _, ok := paths[in.Files[i].Path]; ok {
+			return usererror.BadRequestf("File %q is provided more than once", in.Files[i].Path)
+		}
+		paths[in.Files[i].Path] = struct{}{}
+	}
+
+	return nil
+}
+
+// ResolveConflicts merges the target branch into the source branch of the pull request using
+// the provided resolutions of the conflicted files. The source branch is updated with the merge commit.
+//
+//nolint:gocognit // refactor if needed.
+func (c *Controller) ResolveConflicts(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	pullreqNum int64,
+	in *ResolveConflictsInput,
+) (*types.ResolveConflictsResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, pullreqNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get pull request by number: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, usererror.BadRequest("Pull request must be open")
+	}
+
+	if pr.Flow == enum.PullReqFlowAGit {
+		return nil, nil, usererror.ErrPullReqNoSourceBranch
+	}
+
+	if pr.SourceRepoID == nil || *pr.SourceRepoID != pr.TargetRepoID {
+		return nil, nil, usererror.BadRequest(
+			"Conflicts of pull requests from a different repository must be resolved locally")
+	}
+
+	if pr.SourceSHA != in.SourceSHA {
+		return nil, nil, errors.InvalidArgument("The source branch has changed, reload the conflicts and try again.")
+	}
+
+	rules, isRepoOwner, err := c.fetchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          protection.RefActionUpdate,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{pr.SourceBranch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		return &types.ResolveConflictsResponse{
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        protection.GenerateErrorMessageForBlockingViolations(violations),
+		}, nil
+	}
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: git.CreateReadParams(repo),
+		BranchName: pr.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	if targetBranch.Branch.SHA.String() != in.TargetSHA {
+		return nil, nil, errors.InvalidArgument("The target branch has changed, reload the conflicts and try again.")
+	}
+
+	files := make([]git.ResolvedFile, len(in.Files))
+	for i, file := range in.Files {
+		var content []byte
+		switch file.Encoding {
+		case enum.ContentEncodingTypeBase64:
+			content, err = base64.StdEncoding.DecodeString(file.Content)
+			if err != nil {
+				return nil, nil, usererror.BadRequestf("Failed to decode base64 content of file %q", file.Path)
+			}
+		case enum.ContentEncodingTypeUTF8:
+			fallthrough
+		default:
+			// by default we treat content as is
+			content = []byte(file.Content)
+		}
+
+		files[i] = git.ResolvedFile{
+			Path:    file.Path,
+			Content: content,
+			Delete:  file.Delete,
+		}
+	}
+
+	title := in.Title
+	if title == "" {
+		title = fmt.Sprintf("Merge branch '%s' into %s", pr.TargetBranch, pr.SourceBranch)
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	author := controller.IdentityFromPrincipalInfo(*session.Principal.ToPrincipalInfo())
+	committer := controller.SystemServicePrincipalInfo()
+	now := time.Now()
+
+	out, err := c.git.ResolveMergeConflicts(ctx, &git.ResolveMergeConflictsParams{
+		WriteParams:       writeParams,
+		SourceBranch:      pr.SourceBranch,
+		SourceExpectedSHA: sha.Must(pr.SourceSHA),
+		TargetSHA:         targetBranch.Branch.SHA,
+		Files:             files,
+		Message:           git.CommitMessage(title, in.Message),
+		Committer:         committer,
+		CommitterDate:     &now,
+		Author:            author,
+		AuthorDate:        &now,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to resolve merge conflicts: %w", err)
+	}
+
+	return &types.ResolveConflictsResponse{
+		SHA:            out.CommitSHA.String(),
+		RuleViolations: violations,
+	}, nil, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type MilestoneAssignInput struct {
+	MilestoneID int64 `json:"milestone_id"`
+}
+
+// MilestoneAssign associates the pull request with a milestone of the repository or of one of its parent spaces.
+// The pull request can be associated with one milestone only, any previous milestone is replaced.
+func (c *Controller) MilestoneAssign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	in *MilestoneAssignInput,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if in.MilestoneID == 0 {
+		return nil, usererror.BadRequest("Must specify milestone ID.")
+	}
+
+	milestone, err := c.milestoneSvc.FindForRepo(ctx, repo, in.MilestoneID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.changeMilestone(ctx, session, repo, prNum, milestone)
+}
+
+// MilestoneUnassign removes the pull request from its milestone.
+func (c *Controller) MilestoneUnassign(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+) (*types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	return c.changeMilestone(ctx, session, repo, prNum, nil)
+}
+
+func (c *Controller) changeMilestone(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	prNum int64,
+	milestone *types.Milestone,
+) (*types.PullReq, error) {
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	var newMilestoneID *int64
+	var newMilestone *types.MilestoneInfo
+	if milestone != nil {
+		newMilestoneID = &milestone.ID
+		newMilestone = milestone.ToMilestoneInfo()
+	}
+
+	if (pr.MilestoneID == nil && newMilestoneID == nil) ||
+		(pr.MilestoneID != nil && newMilestoneID != nil && *pr.MilestoneID == *newMilestoneID) {
+		pr.Milestone = newMilestone
+		return pr, nil
+	}
+
+	if err := c.milestoneSvc.BackfillMany(ctx, []*types.PullReq{pr}); err != nil {
+		return nil, fmt.Errorf("failed to find the old milestone of the pull request: %w", err)
+	}
+
+	oldMilestone := pr.Milestone
+
+	pr, err = c.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.MilestoneID = newMilestoneID
+		pr.ActivitySeq++
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pull request milestone: %w", err)
+	}
+
+	pr.Milestone = newMilestone
+
+	payload := &types.PullRequestActivityPayloadMilestoneChange{
+		Old: oldMilestone,
+		New: newMilestone,
+	}
+	if _, err = c.activityStore.CreateWithPayload(ctx, pr, session.Principal.ID, payload, nil); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after milestone change")
+	}
+
+	c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return pr, nil
+}
@@ -0,0 +1,196 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqservice "github.com/harness/gitness/app/services/pullreq"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// AGitPushInput holds the information about a commit pushed to refs/for/<target-branch> (AGit flow).
+type AGitPushInput struct {
+	TargetBranch string
+	Topic        string
+	SHA          sha.SHA
+
+	// Title, Description and IsDraft are used only if a new pull request gets created.
+	// If the title is not provided, the title of the pushed commit is used. If the description
+	// is not provided, the repository's default template is used, or the body of the pushed commit
+	// if the repository has no template and the title is not provided either.
+	Title       string
+	Description string
+	IsDraft     bool
+
+	// SkipCI suppresses the pipeline triggers of the pull request creation or update.
+	SkipCI bool
+}
+
+func (in *AGitPushInput) sanitize() error {
+	in.TargetBranch = strings.TrimSpace(in.TargetBranch)
+	in.Topic = strings.TrimSpace(in.Topic)
+
+	if in.TargetBranch == "" {
+		return usererror.BadRequest("Target branch is required.")
+	}
+
+	if in.Topic == "" {
+		return usererror.BadRequest("Topic is required.")
+	}
+
+	if in.SHA.IsEmpty() || in.SHA.IsNil() {
+		return usererror.BadRequest("Commit SHA is required.")
+	}
+
+	return nil
+}
+
+// AGitPushOutput holds the pull request affected by the AGit push.
+type AGitPushOutput struct {
+	PullReq *types.PullReq
+	Created bool
+	Updated bool
+}
+
+// AGitPush creates a new pull request, or updates the existing open pull request, from a commit
+// pushed to refs/for/<target-branch>. The pull request is identified by the target branch, the topic
+// and the author, so contributors don't need a branch in the repository to propose changes.
+func (c *Controller) AGitPush(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *AGitPushInput,
+) (AGitPushOutput, error) {
+	if err := in.sanitize(); err != nil {
+		return AGitPushOutput{}, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return AGitPushOutput{}, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	existing, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+		CreatedBy:          []int64{session.Principal.ID},
+		SourceRepoID:       repo.ID,
+		SourceBranch:       in.Topic,
+		TargetRepoID:       repo.ID,
+		TargetBranch:       in.TargetBranch,
+		States:             []enum.PullReqState{enum.PullReqStateOpen},
+		Flow:               enum.PullReqFlowAGit,
+		Size:               1,
+		Sort:               enum.PullReqSortNumber,
+		Order:              enum.OrderAsc,
+		ExcludeDescription: true,
+	})
+	if err != nil {
+		return AGitPushOutput{}, fmt.Errorf("failed to get existing pull requests: %w", err)
+	}
+
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(repo),
+		Revision:   in.SHA.String(),
+	})
+	if err != nil {
+		return AGitPushOutput{}, fmt.Errorf("failed to get pushed commit: %w", err)
+	}
+
+	if len(existing) > 0 {
+		pr := existing[0]
+		if pr.SourceSHA == in.SHA.String() {
+			return AGitPushOutput{PullReq: pr}, nil
+		}
+
+		oldSHA, err := sha.New(pr.SourceSHA)
+		if err != nil {
+			return AGitPushOutput{}, fmt.Errorf("failed to parse pull request source SHA: %w", err)
+		}
+
+		ancestor, err := c.git.IsAncestor(ctx, git.IsAncestorParams{
+			ReadParams:          git.CreateReadParams(repo),
+			AncestorCommitSHA:   oldSHA,
+			DescendantCommitSHA: in.SHA,
+		})
+		if err != nil {
+			return AGitPushOutput{}, fmt.Errorf("failed to check if the update is forced: %w", err)
+		}
+
+		err = c.pullreqService.UpdateSource(ctx, pr, pullreqservice.SourceUpdateInput{
+			PrincipalID: session.Principal.ID,
+			OldSHA:      oldSHA,
+			NewSHA:      in.SHA,
+			Forced:      !ancestor.Ancestor,
+			CommitTitle: commit.Commit.Title,
+			SkipCI:      in.SkipCI,
+		})
+		if err != nil {
+			return AGitPushOutput{}, fmt.Errorf("failed to update pull request source: %w", err)
+		}
+
+		pr, err = c.pullreqStore.Find(ctx, pr.ID)
+		if err != nil {
+			return AGitPushOutput{}, fmt.Errorf("failed to find updated pull request: %w", err)
+		}
+
+		return AGitPushOutput{PullReq: pr, Updated: true}, nil
+	}
+
+	targetSHA, err := c.verifyBranchExistence(ctx, repo, in.TargetBranch)
+	if err != nil {
+		return AGitPushOutput{}, err
+	}
+
+	createIn := &CreateInput{
+		IsDraft:      in.IsDraft,
+		Title:        in.Title,
+		Description:  in.Description,
+		SourceBranch: in.Topic,
+		TargetBranch: in.TargetBranch,
+		SkipCI:       in.SkipCI,
+	}
+	if createIn.Title == "" {
+		createIn.Title = commit.Commit.Title
+	}
+
+	if err = createIn.Sanitize(); err != nil {
+		return AGitPushOutput{}, err
+	}
+
+	if err = c.applyTemplate(ctx, repo, targetSHA.String(), createIn); err != nil {
+		return AGitPushOutput{}, err
+	}
+
+	if createIn.Description == "" && in.Title == "" {
+		createIn.Description = strings.TrimSpace(strings.TrimPrefix(commit.Commit.Message, commit.Commit.Title))
+		if err = validateDescription(createIn.Description); err != nil {
+			return AGitPushOutput{}, err
+		}
+	}
+
+	pr, err := c.create(ctx, session, repo, repo, createIn, in.SHA, enum.PullReqFlowAGit)
+	if err != nil {
+		return AGitPushOutput{}, err
+	}
+
+	return AGitPushOutput{PullReq: pr, Created: true}, nil
+}
@@ -49,6 +49,10 @@ type CreateInput struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
+	// Template is the name of the pull request template stored in the repository
+	// that is used as the description. It can't be provided together with the description.
+	Template string `json:"template"`
+
 	SourceRepoRef string `json:"source_repo_ref"`
 	SourceBranch  string `json:"source_branch"`
 	TargetBranch  string `json:"target_branch"`
@@ -59,11 +63,16 @@ type CreateInput struct {
 	Labels []*types.PullReqLabelAssignInput `json:"labels"`
 
 	BypassRules bool `json:"bypass_rules"`
+
+	// SkipCI suppresses the pipeline triggers of the pull request creation.
+	// It's set only internally, for pull requests created with git push options.
+	SkipCI bool `json:"-"`
 }
 
 func (in *CreateInput) Sanitize() error {
 	in.Title = strings.TrimSpace(in.Title)
 	in.Description = strings.TrimSpace(in.Description)
+	in.Template = strings.TrimSpace(in.Template)
 
 	if err := validateTitle(in.Title); err != nil {
 		return err
@@ -73,6 +82,10 @@ func (in *CreateInput) Sanitize() error {
 		return err
 	}
 
+	if in.Template != "" && in.Description != "" {
+		return usererror.BadRequest("Pull request description and template can't be provided together")
+	}
+
 	return nil
 }
 
@@ -115,7 +128,12 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
-	if _, err = c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch); err != nil {
+	targetSHA, err := c.verifyBranchExistence(ctx, targetRepo, in.TargetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = c.applyTemplate(ctx, targetRepo, targetSHA.String(), in); err != nil {
 		return nil, err
 	}
 
@@ -123,6 +141,20 @@ func (c *Controller) Create(
 		return nil, err
 	}
 
+	return c.create(ctx, session, targetRepo, sourceRepo, in, sourceSHA, enum.PullReqFlowGithub)
+}
+
+// create creates a new pull request from the provided source commit.
+// The caller is responsible for verifying the source and the target of the pull request.
+func (c *Controller) create(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	sourceRepo *types.RepositoryCore,
+	in *CreateInput,
+	sourceSHA sha.SHA,
+	flow enum.PullReqFlow,
+) (*types.PullReq, error) {
 	targetWriteParams, err := controller.CreateRPCSystemReferencesWriteParams(
 		ctx, c.urlProvider, session, targetRepo,
 	)
@@ -204,7 +236,8 @@ func (c *Controller) Create(
 
 	// Rules based reviewers
 
-	out, err := c.createPullReqVerify(ctx, session, targetRepo, in)
+	out, err := c.createPullReqVerify(ctx, session, targetRepo, in,
+		c.mergeService.ChangedFilesBetween(targetRepo, mergeBaseSHA.String(), sourceSHA.String()))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get create pull request protection: %w", err)
 	}
@@ -230,8 +263,11 @@ func (c *Controller) Create(
 		activitySeq++
 	}
 
+	// path reviewers are requested along with the default reviewers of the rules.
 	defaultUserReviewerMap, defaultUserGroupReviewerMap, err := c.getDefaultReviewers(
-		ctx, session.Principal.ID, out.DefaultReviewerIDs, out.DefaultGroupReviewerIDs,
+		ctx, session.Principal.ID,
+		append(out.DefaultReviewerIDs, out.PathReviewerIDs...),
+		append(out.DefaultGroupReviewerIDs, out.PathUserGroupReviewerIDs...),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to prepare default reviewers: %w", err)
@@ -323,6 +359,7 @@ func (c *Controller) Create(
 			SourceSHA:         sourceSHA.String(),
 			TargetRepoID:      targetRepo.ID,
 			TargetBranch:      in.TargetBranch,
+			Flow:              flow,
 			ActivitySeq:       0,
 			MergedBy:          nil,
 			Merged:            nil,
@@ -415,6 +452,7 @@ func (c *Controller) Create(
 		TargetBranch: in.TargetBranch,
 		SourceSHA:    sourceSHA.String(),
 		ReviewerIDs:  maps.Keys(userReviewerMap),
+		SkipCI:       in.SkipCI,
 	})
 
 	c.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
@@ -500,6 +538,7 @@ func (c *Controller) createPullReqVerify(
 	session *auth.Session,
 	targetRepo *types.RepositoryCore,
 	in *CreateInput,
+	changedFiles func(ctx context.Context) ([]protection.ChangedFile, error),
 ) (*protection.CreatePullReqVerifyOutput, error) {
 	rules, isRepoOwner, err := c.fetchRules(ctx, session, targetRepo)
 	if err != nil {
@@ -515,6 +554,7 @@ func (c *Controller) createPullReqVerify(
 		TargetBranch:       in.TargetBranch,
 		RepoID:             targetRepo.ID,
 		RepoIdentifier:     targetRepo.Identifier,
+		ChangedFiles:       changedFiles,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
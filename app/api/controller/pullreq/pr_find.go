@@ -52,6 +52,15 @@ func (c *Controller) Find(
 		return nil, fmt.Errorf("failed to backfill labels assigned to pull request: %w", err)
 	}
 
+	err = c.pullreqListService.BackfillStack(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to backfill pull request stack: %w", err)
+	}
+
+	if err := c.backfillReactions(ctx, session, pr, nil); err != nil {
+		return nil, fmt.Errorf("failed to backfill reactions: %w", err)
+	}
+
 	if err := c.pullreqListService.BackfillMetadataForPullReq(ctx, repo, pr, options); err != nil {
 		return nil, fmt.Errorf("failed to backfill pull request metadata: %w", err)
 	}
@@ -140,7 +140,12 @@ func (c *Controller) State(ctx context.Context,
 			return nil, usererror.BadRequest("Forked repository doesn't exist anymore.")
 		}
 
-		if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, pr.SourceBranch); err != nil {
+		if pr.Flow == enum.PullReqFlowAGit {
+			// pull requests created by pushing to refs/for/<target-branch> keep their source only in the head ref.
+			if sourceSHA, err = sha.New(pr.SourceSHA); err != nil {
+				return nil, fmt.Errorf("failed to parse pull request source SHA: %w", err)
+			}
+		} else if sourceSHA, err = c.verifyBranchExistence(ctx, sourceRepo, pr.SourceBranch); err != nil {
 			return nil, err
 		}
 
@@ -148,9 +153,11 @@ func (c *Controller) State(ctx context.Context,
 			return nil, err
 		}
 
-		err = c.checkIfAlreadyExists(ctx, pr.TargetRepoID, *pr.SourceRepoID, pr.TargetBranch, pr.SourceBranch)
-		if err != nil {
-			return nil, err
+		if pr.Flow != enum.PullReqFlowAGit {
+			err = c.checkIfAlreadyExists(ctx, pr.TargetRepoID, *pr.SourceRepoID, pr.TargetBranch, pr.SourceBranch)
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		if targetRepo.ID != sourceRepo.ID {
@@ -0,0 +1,207 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// templateFilePath is the path of the default pull request description template.
+	templateFilePath = ".harness/pull_request_template.md"
+
+	// templateDirPath is the path of the directory with named pull request description templates.
+	templateDirPath = ".harness/PULL_REQUEST_TEMPLATE"
+
+	templateDefaultName = "default"
+	templateExtension   = ".md"
+	templateMaxSize     = 64 << 10 // 64K, same as the max length of a pull request description
+)
+
+// Templates returns the pull request description templates found on the provided target branch.
+// If the target branch is not provided, the repository's default branch is used.
+func (c *Controller) Templates(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	targetBranch string,
+) ([]types.PullReqTemplate, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	if targetBranch == "" {
+		targetBranch = repo.DefaultBranch
+	}
+
+	targetSHA, err := c.verifyBranchExistence(ctx, repo, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.listTemplates(ctx, repo, targetSHA.String())
+}
+
+// applyTemplate fills the description of the pull request from a template stored on the target branch.
+// It's a no-op if the description is already provided (CreateInput.Sanitize rejects a template name
+// provided together with a description). If a template name isn't provided,
+// the default template is used if the repository has one.
+func (c *Controller) applyTemplate(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	targetSHA string,
+	in *CreateInput,
+) error {
+	if in.Description != "" {
+		return nil
+	}
+
+	templates, err := c.listTemplates(ctx, repo, targetSHA)
+	if err != nil {
+		return fmt.Errorf("failed to list pull request templates: %w", err)
+	}
+
+	template := findTemplate(templates, in.Template)
+	if template == nil {
+		if in.Template != "" {
+			return usererror.BadRequestf("Pull request template %q not found on the target branch", in.Template)
+		}
+
+		return nil
+	}
+
+	in.Description = strings.TrimSpace(template.Content)
+
+	return nil
+}
+
+// findTemplate returns the template with the provided name,
+// or the default template if the name is empty.
+func findTemplate(templates []types.PullReqTemplate, name string) *types.PullReqTemplate {
+	for i := range templates {
+		if name == "" && templates[i].IsDefault || name != "" && templates[i].Name == name {
+			return &templates[i]
+		}
+	}
+
+	return nil
+}
+
+// listTemplates reads the default template file and all markdown files from the template directory.
+// Missing files are not considered an error.
+func (c *Controller) listTemplates(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	gitRef string,
+) ([]types.PullReqTemplate, error) {
+	readParams := git.CreateReadParams(repo)
+
+	templates := make([]types.PullReqTemplate, 0)
+
+	node, err := c.git.GetTreeNode(ctx, &git.GetTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       templateFilePath,
+	})
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get pull request template file: %w", err)
+	}
+	if err == nil && node.Node.Mode == git.TreeNodeModeFile {
+		content, err := c.readTemplate(ctx, readParams, node.Node.SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, types.PullReqTemplate{
+			Name:      templateDefaultName,
+			Path:      templateFilePath,
+			Content:   content,
+			IsDefault: true,
+		})
+	}
+
+	dir, err := c.git.ListTreeNodes(ctx, &git.ListTreeNodeParams{
+		ReadParams: readParams,
+		GitREF:     gitRef,
+		Path:       templateDirPath,
+	})
+	if errors.IsNotFound(err) {
+		return templates, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request template directory: %w", err)
+	}
+
+	named := make([]types.PullReqTemplate, 0, len(dir.Nodes))
+	for _, n := range dir.Nodes {
+		if n.Mode != git.TreeNodeModeFile || !strings.EqualFold(path.Ext(n.Name), templateExtension) {
+			continue
+		}
+
+		content, err := c.readTemplate(ctx, readParams, n.SHA)
+		if err != nil {
+			return nil, err
+		}
+
+		named = append(named, types.PullReqTemplate{
+			Name:    strings.TrimSuffix(n.Name, path.Ext(n.Name)),
+			Path:    n.Path,
+			Content: content,
+		})
+	}
+
+	sort.Slice(named, func(i, j int) bool { return named[i].Name < named[j].Name })
+
+	return append(templates, named...), nil
+}
+
+func (c *Controller) readTemplate(ctx context.Context, readParams git.ReadParams, blobSHA string) (string, error) {
+	output, err := c.git.GetBlob(ctx, &git.GetBlobParams{
+		ReadParams: readParams,
+		SHA:        blobSHA,
+		SizeLimit:  templateMaxSize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get pull request template blob: %w", err)
+	}
+
+	defer func() {
+		if err := output.Content.Close(); err != nil {
+			log.Ctx(ctx).Warn().Err(err).Msg("failed to close pull request template blob content reader")
+		}
+	}()
+
+	content, err := io.ReadAll(output.Content)
+	if err != nil {
+		return "", fmt.Errorf("failed to read pull request template blob content: %w", err)
+	}
+
+	return string(content), nil
+}
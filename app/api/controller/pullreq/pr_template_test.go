@@ -0,0 +1,108 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+)
+
+func TestFindTemplate(t *testing.T) {
+	templates := []types.PullReqTemplate{
+		{Name: "default", Path: templateFilePath, IsDefault: true},
+		{Name: "bugfix", Path: templateDirPath + "/bugfix.md"},
+		{Name: "feature", Path: templateDirPath + "/feature.md"},
+	}
+
+	tests := []struct {
+		name      string
+		templates []types.PullReqTemplate
+		template  string
+		wantPath  string
+	}{
+		{
+			name:      "default-when-name-empty",
+			templates: templates,
+			wantPath:  templateFilePath,
+		},
+		{
+			name:      "named",
+			templates: templates,
+			template:  "feature",
+			wantPath:  templateDirPath + "/feature.md",
+		},
+		{
+			name:      "named-not-found",
+			templates: templates,
+			template:  "release",
+		},
+		{
+			name:      "no-default",
+			templates: templates[1:],
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := findTemplate(test.templates, test.template)
+			if test.wantPath == "" {
+				if got != nil {
+					t.Errorf("expected no template, got %q", got.Path)
+				}
+				return
+			}
+			if got == nil || got.Path != test.wantPath {
+				t.Errorf("expected template %q, got %v", test.wantPath, got)
+			}
+		})
+	}
+}
+
+func TestCreateInput_Sanitize_Template(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		template    string
+		wantErr     bool
+	}{
+		{name: "template", template: "feature"},
+		{name: "description", description: "text"},
+		{name: "description-and-template", description: "text", template: "feature", wantErr: true},
+		{name: "blank-description-and-template", description: "  ", template: "feature"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := &CreateInput{Title: "title", Description: test.description, Template: test.template}
+
+			err := in.Sanitize()
+			if !test.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var userErr *usererror.Error
+			if !errors.As(err, &userErr) || userErr.Status != http.StatusBadRequest {
+				t.Errorf("expected bad request error, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type ReactionInput struct {
+	Reaction enum.PullReqReaction `json:"reaction"`
+}
+
+func (in *ReactionInput) sanitize() error {
+	reaction, ok := in.Reaction.Sanitize()
+	if !ok {
+		return usererror.BadRequestf("Unsupported reaction %q", in.Reaction)
+	}
+
+	in.Reaction = reaction
+
+	return nil
+}
+
+// ReactionAdd adds a reaction of the principal to the pull request description or,
+// if the commentID is provided, to the pull request comment.
+func (c *Controller) ReactionAdd(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+	in *ReactionInput,
+) (*types.PullReqReactions, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, pr, err := c.getReactionTarget(ctx, session, repoRef, prNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	created, err := c.reactionStore.Create(ctx, &types.PullReqReaction{
+		PullReqID:   pr.ID,
+		ActivityID:  commentID,
+		PrincipalID: session.Principal.ID,
+		Reaction:    in.Reaction,
+		Created:     time.Now().UnixMilli(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create reaction: %w", err)
+	}
+
+	if created {
+		c.eventReporter.ReactionCreated(ctx, &pullreqevents.ReactionCreatedPayload{
+			Base:       eventBase(pr, &session.Principal),
+			ActivityID: commentID,
+			Reaction:   in.Reaction,
+		})
+	}
+
+	return c.reactionsUpdated(ctx, session, repo, pr, commentID, created)
+}
+
+// ReactionDelete removes a reaction of the principal from the pull request description or,
+// if the commentID is provided, from the pull request comment.
+func (c *Controller) ReactionDelete(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+	reaction enum.PullReqReaction,
+) (*types.PullReqReactions, error) {
+	in := &ReactionInput{Reaction: reaction}
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	repo, pr, err := c.getReactionTarget(ctx, session, repoRef, prNum, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted, err := c.reactionStore.Delete(ctx, &types.PullReqReaction{
+		PullReqID:   pr.ID,
+		ActivityID:  commentID,
+		PrincipalID: session.Principal.ID,
+		Reaction:    in.Reaction,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete reaction: %w", err)
+	}
+
+	return c.reactionsUpdated(ctx, session, repo, pr, commentID, deleted)
+}
+
+func (c *Controller) getReactionTarget(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	prNum int64,
+	commentID *int64,
+) (*types.RepositoryCore, *types.PullReq, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoReview)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	pr, err := c.pullreqStore.FindByNumber(ctx, repo.ID, prNum)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find pull request by number: %w", err)
+	}
+
+	if commentID != nil {
+		if _, err = c.getCommentForPR(ctx, pr, *commentID); err != nil {
+			return nil, nil, fmt.Errorf("failed to get comment: %w", err)
+		}
+	}
+
+	return repo, pr, nil
+}
+
+// reactionsUpdated returns the current reactions of the pull request description or the comment.
+// If the reactions have changed, it publishes them to the event stream.
+func (c *Controller) reactionsUpdated(
+	ctx context.Context,
+	session *auth.Session,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	commentID *int64,
+	changed bool,
+) (*types.PullReqReactions, error) {
+	reactions, err := c.reactionStore.ListForTarget(ctx, pr.ID, commentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reactions: %w", err)
+	}
+
+	if changed {
+		// Don't include the "reacted" flag, the event is sent to all users.
+		c.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqReactionsUpdated, &types.PullReqReactions{
+			PullReqNumber: pr.Number,
+			ActivityID:    commentID,
+			Reactions:     summarizeReactions(reactions, 0),
+		})
+	}
+
+	return &types.PullReqReactions{
+		PullReqNumber: pr.Number,
+		ActivityID:    commentID,
+		Reactions:     summarizeReactions(reactions, session.Principal.ID),
+	}, nil
+}
+
+// backfillReactions sets the reactions summary of the pull request description
+// and of the provided pull request activities.
+func (c *Controller) backfillReactions(
+	ctx context.Context,
+	session *auth.Session,
+	pr *types.PullReq,
+	activities []*types.PullReqActivity,
+) error {
+	reactions, err := c.reactionStore.List(ctx, pr.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list reactions: %w", err)
+	}
+
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	var prReactions []*types.PullReqReaction
+	activityReactions := make(map[int64][]*types.PullReqReaction)
+	for _, reaction := range reactions {
+		if reaction.ActivityID == nil {
+			prReactions = append(prReactions, reaction)
+			continue
+		}
+		activityReactions[*reaction.ActivityID] = append(activityReactions[*reaction.ActivityID], reaction)
+	}
+
+	pr.Reactions = summarizeReactions(prReactions, session.Principal.ID)
+
+	for _, act := range activities {
+		act.Reactions = summarizeReactions(activityReactions[act.ID], session.Principal.ID)
+	}
+
+	return nil
+}
+
+// summarizeReactions aggregates the reactions, in the order of the first occurrence of each reaction.
+func summarizeReactions(reactions []*types.PullReqReaction, principalID int64) []types.PullReqReactionSummary {
+	if len(reactions) == 0 {
+		return nil
+	}
+
+	summary := make([]types.PullReqReactionSummary, 0, len(reactions))
+	indexes := make(map[enum.PullReqReaction]int)
+	for _, reaction := range reactions {
+		idx, ok := indexes[reaction.Reaction]
+		if !ok {
+			idx = len(summary)
+			indexes[reaction.Reaction] = idx
+			summary = append(summary, types.PullReqReactionSummary{Reaction: reaction.Reaction})
+		}
+
+		summary[idx].Count++
+		if principalID != 0 && reaction.PrincipalID == principalID {
+			summary[idx].Reacted = true
+		}
+	}
+
+	return summary
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestSummarizeReactions(t *testing.T) {
+	reaction := func(principalID int64, r enum.PullReqReaction) *types.PullReqReaction {
+		return &types.PullReqReaction{PrincipalID: principalID, Reaction: r}
+	}
+
+	tests := []struct {
+		name        string
+		input       []*types.PullReqReaction
+		principalID int64
+		want        []types.PullReqReactionSummary
+	}{
+		{
+			name:  "empty",
+			input: nil,
+			want:  nil,
+		},
+		{
+			name: "counts-in-order-of-first-occurrence",
+			input: []*types.PullReqReaction{
+				reaction(1, enum.PullReqReactionRocket),
+				reaction(2, enum.PullReqReactionThumbsUp),
+				reaction(3, enum.PullReqReactionRocket),
+			},
+			principalID: 2,
+			want: []types.PullReqReactionSummary{
+				{Reaction: enum.PullReqReactionRocket, Count: 2, Reacted: false},
+				{Reaction: enum.PullReqReactionThumbsUp, Count: 1, Reacted: true},
+			},
+		},
+		{
+			name: "no-principal",
+			input: []*types.PullReqReaction{
+				reaction(1, enum.PullReqReactionEyes),
+				reaction(2, enum.PullReqReactionEyes),
+			},
+			principalID: 0,
+			want: []types.PullReqReactionSummary{
+				{Reaction: enum.PullReqReactionEyes, Count: 2, Reacted: false},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := summarizeReactions(test.input, test.principalID)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("want=%v got=%v", test.want, got)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/harness/gitness/app/services/locker"
 	"github.com/harness/gitness/app/services/merge"
 	"github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
@@ -61,6 +62,8 @@ func ProvideController(
 	userGroupReviewerStore store.UserGroupReviewerStore,
 	principalInfoCache store.PrincipalInfoCache,
 	fileViewStore store.PullReqFileViewStore,
+	reactionStore store.PullReqReactionStore,
+	assigneeStore store.PullReqAssigneeStore,
 	membershipStore store.MembershipStore,
 	checkStore store.CheckStore,
 	autoMergeStore store.AutoMergeStore,
@@ -79,6 +82,7 @@ func ProvideController(
 	settings *settings.Service,
 	importer *migrate.PullReq,
 	labelSvc *label.Service,
+	milestoneSvc *milestone.Service,
 	instrumentation instrument.Service,
 	userGroupService usergroup.Service,
 	branchStore store.BranchStore,
@@ -99,6 +103,8 @@ func ProvideController(
 		userGroupReviewerStore,
 		principalInfoCache,
 		fileViewStore,
+		reactionStore,
+		assigneeStore,
 		membershipStore,
 		checkStore,
 		autoMergeStore,
@@ -117,6 +123,7 @@ func ProvideController(
 		settings,
 		importer,
 		labelSvc,
+		milestoneSvc,
 		instrumentation,
 		userGroupService,
 		branchStore,
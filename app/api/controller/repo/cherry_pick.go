@@ -0,0 +1,331 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+type CherryPickInput struct {
+	// CommitSHAs are the commits to cherry-pick, applied in the provided order.
+	CommitSHAs []sha.SHA `json:"commit_shas"`
+
+	TargetBranch string `json:"target_branch"`
+
+	// CreatePullReq puts the cherry-picked commits on a new branch created from the target branch
+	// and opens a pull request to the target branch. Otherwise, the target branch is updated directly.
+	CreatePullReq bool `json:"create_pull_req"`
+
+	// Branch is the name of the new branch, used only if CreatePullReq is set.
+	// It's optional, if no value has been provided the default ("cherry-pick-<sha>-to-<target>") would be used.
+	Branch string `json:"branch"`
+
+	// Title and Description of the pull request, used only if CreatePullReq is set.
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	// RecordOrigin appends "(cherry picked from commit ...)" to the messages of the created commits.
+	RecordOrigin bool `json:"record_origin"`
+
+	DryRun      bool `json:"dry_run"`
+	DryRunRules bool `json:"dry_run_rules"`
+	BypassRules bool `json:"bypass_rules"`
+}
+
+func (in *CherryPickInput) sanitize() error {
+	in.TargetBranch = strings.TrimSpace(in.TargetBranch)
+	in.Branch = strings.TrimSpace(in.Branch)
+	in.Title = strings.TrimSpace(in.Title)
+	in.Description = strings.TrimSpace(in.Description)
+
+	if len(in.CommitSHAs) == 0 {
+		return usererror.BadRequest("At least one commit SHA must be provided")
+	}
+
+	for _, commitSHA := range in.CommitSHAs {
+		if commitSHA.IsEmpty() || commitSHA.IsNil() {
+			return usererror.BadRequest("Commit SHAs must not be empty")
+		}
+	}
+
+	if in.TargetBranch == "" {
+		return usererror.BadRequest("Target branch name must be provided")
+	}
+
+	if !in.CreatePullReq && (in.Branch != "" || in.Title != "" || in.Description != "") {
+		return usererror.BadRequest("Branch, title and description can be provided only when creating a pull request")
+	}
+
+	if in.CreatePullReq && in.Branch == "" {
+		in.Branch = fmt.Sprintf("cherry-pick-%s-to-%s", in.CommitSHAs[0].String()[:7], in.TargetBranch)
+	}
+
+	if in.CreatePullReq && in.Branch == in.TargetBranch {
+		return usererror.BadRequest("Branch and target branch can't be the same")
+	}
+
+	return nil
+}
+
+// CherryPick applies the changes of the commits onto the target branch. The target branch is updated
+// directly or, if requested, a new branch holding the commits and a pull request to the target branch are created.
+//
+//nolint:gocognit // refactor if needed.
+func (c *Controller) CherryPick(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *CherryPickInput,
+) (*types.CherryPickResponse, *types.MergeViolations, error) {
+	if err := in.sanitize(); err != nil {
+		return nil, nil, err
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoPush)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to acquire access to repo: %w", err)
+	}
+
+	protectionRules, isRepoOwner, err := c.fetchBranchRules(ctx, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch rules: %w", err)
+	}
+
+	branch := in.TargetBranch
+	refAction := protection.RefActionUpdate
+	if in.CreatePullReq {
+		branch = in.Branch
+		refAction = protection.RefActionCreate
+	}
+
+	violations, err := protectionRules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+		ResolveUserGroupID: c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              &session.Principal,
+		AllowBypass:        in.BypassRules,
+		IsRepoOwner:        isRepoOwner,
+		Repo:               repo,
+		RefAction:          refAction,
+		RefType:            protection.RefTypeBranch,
+		RefNames:           []string{branch},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	if in.DryRunRules {
+		// DryRunRules is true: Just return rule violations and don't attempt to cherry-pick.
+		return &types.CherryPickResponse{
+			Branch:         branch,
+			RuleViolations: violations,
+			DryRunRules:    true,
+		}, nil, nil
+	}
+
+	if protection.IsCritical(violations) {
+		message := protection.GenerateErrorMessageForBlockingViolations(violations)
+		if !in.CreatePullReq {
+			message += " Cherry-pick the commits with a pull request instead."
+		}
+
+		return nil, &types.MergeViolations{
+			RuleViolations: violations,
+			Message:        message,
+		}, nil
+	}
+
+	readParams := git.CreateReadParams(repo)
+
+	targetBranch, err := c.git.GetBranch(ctx, &git.GetBranchParams{
+		ReadParams: readParams,
+		BranchName: in.TargetBranch,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get target branch: %w", err)
+	}
+
+	if in.CreatePullReq {
+		_, err = c.git.GetBranch(ctx, &git.GetBranchParams{
+			ReadParams: readParams,
+			BranchName: in.Branch,
+		})
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, nil, fmt.Errorf("failed to get branch: %w", err)
+		}
+		if err == nil {
+			return nil, nil, errors.InvalidArgumentf("Branch %q already exists.", in.Branch)
+		}
+	}
+
+	writeParams, err := controller.CreateRPCInternalWriteParams(ctx, c.urlProvider, session, repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create RPC write params: %w", err)
+	}
+
+	var refs []git.RefUpdate
+	if !in.DryRun {
+		branchRef, err := git.GetRefPath(branch, gitenum.RefTypeBranch)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate ref name: %w", err)
+		}
+
+		oldSHA := targetBranch.Branch.SHA
+		if in.CreatePullReq {
+			oldSHA = sha.Nil // Expect that the new branch doesn't exist.
+		}
+
+		refs = append(refs, git.RefUpdate{
+			Name: branchRef,
+			Old:  oldSHA,
+			New:  sha.SHA{}, // update to the result of the cherry-pick
+		})
+	}
+
+	cherryPickOutput, err := c.git.CherryPick(ctx, &git.CherryPickParams{
+		WriteParams:  writeParams,
+		BaseSHA:      targetBranch.Branch.SHA,
+		CommitSHAs:   in.CommitSHAs,
+		RecordOrigin: in.RecordOrigin,
+		Refs:         refs,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("cherry-pick execution failed: %w", err)
+	}
+
+	if in.DryRun {
+		// DryRun is true: Just return rule violations and list of conflicted files.
+		// No reference is updated, so don't return the resulting commit SHA.
+		return &types.CherryPickResponse{
+			Branch:            branch,
+			RuleViolations:    violations,
+			DryRun:            true,
+			ConflictCommitSHA: cherryPickOutput.ConflictCommitSHA.String(),
+			ConflictFiles:     cherryPickOutput.ConflictFiles,
+		}, nil, nil
+	}
+
+	if cherryPickOutput.CommitSHA.IsEmpty() || len(cherryPickOutput.ConflictFiles) > 0 {
+		return nil, &types.MergeViolations{
+			ConflictFiles:  cherryPickOutput.ConflictFiles,
+			RuleViolations: violations,
+			Message: fmt.Sprintf("Cherry-pick of commit %s blocked by conflicting files: %v",
+				cherryPickOutput.ConflictCommitSHA, cherryPickOutput.ConflictFiles),
+		}, nil
+	}
+
+	out := &types.CherryPickResponse{
+		Branch:         branch,
+		NewBranchSHA:   cherryPickOutput.CommitSHA,
+		RuleViolations: violations,
+	}
+
+	if !in.CreatePullReq {
+		return out, nil, nil
+	}
+
+	out.PullReq, err = c.cherryPickPullReq(ctx, session, repoRef, readParams, in)
+	if err != nil {
+		// the branch was created only for the pull request, so don't leave it behind.
+		c.deleteCherryPickBranch(ctx, writeParams, branch, out.NewBranchSHA)
+		return nil, nil, err
+	}
+
+	return out, nil, nil
+}
+
+// cherryPickPullReq creates the pull request from the branch with the cherry-picked commits.
+func (c *Controller) cherryPickPullReq(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	readParams git.ReadParams,
+	in *CherryPickInput,
+) (*types.PullReq, error) {
+	title := in.Title
+	if title == "" {
+		var err error
+		title, err = c.cherryPickPullReqTitle(ctx, readParams, in)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pr, err := c.pullreqCtrl.Create(ctx, session, repoRef, &pullreq.CreateInput{
+		Title:        title,
+		Description:  in.Description,
+		SourceBranch: in.Branch,
+		TargetBranch: in.TargetBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pull request for the cherry-picked commits: %w", err)
+	}
+
+	return pr, nil
+}
+
+// deleteCherryPickBranch deletes the branch created for the cherry-pick pull request.
+// Failures are only logged, the caller reports the reason the pull request wasn't created.
+func (c *Controller) deleteCherryPickBranch(
+	ctx context.Context,
+	writeParams git.WriteParams,
+	branch string,
+	branchSHA sha.SHA,
+) {
+	err := c.git.DeleteBranch(ctx, &git.DeleteBranchParams{
+		WriteParams: writeParams,
+		BranchName:  branch,
+		SHA:         branchSHA.String(),
+	})
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to delete cherry-pick branch %q", branch)
+	}
+}
+
+// cherryPickPullReqTitle returns the default title of the cherry-pick pull request:
+// the title of the commit, prefixed with the target branch, if a single commit is cherry-picked.
+func (c *Controller) cherryPickPullReqTitle(
+	ctx context.Context,
+	readParams git.ReadParams,
+	in *CherryPickInput,
+) (string, error) {
+	if len(in.CommitSHAs) > 1 {
+		return fmt.Sprintf("Cherry-pick %d commits to %s", len(in.CommitSHAs), in.TargetBranch), nil
+	}
+
+	commit, err := c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: readParams,
+		Revision:   in.CommitSHAs[0].String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get cherry-picked commit: %w", err)
+	}
+
+	return fmt.Sprintf("[%s] %s", in.TargetBranch, commit.Commit.Title), nil
+}
@@ -24,11 +24,13 @@ import (
 	apiauth "github.com/harness/gitness/app/api/auth"
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/dotrange"
 	"github.com/harness/gitness/app/services/importer"
@@ -36,10 +38,13 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/merge"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -126,6 +131,12 @@ type Controller struct {
 	dotRangeService        *dotrange.Service
 	connectorService       importer.ConnectorService
 	repoLangStore          store.RepoLangStore
+	pullreqCtrl            *pullreq.Controller
+	milestoneSvc           *milestone.Service
+	mergeService           *merge.Service
+	branchStore            store.BranchStore
+	automationSvc          *automation.Service
+	reviewAnalyticsSvc     *reviewanalytics.Service
 }
 
 func NewController(
@@ -174,6 +185,12 @@ func NewController(
 	dotRangeService *dotrange.Service,
 	connectorService importer.ConnectorService,
 	repoLangStore store.RepoLangStore,
+	pullreqCtrl *pullreq.Controller,
+	milestoneSvc *milestone.Service,
+	mergeService *merge.Service,
+	branchStore store.BranchStore,
+	automationSvc *automation.Service,
+	reviewAnalyticsSvc *reviewanalytics.Service,
 ) *Controller {
 	return &Controller{
 		defaultBranch:          config.Git.DefaultBranch,
@@ -221,6 +238,12 @@ func NewController(
 		dotRangeService:        dotRangeService,
 		connectorService:       connectorService,
 		repoLangStore:          repoLangStore,
+		pullreqCtrl:            pullreqCtrl,
+		milestoneSvc:           milestoneSvc,
+		mergeService:           mergeService,
+		branchStore:            branchStore,
+		automationSvc:          automationSvc,
+		reviewAnalyticsSvc:     reviewAnalyticsSvc,
 	}
 }
 
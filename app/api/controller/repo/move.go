@@ -19,6 +19,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/auth"
+	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
@@ -97,6 +98,14 @@ func (c *Controller) Move(ctx context.Context,
 	movedRepo.GitURL = c.urlProvider.GenerateGITCloneURL(ctx, movedRepo.Path)
 	movedRepo.GitSSHURL = c.urlProvider.GenerateGITCloneSSHURL(ctx, movedRepo.Path)
 
+	c.eventReporter.Renamed(ctx, &repoevents.RenamedPayload{
+		Base:          eventBase(movedRepo.Core(), &session.Principal),
+		OldIdentifier: repo.Identifier,
+		NewIdentifier: movedRepo.Identifier,
+		OldPath:       repo.Path,
+		NewPath:       movedRepo.Path,
+	})
+
 	// TODO: add audit log
 	log.Ctx(ctx).Info().Msgf(
 		"Moved repository %s to %s operation performed by %s",
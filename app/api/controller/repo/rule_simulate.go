@@ -0,0 +1,532 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	ruleSimulateDefaultLimit = 20
+	ruleSimulateMaxLimit     = 100
+)
+
+// RuleSimulateInput holds a rule definition to evaluate against the recent activity of a repository.
+type RuleSimulateInput struct {
+	Type         enum.RuleType         `json:"type"`
+	Identifier   string                `json:"identifier"`
+	Pattern      protection.Pattern    `json:"pattern"`
+	RepoTarget   protection.RepoTarget `json:"repo_target"`
+	Definition   json.RawMessage       `json:"definition"`
+	PullReqLimit int                   `json:"pullreq_limit"`
+	PushLimit    int                   `json:"push_limit"`
+}
+
+func (in *RuleSimulateInput) sanitize() error {
+	if in.Type == "" {
+		in.Type = protection.TypeBranch
+	}
+
+	if err := in.Pattern.Validate(); err != nil {
+		return usererror.BadRequestf("Invalid pattern: %s", err)
+	}
+
+	if err := in.RepoTarget.Validate(); err != nil {
+		return usererror.BadRequestf("Invalid repo target: %s", err)
+	}
+
+	if len(in.Definition) == 0 {
+		return usererror.BadRequest("Rule definition missing")
+	}
+
+	in.PullReqLimit = sanitizeRuleSimulateLimit(in.PullReqLimit)
+	in.PushLimit = sanitizeRuleSimulateLimit(in.PushLimit)
+
+	return nil
+}
+
+func sanitizeRuleSimulateLimit(limit int) int {
+	if limit <= 0 {
+		return ruleSimulateDefaultLimit
+	}
+	if limit > ruleSimulateMaxLimit {
+		return ruleSimulateMaxLimit
+	}
+	return limit
+}
+
+// RuleSimulate evaluates a protection rule definition against the last merged pull requests
+// and the recent pushes of a repository. Nothing is stored, the rule is only used for verification.
+// Pushed objects aren't retained, so push rules are evaluated against the last commit of the most
+// recently updated branches, as if it was pushed on its own by the principal who updated the branch.
+func (c *Controller) RuleSimulate(ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	in *RuleSimulateInput,
+) (*types.RuleSimulation, error) {
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := in.sanitize(); err != nil {
+		return nil, err
+	}
+
+	in.Definition, err = c.protectionManager.SanitizeJSON(in.Type, in.Definition)
+	if err != nil {
+		return nil, errors.InvalidArgument("Invalid rule definition.")
+	}
+
+	rule := types.RuleInfoInternal{
+		RuleInfo: types.RuleInfo{
+			RepoPath:   repo.Path,
+			Identifier: in.Identifier,
+			Type:       in.Type,
+			State:      enum.RuleStateActive,
+		},
+		Pattern:    in.Pattern.JSON(),
+		RepoTarget: in.RepoTarget.JSON(),
+		Definition: in.Definition,
+	}
+
+	s := ruleSimulator{
+		c:         c,
+		repo:      repo,
+		actors:    make(map[int64]*types.Principal),
+		repoOwner: make(map[int64]bool),
+	}
+
+	out := &types.RuleSimulation{
+		PullReqs: []types.RuleSimulationPullReq{},
+		Pushes:   []types.RuleSimulationPush{},
+	}
+
+	switch in.Type {
+	case protection.TypeBranch:
+		rules := c.protectionManager.FilterCreateBranchProtection([]types.RuleInfoInternal{rule})
+
+		mergeSHAs := make(map[string]struct{})
+
+		out.PullReqs, err = s.pullReqs(ctx, rules, in.PullReqLimit, mergeSHAs)
+		if err != nil {
+			return nil, err
+		}
+
+		out.Pushes, err = s.branchPushes(ctx, rules, in.PushLimit, mergeSHAs)
+		if err != nil {
+			return nil, err
+		}
+	case protection.TypeTag:
+		rules := c.protectionManager.FilterCreateTagProtection([]types.RuleInfoInternal{rule})
+
+		out.Pushes, err = s.tagPushes(ctx, rules, in.PushLimit)
+		if err != nil {
+			return nil, err
+		}
+	case protection.TypePush:
+		rules := c.protectionManager.FilterCreatePushProtection([]types.RuleInfoInternal{rule})
+
+		out.Pushes, err = s.pushes(ctx, rules, in.PushLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return out, nil
+}
+
+// ruleSimulator evaluates a rule on behalf of the principals who performed the original operations.
+type ruleSimulator struct {
+	c         *Controller
+	repo      *types.RepositoryCore
+	actors    map[int64]*types.Principal
+	repoOwner map[int64]bool
+}
+
+// actor returns the principal and whether the principal is a repo owner. Missing principals return nil.
+func (s *ruleSimulator) actor(ctx context.Context, principalID int64) (*types.Principal, bool, error) {
+	if actor, ok := s.actors[principalID]; ok {
+		return actor, s.repoOwner[principalID], nil
+	}
+
+	actor, err := s.c.principalStore.Find(ctx, principalID)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		s.actors[principalID] = nil
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	return s.remember(ctx, actor)
+}
+
+func (s *ruleSimulator) actorByEmail(ctx context.Context, email string) (*types.Principal, bool, error) {
+	actor, err := s.c.principalStore.FindByEmail(ctx, email)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to find principal by email: %w", err)
+	}
+
+	if cached, ok := s.actors[actor.ID]; ok {
+		return cached, s.repoOwner[actor.ID], nil
+	}
+
+	return s.remember(ctx, actor)
+}
+
+func (s *ruleSimulator) remember(ctx context.Context, actor *types.Principal) (*types.Principal, bool, error) {
+	isRepoOwner, err := apiauth.IsRepoOwner(ctx, s.c.authorizer, &auth.Session{Principal: *actor}, s.repo)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to determine if principal is repo owner: %w", err)
+	}
+
+	s.actors[actor.ID] = actor
+	s.repoOwner[actor.ID] = isRepoOwner
+
+	return actor, isRepoOwner, nil
+}
+
+func (s *ruleSimulator) pullReqs(
+	ctx context.Context,
+	rules protection.BranchProtection,
+	limit int,
+	mergeSHAs map[string]struct{},
+) ([]types.RuleSimulationPullReq, error) {
+	prs, err := s.c.pullReqStore.List(ctx, &types.PullReqFilter{
+		Size:         limit,
+		TargetRepoID: s.repo.ID,
+		States:       []enum.PullReqState{enum.PullReqStateMerged},
+		Sort:         enum.PullReqSortMerged,
+		Order:        enum.OrderDesc,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list merged pull requests: %w", err)
+	}
+
+	results := make([]types.RuleSimulationPullReq, 0, len(prs))
+	for _, pr := range prs {
+		if pr.MergeSHA != nil {
+			mergeSHAs[*pr.MergeSHA] = struct{}{}
+		}
+
+		var actor *types.Principal
+		var isRepoOwner bool
+		if pr.MergedBy != nil {
+			actor, isRepoOwner, err = s.actor(ctx, *pr.MergedBy)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		violations, err := s.c.mergeService.VerifyRules(ctx, rules, s.repo, pr, actor, isRepoOwner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify rules for pull request #%d: %w", pr.Number, err)
+		}
+
+		results = append(results, types.RuleSimulationPullReq{
+			Number:     pr.Number,
+			Title:      pr.Title,
+			Merged:     pr.Merged,
+			Merger:     pr.Merger,
+			Blocked:    protection.IsCritical(violations),
+			Violations: violations,
+		})
+	}
+
+	return results, nil
+}
+
+// branchPushes evaluates the rule against the last update of the most recently updated branches.
+// Updates made by merging one of the evaluated pull requests are skipped, they're covered by the pull request.
+func (s *ruleSimulator) branchPushes(
+	ctx context.Context,
+	rules protection.BranchProtection,
+	limit int,
+	mergeSHAs map[string]struct{},
+) ([]types.RuleSimulationPush, error) {
+	branches, err := s.c.branchStore.ListRecentlyUpdated(ctx, s.repo.ID, uint64(limit)) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently updated branches: %w", err)
+	}
+
+	results := make([]types.RuleSimulationPush, 0, len(branches))
+	for _, branch := range branches {
+		if _, ok := mergeSHAs[branch.SHA.String()]; ok {
+			continue
+		}
+
+		actor, isRepoOwner, err := s.actor(ctx, branch.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		refAction := protection.RefActionUpdate
+		if branch.Created == branch.Updated {
+			refAction = protection.RefActionCreate
+		}
+
+		violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+			ResolveUserGroupID: s.c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              actor,
+			AllowBypass:        true,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               s.repo,
+			RefAction:          refAction,
+			RefType:            protection.RefTypeBranch,
+			RefNames:           []string{branch.Name},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify rules for branch %q: %w", branch.Name, err)
+		}
+
+		results = append(results, types.RuleSimulationPush{
+			Ref:        branch.Name,
+			SHA:        branch.SHA.String(),
+			Pushed:     branch.Updated,
+			Pusher:     principalInfo(actor),
+			Blocked:    protection.IsCritical(violations),
+			Violations: violations,
+		})
+	}
+
+	return results, nil
+}
+
+// tagPushes evaluates the rule against the creation of the most recent tags.
+// The pusher of a tag isn't known, so the tagger of annotated tags is used instead.
+func (s *ruleSimulator) tagPushes(
+	ctx context.Context,
+	rules protection.TagProtection,
+	limit int,
+) ([]types.RuleSimulationPush, error) {
+	tags, err := s.c.git.ListCommitTags(ctx, &git.ListCommitTagsParams{
+		ReadParams: git.CreateReadParams(s.repo),
+		Sort:       git.TagSortOptionDate,
+		Order:      git.SortOrderDesc,
+		Page:       1,
+		PageSize:   int32(limit), //nolint:gosec
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	results := make([]types.RuleSimulationPush, 0, len(tags.Tags))
+	for _, tag := range tags.Tags {
+		var actor *types.Principal
+		var isRepoOwner bool
+		var pushed int64
+		if tag.Tagger != nil {
+			pushed = tag.Tagger.When.UnixMilli()
+
+			actor, isRepoOwner, err = s.actorByEmail(ctx, tag.Tagger.Identity.Email)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		violations, err := rules.RefChangeVerify(ctx, protection.RefChangeVerifyInput{
+			ResolveUserGroupID: s.c.userGroupService.ListUserIDsByGroupIDs,
+			Actor:              actor,
+			AllowBypass:        true,
+			IsRepoOwner:        isRepoOwner,
+			Repo:               s.repo,
+			RefAction:          protection.RefActionCreate,
+			RefType:            protection.RefTypeTag,
+			RefNames:           []string{tag.Name},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify rules for tag %q: %w", tag.Name, err)
+		}
+
+		results = append(results, types.RuleSimulationPush{
+			Ref:        tag.Name,
+			SHA:        tag.SHA.String(),
+			Pushed:     pushed,
+			Pusher:     principalInfo(actor),
+			Blocked:    protection.IsCritical(violations),
+			Violations: violations,
+		})
+	}
+
+	return results, nil
+}
+
+// pushes evaluates the push rule against the last commit of the most recently updated branches.
+func (s *ruleSimulator) pushes(
+	ctx context.Context,
+	rules protection.PushProtection,
+	limit int,
+) ([]types.RuleSimulationPush, error) {
+	branches, err := s.c.branchStore.ListRecentlyUpdated(ctx, s.repo.ID, uint64(limit)) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recently updated branches: %w", err)
+	}
+
+	results := make([]types.RuleSimulationPush, 0, len(branches))
+	for _, branch := range branches {
+		actor, isRepoOwner, err := s.actor(ctx, branch.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+
+		violations, err := s.pushViolations(ctx, rules, actor, isRepoOwner, branch.SHA.String())
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify rules for branch %q: %w", branch.Name, err)
+		}
+
+		results = append(results, types.RuleSimulationPush{
+			Ref:        branch.Name,
+			SHA:        branch.SHA.String(),
+			Pushed:     branch.Updated,
+			Pusher:     principalInfo(actor),
+			Blocked:    protection.IsCritical(violations),
+			Violations: violations,
+		})
+	}
+
+	return results, nil
+}
+
+// pushViolations runs the checks of the pre-receive hook on the objects introduced by the commit.
+func (s *ruleSimulator) pushViolations(
+	ctx context.Context,
+	rules protection.PushProtection,
+	actor *types.Principal,
+	isRepoOwner bool,
+	commitSHA string,
+) ([]types.RuleViolations, error) {
+	verifyOut, _, err := rules.PushVerify(ctx, protection.PushVerifyInput{
+		ResolveUserGroupID: s.c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:              actor,
+		IsRepoOwner:        isRepoOwner,
+		RepoID:             s.repo.ID,
+		RepoIdentifier:     s.repo.Identifier,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify push rules: %w", err)
+	}
+
+	if len(verifyOut.Protections) == 0 {
+		return []types.RuleViolations{}, nil
+	}
+
+	commit, err := s.c.git.GetCommit(ctx, &git.GetCommitParams{
+		ReadParams: git.CreateReadParams(s.repo),
+		Revision:   commitSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+
+	var baseRev string
+	if len(commit.Commit.ParentSHAs) > 0 {
+		baseRev = commit.Commit.ParentSHAs[0].String()
+	}
+
+	objectsIn := git.ProcessRangeObjectsParams{
+		ReadParams: git.CreateReadParams(s.repo),
+		BaseRev:    baseRev,
+		Rev:        commitSHA,
+	}
+
+	var sizeLimits []int64
+	for _, limit := range verifyOut.FileSizeLimits {
+		if limit > 0 {
+			sizeLimits = append(sizeLimits, limit)
+		}
+	}
+	if len(sizeLimits) > 0 {
+		slices.Sort(sizeLimits)
+		objectsIn.FindOversizeFilesParams = &git.FindOversizeFilesParams{
+			SizeLimit:  sizeLimits[0],
+			SizeLimits: slices.Compact(sizeLimits),
+		}
+	}
+
+	if verifyOut.PrincipalCommitterMatch && actor != nil {
+		objectsIn.FindCommitterMismatchParams = &git.FindCommitterMismatchParams{
+			PrincipalEmail: actor.Email,
+		}
+	}
+
+	objectsOut, err := s.c.git.ProcessRangeObjects(ctx, objectsIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process commit objects: %w", err)
+	}
+
+	violationsIn := &protection.PushViolationsInput{
+		ResolveUserGroupID:      s.c.userGroupService.ListUserIDsByGroupIDs,
+		Actor:                   actor,
+		IsRepoOwner:             isRepoOwner,
+		Protections:             verifyOut.Protections,
+		FileSizeLimits:          verifyOut.FileSizeLimits,
+		FindOversizeFilesOutput: objectsOut.FindOversizeFilesOutput,
+		PrincipalCommitterMatch: verifyOut.PrincipalCommitterMatch,
+		SecretScanningEnabled:   verifyOut.SecretScanningEnabled,
+	}
+
+	if out := objectsOut.FindCommitterMismatchOutput; out != nil {
+		violationsIn.CommitterMismatchCount = out.Total
+	}
+
+	if verifyOut.SecretScanningEnabled {
+		scanOut, err := s.c.git.ScanSecrets(ctx, &git.ScanSecretsParams{
+			ReadParams:         git.CreateReadParams(s.repo),
+			BaseRev:            baseRev,
+			Rev:                commitSHA,
+			GitleaksIgnorePath: git.DefaultGitleaksIgnorePath,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan for secrets: %w", err)
+		}
+
+		violationsIn.FoundSecretsCount = len(scanOut.Findings)
+	}
+
+	if !violationsIn.HasViolations() {
+		return []types.RuleViolations{}, nil
+	}
+
+	out, err := rules.Violations(ctx, violationsIn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to backfill push rule violations: %w", err)
+	}
+
+	return out.Violations, nil
+}
+
+func principalInfo(principal *types.Principal) *types.PrincipalInfo {
+	if principal == nil {
+		return nil
+	}
+	return principal.ToPrincipalInfo()
+}
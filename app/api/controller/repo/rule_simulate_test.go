@@ -0,0 +1,299 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	simulateOwnerID   = 1
+	simulateUserID    = 2
+	simulateOtherSHA  = "1111111111111111111111111111111111111111"
+	simulateParentSHA = "2222222222222222222222222222222222222222"
+)
+
+var simulatePrincipals = map[int64]*types.Principal{
+	simulateOwnerID: {ID: simulateOwnerID, UID: "owner", Email: "owner@example.com", Type: enum.PrincipalTypeUser},
+	simulateUserID:  {ID: simulateUserID, UID: "user", Email: "user@example.com", Type: enum.PrincipalTypeUser},
+}
+
+type simulatePrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (simulatePrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if p, ok := simulatePrincipals[id]; ok {
+		return p, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (simulatePrincipalStore) FindByEmail(_ context.Context, email string) (*types.Principal, error) {
+	for _, p := range simulatePrincipals {
+		if p.Email == email {
+			return p, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// simulateAuthorizer grants everything to the repo owner only.
+type simulateAuthorizer struct {
+	authz.Authorizer
+}
+
+func (simulateAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	return session.Principal.ID == simulateOwnerID, nil
+}
+
+type simulateBranchStore struct {
+	store.BranchStore
+	branches []types.BranchTable
+}
+
+func (s simulateBranchStore) ListRecentlyUpdated(context.Context, int64, uint64) ([]types.BranchTable, error) {
+	return s.branches, nil
+}
+
+type simulateUserGroupService struct {
+	usergroup.Service
+}
+
+func (simulateUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return nil, nil
+}
+
+type simulateGit struct {
+	git.Interface
+	tags []git.CommitTag
+	// oversize is the size of the largest file introduced by any commit.
+	oversize int64
+	// committer is the committer email of all commits.
+	committer string
+}
+
+func (g simulateGit) ListCommitTags(context.Context, *git.ListCommitTagsParams) (*git.ListCommitTagsOutput, error) {
+	return &git.ListCommitTagsOutput{Tags: g.tags}, nil
+}
+
+func (g simulateGit) GetCommit(_ context.Context, params *git.GetCommitParams) (*git.GetCommitOutput, error) {
+	return &git.GetCommitOutput{Commit: git.Commit{
+		SHA:        sha.Must(params.Revision),
+		ParentSHAs: []sha.SHA{sha.Must(simulateParentSHA)},
+	}}, nil
+}
+
+func (g simulateGit) ProcessRangeObjects(
+	_ context.Context,
+	params git.ProcessRangeObjectsParams,
+) (git.ProcessRangeObjectsOutput, error) {
+	var out git.ProcessRangeObjectsOutput
+
+	if p := params.FindOversizeFilesParams; p != nil {
+		out.FindOversizeFilesOutput = &git.FindOversizeFilesOutput{
+			FileInfosPerLimit: map[int64][]git.FileInfo{},
+			TotalsPerLimit:    map[int64]int64{},
+		}
+		for _, limit := range p.SizeLimits {
+			if g.oversize > limit {
+				out.FindOversizeFilesOutput.TotalsPerLimit[limit] = 1
+				out.FindOversizeFilesOutput.FileInfosPerLimit[limit] = []git.FileInfo{{Size: g.oversize}}
+			}
+		}
+	}
+
+	if p := params.FindCommitterMismatchParams; p != nil {
+		out.FindCommitterMismatchOutput = &git.FindCommitterMismatchOutput{}
+		if p.PrincipalEmail != g.committer {
+			out.FindCommitterMismatchOutput.Total = 1
+		}
+	}
+
+	return out, nil
+}
+
+//nolint:funlen // table test
+func TestRuleSimulator_Pushes(t *testing.T) {
+	repo := &types.RepositoryCore{ID: 1, Identifier: "repo", Path: "space/repo", DefaultBranch: "main",
+		State: enum.RepoStateActive}
+
+	branches := []types.BranchTable{
+		{Name: "main", SHA: sha.Must(simulateOtherSHA), Created: 1, Updated: 2, UpdatedBy: simulateUserID},
+		{Name: "feature", SHA: sha.Must(simulateOtherSHA), Created: 3, Updated: 3, UpdatedBy: simulateOwnerID},
+	}
+
+	tags := []git.CommitTag{
+		{Name: "v1.0.0", SHA: sha.Must(simulateOtherSHA), Tagger: &git.Signature{
+			Identity: git.Identity{Email: "user@example.com"}, When: time.UnixMilli(5)}},
+		{Name: "v1.0.1", SHA: sha.Must(simulateOtherSHA), Tagger: &git.Signature{
+			Identity: git.Identity{Email: "owner@example.com"}, When: time.UnixMilli(6)}},
+	}
+
+	tests := []struct {
+		name       string
+		ruleType   enum.RuleType
+		definition string
+		pattern    protection.Pattern
+		oversize   int64
+		committer  string
+		// expBlocked maps the evaluated refs to the expected verdict.
+		expBlocked map[string]bool
+	}{
+		{
+			name:       "branch update forbidden, owner bypasses",
+			ruleType:   protection.TypeBranch,
+			definition: `{"bypass":{"repo_owners":true},"lifecycle":{"update_forbidden":true}}`,
+			expBlocked: map[string]bool{"main": true, "feature": false},
+		},
+		{
+			name:       "branch creation forbidden",
+			ruleType:   protection.TypeBranch,
+			definition: `{"lifecycle":{"create_forbidden":true}}`,
+			expBlocked: map[string]bool{"main": false, "feature": true},
+		},
+		{
+			name:       "branch rule not matching",
+			ruleType:   protection.TypeBranch,
+			definition: `{"lifecycle":{"update_forbidden":true,"create_forbidden":true}}`,
+			pattern:    protection.Pattern{Include: []string{"release/*"}},
+			expBlocked: map[string]bool{"main": false, "feature": false},
+		},
+		{
+			name:       "tag creation forbidden, owner bypasses",
+			ruleType:   protection.TypeTag,
+			definition: `{"bypass":{"repo_owners":true},"lifecycle":{"create_forbidden":true}}`,
+			expBlocked: map[string]bool{"v1.0.0": true, "v1.0.1": false},
+		},
+		{
+			name:       "tag rule not matching",
+			ruleType:   protection.TypeTag,
+			definition: `{"lifecycle":{"create_forbidden":true}}`,
+			pattern:    protection.Pattern{Include: []string{"v2.*"}},
+			expBlocked: map[string]bool{"v1.0.0": false, "v1.0.1": false},
+		},
+		{
+			name:       "push file size limit exceeded",
+			ruleType:   protection.TypePush,
+			definition: `{"push":{"file_size_limit":100}}`,
+			oversize:   200,
+			expBlocked: map[string]bool{"main": true, "feature": true},
+		},
+		{
+			name:       "push file size limit exceeded, owner bypasses",
+			ruleType:   protection.TypePush,
+			definition: `{"bypass":{"repo_owners":true},"push":{"file_size_limit":100}}`,
+			oversize:   200,
+			expBlocked: map[string]bool{"main": true, "feature": false},
+		},
+		{
+			name:       "push file size limit respected",
+			ruleType:   protection.TypePush,
+			definition: `{"push":{"file_size_limit":100}}`,
+			oversize:   50,
+			expBlocked: map[string]bool{"main": false, "feature": false},
+		},
+		{
+			name:       "push committer mismatch",
+			ruleType:   protection.TypePush,
+			definition: `{"push":{"principal_committer_match":true}}`,
+			committer:  "owner@example.com",
+			expBlocked: map[string]bool{"main": true, "feature": false},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			manager, err := protection.ProvideManager(nil)
+			require.NoError(t, err)
+
+			c := &Controller{
+				authorizer:        simulateAuthorizer{},
+				principalStore:    simulatePrincipalStore{},
+				branchStore:       simulateBranchStore{branches: branches},
+				userGroupService:  simulateUserGroupService{},
+				protectionManager: manager,
+				git: simulateGit{
+					tags:      tags,
+					oversize:  test.oversize,
+					committer: test.committer,
+				},
+			}
+
+			s := ruleSimulator{
+				c:         c,
+				repo:      repo,
+				actors:    make(map[int64]*types.Principal),
+				repoOwner: make(map[int64]bool),
+			}
+
+			rule := []types.RuleInfoInternal{{
+				RuleInfo: types.RuleInfo{
+					RepoPath:   repo.Path,
+					Identifier: "rule",
+					Type:       test.ruleType,
+					State:      enum.RuleStateActive,
+				},
+				Pattern:    test.pattern.JSON(),
+				RepoTarget: (&protection.RepoTarget{}).JSON(),
+				Definition: json.RawMessage(test.definition),
+			}}
+
+			var pushes []types.RuleSimulationPush
+			switch test.ruleType {
+			case protection.TypeBranch:
+				pushes, err = s.branchPushes(ctx, manager.FilterCreateBranchProtection(rule), 10,
+					map[string]struct{}{})
+			case protection.TypeTag:
+				pushes, err = s.tagPushes(ctx, manager.FilterCreateTagProtection(rule), 10)
+			case protection.TypePush:
+				pushes, err = s.pushes(ctx, manager.FilterCreatePushProtection(rule), 10)
+			}
+			require.NoError(t, err)
+
+			blocked := make(map[string]bool, len(pushes))
+			for _, push := range pushes {
+				blocked[push.Ref] = push.Blocked
+			}
+
+			assert.Equal(t, test.expBlocked, blocked)
+		})
+	}
+}
@@ -17,9 +17,11 @@ package repo
 import (
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/limiter"
+	"github.com/harness/gitness/app/api/controller/pullreq"
 	"github.com/harness/gitness/app/auth/authz"
 	repoevents "github.com/harness/gitness/app/events/repo"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/dotrange"
 	"github.com/harness/gitness/app/services/importer"
@@ -27,10 +29,13 @@ import (
 	"github.com/harness/gitness/app/services/keywordsearch"
 	"github.com/harness/gitness/app/services/label"
 	"github.com/harness/gitness/app/services/locker"
+	"github.com/harness/gitness/app/services/merge"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/publickey"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/services/usergroup"
@@ -98,6 +103,12 @@ func ProvideController(
 	dotRangeService *dotrange.Service,
 	connectorService importer.ConnectorService,
 	repoLangStore store.RepoLangStore,
+	pullreqCtrl *pullreq.Controller,
+	milestoneSvc *milestone.Service,
+	mergeService *merge.Service,
+	branchStore store.BranchStore,
+	automationSvc *automation.Service,
+	reviewAnalyticsSvc *reviewanalytics.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		authorizer,
@@ -109,7 +120,8 @@ func ProvideController(
 		repoChecks, publicAccess, labelSvc, instrumentation, userGroupStore, userGroupService,
 		rulesSvc, sseStreamer, lfsCtrl, favoriteStore, signatureVerifyService,
 		autolinkSvc, dotRangeService, connectorService,
-		repoLangStore,
+		repoLangStore, pullreqCtrl, milestoneSvc,
+		mergeService, branchStore, automationSvc, reviewAnalyticsSvc,
 	)
 }
 
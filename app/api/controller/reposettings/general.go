@@ -25,6 +25,8 @@ type GeneralSettings struct {
 	FileSizeLimit    *int64 `json:"file_size_limit" yaml:"file_size_limit" description:"file size limit in bytes"`
 	GitLFSEnabled    *bool  `json:"git_lfs_enabled" yaml:"git_lfs_enabled"`
 	AutoMergeEnabled *bool  `json:"auto_merge_enabled" yaml:"auto_merge_enabled"`
+
+	StackedPullReqRebase *bool `json:"stacked_pullreq_rebase" yaml:"stacked_pullreq_rebase"`
 }
 
 func GetDefaultGeneralSettings() *GeneralSettings {
@@ -32,6 +34,8 @@ func GetDefaultGeneralSettings() *GeneralSettings {
 		FileSizeLimit:    ptr.Int64(settings.DefaultFileSizeLimit),
 		GitLFSEnabled:    ptr.Bool(settings.DefaultGitLFSEnabled),
 		AutoMergeEnabled: ptr.Bool(settings.DefaultAutoMergeEnabled),
+
+		StackedPullReqRebase: ptr.Bool(settings.DefaultStackedPullReqRebase),
 	}
 }
 
@@ -40,6 +44,7 @@ func GetGeneralSettingsMappings(s *GeneralSettings) []settings.SettingHandler {
 		settings.Mapping(settings.KeyFileSizeLimit, s.FileSizeLimit),
 		settings.Mapping(settings.KeyGitLFSEnabled, s.GitLFSEnabled),
 		settings.Mapping(settings.KeyAutoMergeEnabled, s.AutoMergeEnabled),
+		settings.Mapping(settings.KeyStackedPullReqRebase, s.StackedPullReqRebase),
 	}
 }
 
@@ -67,5 +72,12 @@ func GetGeneralSettingsAsKeyValues(s *GeneralSettings) []settings.KeyValue {
 		})
 	}
 
+	if s.StackedPullReqRebase != nil {
+		kvs = append(kvs, settings.KeyValue{
+			Key:   settings.KeyStackedPullReqRebase,
+			Value: s.StackedPullReqRebase,
+		})
+	}
+
 	return kvs
 }
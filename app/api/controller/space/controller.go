@@ -28,15 +28,18 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	"github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/space"
 	"github.com/harness/gitness/app/sse"
@@ -111,6 +114,9 @@ type Controller struct {
 	favoriteStore       store.FavoriteStore
 	autolinkSvc         *autolink.Service
 	spaceSvc            *space.Service
+	milestoneSvc        *milestone.Service
+	automationSvc       *automation.Service
+	reviewAnalyticsSvc  *reviewanalytics.Service
 }
 
 func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Provider,
@@ -126,7 +132,8 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 	instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider.Service, favoriteStore store.FavoriteStore, autolinkSvc *autolink.Service,
-	spaceSvc *space.Service,
+	spaceSvc *space.Service, milestoneSvc *milestone.Service, automationSvc *automation.Service,
+	reviewAnalyticsSvc *reviewanalytics.Service,
 ) *Controller {
 	return &Controller{
 		nestedSpacesEnabled: config.NestedSpacesEnabled,
@@ -164,6 +171,9 @@ func NewController(config *types.Config, tx dbtx.Transactor, urlProvider url.Pro
 		favoriteStore:       favoriteStore,
 		autolinkSvc:         autolinkSvc,
 		spaceSvc:            spaceSvc,
+		milestoneSvc:        milestoneSvc,
+		automationSvc:       automationSvc,
+		reviewAnalyticsSvc:  reviewAnalyticsSvc,
 	}
 }
 
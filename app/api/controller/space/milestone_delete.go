@@ -0,0 +1,43 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types/enum"
+)
+
+// DeleteMilestone deletes a milestone of the specified space.
+// Pull requests associated with the milestone are left without a milestone.
+func (c *Controller) DeleteMilestone(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	milestoneID int64,
+) error {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := c.milestoneSvc.Delete(ctx, &space.ID, nil, milestoneID); err != nil {
+		return fmt.Errorf("failed to delete space milestone: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// UpdateMilestone updates a milestone of the specified space.
+func (c *Controller) UpdateMilestone(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	milestoneID int64,
+	in *types.UpdateMilestoneInput,
+) (*types.Milestone, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	if err := in.Sanitize(); err != nil {
+		return nil, fmt.Errorf("failed to sanitize input: %w", err)
+	}
+
+	milestone, err := c.milestoneSvc.Update(ctx, session.Principal.ID, &space.ID, nil, milestoneID, in)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update space milestone: %w", err)
+	}
+
+	return milestone, nil
+}
@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package space
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// ReviewAnalytics returns pull request review metrics of all repositories in the specified space.
+func (c *Controller) ReviewAnalytics(
+	ctx context.Context,
+	session *auth.Session,
+	spaceRef string,
+	includeSubspaces bool,
+	filter *types.ReviewAnalyticsFilter,
+) (*types.ReviewAnalytics, error) {
+	space, err := c.getSpaceCheckAuth(ctx, session, spaceRef, enum.PermissionSpaceView)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to space: %w", err)
+	}
+
+	filter.SpaceIDs = []int64{space.ID}
+	if includeSubspaces {
+		filter.SpaceIDs, err = c.spaceStore.GetDescendantsIDs(ctx, space.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get space descendants: %w", err)
+		}
+	}
+
+	analytics, err := c.reviewAnalyticsSvc.Compute(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute space review analytics: %w", err)
+	}
+
+	return analytics, nil
+}
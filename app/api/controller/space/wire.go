@@ -19,15 +19,18 @@ import (
 	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/importer"
 	infraprovider2 "github.com/harness/gitness/app/services/infraprovider"
 	"github.com/harness/gitness/app/services/instrument"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/publicaccess"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	"github.com/harness/gitness/app/services/space"
 	"github.com/harness/gitness/app/sse"
@@ -59,7 +62,8 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 	labelSvc *label.Service, instrumentation instrument.Service, executionStore store.ExecutionStore,
 	rulesSvc *rules.Service, usageMetricStore store.UsageMetricStore, repoIdentifierCheck check.RepoIdentifier,
 	infraProviderSvc *infraprovider2.Service, favoriteStore store.FavoriteStore, autolinkSvc *autolink.Service,
-	spaceSvc *space.Service,
+	spaceSvc *space.Service, milestoneSvc *milestone.Service, automationSvc *automation.Service,
+	reviewAnalyticsSvc *reviewanalytics.Service,
 ) *Controller {
 	return NewController(config, tx, urlProvider,
 		sseStreamer, identifierCheck, authorizer,
@@ -72,6 +76,7 @@ func ProvideController(config *types.Config, tx dbtx.Transactor, urlProvider url
 		auditService, gitspaceService,
 		labelSvc, instrumentation, executionStore,
 		rulesSvc, usageMetricStore, repoIdentifierCheck,
-		infraProviderSvc, favoriteStore, autolinkSvc, spaceSvc,
+		infraProviderSvc, favoriteStore, autolinkSvc, spaceSvc, milestoneSvc, automationSvc,
+		reviewAnalyticsSvc,
 	)
 }
@@ -0,0 +1,32 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/types"
+)
+
+// FindSigningKey returns the public key used to verify webhook requests signed with the ed25519 signature mode.
+func (c *Controller) FindSigningKey(_ context.Context) (*types.WebhookSigningKey, error) {
+	key, err := c.webhookService.SigningKeyInfo()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook signing key: %w", err)
+	}
+
+	return key, nil
+}
@@ -0,0 +1,51 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// RedeliverExecutionsRepo queues the redelivery of all failed webhook executions since the provided time.
+func (c *Controller) RedeliverExecutionsRepo(
+	ctx context.Context,
+	session *auth.Session,
+	repoRef string,
+	webhookIdentifier string,
+	in *types.WebhookRedeliverInput,
+) (*types.WebhookRedeliverOutput, error) {
+	if in.Since <= 0 {
+		return nil, errors.InvalidArgument("A valid since time must be provided.")
+	}
+
+	repo, err := c.getRepoCheckAccess(ctx, session, repoRef, enum.PermissionRepoEdit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire access to the repo: %w", err)
+	}
+
+	count, err := c.webhookService.RedeliverFailedExecutions(
+		ctx, repo.ID, enum.WebhookParentRepo, webhookIdentifier, in.Since)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.WebhookRedeliverOutput{Count: count}, nil
+}
@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types"
+
+	"github.com/rs/zerolog/log"
+)
+
+// HandleListNotifications returns a http.HandlerFunc that lists the in-app notifications of the current user.
+func HandleListNotifications(notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		filter, err := request.ParseNotificationFilter(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		notifications, count, err := notificationCtrl.ListNotifications(ctx, session, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.Pagination(r, w, filter.Page, filter.Size, int(count))
+		render.JSON(w, http.StatusOK, notifications)
+	}
+}
+
+// HandleCountNotifications returns a http.HandlerFunc that returns the number of unread
+// in-app notifications of the current user.
+func HandleCountNotifications(notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		count, err := notificationCtrl.CountNotifications(ctx, session)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, count)
+	}
+}
+
+// HandleMarkNotificationsRead returns a http.HandlerFunc that marks in-app notifications of the current user as read.
+func HandleMarkNotificationsRead(notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		in := new(types.NotificationMarkReadInput)
+		err := json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		count, err := notificationCtrl.MarkNotificationsRead(ctx, session, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, count)
+	}
+}
+
+// HandleMarkAllNotificationsRead returns a http.HandlerFunc that marks all in-app notifications
+// of the current user as read.
+func HandleMarkAllNotificationsRead(notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		count, err := notificationCtrl.MarkAllNotificationsRead(ctx, session)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, count)
+	}
+}
+
+// HandleNotificationEvents returns a http.HandlerFunc that streams the in-app notifications
+// of the current user as they are created.
+func HandleNotificationEvents(appCtx context.Context, notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) { //nolint:contextcheck
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx) //nolint:contextcheck
+
+		chEvents, chErr, sseCancel := notificationCtrl.NotificationEvents(ctx, session) //nolint:contextcheck
+		defer func() {
+			if err := sseCancel(ctx); err != nil {
+				log.Ctx(ctx).Err(err).Msgf("failed to cancel sse stream for principal %d", session.Principal.ID)
+			}
+		}()
+
+		render.StreamSSE(ctx, w, appCtx.Done(), chEvents, chErr) //nolint:contextcheck
+	}
+}
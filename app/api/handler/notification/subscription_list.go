@@ -0,0 +1,42 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/notification"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+)
+
+// HandleListSubscriptions returns a http.HandlerFunc that lists the notification subscriptions of the current user.
+func HandleListSubscriptions(notificationCtrl *notification.Controller) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		filter := request.ParseNotificationSubscriptionFilter(r)
+
+		subscriptions, err := notificationCtrl.ListSubscriptions(ctx, session, filter)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.PaginationNoTotal(r, w, filter.Page, filter.Size, len(subscriptions) < filter.Size)
+		render.JSON(w, http.StatusOK, subscriptions)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/render"
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/types/enum"
+)
+
+// HandleReactionAdd returns a http.HandlerFunc that adds a reaction to the pull request description.
+func HandleReactionAdd(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionAdd(pullreqCtrl, false)
+}
+
+// HandleCommentReactionAdd returns a http.HandlerFunc that adds a reaction to a pull request comment.
+func HandleCommentReactionAdd(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionAdd(pullreqCtrl, true)
+}
+
+// HandleReactionDelete returns a http.HandlerFunc that removes a reaction from the pull request description.
+func HandleReactionDelete(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionDelete(pullreqCtrl, false)
+}
+
+// HandleCommentReactionDelete returns a http.HandlerFunc that removes a reaction from a pull request comment.
+func HandleCommentReactionDelete(pullreqCtrl *pullreq.Controller) http.HandlerFunc {
+	return handleReactionDelete(pullreqCtrl, true)
+}
+
+func handleReactionAdd(pullreqCtrl *pullreq.Controller, isComment bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commentID, err := reactionCommentIDFromPath(r, isComment)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		in := new(pullreq.ReactionInput)
+		err = json.NewDecoder(r.Body).Decode(in)
+		if err != nil {
+			render.BadRequestf(ctx, w, "Invalid Request Body: %s.", err)
+			return
+		}
+
+		reactions, err := pullreqCtrl.ReactionAdd(ctx, session, repoRef, pullreqNumber, commentID, in)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, reactions)
+	}
+}
+
+func handleReactionDelete(pullreqCtrl *pullreq.Controller, isComment bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		session, _ := request.AuthSessionFrom(ctx)
+
+		repoRef, err := request.GetRepoRefFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		pullreqNumber, err := request.GetPullReqNumberFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		commentID, err := reactionCommentIDFromPath(r, isComment)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		reaction, err := request.GetPullReqReactionFromPath(r)
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		reactions, err := pullreqCtrl.ReactionDelete(ctx, session, repoRef, pullreqNumber, commentID,
+			enum.PullReqReaction(reaction))
+		if err != nil {
+			render.TranslatedUserError(ctx, w, err)
+			return
+		}
+
+		render.JSON(w, http.StatusOK, reactions)
+	}
+}
+
+func reactionCommentIDFromPath(r *http.Request, isComment bool) (*int64, error) {
+	if !isComment {
+		return nil, nil
+	}
+
+	commentID, err := request.GetPullReqCommentIDPath(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commentID, nil
+}
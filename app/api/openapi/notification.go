@@ -0,0 +1,346 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/app/api/request"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/swaggest/openapi-go/openapi3"
+)
+
+type setRepoSubscriptionRequest struct {
+	repoRequest
+	types.NotificationSubscriptionInput
+}
+
+type setSpaceSubscriptionRequest struct {
+	spaceRequest
+	types.NotificationSubscriptionInput
+}
+
+type setPullReqSubscriptionRequest struct {
+	pullReqRequest
+	types.NotificationSubscriptionInput
+}
+
+type notificationChannelRequest struct {
+	spaceRequest
+	Identifier string `path:"notification_channel_identifier"`
+}
+
+type createNotificationChannelRequest struct {
+	spaceRequest
+	types.NotificationChannelCreateInput
+}
+
+type updateNotificationChannelRequest struct {
+	notificationChannelRequest
+	types.NotificationChannelUpdateInput
+}
+
+type notificationTemplateRequest struct {
+	Name string `path:"notification_template"`
+}
+
+type updateNotificationTemplateRequest struct {
+	notificationTemplateRequest
+	types.NotificationTemplateInput
+}
+
+var queryParameterNotificationResourceType = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamResourceType,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The type of the resources the subscriptions are listed for."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+				Enum: enum.NotificationResourceType("").Enum(),
+			},
+		},
+	},
+}
+
+var queryParameterNotificationUnread = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamUnread,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("List only the unread notifications."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeBoolean),
+				Default: ptrptr(false),
+			},
+		},
+	},
+}
+
+//nolint:funlen // api spec generation no need for checking func complexity
+func notificationOperations(reflector *openapi3.Reflector) {
+	opListPreferences := openapi3.Operation{}
+	opListPreferences.WithTags("notification")
+	opListPreferences.WithMapOfAnything(map[string]any{"operationId": "listNotificationPreferences"})
+	_ = reflector.SetRequest(&opListPreferences, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListPreferences, new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListPreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListPreferences, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-preferences", opListPreferences)
+
+	opUpdatePreferences := openapi3.Operation{}
+	opUpdatePreferences.WithTags("notification")
+	opUpdatePreferences.WithMapOfAnything(map[string]any{"operationId": "updateNotificationPreferences"})
+	_ = reflector.SetRequest(&opUpdatePreferences, new(types.NotificationPreferencesUpdateInput), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdatePreferences, new([]types.NotificationPreference), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdatePreferences, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdatePreferences, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdatePreferences, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch, "/user/notification-preferences", opUpdatePreferences)
+
+	opListSubscriptions := openapi3.Operation{}
+	opListSubscriptions.WithTags("notification")
+	opListSubscriptions.WithMapOfAnything(map[string]any{"operationId": "listNotificationSubscriptions"})
+	opListSubscriptions.WithParameters(queryParameterNotificationResourceType, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListSubscriptions, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new([]types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListSubscriptions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notification-subscriptions", opListSubscriptions)
+
+	opListNotifications := openapi3.Operation{}
+	opListNotifications.WithTags("notification")
+	opListNotifications.WithMapOfAnything(map[string]any{"operationId": "listNotifications"})
+	opListNotifications.WithParameters(queryParameterNotificationUnread, QueryParameterPage, QueryParameterLimit)
+	_ = reflector.SetRequest(&opListNotifications, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListNotifications, new([]types.Notification), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListNotifications, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications", opListNotifications)
+
+	opCountNotifications := openapi3.Operation{}
+	opCountNotifications.WithTags("notification")
+	opCountNotifications.WithMapOfAnything(map[string]any{"operationId": "countNotifications"})
+	_ = reflector.SetRequest(&opCountNotifications, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opCountNotifications, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCountNotifications, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCountNotifications, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/user/notifications/count", opCountNotifications)
+
+	opMarkRead := openapi3.Operation{}
+	opMarkRead.WithTags("notification")
+	opMarkRead.WithMapOfAnything(map[string]any{"operationId": "markNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkRead, new(types.NotificationMarkReadInput), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMarkRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/read", opMarkRead)
+
+	opMarkAllRead := openapi3.Operation{}
+	opMarkAllRead.WithTags("notification")
+	opMarkAllRead.WithMapOfAnything(map[string]any{"operationId": "markAllNotificationsRead"})
+	_ = reflector.SetRequest(&opMarkAllRead, nil, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(types.NotificationCount), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opMarkAllRead, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/user/notifications/read-all", opMarkAllRead)
+
+	notificationChannelOperations(reflector)
+	notificationTemplateOperations(reflector)
+
+	subscriptionOperations(reflector, "Space", "/spaces/{space_ref}/subscription",
+		new(spaceRequest), new(setSpaceSubscriptionRequest))
+	subscriptionOperations(reflector, "Repo", "/repos/{repo_ref}/subscription",
+		new(repoRequest), new(setRepoSubscriptionRequest))
+	subscriptionOperations(reflector, "PullReq", "/repos/{repo_ref}/pullreq/{pullreq_number}/subscription",
+		new(pullReqRequest), new(setPullReqSubscriptionRequest))
+}
+
+//nolint:funlen // api spec generation no need for checking func complexity
+func notificationChannelOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("notification")
+	opList.WithMapOfAnything(map[string]any{"operationId": "listNotificationChannels"})
+	_ = reflector.SetRequest(&opList, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/notification-channels", opList)
+
+	opCreate := openapi3.Operation{}
+	opCreate.WithTags("notification")
+	opCreate.WithMapOfAnything(map[string]any{"operationId": "createNotificationChannel"})
+	_ = reflector.SetRequest(&opCreate, new(createNotificationChannelRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreate, new(types.NotificationChannel), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opCreate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/notification-channels", opCreate)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("notification")
+	opFind.WithMapOfAnything(map[string]any{"operationId": "findNotificationChannel"})
+	_ = reflector.SetRequest(&opFind, new(notificationChannelRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("notification")
+	opUpdate.WithMapOfAnything(map[string]any{"operationId": "updateNotificationChannel"})
+	_ = reflector.SetRequest(&opUpdate, new(updateNotificationChannelRequest), http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.NotificationChannel), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusConflict)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPatch,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opUpdate)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("notification")
+	opDelete.WithMapOfAnything(map[string]any{"operationId": "deleteNotificationChannel"})
+	_ = reflector.SetRequest(&opDelete, new(notificationChannelRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/spaces/{space_ref}/notification-channels/{notification_channel_identifier}", opDelete)
+}
+
+//nolint:funlen // api spec generation no need for checking func complexity
+func notificationTemplateOperations(reflector *openapi3.Reflector) {
+	opList := openapi3.Operation{}
+	opList.WithTags("admin")
+	opList.WithMapOfAnything(map[string]any{"operationId": "listNotificationTemplates"})
+	_ = reflector.SetRequest(&opList, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opList, new([]types.NotificationTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/admin/notification-templates", opList)
+
+	opFind := openapi3.Operation{}
+	opFind.WithTags("admin")
+	opFind.WithMapOfAnything(map[string]any{"operationId": "findNotificationTemplate"})
+	_ = reflector.SetRequest(&opFind, new(notificationTemplateRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.NotificationTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/admin/notification-templates/{notification_template}", opFind)
+
+	opUpdate := openapi3.Operation{}
+	opUpdate.WithTags("admin")
+	opUpdate.WithMapOfAnything(map[string]any{"operationId": "updateNotificationTemplate"})
+	_ = reflector.SetRequest(&opUpdate, new(updateNotificationTemplateRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&opUpdate, new(types.NotificationTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opUpdate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/admin/notification-templates/{notification_template}", opUpdate)
+
+	opReset := openapi3.Operation{}
+	opReset.WithTags("admin")
+	opReset.WithMapOfAnything(map[string]any{"operationId": "resetNotificationTemplate"})
+	_ = reflector.SetRequest(&opReset, new(notificationTemplateRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opReset, new(types.NotificationTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opReset, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/admin/notification-templates/{notification_template}", opReset)
+
+	opPreview := openapi3.Operation{}
+	opPreview.WithTags("admin")
+	opPreview.WithMapOfAnything(map[string]any{"operationId": "previewNotificationTemplate"})
+	_ = reflector.SetRequest(&opPreview, new(updateNotificationTemplateRequest), http.MethodPost)
+	_ = reflector.SetJSONResponse(&opPreview, new(types.NotificationEmail), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opPreview, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opPreview, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opPreview, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opPreview, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opPreview, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/admin/notification-templates/{notification_template}/preview", opPreview)
+}
+
+// subscriptionOperations constructs the find, set and delete operations of the notification subscription
+// of a space, repository or pull request.
+func subscriptionOperations(
+	reflector *openapi3.Reflector,
+	resource string,
+	path string,
+	req any,
+	setReq any,
+) {
+	opFind := openapi3.Operation{}
+	opFind.WithTags("notification")
+	opFind.WithMapOfAnything(map[string]any{"operationId": "find" + resource + "NotificationSubscription"})
+	_ = reflector.SetRequest(&opFind, req, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFind, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opFind, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, path, opFind)
+
+	opSet := openapi3.Operation{}
+	opSet.WithTags("notification")
+	opSet.WithMapOfAnything(map[string]any{"operationId": "set" + resource + "NotificationSubscription"})
+	_ = reflector.SetRequest(&opSet, setReq, http.MethodPut)
+	_ = reflector.SetJSONResponse(&opSet, new(types.NotificationSubscription), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opSet, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opSet, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opSet, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opSet, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodPut, path, opSet)
+
+	opDelete := openapi3.Operation{}
+	opDelete.WithTags("notification")
+	opDelete.WithMapOfAnything(map[string]any{"operationId": "delete" + resource + "NotificationSubscription"})
+	_ = reflector.SetRequest(&opDelete, req, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodDelete, path, opDelete)
+}
@@ -111,6 +111,26 @@ type commentStatusPullReqRequest struct {
 	pullreq.CommentStatusInput
 }
 
+type reactionAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.ReactionInput
+}
+
+type reactionDeletePullReqRequest struct {
+	pullReqRequest
+	Reaction enum.PullReqReaction `path:"pullreq_reaction"`
+}
+
+type commentReactionAddPullReqRequest struct {
+	pullReqCommentRequest
+	pullreq.ReactionInput
+}
+
+type commentReactionDeletePullReqRequest struct {
+	pullReqCommentRequest
+	Reaction enum.PullReqReaction `path:"pullreq_reaction"`
+}
+
 type reviewerListPullReqRequest struct {
 	pullReqRequest
 }
@@ -125,6 +145,25 @@ type reviewerAddPullReqRequest struct {
 	pullreq.ReviewerAddInput
 }
 
+type assigneeListPullReqRequest struct {
+	pullReqRequest
+}
+
+type assigneeDeletePullReqRequest struct {
+	pullReqRequest
+	PullReqAssigneeID int64 `path:"pullreq_assignee_id"`
+}
+
+type assigneeAddPullReqRequest struct {
+	pullReqRequest
+	pullreq.AssigneeAddInput
+}
+
+type milestoneAssignPullReqRequest struct {
+	pullReqRequest
+	pullreq.MilestoneAssignInput
+}
+
 type reviewSubmitPullReqRequest struct {
 	pullreq.ReviewSubmitInput
 	pullReqRequest
@@ -490,6 +529,48 @@ var queryParameterMentionedID = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterAssigneeID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamAssigneeID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only pull requests assigned to one of these users."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterMilestoneID = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamMilestoneID,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("Return only pull requests that belong to one of these milestones."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeInteger),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
 //nolint:funlen
 func pullReqOperations(reflector *openapi3.Reflector) {
 	createPullReq := openapi3.Operation{}
@@ -517,6 +598,7 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 		QueryParameterLabelID, QueryParameterValueID,
 		queryParameterAuthorID, queryParameterCommenterID, queryParameterMentionedID,
 		queryParameterReviewerID, queryParameterReviewDecision,
+		queryParameterAssigneeID, queryParameterMilestoneID,
 		queryParamIncludeGitStats, queryParameterIncludeChecks, queryParameterIncludeRules)
 	_ = reflector.SetRequest(&listPullReq, new(listPullReqRequest), http.MethodGet)
 	_ = reflector.SetJSONResponse(&listPullReq, new([]types.PullReq), http.StatusOK)
@@ -637,6 +719,55 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPut,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/status", commentStatusPullReq)
 
+	reactionAddPullReq := openapi3.Operation{}
+	reactionAddPullReq.WithTags("pullreq")
+	reactionAddPullReq.WithMapOfAnything(map[string]any{"operationId": "reactionAddPullReq"})
+	_ = reflector.SetRequest(&reactionAddPullReq, new(reactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(types.PullReqReactions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions", reactionAddPullReq)
+
+	reactionDeletePullReq := openapi3.Operation{}
+	reactionDeletePullReq.WithTags("pullreq")
+	reactionDeletePullReq.WithMapOfAnything(map[string]any{"operationId": "reactionDeletePullReq"})
+	_ = reflector.SetRequest(&reactionDeletePullReq, new(reactionDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(types.PullReqReactions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&reactionDeletePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/reactions/{pullreq_reaction}", reactionDeletePullReq)
+
+	commentReactionAddPullReq := openapi3.Operation{}
+	commentReactionAddPullReq.WithTags("pullreq")
+	commentReactionAddPullReq.WithMapOfAnything(map[string]any{"operationId": "commentReactionAddPullReq"})
+	_ = reflector.SetRequest(&commentReactionAddPullReq, new(commentReactionAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(types.PullReqReactions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionAddPullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions", commentReactionAddPullReq)
+
+	commentReactionDeletePullReq := openapi3.Operation{}
+	commentReactionDeletePullReq.WithTags("pullreq")
+	commentReactionDeletePullReq.WithMapOfAnything(map[string]any{"operationId": "commentReactionDeletePullReq"})
+	_ = reflector.SetRequest(&commentReactionDeletePullReq, new(commentReactionDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(types.PullReqReactions), http.StatusOK)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&commentReactionDeletePullReq, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/comments/{pullreq_comment_id}/reactions/{pullreq_reaction}",
+		commentReactionDeletePullReq)
+
 	commentApplySuggestions := openapi3.Operation{}
 	commentApplySuggestions.WithTags("pullreq")
 	commentApplySuggestions.WithMapOfAnything(map[string]any{"operationId": "commentApplySuggestions"})
@@ -765,6 +896,35 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/revert", revertPullReqOp)
 
+	mergeConflictsPullReqOp := openapi3.Operation{}
+	mergeConflictsPullReqOp.WithTags("pullreq")
+	mergeConflictsPullReqOp.WithMapOfAnything(map[string]any{"operationId": "mergeConflictsPullReqOp"})
+	_ = reflector.SetRequest(&mergeConflictsPullReqOp, new(pullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&mergeConflictsPullReqOp, new(types.PullReqMergeConflicts), http.StatusOK)
+	_ = reflector.SetJSONResponse(&mergeConflictsPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&mergeConflictsPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&mergeConflictsPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&mergeConflictsPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/conflicts", mergeConflictsPullReqOp)
+
+	resolveConflictsPullReqOp := openapi3.Operation{}
+	resolveConflictsPullReqOp.WithTags("pullreq")
+	resolveConflictsPullReqOp.WithMapOfAnything(map[string]any{"operationId": "resolveConflictsPullReqOp"})
+	_ = reflector.SetRequest(&resolveConflictsPullReqOp, &struct {
+		pullReqRequest
+		pullreq.ResolveConflictsInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(types.ResolveConflictsResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&resolveConflictsPullReqOp, new(types.MergeViolations),
+		http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/conflicts/resolve", resolveConflictsPullReqOp)
+
 	opListCommits := openapi3.Operation{}
 	opListCommits.WithTags("pullreq")
 	opListCommits.WithMapOfAnything(map[string]any{"operationId": "listPullReqCommits"})
@@ -1011,4 +1171,77 @@ func pullReqOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opAutoMergeGet, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet,
 		"/repos/{repo_ref}/pullreq/{pullreq_number}/automerge", opAutoMergeGet)
+
+	assigneeAdd := openapi3.Operation{}
+	assigneeAdd.WithTags("pullreq")
+	assigneeAdd.WithMapOfAnything(map[string]any{"operationId": "assigneeAddPullReq"})
+	_ = reflector.SetRequest(&assigneeAdd, new(assigneeAddPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&assigneeAdd, new(types.PullReqAssignee), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assigneeAdd, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeAdd, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeAdd, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeAdd, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/assignees", assigneeAdd)
+
+	assigneeList := openapi3.Operation{}
+	assigneeList.WithTags("pullreq")
+	assigneeList.WithMapOfAnything(map[string]any{"operationId": "assigneeListPullReq"})
+	_ = reflector.SetRequest(&assigneeList, new(assigneeListPullReqRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&assigneeList, new([]*types.PullReqAssignee), http.StatusOK)
+	_ = reflector.SetJSONResponse(&assigneeList, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeList, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeList, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeList, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/assignees", assigneeList)
+
+	assigneeDelete := openapi3.Operation{}
+	assigneeDelete.WithTags("pullreq")
+	assigneeDelete.WithMapOfAnything(map[string]any{"operationId": "assigneeDeletePullReq"})
+	_ = reflector.SetRequest(&assigneeDelete, new(assigneeDeletePullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&assigneeDelete, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&assigneeDelete, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&assigneeDelete, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&assigneeDelete, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&assigneeDelete, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&assigneeDelete, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/assignees/{pullreq_assignee_id}", assigneeDelete)
+
+	milestoneAssign := openapi3.Operation{}
+	milestoneAssign.WithTags("pullreq")
+	milestoneAssign.WithMapOfAnything(map[string]any{"operationId": "milestoneAssignPullReq"})
+	_ = reflector.SetRequest(&milestoneAssign, new(milestoneAssignPullReqRequest), http.MethodPut)
+	_ = reflector.SetJSONResponse(&milestoneAssign, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&milestoneAssign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&milestoneAssign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&milestoneAssign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&milestoneAssign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodPut,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/milestone", milestoneAssign)
+
+	milestoneUnassign := openapi3.Operation{}
+	milestoneUnassign.WithTags("pullreq")
+	milestoneUnassign.WithMapOfAnything(map[string]any{"operationId": "milestoneUnassignPullReq"})
+	_ = reflector.SetRequest(&milestoneUnassign, new(pullReqRequest), http.MethodDelete)
+	_ = reflector.SetJSONResponse(&milestoneUnassign, new(types.PullReq), http.StatusOK)
+	_ = reflector.SetJSONResponse(&milestoneUnassign, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&milestoneUnassign, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&milestoneUnassign, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&milestoneUnassign, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodDelete,
+		"/repos/{repo_ref}/pullreq/{pullreq_number}/milestone", milestoneUnassign)
+
+	opTemplates := openapi3.Operation{}
+	opTemplates.WithTags("pullreq")
+	opTemplates.WithMapOfAnything(map[string]any{"operationId": "listPullReqTemplates"})
+	opTemplates.WithParameters(queryParameterTargetBranchPullRequest)
+	_ = reflector.SetRequest(&opTemplates, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opTemplates, new([]types.PullReqTemplate), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opTemplates, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opTemplates, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opTemplates, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opTemplates, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/pullreq/templates", opTemplates)
 }
@@ -785,6 +785,72 @@ var queryParameterIncludeValues = openapi3.ParameterOrRef{
 	},
 }
 
+var queryParameterQueryMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the milestones by their title."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterQueryAutomation = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamQuery,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The substring which is used to filter the automations by their name."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeString),
+			},
+		},
+	},
+}
+
+var queryParameterMilestoneState = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamState,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The state of the milestones to return."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type: ptrSchemaType(openapi3.SchemaTypeArray),
+				Items: &openapi3.SchemaOrRef{
+					Schema: &openapi3.Schema{
+						Type: ptrSchemaType(openapi3.SchemaTypeString),
+						Enum: enum.MilestoneState("").Enum(),
+					},
+				},
+			},
+		},
+		Style:   ptr.String(string(openapi3.EncodingStyleForm)),
+		Explode: ptr.Bool(true),
+	},
+}
+
+var queryParameterSortMilestone = openapi3.ParameterOrRef{
+	Parameter: &openapi3.Parameter{
+		Name:        request.QueryParamSort,
+		In:          openapi3.ParameterInQuery,
+		Description: ptr.String("The data by which the milestones are sorted."),
+		Required:    ptr.Bool(false),
+		Schema: &openapi3.SchemaOrRef{
+			Schema: &openapi3.Schema{
+				Type:    ptrSchemaType(openapi3.SchemaTypeString),
+				Default: ptrptr(enum.MilestoneSortDueDate),
+				Enum:    enum.MilestoneSort("").Enum(),
+			},
+		},
+	},
+}
+
 //nolint:funlen
 func repoOperations(reflector *openapi3.Reflector) {
 	createRepository := openapi3.Operation{}
@@ -1518,6 +1584,24 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/squash", opSquashBranch)
 
+	opCherryPick := openapi3.Operation{}
+	opCherryPick.WithTags("repository")
+	opCherryPick.WithMapOfAnything(
+		map[string]any{"operationId": "cherryPick"})
+	_ = reflector.SetRequest(&opCherryPick, &struct {
+		repoRequest
+		repo.CherryPickInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.CherryPickResponse), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.SetJSONResponse(&opCherryPick, new(types.MergeViolations), http.StatusUnprocessableEntity)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/cherry-pick", opCherryPick)
+
 	opForkCreate := openapi3.Operation{}
 	opForkCreate.WithTags("repository")
 	opForkCreate.WithMapOfAnything(
@@ -1550,4 +1634,187 @@ func repoOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opForkSyncBranch, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opForkSyncBranch, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/fork-sync", opForkSyncBranch)
+
+	opCreateMilestone := openapi3.Operation{}
+	opCreateMilestone.WithTags("repository")
+	opCreateMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "createRepoMilestone"})
+	_ = reflector.SetRequest(&opCreateMilestone, &struct {
+		repoRequest
+		types.CreateMilestoneInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(types.Milestone), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/milestones", opCreateMilestone)
+
+	opListMilestones := openapi3.Operation{}
+	opListMilestones.WithTags("repository")
+	opListMilestones.WithMapOfAnything(
+		map[string]any{"operationId": "listRepoMilestones"})
+	opListMilestones.WithParameters(
+		QueryParameterPage, QueryParameterLimit, QueryParameterInherited, queryParameterQueryMilestone,
+		queryParameterMilestoneState, queryParameterSortMilestone, queryParameterOrder)
+	_ = reflector.SetRequest(&opListMilestones, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListMilestones, new([]*types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/milestones", opListMilestones)
+
+	opFindMilestone := openapi3.Operation{}
+	opFindMilestone.WithTags("repository")
+	opFindMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "findRepoMilestone"})
+	_ = reflector.SetRequest(&opFindMilestone, &struct {
+		repoRequest
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/milestones/{milestone_id}", opFindMilestone)
+
+	opUpdateMilestone := openapi3.Operation{}
+	opUpdateMilestone.WithTags("repository")
+	opUpdateMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "updateRepoMilestone"})
+	_ = reflector.SetRequest(&opUpdateMilestone, &struct {
+		repoRequest
+		types.UpdateMilestoneInput
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/repos/{repo_ref}/milestones/{milestone_id}", opUpdateMilestone)
+
+	opDeleteMilestone := openapi3.Operation{}
+	opDeleteMilestone.WithTags("repository")
+	opDeleteMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "deleteRepoMilestone"})
+	_ = reflector.SetRequest(&opDeleteMilestone, &struct {
+		repoRequest
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/repos/{repo_ref}/milestones/{milestone_id}", opDeleteMilestone)
+
+	opCreateAutomation := openapi3.Operation{}
+	opCreateAutomation.WithTags("repository")
+	opCreateAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "createRepoAutomation"})
+	_ = reflector.SetRequest(&opCreateAutomation, &struct {
+		repoRequest
+		types.CreateAutomationInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(types.Automation), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/automations", opCreateAutomation)
+
+	opListAutomations := openapi3.Operation{}
+	opListAutomations.WithTags("repository")
+	opListAutomations.WithMapOfAnything(
+		map[string]any{"operationId": "listRepoAutomations"})
+	opListAutomations.WithParameters(
+		QueryParameterPage, QueryParameterLimit, QueryParameterInherited, queryParameterQueryAutomation)
+	_ = reflector.SetRequest(&opListAutomations, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListAutomations, new([]*types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/automations", opListAutomations)
+
+	opReviewAnalytics := openapi3.Operation{}
+	opReviewAnalytics.WithTags("repository")
+	opReviewAnalytics.WithMapOfAnything(
+		map[string]any{"operationId": "repoReviewAnalytics"})
+	opReviewAnalytics.WithParameters(
+		queryParameterCreatedLt, queryParameterCreatedGt,
+		QueryParameterLabelID, QueryParameterValueID)
+	_ = reflector.SetRequest(&opReviewAnalytics, new(repoRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(types.ReviewAnalytics), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/analytics/reviews", opReviewAnalytics)
+
+	opFindAutomation := openapi3.Operation{}
+	opFindAutomation.WithTags("repository")
+	opFindAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "findRepoAutomation"})
+	_ = reflector.SetRequest(&opFindAutomation, &struct {
+		repoRequest
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/repos/{repo_ref}/automations/{automation_id}", opFindAutomation)
+
+	opUpdateAutomation := openapi3.Operation{}
+	opUpdateAutomation.WithTags("repository")
+	opUpdateAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "updateRepoAutomation"})
+	_ = reflector.SetRequest(&opUpdateAutomation, &struct {
+		repoRequest
+		types.UpdateAutomationInput
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/repos/{repo_ref}/automations/{automation_id}", opUpdateAutomation)
+
+	opDeleteAutomation := openapi3.Operation{}
+	opDeleteAutomation.WithTags("repository")
+	opDeleteAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "deleteRepoAutomation"})
+	_ = reflector.SetRequest(&opDeleteAutomation, &struct {
+		repoRequest
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/repos/{repo_ref}/automations/{automation_id}", opDeleteAutomation)
 }
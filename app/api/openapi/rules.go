@@ -17,6 +17,7 @@ package openapi
 import (
 	"net/http"
 
+	"github.com/harness/gitness/app/api/controller/repo"
 	"github.com/harness/gitness/app/api/request"
 	"github.com/harness/gitness/app/api/usererror"
 	"github.com/harness/gitness/app/services/protection"
@@ -259,6 +260,25 @@ func rulesOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opRepoRuleList, new(usererror.Error), http.StatusNotFound)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/repos/{repo_ref}/rules", opRepoRuleList)
 
+	opRepoRuleSimulate := openapi3.Operation{}
+	opRepoRuleSimulate.WithTags("repository")
+	opRepoRuleSimulate.WithMapOfAnything(map[string]any{"operationId": "repoRuleSimulate"})
+	_ = reflector.SetRequest(&opRepoRuleSimulate, struct {
+		repoRequest
+		repo.RuleSimulateInput
+
+		// overshadow "definition"
+		Type       RuleType       `json:"type"`
+		Definition RuleDefinition `json:"definition"`
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, types.RuleSimulation{}, http.StatusOK)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opRepoRuleSimulate, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/repos/{repo_ref}/rules/simulate", opRepoRuleSimulate)
+
 	opRepoRuleGet := openapi3.Operation{}
 	opRepoRuleGet.WithTags("repository")
 	opRepoRuleGet.WithMapOfAnything(map[string]any{"operationId": "repoRuleGet"})
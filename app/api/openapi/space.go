@@ -730,6 +730,22 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&countPullReq, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/pullreq/count", countPullReq)
 
+	opReviewAnalytics := openapi3.Operation{}
+	opReviewAnalytics.WithTags("space")
+	opReviewAnalytics.WithMapOfAnything(
+		map[string]any{"operationId": "spaceReviewAnalytics"})
+	opReviewAnalytics.WithParameters(
+		queryParameterCreatedLt, queryParameterCreatedGt, queryParameterIncludeSubspaces,
+		QueryParameterLabelID, QueryParameterValueID)
+	_ = reflector.SetRequest(&opReviewAnalytics, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(types.ReviewAnalytics), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opReviewAnalytics, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/analytics/reviews", opReviewAnalytics)
+
 	listPullReq := openapi3.Operation{}
 	listPullReq.WithTags("space")
 	listPullReq.WithMapOfAnything(map[string]any{"operationId": "listSpacePullReq"})
@@ -773,4 +789,171 @@ func spaceOperations(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opUsergroups, new(usererror.Error), http.StatusForbidden)
 	_ = reflector.SetJSONResponse(&opUsergroups, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/usergroups", opUsergroups)
+
+	opCreateMilestone := openapi3.Operation{}
+	opCreateMilestone.WithTags("space")
+	opCreateMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "createSpaceMilestone"})
+	_ = reflector.SetRequest(&opCreateMilestone, &struct {
+		spaceRequest
+		types.CreateMilestoneInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(types.Milestone), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/milestones", opCreateMilestone)
+
+	opListMilestones := openapi3.Operation{}
+	opListMilestones.WithTags("space")
+	opListMilestones.WithMapOfAnything(
+		map[string]any{"operationId": "listSpaceMilestones"})
+	opListMilestones.WithParameters(
+		QueryParameterPage, QueryParameterLimit, QueryParameterInherited, queryParameterQueryMilestone,
+		queryParameterMilestoneState, queryParameterSortMilestone, queryParameterOrder)
+	_ = reflector.SetRequest(&opListMilestones, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListMilestones, new([]*types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListMilestones, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/milestones", opListMilestones)
+
+	opFindMilestone := openapi3.Operation{}
+	opFindMilestone.WithTags("space")
+	opFindMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "findSpaceMilestone"})
+	_ = reflector.SetRequest(&opFindMilestone, &struct {
+		spaceRequest
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/milestones/{milestone_id}", opFindMilestone)
+
+	opUpdateMilestone := openapi3.Operation{}
+	opUpdateMilestone.WithTags("space")
+	opUpdateMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "updateSpaceMilestone"})
+	_ = reflector.SetRequest(&opUpdateMilestone, &struct {
+		spaceRequest
+		types.UpdateMilestoneInput
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(types.Milestone), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/spaces/{space_ref}/milestones/{milestone_id}", opUpdateMilestone)
+
+	opDeleteMilestone := openapi3.Operation{}
+	opDeleteMilestone.WithTags("space")
+	opDeleteMilestone.WithMapOfAnything(
+		map[string]any{"operationId": "deleteSpaceMilestone"})
+	_ = reflector.SetRequest(&opDeleteMilestone, &struct {
+		spaceRequest
+		MilestoneID int64 `path:"milestone_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteMilestone, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/spaces/{space_ref}/milestones/{milestone_id}", opDeleteMilestone)
+
+	opCreateAutomation := openapi3.Operation{}
+	opCreateAutomation.WithTags("space")
+	opCreateAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "createSpaceAutomation"})
+	_ = reflector.SetRequest(&opCreateAutomation, &struct {
+		spaceRequest
+		types.CreateAutomationInput
+	}{}, http.MethodPost)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(types.Automation), http.StatusCreated)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opCreateAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodPost, "/spaces/{space_ref}/automations", opCreateAutomation)
+
+	opListAutomations := openapi3.Operation{}
+	opListAutomations.WithTags("space")
+	opListAutomations.WithMapOfAnything(
+		map[string]any{"operationId": "listSpaceAutomations"})
+	opListAutomations.WithParameters(
+		QueryParameterPage, QueryParameterLimit, QueryParameterInherited, queryParameterQueryAutomation)
+	_ = reflector.SetRequest(&opListAutomations, new(spaceRequest), http.MethodGet)
+	_ = reflector.SetJSONResponse(&opListAutomations, new([]*types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opListAutomations, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/spaces/{space_ref}/automations", opListAutomations)
+
+	opFindAutomation := openapi3.Operation{}
+	opFindAutomation.WithTags("space")
+	opFindAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "findSpaceAutomation"})
+	_ = reflector.SetRequest(&opFindAutomation, &struct {
+		spaceRequest
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opFindAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodGet, "/spaces/{space_ref}/automations/{automation_id}", opFindAutomation)
+
+	opUpdateAutomation := openapi3.Operation{}
+	opUpdateAutomation.WithTags("space")
+	opUpdateAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "updateSpaceAutomation"})
+	_ = reflector.SetRequest(&opUpdateAutomation, &struct {
+		spaceRequest
+		types.UpdateAutomationInput
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodPatch)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(types.Automation), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opUpdateAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodPatch, "/spaces/{space_ref}/automations/{automation_id}", opUpdateAutomation)
+
+	opDeleteAutomation := openapi3.Operation{}
+	opDeleteAutomation.WithTags("space")
+	opDeleteAutomation.WithMapOfAnything(
+		map[string]any{"operationId": "deleteSpaceAutomation"})
+	_ = reflector.SetRequest(&opDeleteAutomation, &struct {
+		spaceRequest
+		AutomationID int64 `path:"automation_id"`
+	}{}, http.MethodDelete)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, nil, http.StatusNoContent)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&opDeleteAutomation, new(usererror.Error), http.StatusNotFound)
+	_ = reflector.Spec.AddOperation(
+		http.MethodDelete, "/spaces/{space_ref}/automations/{automation_id}", opDeleteAutomation)
 }
@@ -19,6 +19,7 @@ import (
 
 	"github.com/harness/gitness/app/api/handler/system"
 	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/types"
 
 	"github.com/swaggest/openapi-go/openapi3"
 )
@@ -34,4 +35,12 @@ func buildSystem(reflector *openapi3.Reflector) {
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusInternalServerError)
 	_ = reflector.SetJSONResponse(&opGetConfig, new(usererror.Error), http.StatusBadRequest)
 	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/config", opGetConfig)
+
+	opGetWebhookSigningKey := openapi3.Operation{}
+	opGetWebhookSigningKey.WithTags("system")
+	opGetWebhookSigningKey.WithMapOfAnything(map[string]any{"operationId": "getWebhookSigningKey"})
+	_ = reflector.SetRequest(&opGetWebhookSigningKey, nil, http.MethodGet)
+	_ = reflector.SetJSONResponse(&opGetWebhookSigningKey, new(types.WebhookSigningKey), http.StatusOK)
+	_ = reflector.SetJSONResponse(&opGetWebhookSigningKey, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.Spec.AddOperation(http.MethodGet, "/system/webhook-signing-key", opGetWebhookSigningKey)
 }
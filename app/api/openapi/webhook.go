@@ -94,6 +94,16 @@ type listRepoWebhookExecutionsRequest struct {
 	repoWebhookRequest
 }
 
+type redeliverSpaceWebhookExecutionsRequest struct {
+	spaceWebhookRequest
+	types.WebhookRedeliverInput
+}
+
+type redeliverRepoWebhookExecutionsRequest struct {
+	repoWebhookRequest
+	types.WebhookRedeliverInput
+}
+
 type spaceWebhookExecutionRequest struct {
 	spaceWebhookRequest
 	ID int64 `path:"webhook_execution_id"`
@@ -259,6 +269,24 @@ func webhookOperations(reflector *openapi3.Reflector) {
 		retriggerSpaceWebhookExecution,
 	)
 
+	redeliverSpaceWebhookExecutions := openapi3.Operation{}
+	redeliverSpaceWebhookExecutions.WithTags("webhook")
+	redeliverSpaceWebhookExecutions.WithMapOfAnything(
+		map[string]any{"operationId": "redeliverSpaceWebhookExecutions"},
+	)
+	_ = reflector.SetRequest(&redeliverSpaceWebhookExecutions, new(redeliverSpaceWebhookExecutionsRequest),
+		http.MethodPost)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(types.WebhookRedeliverOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&redeliverSpaceWebhookExecutions, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/spaces/{space_ref}/webhooks/{webhook_identifier}/executions/redeliver",
+		redeliverSpaceWebhookExecutions,
+	)
+
 	// repo
 
 	createRepoWebhook := openapi3.Operation{}
@@ -358,4 +386,19 @@ func webhookOperations(reflector *openapi3.Reflector) {
 	_ = reflector.Spec.AddOperation(http.MethodPost,
 		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/{webhook_execution_id}/retrigger",
 		retriggerRepoWebhookExecution)
+
+	redeliverRepoWebhookExecutions := openapi3.Operation{}
+	redeliverRepoWebhookExecutions.WithTags("webhook")
+	redeliverRepoWebhookExecutions.WithMapOfAnything(map[string]any{"operationId": "redeliverRepoWebhookExecutions"})
+	_ = reflector.SetRequest(&redeliverRepoWebhookExecutions, new(redeliverRepoWebhookExecutionsRequest),
+		http.MethodPost)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(types.WebhookRedeliverOutput), http.StatusOK)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusBadRequest)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusInternalServerError)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusUnauthorized)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusForbidden)
+	_ = reflector.SetJSONResponse(&redeliverRepoWebhookExecutions, new(usererror.Error), http.StatusPreconditionFailed)
+	_ = reflector.Spec.AddOperation(http.MethodPost,
+		"/repos/{repo_ref}/webhooks/{webhook_identifier}/executions/redeliver",
+		redeliverRepoWebhookExecutions)
 }
@@ -0,0 +1,44 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+const (
+	PathParamAutomationID = "automation_id"
+)
+
+// GetAutomationIDFromPath extracts the automation ID from the url.
+func GetAutomationIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAutomationID)
+}
+
+// ParseAutomationFilter extracts the automation filter from the url.
+func ParseAutomationFilter(r *http.Request) (*types.AutomationFilter, error) {
+	// inherited is used to list automations from parent scopes
+	inherited, err := ParseInheritedFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.AutomationFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		Inherited:       inherited,
+	}, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	PathParamMilestoneID = "milestone_id"
+)
+
+// GetMilestoneIDFromPath extracts the milestone ID from the url.
+func GetMilestoneIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamMilestoneID)
+}
+
+// ParseMilestoneFilter extracts the milestone filter from the url.
+func ParseMilestoneFilter(r *http.Request) (*types.MilestoneFilter, error) {
+	// inherited is used to list milestones from parent scopes
+	inherited, err := ParseInheritedFromQuery(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.MilestoneFilter{
+		ListQueryFilter: ParseListQueryFilterFromRequest(r),
+		States:          parseMilestoneStates(r),
+		Sort:            ParseSortMilestone(r),
+		Order:           ParseOrder(r),
+		Inherited:       inherited,
+	}, nil
+}
+
+// ParseSortMilestone extracts the milestone sort parameter from the url.
+func ParseSortMilestone(r *http.Request) enum.MilestoneSort {
+	result, _ := enum.MilestoneSort(r.URL.Query().Get(QueryParamSort)).Sanitize()
+	return result
+}
+
+// parseMilestoneStates extracts the milestone states from the url.
+func parseMilestoneStates(r *http.Request) []enum.MilestoneState {
+	strStates, _ := QueryParamList(r, QueryParamState)
+	states := make([]enum.MilestoneState, 0, len(strStates))
+	for _, s := range strStates {
+		if state, ok := enum.MilestoneState(s).Sanitize(); ok {
+			states = append(states, state)
+		}
+	}
+
+	return states
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	QueryParamUnread = "unread"
+
+	PathParamNotificationChannelIdentifier = "notification_channel_identifier"
+	PathParamNotificationTemplate          = "notification_template"
+)
+
+func GetNotificationChannelIdentifierFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamNotificationChannelIdentifier)
+}
+
+func GetNotificationTemplateFromPath(r *http.Request) (enum.NotificationTemplate, error) {
+	name, err := PathParamOrError(r, PathParamNotificationTemplate)
+	if err != nil {
+		return "", err
+	}
+
+	return enum.NotificationTemplate(name), nil
+}
+
+// ParseNotificationFilter extracts the in-app notification query parameters from the url.
+func ParseNotificationFilter(r *http.Request) (*types.NotificationFilter, error) {
+	unreadOnly, err := QueryParamAsBoolOrDefault(r, QueryParamUnread, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.NotificationFilter{
+		Pagination: ParsePaginationFromRequest(r),
+		UnreadOnly: unreadOnly,
+	}, nil
+}
+
+// ParseNotificationSubscriptionFilter extracts the notification subscription query parameters from the url.
+func ParseNotificationSubscriptionFilter(r *http.Request) *types.NotificationSubscriptionFilter {
+	return &types.NotificationSubscriptionFilter{
+		Pagination:   ParsePaginationFromRequest(r),
+		ResourceType: enum.NotificationResourceType(r.URL.Query().Get(QueryParamResourceType)),
+	}
+}
@@ -26,7 +26,9 @@ import (
 const (
 	PathParamPullReqNumber    = "pullreq_number"
 	PathParamPullReqCommentID = "pullreq_comment_id"
+	PathParamPullReqReaction  = "pullreq_reaction"
 	PathParamReviewerID       = "pullreq_reviewer_id"
+	PathParamAssigneeID       = "pullreq_assignee_id"
 	PathParamUserGroupID      = "user_group_id"
 	PathParamSourceBranch     = "source_branch"
 	PathParamTargetBranch     = "target_branch"
@@ -35,6 +37,8 @@ const (
 	QueryParamReviewerID         = "reviewer_id"
 	QueryParamReviewDecision     = "review_decision"
 	QueryParamMentionedID        = "mentioned_id"
+	QueryParamAssigneeID         = "assignee_id"
+	QueryParamMilestoneID        = "milestone_id"
 	QueryParamExcludeDescription = "exclude_description"
 	QueryParamSourceRepoRef      = "source_repo_ref"
 	QueryParamSourceBranch       = "source_branch"
@@ -48,6 +52,15 @@ func GetPullReqNumberFromPath(r *http.Request) (int64, error) {
 func GetReviewerIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamReviewerID)
 }
+
+func GetAssigneeIDFromPath(r *http.Request) (int64, error) {
+	return PathParamAsPositiveInt64(r, PathParamAssigneeID)
+}
+
+func GetTargetBranchFromQuery(r *http.Request) string {
+	return r.URL.Query().Get(QueryParamTargetBranch)
+}
+
 func GetUserGroupIDFromPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamUserGroupID)
 }
@@ -56,6 +69,10 @@ func GetPullReqCommentIDPath(r *http.Request) (int64, error) {
 	return PathParamAsPositiveInt64(r, PathParamPullReqCommentID)
 }
 
+func GetPullReqReactionFromPath(r *http.Request) (string, error) {
+	return PathParamOrError(r, PathParamPullReqReaction)
+}
+
 func GetPullReqSourceBranchFromPath(r *http.Request) (string, error) {
 	return PathParamOrError(r, PathParamSourceBranch)
 }
@@ -195,6 +212,16 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		return nil, fmt.Errorf("encountered error parsing mentioned ID filter: %w", err)
 	}
 
+	assigneeID, err := QueryParamListAsPositiveInt64(r, QueryParamAssigneeID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing assignee ID filter: %w", err)
+	}
+
+	milestoneID, err := QueryParamListAsPositiveInt64(r, QueryParamMilestoneID)
+	if err != nil {
+		return nil, fmt.Errorf("encountered error parsing milestone ID filter: %w", err)
+	}
+
 	metadataOptions, err := ParsePullReqMetadataOptions(r)
 	if err != nil {
 		return nil, err
@@ -221,6 +248,8 @@ func ParsePullReqFilter(r *http.Request) (*types.PullReqFilter, error) {
 		ReviewerID:             reviewerID,
 		ReviewDecisions:        reviewDecisions,
 		MentionedID:            mentionedID,
+		AssigneeID:             assigneeID,
+		MilestoneID:            milestoneID,
 		ExcludeDescription:     excludeDescription,
 		CreatedFilter:          createdFilter,
 		UpdatedFilter:          updatedFilter,
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package request
+
+import (
+	"net/http"
+
+	"github.com/harness/gitness/types"
+)
+
+// ParseReviewAnalyticsFilter extracts the review analytics filter from the url.
+func ParseReviewAnalyticsFilter(r *http.Request) (*types.ReviewAnalyticsFilter, error) {
+	createdFilter, err := ParseCreated(r)
+	if err != nil {
+		return nil, err
+	}
+
+	labelID, err := QueryParamListAsPositiveInt64(r, QueryParamLabelID)
+	if err != nil {
+		return nil, err
+	}
+
+	valueID, err := QueryParamListAsPositiveInt64(r, QueryParamValueID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.ReviewAnalyticsFilter{
+		CreatedFilter: createdFilter,
+		LabelID:       labelID,
+		ValueID:       valueID,
+	}, nil
+}
@@ -72,6 +72,10 @@ var (
 	// ErrPullReqRefsCantBeModified is returned if a user tries to tinker with a pull request git ref.
 	ErrPullReqRefsCantBeModified = New(http.StatusBadRequest, "The pull request git refs can't be modified")
 
+	// ErrPullReqNoSourceBranch is returned if a user tries to alter the source branch of a pull request
+	// which has been created without a source branch (e.g. by pushing to refs/for/<target-branch>).
+	ErrPullReqNoSourceBranch = New(http.StatusBadRequest, "The pull request doesn't have a source branch")
+
 	// ErrRequestTooLarge is returned if the request it too large.
 	ErrRequestTooLarge = New(http.StatusRequestEntityTooLarge, "The request is too large")
 
@@ -29,6 +29,9 @@ type BranchCreatedPayload struct {
 	PrincipalID int64  `json:"principal_id"`
 	Ref         string `json:"ref"`
 	SHA         string `json:"sha"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) BranchCreated(ctx context.Context, payload *BranchCreatedPayload) {
@@ -55,6 +58,9 @@ type BranchUpdatedPayload struct {
 	OldSHA      string `json:"old_sha"`
 	NewSHA      string `json:"new_sha"`
 	Forced      bool   `json:"forced"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) BranchUpdated(ctx context.Context, payload *BranchUpdatedPayload) {
@@ -29,6 +29,9 @@ type TagCreatedPayload struct {
 	PrincipalID int64  `json:"principal_id"`
 	Ref         string `json:"ref"`
 	SHA         string `json:"sha"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) TagCreated(ctx context.Context, payload *TagCreatedPayload) {
@@ -55,6 +58,9 @@ type TagUpdatedPayload struct {
 	OldSHA      string `json:"old_sha"`
 	NewSHA      string `json:"new_sha"`
 	Forced      bool   `json:"forced"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) TagUpdated(ctx context.Context, payload *TagUpdatedPayload) {
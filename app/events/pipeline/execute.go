@@ -23,6 +23,29 @@ import (
 	"github.com/rs/zerolog/log"
 )
 
+const StartedEvent events.EventType = "started"
+
+type StartedPayload struct {
+	PipelineID   int64 `json:"pipeline_id"`
+	RepoID       int64 `json:"repo_id"`
+	ExecutionNum int64 `json:"execution_number"`
+}
+
+func (r *Reporter) Started(ctx context.Context, payload *StartedPayload) {
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, StartedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send pipeline started event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported pipeline started event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterStarted(fn events.HandlerFunc[*StartedPayload],
+	opts ...events.HandlerOption) error {
+	return events.ReaderRegisterEvent(r.innerReader, StartedEvent, fn, opts...)
+}
+
 const ExecutedEvent events.EventType = "executed"
 
 type ExecutedPayload struct {
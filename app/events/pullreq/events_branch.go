@@ -31,6 +31,9 @@ type BranchUpdatedPayload struct {
 	OldMergeBaseSHA string `json:"old_merge_base_sha"`
 	NewMergeBaseSHA string `json:"new_merge_base_sha"`
 	Forced          bool   `json:"forced"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) BranchUpdated(ctx context.Context, payload *BranchUpdatedPayload) {
@@ -31,6 +31,9 @@ type CreatedPayload struct {
 	TargetBranch string  `json:"target_branch"`
 	SourceSHA    string  `json:"source_sha"`
 	ReviewerIDs  []int64 `json:"reviewer_ids"`
+
+	// SkipCI is set if pipeline triggers should be suppressed (git push -o ci.skip).
+	SkipCI bool `json:"skip_ci,omitempty"`
 }
 
 func (r *Reporter) Created(ctx context.Context, payload *CreatedPayload) {
@@ -82,6 +82,37 @@ func (r *Reader) RegisterStateChanged(
 	return events.ReaderRegisterEvent(r.innerReader, StateChangedEvent, fn, opts...)
 }
 
+const RenamedEvent events.EventType = "renamed"
+
+type RenamedPayload struct {
+	Base
+	OldIdentifier string `json:"old_identifier"`
+	NewIdentifier string `json:"new_identifier"`
+	OldPath       string `json:"old_path"`
+	NewPath       string `json:"new_path"`
+}
+
+func (r *Reporter) Renamed(ctx context.Context, payload *RenamedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, RenamedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send repo renamed event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported repo renamed event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterRenamed(
+	fn events.HandlerFunc[*RenamedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, RenamedEvent, fn, opts...)
+}
+
 const PublicAccessChangedEvent events.EventType = "public-access-changed"
 
 type PublicAccessChangedPayload struct {
@@ -48,3 +48,30 @@ func (r *Reader) RegisterCreated(
 ) error {
 	return events.ReaderRegisterEvent(r.innerReader, CreatedEvent, fn, opts...)
 }
+
+const UpdatedEvent events.EventType = "updated"
+
+type UpdatedPayload struct {
+	Base
+}
+
+func (r *Reporter) Updated(ctx context.Context, payload *UpdatedPayload) {
+	if payload == nil {
+		return
+	}
+
+	eventID, err := events.ReporterSendEvent(r.innerReporter, ctx, UpdatedEvent, payload)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to send rule updated event")
+		return
+	}
+
+	log.Ctx(ctx).Debug().Msgf("reported rule updated event with id '%s'", eventID)
+}
+
+func (r *Reader) RegisterUpdated(
+	fn events.HandlerFunc[*UpdatedPayload],
+	opts ...events.HandlerOption,
+) error {
+	return events.ReaderRegisterEvent(r.innerReader, UpdatedEvent, fn, opts...)
+}
@@ -0,0 +1,194 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manager
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"testing"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetup_ReportExecutionStarted(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     enum.CIStatus
+		updateErr  error
+		expStarted bool
+	}{
+		{
+			name:       "pending",
+			status:     enum.CIStatusPending,
+			expStarted: true,
+		},
+		{
+			name:   "already-running",
+			status: enum.CIStatusRunning,
+		},
+		{
+			// another stage started the execution concurrently.
+			name:      "version-conflict",
+			status:    enum.CIStatusPending,
+			updateErr: gitness_store.ErrVersionConflict,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			producer := &setupStreamProducer{}
+			eventSystem, err := events.NewSystem(func(string, string) (events.StreamConsumer, error) {
+				return nil, nil
+			}, producer)
+			require.NoError(t, err)
+
+			reporter, err := pipelineevents.NewReporter(eventSystem)
+			require.NoError(t, err)
+
+			executions := &setupExecutionStore{
+				execution: &types.Execution{
+					ID:         1,
+					PipelineID: 2,
+					RepoID:     3,
+					Number:     4,
+					Status:     test.status,
+				},
+				updateErr: test.updateErr,
+			}
+
+			s := &setup{
+				Executions:  executions,
+				Checks:      setupCheckStore{},
+				SSEStreamer: setupStreamer{},
+				Pipelines:   setupPipelineStore{},
+				Repos:       setupRepoStore{},
+				Stages:      setupStageStore{},
+				Reporter:    *reporter,
+			}
+
+			err = s.do(context.Background(), &types.Stage{ExecutionID: 1})
+			require.NoError(t, err)
+
+			if !test.expStarted {
+				assert.Empty(t, producer.streamIDs)
+				return
+			}
+
+			require.Equal(t, []string{"events:pipeline:started"}, producer.streamIDs)
+			assert.Equal(t, &pipelineevents.StartedPayload{
+				PipelineID:   2,
+				RepoID:       3,
+				ExecutionNum: 4,
+			}, producer.startedPayload(t))
+		})
+	}
+}
+
+// setupStreamProducer records all events sent by the reporter.
+type setupStreamProducer struct {
+	streamIDs []string
+	payloads  []map[string]any
+}
+
+func (p *setupStreamProducer) Send(_ context.Context, streamID string, payload map[string]any) (string, error) {
+	p.streamIDs = append(p.streamIDs, streamID)
+	p.payloads = append(p.payloads, payload)
+	return "1-0", nil
+}
+
+func (p *setupStreamProducer) startedPayload(t *testing.T) *pipelineevents.StartedPayload {
+	t.Helper()
+
+	require.Len(t, p.payloads, 1)
+	require.Len(t, p.payloads[0], 1)
+
+	for _, raw := range p.payloads[0] {
+		data, ok := raw.([]byte)
+		require.True(t, ok)
+
+		event := events.Event[*pipelineevents.StartedPayload]{}
+		require.NoError(t, gob.NewDecoder(bytes.NewReader(data)).Decode(&event))
+
+		return event.Payload
+	}
+
+	return nil
+}
+
+type setupExecutionStore struct {
+	store.ExecutionStore
+	execution *types.Execution
+	updateErr error
+}
+
+func (s *setupExecutionStore) Find(context.Context, int64) (*types.Execution, error) {
+	return s.execution, nil
+}
+
+func (s *setupExecutionStore) Update(context.Context, *types.Execution) error {
+	return s.updateErr
+}
+
+type setupRepoStore struct {
+	store.RepoStore
+}
+
+func (setupRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	return &types.Repository{ID: id, ParentID: 1}, nil
+}
+
+type setupStageStore struct {
+	store.StageStore
+}
+
+func (setupStageStore) Update(context.Context, *types.Stage) error {
+	return nil
+}
+
+func (setupStageStore) ListWithSteps(context.Context, int64) ([]*types.Stage, error) {
+	return nil, nil
+}
+
+type setupPipelineStore struct {
+	store.PipelineStore
+}
+
+func (setupPipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	return &types.Pipeline{ID: id, Identifier: "build"}, nil
+}
+
+type setupCheckStore struct {
+	store.CheckStore
+}
+
+func (setupCheckStore) Upsert(context.Context, *types.Check) error {
+	return nil
+}
+
+type setupStreamer struct {
+	sse.Streamer
+}
+
+func (setupStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
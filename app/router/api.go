@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/keywordsearch"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -54,6 +55,7 @@ import (
 	handlerkeywordsearch "github.com/harness/gitness/app/api/handler/keywordsearch"
 	handlerlogs "github.com/harness/gitness/app/api/handler/logs"
 	handlermigrate "github.com/harness/gitness/app/api/handler/migrate"
+	handlernotification "github.com/harness/gitness/app/api/handler/notification"
 	handlerpipeline "github.com/harness/gitness/app/api/handler/pipeline"
 	handlerplugin "github.com/harness/gitness/app/api/handler/plugin"
 	handlerprincipal "github.com/harness/gitness/app/api/handler/principal"
@@ -131,6 +133,7 @@ func NewAPIHandler(
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
 	gitspaceCtrl *gitspace.Controller,
+	notificationCtrl *notification.Controller,
 	usageSender usage.Sender,
 ) http.Handler {
 	// Use go-chi router for inner routing.
@@ -155,7 +158,7 @@ func NewAPIHandler(
 	r.Route("/v1", func(r chi.Router) {
 		// special methods that don't require authentication
 		setupAccountWithoutAuth(r, userCtrl, sysCtrl, config)
-		setupSystem(r, config, sysCtrl)
+		setupSystem(r, config, sysCtrl, webhookCtrl)
 		setupResources(r)
 
 		r.Group(func(r chi.Router) {
@@ -164,7 +167,7 @@ func NewAPIHandler(
 			setupRoutesV1WithAuth(r, appCtx, config, repoCtrl, repoSettingsCtrl, executionCtrl, triggerCtrl, logCtrl,
 				pipelineCtrl, connectorCtrl, templateCtrl, pluginCtrl, secretCtrl, spaceCtrl, pullreqCtrl,
 				webhookCtrl, githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, uploadCtrl,
-				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, usageSender)
+				searchCtrl, gitspaceCtrl, infraProviderCtrl, migrateCtrl, notificationCtrl, usageSender)
 		})
 	})
 
@@ -214,20 +217,21 @@ func setupRoutesV1WithAuth(r chi.Router,
 	gitspaceCtrl *gitspace.Controller,
 	infraProviderCtrl *infraprovider.Controller,
 	migrateCtrl *migrate.Controller,
+	notificationCtrl *notification.Controller,
 	usageSender usage.Sender,
 ) {
 	setupAccountWithAuth(r, userCtrl, config)
-	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl)
+	setupSpaces(r, appCtx, infraProviderCtrl, spaceCtrl, userGroupCtrl, webhookCtrl, checkCtrl, notificationCtrl)
 	setupRepos(r, repoCtrl, repoSettingsCtrl, pipelineCtrl, executionCtrl, triggerCtrl,
-		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, usageSender)
+		logCtrl, pullreqCtrl, webhookCtrl, checkCtrl, uploadCtrl, notificationCtrl, usageSender)
 	setupConnectors(r, connectorCtrl)
 	setupTemplates(r, templateCtrl)
 	setupSecrets(r, secretCtrl)
-	setupUser(r, userCtrl)
+	setupUser(r, appCtx, userCtrl, notificationCtrl)
 	setupServiceAccounts(r, saCtrl)
 	setupPrincipals(r, principalCtrl)
 	setupInternal(r, githookCtrl, git)
-	setupAdmin(r, userCtrl)
+	setupAdmin(r, userCtrl, notificationCtrl)
 	setupPlugins(r, pluginCtrl)
 	setupKeywordSearch(r, searchCtrl)
 	setupInfraProviders(r, infraProviderCtrl)
@@ -244,6 +248,7 @@ func setupSpaces(
 	userGroupCtrl *usergroup.Controller,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
+	notificationCtrl *notification.Controller,
 ) {
 	r.Route("/spaces", func(r chi.Router) {
 		// Create takes path and parentId via body, not uri
@@ -278,6 +283,7 @@ func setupSpaces(
 			r.Post("/public-access", handlerspace.HandleUpdatePublicAccess(spaceCtrl))
 			r.Get("/pullreq", handlerspace.HandleListPullReqs(spaceCtrl))
 			r.Get("/pullreq/count", handlerspace.HandleCountPullReqs(spaceCtrl))
+			r.Get("/analytics/reviews", handlerspace.HandleReviewAnalytics(spaceCtrl))
 
 			r.Route("/members", func(r chi.Router) {
 				r.Get("/", handlerspace.HandleMembershipList(spaceCtrl))
@@ -289,9 +295,13 @@ func setupSpaces(
 			})
 
 			SetupSpaceLabels(r, spaceCtrl)
+			SetupSpaceMilestones(r, spaceCtrl)
+			SetupSpaceAutomations(r, spaceCtrl)
 			SetupWebhookSpace(r, webhookCtrl)
 			SetupRulesSpace(r, spaceCtrl)
 			SetupAutolinkSpace(r, spaceCtrl)
+			SetupNotificationSubscriptionSpace(r, notificationCtrl)
+			SetupNotificationChannels(r, notificationCtrl)
 
 			r.Get("/checks/recent", handlercheck.HandleCheckListRecentSpace(checkCtrl))
 			r.Route("/usage", func(r chi.Router) {
@@ -324,6 +334,32 @@ func SetupSpaceLabels(r chi.Router, spaceCtrl *space.Controller) {
 	})
 }
 
+func SetupSpaceAutomations(r chi.Router, spaceCtrl *space.Controller) {
+	r.Route("/automations", func(r chi.Router) {
+		r.Post("/", handlerspace.HandleCreateAutomation(spaceCtrl))
+		r.Get("/", handlerspace.HandleListAutomations(spaceCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamAutomationID), func(r chi.Router) {
+			r.Get("/", handlerspace.HandleFindAutomation(spaceCtrl))
+			r.Patch("/", handlerspace.HandleUpdateAutomation(spaceCtrl))
+			r.Delete("/", handlerspace.HandleDeleteAutomation(spaceCtrl))
+		})
+	})
+}
+
+func SetupSpaceMilestones(r chi.Router, spaceCtrl *space.Controller) {
+	r.Route("/milestones", func(r chi.Router) {
+		r.Post("/", handlerspace.HandleCreateMilestone(spaceCtrl))
+		r.Get("/", handlerspace.HandleListMilestones(spaceCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Get("/", handlerspace.HandleFindMilestone(spaceCtrl))
+			r.Patch("/", handlerspace.HandleUpdateMilestone(spaceCtrl))
+			r.Delete("/", handlerspace.HandleDeleteMilestone(spaceCtrl))
+		})
+	})
+}
+
 func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 	r.Route("/webhooks", func(r chi.Router) {
 		r.Post("/", handlerwebhook.HandleCreateSpace(webhookCtrl))
@@ -336,6 +372,7 @@ func SetupWebhookSpace(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsSpace(webhookCtrl))
+				r.Post("/redeliver", handlerwebhook.HandleRedeliverExecutionsSpace(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionSpace(webhookCtrl))
@@ -383,6 +420,7 @@ func setupRepos(r chi.Router,
 	webhookCtrl *webhook.Controller,
 	checkCtrl *check.Controller,
 	uploadCtrl *upload.Controller,
+	notificationCtrl *notification.Controller,
 	usageSender usage.Sender,
 ) {
 	r.Route("/repos", func(r chi.Router) {
@@ -485,6 +523,7 @@ func setupRepos(r chi.Router,
 
 			r.Post("/rebase", handlerrepo.HandleRebase(repoCtrl))
 			r.Post("/squash", handlerrepo.HandleSquash(repoCtrl))
+			r.Post("/cherry-pick", handlerrepo.HandleCherryPick(repoCtrl))
 
 			r.Get("/codeowners/validate", handlerrepo.HandleCodeOwnersValidate(repoCtrl))
 
@@ -492,7 +531,7 @@ func setupRepos(r chi.Router,
 				usage.Middleware(usageSender),
 			).Get(fmt.Sprintf("/archive/%s", request.PathParamArchiveGitRef), handlerrepo.HandleArchive(repoCtrl))
 
-			SetupPullReq(r, pullreqCtrl)
+			SetupPullReq(r, pullreqCtrl, notificationCtrl)
 
 			SetupWebhookRepo(r, webhookCtrl)
 
@@ -506,7 +545,14 @@ func setupRepos(r chi.Router,
 
 			SetupRepoLabels(r, repoCtrl)
 
+			SetupRepoMilestones(r, repoCtrl)
+			SetupRepoAutomations(r, repoCtrl)
+
+			r.Get("/analytics/reviews", handlerrepo.HandleReviewAnalytics(repoCtrl))
+
 			SetupAutolinkRepo(r, repoCtrl)
+
+			SetupNotificationSubscriptionRepo(r, notificationCtrl)
 		})
 	})
 }
@@ -534,6 +580,32 @@ func SetupRepoLabels(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
+func SetupRepoAutomations(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/automations", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleCreateAutomation(repoCtrl))
+		r.Get("/", handlerrepo.HandleListAutomations(repoCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamAutomationID), func(r chi.Router) {
+			r.Get("/", handlerrepo.HandleFindAutomation(repoCtrl))
+			r.Patch("/", handlerrepo.HandleUpdateAutomation(repoCtrl))
+			r.Delete("/", handlerrepo.HandleDeleteAutomation(repoCtrl))
+		})
+	})
+}
+
+func SetupRepoMilestones(r chi.Router, repoCtrl *repo.Controller) {
+	r.Route("/milestones", func(r chi.Router) {
+		r.Post("/", handlerrepo.HandleCreateMilestone(repoCtrl))
+		r.Get("/", handlerrepo.HandleListMilestones(repoCtrl))
+
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamMilestoneID), func(r chi.Router) {
+			r.Get("/", handlerrepo.HandleFindMilestone(repoCtrl))
+			r.Patch("/", handlerrepo.HandleUpdateMilestone(repoCtrl))
+			r.Delete("/", handlerrepo.HandleDeleteMilestone(repoCtrl))
+		})
+	})
+}
+
 func SetupUploads(r chi.Router, uploadCtrl *upload.Controller) {
 	r.Route("/uploads", func(r chi.Router) {
 		r.Post("/", handlerupload.HandleUpload(uploadCtrl))
@@ -669,7 +741,7 @@ func SetupGitHooks(r chi.Router, githookCtrl *controllergithook.Controller, git
 	})
 }
 
-func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
+func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller, notificationCtrl *notification.Controller) {
 	r.Route("/pullreq", func(r chi.Router) {
 		r.Post("/", handlerpullreq.HandleCreate(pullreqCtrl))
 		r.Get("/", handlerpullreq.HandleList(pullreqCtrl))
@@ -678,11 +750,17 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 			handlerpullreq.HandleFindByBranches(pullreqCtrl),
 		)
 		r.Get("/candidates", handlerpullreq.HandlePRBranchCandidates(pullreqCtrl))
+		r.Get("/templates", handlerpullreq.HandleTemplates(pullreqCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamPullReqNumber), func(r chi.Router) {
 			r.Get("/", handlerpullreq.HandleFind(pullreqCtrl))
 			r.Patch("/", handlerpullreq.HandleUpdate(pullreqCtrl))
 			r.Post("/state", handlerpullreq.HandleState(pullreqCtrl))
+			r.Route("/subscription", func(r chi.Router) {
+				r.Get("/", handlernotification.HandleFindSubscriptionPullReq(notificationCtrl))
+				r.Put("/", handlernotification.HandleSetSubscriptionPullReq(notificationCtrl))
+				r.Delete("/", handlernotification.HandleDeleteSubscriptionPullReq(notificationCtrl))
+			})
 			r.Get("/activities", handlerpullreq.HandleListActivities(pullreqCtrl))
 			r.Route("/comments", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleCommentCreate(pullreqCtrl))
@@ -691,8 +769,18 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Patch("/", handlerpullreq.HandleCommentUpdate(pullreqCtrl))
 					r.Delete("/", handlerpullreq.HandleCommentDelete(pullreqCtrl))
 					r.Put("/status", handlerpullreq.HandleCommentStatus(pullreqCtrl))
+					r.Route("/reactions", func(r chi.Router) {
+						r.Put("/", handlerpullreq.HandleCommentReactionAdd(pullreqCtrl))
+						r.Delete(fmt.Sprintf("/{%s}", request.PathParamPullReqReaction),
+							handlerpullreq.HandleCommentReactionDelete(pullreqCtrl))
+					})
 				})
 			})
+			r.Route("/reactions", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleReactionAdd(pullreqCtrl))
+				r.Delete(fmt.Sprintf("/{%s}", request.PathParamPullReqReaction),
+					handlerpullreq.HandleReactionDelete(pullreqCtrl))
+			})
 			r.Route("/reviewers", func(r chi.Router) {
 				r.Get("/", handlerpullreq.HandleReviewerList(pullreqCtrl))
 				r.Put("/", handlerpullreq.HandleReviewerAdd(pullreqCtrl))
@@ -709,11 +797,26 @@ func SetupPullReq(r chi.Router, pullreqCtrl *pullreq.Controller) {
 					r.Get("/", handlerpullreq.HandleReviewerCombinedList(pullreqCtrl))
 				})
 			})
+			r.Route("/assignees", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleAssigneeList(pullreqCtrl))
+				r.Put("/", handlerpullreq.HandleAssigneeAdd(pullreqCtrl))
+				r.Route(fmt.Sprintf("/{%s}", request.PathParamAssigneeID), func(r chi.Router) {
+					r.Delete("/", handlerpullreq.HandleAssigneeDelete(pullreqCtrl))
+				})
+			})
+			r.Route("/milestone", func(r chi.Router) {
+				r.Put("/", handlerpullreq.HandleMilestoneAssign(pullreqCtrl))
+				r.Delete("/", handlerpullreq.HandleMilestoneUnassign(pullreqCtrl))
+			})
 			r.Route("/reviews", func(r chi.Router) {
 				r.Post("/", handlerpullreq.HandleReviewSubmit(pullreqCtrl))
 			})
 			r.Post("/merge", handlerpullreq.HandleMerge(pullreqCtrl))
 			r.Post("/revert", handlerpullreq.HandleRevert(pullreqCtrl))
+			r.Route("/conflicts", func(r chi.Router) {
+				r.Get("/", handlerpullreq.HandleMergeConflicts(pullreqCtrl))
+				r.Post("/resolve", handlerpullreq.HandleResolveConflicts(pullreqCtrl))
+			})
 			r.Get("/commits", handlerpullreq.HandleCommits(pullreqCtrl))
 			r.Get("/metadata", handlerpullreq.HandleMetadata(pullreqCtrl))
 			r.Route("/branch", func(r chi.Router) {
@@ -765,6 +868,7 @@ func SetupWebhookRepo(r chi.Router, webhookCtrl *webhook.Controller) {
 
 			r.Route("/executions", func(r chi.Router) {
 				r.Get("/", handlerwebhook.HandleListExecutionsRepo(webhookCtrl))
+				r.Post("/redeliver", handlerwebhook.HandleRedeliverExecutionsRepo(webhookCtrl))
 
 				r.Route(fmt.Sprintf("/{%s}", request.PathParamWebhookExecutionID), func(r chi.Router) {
 					r.Get("/", handlerwebhook.HandleFindExecutionRepo(webhookCtrl))
@@ -789,6 +893,7 @@ func SetupRulesRepo(r chi.Router, repoCtrl *repo.Controller) {
 	r.Route("/rules", func(r chi.Router) {
 		r.Post("/", handlerrepo.HandleRuleCreate(repoCtrl))
 		r.Get("/", handlerrepo.HandleRuleList(repoCtrl))
+		r.Post("/simulate", handlerrepo.HandleRuleSimulate(repoCtrl))
 
 		r.Route(fmt.Sprintf("/{%s}", request.PathParamRuleIdentifier), func(r chi.Router) {
 			r.Patch("/", handlerrepo.HandleRuleUpdate(repoCtrl))
@@ -811,7 +916,13 @@ func SetupAutolinkRepo(r chi.Router, repoCtrl *repo.Controller) {
 	})
 }
 
-func setupUser(r chi.Router, userCtrl *user.Controller) {
+// nolint: revive // it's the app context, it shouldn't be the first argument
+func setupUser(
+	r chi.Router,
+	appCtx context.Context,
+	userCtrl *user.Controller,
+	notificationCtrl *notification.Controller,
+) {
 	r.Route("/user", func(r chi.Router) {
 		// enforce principal authenticated and it's a user
 		r.Use(middlewareprincipal.RestrictTo(enum.PrincipalTypeUser))
@@ -856,6 +967,46 @@ func setupUser(r chi.Router, userCtrl *user.Controller) {
 			r.Delete(fmt.Sprintf("/{%s}", request.PathParamResourceID),
 				handleruser.HandleDeleteFavorite(userCtrl))
 		})
+
+		// Notifications
+		r.Get("/notification-preferences", handlernotification.HandleListPreferences(notificationCtrl))
+		r.Patch("/notification-preferences", handlernotification.HandleUpdatePreferences(notificationCtrl))
+		r.Get("/notification-subscriptions", handlernotification.HandleListSubscriptions(notificationCtrl))
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", handlernotification.HandleListNotifications(notificationCtrl))
+			r.Get("/count", handlernotification.HandleCountNotifications(notificationCtrl))
+			r.Get("/events", handlernotification.HandleNotificationEvents(appCtx, notificationCtrl))
+			r.Post("/read", handlernotification.HandleMarkNotificationsRead(notificationCtrl))
+			r.Post("/read-all", handlernotification.HandleMarkAllNotificationsRead(notificationCtrl))
+		})
+	})
+}
+
+func SetupNotificationSubscriptionSpace(r chi.Router, notificationCtrl *notification.Controller) {
+	r.Route("/subscription", func(r chi.Router) {
+		r.Get("/", handlernotification.HandleFindSubscriptionSpace(notificationCtrl))
+		r.Put("/", handlernotification.HandleSetSubscriptionSpace(notificationCtrl))
+		r.Delete("/", handlernotification.HandleDeleteSubscriptionSpace(notificationCtrl))
+	})
+}
+
+func SetupNotificationChannels(r chi.Router, notificationCtrl *notification.Controller) {
+	r.Route("/notification-channels", func(r chi.Router) {
+		r.Get("/", handlernotification.HandleListChannels(notificationCtrl))
+		r.Post("/", handlernotification.HandleCreateChannel(notificationCtrl))
+		r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationChannelIdentifier), func(r chi.Router) {
+			r.Get("/", handlernotification.HandleFindChannel(notificationCtrl))
+			r.Patch("/", handlernotification.HandleUpdateChannel(notificationCtrl))
+			r.Delete("/", handlernotification.HandleDeleteChannel(notificationCtrl))
+		})
+	})
+}
+
+func SetupNotificationSubscriptionRepo(r chi.Router, notificationCtrl *notification.Controller) {
+	r.Route("/subscription", func(r chi.Router) {
+		r.Get("/", handlernotification.HandleFindSubscriptionRepo(notificationCtrl))
+		r.Put("/", handlernotification.HandleSetSubscriptionRepo(notificationCtrl))
+		r.Delete("/", handlernotification.HandleDeleteSubscriptionRepo(notificationCtrl))
 	})
 }
 
@@ -882,11 +1033,12 @@ func setupServiceAccounts(r chi.Router, saCtrl *serviceaccount.Controller) {
 	})
 }
 
-func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller) {
+func setupSystem(r chi.Router, config *types.Config, sysCtrl *system.Controller, webhookCtrl *webhook.Controller) {
 	r.Route("/system", func(r chi.Router) {
 		r.Get("/health", handlersystem.HandleHealth)
 		r.Get("/version", handlersystem.HandleVersion)
 		r.Get("/config", handlersystem.HandleGetConfig(config, sysCtrl))
+		r.Get("/webhook-signing-key", handlerwebhook.HandleFindSigningKey(webhookCtrl))
 	})
 }
 
@@ -935,7 +1087,7 @@ func setupInfraProviders(r chi.Router, infraProviderCtrl *infraprovider.Controll
 	})
 }
 
-func setupAdmin(r chi.Router, userCtrl *user.Controller) {
+func setupAdmin(r chi.Router, userCtrl *user.Controller, notificationCtrl *notification.Controller) {
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middlewareprincipal.RestrictToAdmin())
 		r.Route("/users", func(r chi.Router) {
@@ -949,6 +1101,16 @@ func setupAdmin(r chi.Router, userCtrl *user.Controller) {
 				r.Patch("/admin", handleruser.HandleUpdateAdmin(userCtrl))
 			})
 		})
+		r.Route("/notification-templates", func(r chi.Router) {
+			r.Get("/", handlernotification.HandleListTemplates(notificationCtrl))
+
+			r.Route(fmt.Sprintf("/{%s}", request.PathParamNotificationTemplate), func(r chi.Router) {
+				r.Get("/", handlernotification.HandleFindTemplate(notificationCtrl))
+				r.Put("/", handlernotification.HandleUpdateTemplate(notificationCtrl))
+				r.Delete("/", handlernotification.HandleResetTemplate(notificationCtrl))
+				r.Post("/preview", handlernotification.HandlePreviewTemplate(notificationCtrl))
+			})
+		})
 	})
 }
 
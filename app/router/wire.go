@@ -28,6 +28,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/lfs"
 	"github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	"github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -107,6 +108,7 @@ func ProvideRouter(
 	infraProviderCtrl *infraprovider.Controller,
 	gitspaceCtrl *gitspace.Controller,
 	migrateCtrl *migrate.Controller,
+	notificationCtrl *notification.Controller,
 	urlProvider url.Provider,
 	openapi openapi.Service,
 	registryRouter router.AppRouter,
@@ -132,7 +134,7 @@ func ProvideRouter(
 		authenticator, repoCtrl, repoSettingsCtrl, executionCtrl, logCtrl, spaceCtrl, pipelineCtrl,
 		secretCtrl, triggerCtrl, connectorCtrl, templateCtrl, pluginCtrl, pullreqCtrl, webhookCtrl,
 		githookCtrl, git, saCtrl, userCtrl, principalCtrl, userGroupCtrl, checkCtrl, sysCtrl, blobCtrl, searchCtrl,
-		infraProviderCtrl, migrateCtrl, gitspaceCtrl, usageSender)
+		infraProviderCtrl, migrateCtrl, gitspaceCtrl, notificationCtrl, usageSender)
 	routers[2] = NewAPIRouter(apiHandler)
 
 	sec := NewSecure(config)
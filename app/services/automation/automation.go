@@ -0,0 +1,316 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *types.CreateAutomationInput,
+) (*types.Automation, error) {
+	if err := s.validate(ctx, spaceID, repoID, &in.Trigger, &in.Action); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+
+	automation := &types.Automation{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Name:        in.Name,
+		Description: in.Description,
+		Disabled:    in.Disabled,
+		Trigger:     in.Trigger,
+		Action:      in.Action,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+		UpdatedBy:   principalID,
+	}
+
+	if err := s.automationStore.Create(ctx, automation); err != nil {
+		return nil, fmt.Errorf("failed to create automation: %w", err)
+	}
+
+	return automation, nil
+}
+
+func (s *Service) Find(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) (*types.Automation, error) {
+	return s.find(ctx, spaceID, repoID, id)
+}
+
+func (s *Service) Update(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	id int64,
+	in *types.UpdateAutomationInput,
+) (*types.Automation, error) {
+	automation, err := s.find(ctx, spaceID, repoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validate(ctx, automation.SpaceID, automation.RepoID, in.Trigger, in.Action); err != nil {
+		return nil, err
+	}
+
+	if !applyChanges(automation, in) {
+		return automation, nil
+	}
+
+	automation.Updated = time.Now().UnixMilli()
+	automation.UpdatedBy = principalID
+
+	if err := s.automationStore.Update(ctx, automation); err != nil {
+		return nil, fmt.Errorf("failed to update automation: %w", err)
+	}
+
+	return automation, nil
+}
+
+func (s *Service) Delete(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) error {
+	automation, err := s.find(ctx, spaceID, repoID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.automationStore.Delete(ctx, automation.ID); err != nil {
+		return fmt.Errorf("failed to delete automation: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) List(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	filter *types.AutomationFilter,
+) ([]*types.Automation, int64, error) {
+	var spaceIDs []int64
+
+	switch {
+	case filter.Inherited:
+		var err error
+		spaceIDs, err = s.spaceStore.GetAncestorIDs(ctx, *spaceID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get parent space ids: %w", err)
+		}
+	case repoID == nil:
+		spaceIDs = []int64{*spaceID}
+	}
+
+	count, err := s.automationStore.Count(ctx, repoID, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count automations: %w", err)
+	}
+
+	automations, err := s.automationStore.List(ctx, repoID, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list automations: %w", err)
+	}
+
+	return automations, count, nil
+}
+
+func (s *Service) find(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) (*types.Automation, error) {
+	automation, err := s.automationStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find automation: %w", err)
+	}
+
+	if (repoID != nil && (automation.RepoID == nil || *automation.RepoID != *repoID)) ||
+		(spaceID != nil && (automation.SpaceID == nil || *automation.SpaceID != *spaceID)) {
+		return nil, errors.NotFoundf("Automation %d not found", id)
+	}
+
+	return automation, nil
+}
+
+// validate checks that the labels, label values and reviewers referenced by the trigger and the action exist
+// and can be used in the repository or space of the automation. Nil trigger or action aren't checked.
+func (s *Service) validate(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	trigger *types.AutomationTrigger,
+	action *types.AutomationAction,
+) error {
+	needsLabel := (trigger != nil && trigger.Type == enum.AutomationTriggerLabelAssigned) ||
+		(action != nil && action.Type == enum.AutomationActionAssignLabel)
+	needsReviewers := action != nil && action.Type == enum.AutomationActionRequestReview
+	if !needsLabel && !needsReviewers {
+		return nil
+	}
+
+	var parentID int64
+	if repoID != nil {
+		repo, err := s.repoFinder.FindByID(ctx, *repoID)
+		if err != nil {
+			return fmt.Errorf("failed to find repository: %w", err)
+		}
+		parentID = repo.ParentID
+	} else {
+		parentID = *spaceID
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("failed to get parent space ids: %w", err)
+	}
+
+	if trigger != nil && trigger.Type == enum.AutomationTriggerLabelAssigned {
+		if err := s.validateLabel(ctx, repoID, spaceIDs, *trigger.LabelID, trigger.ValueID); err != nil {
+			return err
+		}
+	}
+
+	if action == nil {
+		return nil
+	}
+
+	switch action.Type {
+	case enum.AutomationActionAssignLabel:
+		return s.validateLabel(ctx, repoID, spaceIDs, *action.LabelID, action.ValueID)
+	case enum.AutomationActionRequestReview:
+		return s.validateReviewers(ctx, spaceIDs, action.ReviewerIDs, action.UserGroupReviewerIDs)
+	}
+
+	return nil
+}
+
+func (s *Service) validateLabel(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+	labelID int64,
+	valueID *int64,
+) error {
+	label, err := s.labelSvc.FindByID(ctx, labelID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return errors.InvalidArgumentf("Label %d not found", labelID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find label: %w", err)
+	}
+
+	if (label.RepoID != nil && (repoID == nil || *label.RepoID != *repoID)) ||
+		(label.SpaceID != nil && !slices.Contains(spaceIDs, *label.SpaceID)) {
+		return errors.InvalidArgumentf("Label %d is not defined in the scope of the automation", labelID)
+	}
+
+	if valueID == nil {
+		return nil
+	}
+
+	value, err := s.labelSvc.FindValueByID(ctx, *valueID)
+	if errors.Is(err, store.ErrResourceNotFound) || (err == nil && value.LabelID != labelID) {
+		return errors.InvalidArgumentf("Label value %d not found for label %d", *valueID, labelID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to find label value: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) validateReviewers(
+	ctx context.Context,
+	spaceIDs []int64,
+	reviewerIDs []int64,
+	userGroupIDs []int64,
+) error {
+	for _, reviewerID := range reviewerIDs {
+		_, err := s.principalStore.Find(ctx, reviewerID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return errors.InvalidArgumentf("Reviewer %d not found", reviewerID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to find reviewer principal: %w", err)
+		}
+	}
+
+	if len(userGroupIDs) == 0 {
+		return nil
+	}
+
+	userGroups, err := s.userGroupStore.Map(ctx, userGroupIDs)
+	if err != nil {
+		return fmt.Errorf("failed to find user groups: %w", err)
+	}
+
+	for _, userGroupID := range userGroupIDs {
+		userGroup, ok := userGroups[userGroupID]
+		if !ok || !slices.Contains(spaceIDs, userGroup.SpaceID) {
+			return errors.InvalidArgumentf("User group %d not found", userGroupID)
+		}
+	}
+
+	return nil
+}
+
+func applyChanges(automation *types.Automation, in *types.UpdateAutomationInput) bool {
+	changed := false
+
+	if in.Name != nil && *in.Name != automation.Name {
+		automation.Name = *in.Name
+		changed = true
+	}
+
+	if in.Description != nil && *in.Description != automation.Description {
+		automation.Description = *in.Description
+		changed = true
+	}
+
+	if in.Disabled != nil && *in.Disabled != automation.Disabled {
+		automation.Disabled = *in.Disabled
+		changed = true
+	}
+
+	if in.Trigger != nil {
+		automation.Trigger = *in.Trigger
+		changed = true
+	}
+
+	if in.Action != nil {
+		automation.Action = *in.Action
+		changed = true
+	}
+
+	return changed
+}
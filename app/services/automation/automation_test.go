@@ -0,0 +1,403 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRepoID      = 5
+	testSpaceID     = 2
+	testRootSpaceID = 1
+	testSystemID    = 999
+)
+
+type automationPullReqStore struct {
+	store.PullReqStore
+	pr *types.PullReq
+}
+
+func (s automationPullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	pr := *s.pr
+	return &pr, nil
+}
+
+type automationRepoIDCache struct {
+	store.RepoIDCache
+}
+
+func (automationRepoIDCache) Get(_ context.Context, id int64) (*types.RepositoryCore, error) {
+	return &types.RepositoryCore{ID: id, ParentID: testSpaceID, Path: "root/space/repo"}, nil
+}
+
+type automationSpaceStore struct {
+	store.SpaceStore
+}
+
+func (automationSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	if spaceID == testRootSpaceID {
+		return []int64{testRootSpaceID}, nil
+	}
+	return []int64{spaceID, testRootSpaceID}, nil
+}
+
+type automationStore struct {
+	store.AutomationStore
+	automations []*types.Automation
+	saved       []*types.Automation
+}
+
+func (s *automationStore) List(
+	_ context.Context,
+	_ *int64,
+	_ []int64,
+	filter *types.AutomationFilter,
+) ([]*types.Automation, error) {
+	var automations []*types.Automation
+	for _, automation := range s.automations {
+		if automation.Trigger.Type == filter.TriggerType {
+			automations = append(automations, automation)
+		}
+	}
+	return automations, nil
+}
+
+func (s *automationStore) Find(_ context.Context, id int64) (*types.Automation, error) {
+	for _, automation := range s.automations {
+		if automation.ID == id {
+			a := *automation
+			return &a, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *automationStore) Create(_ context.Context, automation *types.Automation) error {
+	s.saved = append(s.saved, automation)
+	return nil
+}
+
+func (s *automationStore) Update(_ context.Context, automation *types.Automation) error {
+	s.saved = append(s.saved, automation)
+	return nil
+}
+
+type automationPrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (automationPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if id == 404 {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Principal{ID: id, UID: "principal", Type: enum.PrincipalTypeUser}, nil
+}
+
+type automationUserGroupStore struct {
+	store.UserGroupStore
+}
+
+func (automationUserGroupStore) Map(_ context.Context, ids []int64) (map[int64]*types.UserGroup, error) {
+	userGroups := map[int64]*types.UserGroup{
+		7: {ID: 7, SpaceID: testRootSpaceID},
+		8: {ID: 8, SpaceID: 3},
+	}
+
+	m := make(map[int64]*types.UserGroup)
+	for _, id := range ids {
+		if userGroup, ok := userGroups[id]; ok {
+			m[id] = userGroup
+		}
+	}
+	return m, nil
+}
+
+type automationLabelStore struct {
+	store.LabelStore
+}
+
+func (automationLabelStore) FindByID(_ context.Context, id int64) (*types.Label, error) {
+	labels := map[int64]*types.Label{
+		10: {ID: 10, RepoID: ptr.Int64(testRepoID)},
+		11: {ID: 11, SpaceID: ptr.Int64(testRootSpaceID)},
+		12: {ID: 12, RepoID: ptr.Int64(testRepoID + 1)},
+		13: {ID: 13, SpaceID: ptr.Int64(3)},
+	}
+	if l, ok := labels[id]; ok {
+		return l, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type automationLabelValueStore struct {
+	store.LabelValueStore
+}
+
+func (automationLabelValueStore) FindByID(_ context.Context, id int64) (*types.LabelValue, error) {
+	values := map[int64]*types.LabelValue{
+		100: {ID: 100, LabelID: 10},
+		200: {ID: 200, LabelID: 12},
+	}
+	if v, ok := values[id]; ok {
+		return v, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+// automationAuthorizer denies everything and records the principals it was asked about.
+type automationAuthorizer struct {
+	authz.Authorizer
+	checked []int64
+}
+
+func (a *automationAuthorizer) Check(
+	_ context.Context,
+	session *auth.Session,
+	_ *types.Scope,
+	_ *types.Resource,
+	_ enum.Permission,
+) (bool, error) {
+	a.checked = append(a.checked, session.Principal.ID)
+	return false, nil
+}
+
+type automationSettingsStore struct {
+	store.SettingsStore
+	values map[string]json.RawMessage
+}
+
+func (s automationSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+) (json.RawMessage, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func newTestService(
+	t *testing.T,
+	pr *types.PullReq,
+	automations []*types.Automation,
+	settingValues map[string]json.RawMessage,
+) (*Service, *automationStore, *automationAuthorizer) {
+	t.Helper()
+
+	eventSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		MaxStreamLength: 100,
+	}, nil)
+	require.NoError(t, err)
+
+	reporter, err := pullreqevents.NewReporter(eventSystem)
+	require.NoError(t, err)
+
+	automationStore := &automationStore{automations: automations}
+	authorizer := &automationAuthorizer{}
+
+	return &Service{
+		authorizer:        authorizer,
+		pullreqEvReporter: reporter,
+		repoFinder: refcache.NewRepoFinder(nil, nil, automationRepoIDCache{}, nil,
+			cache.Evictor[*types.RepositoryCore]{}),
+		spaceStore:      automationSpaceStore{},
+		automationStore: automationStore,
+		pullreqStore:    automationPullReqStore{pr: pr},
+		principalStore:  automationPrincipalStore{},
+		userGroupStore:  automationUserGroupStore{},
+		labelSvc: label.New(nil, nil, automationLabelStore{}, automationLabelValueStore{}, nil,
+			refcache.SpaceFinder{}),
+		settings: settings.NewService(automationSettingsStore{values: settingValues}),
+	}, automationStore, authorizer
+}
+
+func autoMergeAutomation(id, createdBy, updatedBy int64, trigger types.AutomationTrigger) *types.Automation {
+	return &types.Automation{
+		ID:        id,
+		RepoID:    ptr.Int64(testRepoID),
+		Trigger:   trigger,
+		Action:    types.AutomationAction{Type: enum.AutomationActionEnableAutoMerge, MergeMethod: enum.MergeMethodSquash},
+		CreatedBy: createdBy,
+		UpdatedBy: updatedBy,
+	}
+}
+
+func labelTrigger(labelID int64, valueID *int64) types.AutomationTrigger {
+	return types.AutomationTrigger{Type: enum.AutomationTriggerLabelAssigned, LabelID: &labelID, ValueID: valueID}
+}
+
+func TestService_Create(t *testing.T) {
+	reviewAction := func(reviewerIDs, userGroupIDs []int64) types.AutomationAction {
+		return types.AutomationAction{
+			Type:                 enum.AutomationActionRequestReview,
+			ReviewerIDs:          reviewerIDs,
+			UserGroupReviewerIDs: userGroupIDs,
+		}
+	}
+	labelAction := func(labelID int64, valueID *int64) types.AutomationAction {
+		return types.AutomationAction{Type: enum.AutomationActionAssignLabel, LabelID: &labelID, ValueID: valueID}
+	}
+	filesTrigger := types.AutomationTrigger{Type: enum.AutomationTriggerFilesChanged, Patterns: []string{"*.go"}}
+
+	tests := []struct {
+		name    string
+		spaceID *int64
+		repoID  *int64
+		trigger types.AutomationTrigger
+		action  types.AutomationAction
+		errMsg  string
+	}{
+		{
+			name:    "repo label with value",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: labelTrigger(10, ptr.Int64(100)),
+			action:  labelAction(11, nil),
+		},
+		{
+			name:    "unknown label",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: labelTrigger(99, nil),
+			action:  labelAction(11, nil),
+			errMsg:  "Label 99 not found",
+		},
+		{
+			name:    "label of another repo",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: labelTrigger(12, nil),
+			action:  labelAction(11, nil),
+			errMsg:  "Label 12 is not defined in the scope of the automation",
+		},
+		{
+			name:    "label outside of the space tree",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  labelAction(13, nil),
+			errMsg:  "Label 13 is not defined in the scope of the automation",
+		},
+		{
+			name:    "repo label in space automation",
+			spaceID: ptr.Int64(testSpaceID),
+			trigger: labelTrigger(10, nil),
+			action:  labelAction(11, nil),
+			errMsg:  "Label 10 is not defined in the scope of the automation",
+		},
+		{
+			name:    "value of another label",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: labelTrigger(10, ptr.Int64(200)),
+			action:  labelAction(11, nil),
+			errMsg:  "Label value 200 not found for label 10",
+		},
+		{
+			name:    "unknown value",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  labelAction(10, ptr.Int64(300)),
+			errMsg:  "Label value 300 not found for label 10",
+		},
+		{
+			name:    "reviewers",
+			spaceID: ptr.Int64(testSpaceID),
+			trigger: filesTrigger,
+			action:  reviewAction([]int64{101}, []int64{7}),
+		},
+		{
+			name:    "unknown reviewer",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  reviewAction([]int64{101, 404}, nil),
+			errMsg:  "Reviewer 404 not found",
+		},
+		{
+			name:    "user group outside of the space tree",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  reviewAction(nil, []int64{7, 8}),
+			errMsg:  "User group 8 not found",
+		},
+		{
+			name:    "unknown user group",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  reviewAction(nil, []int64{9}),
+			errMsg:  "User group 9 not found",
+		},
+		{
+			name:    "auto merge",
+			repoID:  ptr.Int64(testRepoID),
+			trigger: filesTrigger,
+			action:  types.AutomationAction{Type: enum.AutomationActionEnableAutoMerge, MergeMethod: enum.MergeMethodMerge},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, automationStore, _ := newTestService(t, nil, nil, nil)
+
+			automation, err := service.Create(context.Background(), 101, test.spaceID, test.repoID,
+				&types.CreateAutomationInput{Name: "test", Trigger: test.trigger, Action: test.action})
+			if test.errMsg != "" {
+				require.ErrorContains(t, err, test.errMsg)
+				assert.True(t, errors.IsInvalidArgument(err))
+				assert.Empty(t, automationStore.saved)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, []*types.Automation{automation}, automationStore.saved)
+		})
+	}
+}
+
+func TestService_Update(t *testing.T) {
+	existing := autoMergeAutomation(1, 101, 101, labelTrigger(10, nil))
+	service, automationStore, _ := newTestService(t, nil, []*types.Automation{existing}, nil)
+
+	_, err := service.Update(context.Background(), 102, nil, ptr.Int64(testRepoID), existing.ID,
+		&types.UpdateAutomationInput{Trigger: ptr.Of(labelTrigger(12, nil))})
+	require.ErrorContains(t, err, "Label 12 is not defined in the scope of the automation")
+	assert.Empty(t, automationStore.saved)
+
+	automation, err := service.Update(context.Background(), 102, nil, ptr.Int64(testRepoID), existing.ID,
+		&types.UpdateAutomationInput{Trigger: ptr.Of(labelTrigger(11, nil))})
+	require.NoError(t, err)
+	assert.Equal(t, int64(102), automation.UpdatedBy)
+	assert.Equal(t, []*types.Automation{automation}, automationStore.saved)
+}
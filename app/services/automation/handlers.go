@@ -0,0 +1,430 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/merge"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) handleEventCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CreatedPayload],
+) error {
+	return s.runFilesChanged(ctx, &event.Payload.Base)
+}
+
+func (s *Service) handleEventBranchUpdated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.BranchUpdatedPayload],
+) error {
+	return s.runFilesChanged(ctx, &event.Payload.Base)
+}
+
+func (s *Service) handleEventLabelAssigned(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.LabelAssignedPayload],
+) error {
+	pr, repo, automations, err := s.prepare(ctx, &event.Payload.Base, enum.AutomationTriggerLabelAssigned)
+	if err != nil || len(automations) == 0 {
+		return err
+	}
+
+	for _, automation := range automations {
+		trigger := automation.Trigger
+		if trigger.LabelID == nil || *trigger.LabelID != event.Payload.LabelID {
+			continue
+		}
+
+		if trigger.ValueID != nil &&
+			(event.Payload.ValueID == nil || *trigger.ValueID != *event.Payload.ValueID) {
+			continue
+		}
+
+		s.execute(ctx, automation, pr, repo)
+	}
+
+	return nil
+}
+
+// runFilesChanged executes all files_changed automations whose patterns match a file changed by the pull request.
+func (s *Service) runFilesChanged(ctx context.Context, base *pullreqevents.Base) error {
+	pr, repo, automations, err := s.prepare(ctx, base, enum.AutomationTriggerFilesChanged)
+	if err != nil || len(automations) == 0 {
+		return err
+	}
+
+	changedFiles, err := s.mergeService.ChangedFiles(repo, pr)(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get changed files of the pull request: %w", err)
+	}
+
+	for _, automation := range automations {
+		matched := slices.ContainsFunc(changedFiles, func(f protection.ChangedFile) bool {
+			return automation.Trigger.MatchesFile(f.Path)
+		})
+		if !matched {
+			continue
+		}
+
+		s.execute(ctx, automation, pr, repo)
+	}
+
+	return nil
+}
+
+// prepare returns the open pull request, its target repository and all enabled automations with the trigger type
+// defined in the repository and in all of its ancestor spaces.
+func (s *Service) prepare(
+	ctx context.Context,
+	base *pullreqevents.Base,
+	triggerType enum.AutomationTriggerType,
+) (*types.PullReq, *types.RepositoryCore, []*types.Automation, error) {
+	pr, err := s.pullreqStore.Find(ctx, base.PullReqID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find pull request: %w", err)
+	}
+
+	if pr.State != enum.PullReqStateOpen {
+		return nil, nil, nil, nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to find target repository: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to get parent space ids: %w", err)
+	}
+
+	automations, err := s.automationStore.List(ctx, &repo.ID, spaceIDs, &types.AutomationFilter{
+		TriggerType:     triggerType,
+		ExcludeDisabled: true,
+	})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to list automations: %w", err)
+	}
+
+	return pr, repo, automations, nil
+}
+
+// execute performs the automation action. Failures are only logged to not block other automations.
+func (s *Service) execute(
+	ctx context.Context,
+	automation *types.Automation,
+	pr *types.PullReq,
+	repo *types.RepositoryCore,
+) {
+	var err error
+
+	switch automation.Action.Type {
+	case enum.AutomationActionAssignLabel:
+		err = s.assignLabel(ctx, automation, pr, repo)
+	case enum.AutomationActionRequestReview:
+		err = s.requestReview(ctx, automation, pr, repo)
+	case enum.AutomationActionEnableAutoMerge:
+		err = s.enableAutoMerge(ctx, automation, pr, repo)
+	}
+
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Int64("automation_id", automation.ID).
+			Int64("pullreq_id", pr.ID).
+			Msgf("failed to execute %s automation action", automation.Action.Type)
+	}
+}
+
+func (s *Service) assignLabel(
+	ctx context.Context,
+	automation *types.Automation,
+	pr *types.PullReq,
+	repo *types.RepositoryCore,
+) error {
+	principal := bootstrap.NewSystemServiceSession().Principal
+
+	out, err := s.labelSvc.AssignToPullReq(ctx, principal.ID, pr.ID, repo.ID, repo.ParentID,
+		&types.PullReqLabelAssignInput{
+			LabelID: *automation.Action.LabelID,
+			ValueID: automation.Action.ValueID,
+		})
+	if err != nil {
+		return fmt.Errorf("failed to assign label: %w", err)
+	}
+
+	if out.ActivityType == enum.LabelActivityNoop {
+		return nil
+	}
+
+	pr, err = s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		return fmt.Errorf("failed to update pull request activity sequence: %w", err)
+	}
+
+	if _, err := s.activityStore.CreateWithPayload(
+		ctx, pr, principal.ID, out.ToActivityPayload(), nil); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity after automated label assign")
+	}
+
+	var newValueID *int64
+	if out.NewLabelValue != nil {
+		newValueID = &out.NewLabelValue.ID
+	}
+
+	s.pullreqEvReporter.LabelAssigned(ctx, &pullreqevents.LabelAssignedPayload{
+		Base:    eventBase(pr, principal.ID),
+		LabelID: out.Label.ID,
+		ValueID: newValueID,
+	})
+
+	return nil
+}
+
+//nolint:gocognit // refactor if needed
+func (s *Service) requestReview(
+	ctx context.Context,
+	automation *types.Automation,
+	pr *types.PullReq,
+	repo *types.RepositoryCore,
+) error {
+	session := bootstrap.NewSystemServiceSession()
+	addedBy := session.Principal.ToPrincipalInfo()
+	now := time.Now().UnixMilli()
+
+	var reviewerIDs []int64
+	for _, reviewerID := range automation.Action.ReviewerIDs {
+		if reviewerID == pr.CreatedBy || slices.Contains(reviewerIDs, reviewerID) {
+			continue
+		}
+
+		_, err := s.reviewerStore.Find(ctx, pr.ID, reviewerID)
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, store.ErrResourceNotFound) {
+			return fmt.Errorf("failed to find pull request reviewer: %w", err)
+		}
+
+		reviewerPrincipal, err := s.principalStore.Find(ctx, reviewerID)
+		if err != nil {
+			return fmt.Errorf("failed to find reviewer principal: %w", err)
+		}
+
+		if err = apiauth.CheckRepo(ctx, s.authorizer, &auth.Session{
+			Principal: *reviewerPrincipal,
+		}, repo, enum.PermissionRepoReview); err != nil {
+			log.Ctx(ctx).Info().Msgf("skipping automation reviewer %s: %s", reviewerPrincipal.UID, err)
+			continue
+		}
+
+		err = s.reviewerStore.Create(ctx, &types.PullReqReviewer{
+			PullReqID:      pr.ID,
+			PrincipalID:    reviewerID,
+			CreatedBy:      addedBy.ID,
+			Created:        now,
+			Updated:        now,
+			RepoID:         repo.ID,
+			Type:           enum.PullReqReviewerTypeAssigned,
+			ReviewDecision: enum.PullReqReviewDecisionPending,
+			Reviewer:       *reviewerPrincipal.ToPrincipalInfo(),
+			AddedBy:        *addedBy,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create pull request reviewer: %w", err)
+		}
+
+		reviewerIDs = append(reviewerIDs, reviewerID)
+	}
+
+	var userGroupIDs []int64
+	if len(automation.Action.UserGroupReviewerIDs) > 0 {
+		existing, err := s.userGroupReviewerStore.List(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to list user group reviewers: %w", err)
+		}
+
+		userGroups, err := s.userGroupStore.Map(ctx, automation.Action.UserGroupReviewerIDs)
+		if err != nil {
+			return fmt.Errorf("failed to find user groups: %w", err)
+		}
+
+		for _, userGroupID := range automation.Action.UserGroupReviewerIDs {
+			userGroup, ok := userGroups[userGroupID]
+			if !ok || slices.Contains(userGroupIDs, userGroupID) ||
+				slices.ContainsFunc(existing, func(r *types.UserGroupReviewer) bool {
+					return r.UserGroupID == userGroupID
+				}) {
+				continue
+			}
+
+			err = s.userGroupReviewerStore.Create(ctx, &types.UserGroupReviewer{
+				PullReqID:   pr.ID,
+				UserGroupID: userGroupID,
+				CreatedBy:   addedBy.ID,
+				Created:     now,
+				Updated:     now,
+				RepoID:      repo.ID,
+				UserGroup:   *userGroup.ToUserGroupInfo(),
+				AddedBy:     *addedBy,
+				Decision:    enum.PullReqReviewDecisionPending,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create user group pull request reviewer: %w", err)
+			}
+
+			userGroupIDs = append(userGroupIDs, userGroupID)
+		}
+	}
+
+	if len(reviewerIDs) > 0 {
+		s.writeActivity(ctx, pr, addedBy.ID,
+			&types.PullRequestActivityPayloadReviewerAdd{
+				ReviewerType: enum.PullReqReviewerTypeAssigned,
+				PrincipalIDs: reviewerIDs,
+			},
+			&types.PullReqActivityMetadata{
+				Mentions: &types.PullReqActivityMentionsMetadata{IDs: reviewerIDs},
+			})
+
+		for _, reviewerID := range reviewerIDs {
+			s.pullreqEvReporter.ReviewerAdded(ctx, &pullreqevents.ReviewerAddedPayload{
+				Base:       eventBase(pr, addedBy.ID),
+				ReviewerID: reviewerID,
+			})
+		}
+	}
+
+	if len(userGroupIDs) > 0 {
+		s.writeActivity(ctx, pr, addedBy.ID,
+			&types.PullRequestActivityPayloadUserGroupReviewerAdd{
+				ReviewerType: enum.PullReqReviewerTypeAssigned,
+				UserGroupIDs: userGroupIDs,
+			},
+			&types.PullReqActivityMetadata{
+				Mentions: &types.PullReqActivityMentionsMetadata{UserGroupIDs: userGroupIDs},
+			})
+
+		for _, userGroupID := range userGroupIDs {
+			s.pullreqEvReporter.UserGroupReviewerAdded(ctx, &pullreqevents.UserGroupReviewerAddedPayload{
+				Base:                eventBase(pr, addedBy.ID),
+				UserGroupReviewerID: userGroupID,
+			})
+		}
+	}
+
+	if len(reviewerIDs) > 0 || len(userGroupIDs) > 0 {
+		s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqReviewerAdded, pr)
+	}
+
+	return nil
+}
+
+// enableAutoMerge enables auto merge on behalf of the principal that last saved the automation.
+// The principal of the triggering event isn't used because it's the system service
+// if the event was caused by another automation.
+func (s *Service) enableAutoMerge(
+	ctx context.Context,
+	automation *types.Automation,
+	pr *types.PullReq,
+	repo *types.RepositoryCore,
+) error {
+	if pr.IsDraft || pr.SubState == enum.PullReqSubStateAutoMerge {
+		return nil
+	}
+
+	autoMergeEnabled, err := settings.RepoGet(ctx, s.settings, repo.ID, settings.KeyAutoMergeEnabled, false)
+	if err != nil {
+		return fmt.Errorf("failed to get repo auto-merge enabled setting: %w", err)
+	}
+	if !autoMergeEnabled {
+		return nil
+	}
+
+	principalID := automation.UpdatedBy
+	if principalID == 0 {
+		principalID = automation.CreatedBy
+	}
+
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to find automation principal: %w", err)
+	}
+
+	session := &auth.Session{Principal: *principal}
+	if err = apiauth.CheckRepo(ctx, s.authorizer, session, repo, enum.PermissionRepoPush); err != nil {
+		return fmt.Errorf("principal %s can't enable auto merge: %w", principal.UID, err)
+	}
+
+	autoMerge := types.AutoMerge{
+		PullReqID:    pr.ID,
+		Requested:    time.Now().UnixMilli(),
+		RequestedBy:  principal.ID,
+		MergeMethod:  automation.Action.MergeMethod,
+		DeleteBranch: automation.Action.DeleteSourceBranch,
+	}
+
+	_, _, err = s.mergeService.EnableAutoMerge(ctx, repo, pr, principal, &autoMerge)
+	if err != nil && !errors.Is(err, merge.ErrNotEligible) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *Service) writeActivity(
+	ctx context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+	metadata *types.PullReqActivityMetadata,
+) {
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to update pull request activity sequence")
+		return
+	}
+
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, metadata); err != nil {
+		log.Ctx(ctx).Err(err).Msgf("failed to write %s pull request activity", payload.ActivityType())
+	}
+}
+
+func eventBase(pr *types.PullReq, principalID int64) pullreqevents.Base {
+	return pullreqevents.Base{
+		PullReqID:    pr.ID,
+		SourceRepoID: pr.SourceRepoID,
+		TargetRepoID: pr.TargetRepoID,
+		PrincipalID:  principalID,
+		Number:       pr.Number,
+	}
+}
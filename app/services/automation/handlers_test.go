@@ -0,0 +1,109 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var autoMergeEnabled = map[string]json.RawMessage{string(settings.KeyAutoMergeEnabled): json.RawMessage("true")}
+
+func TestService_HandleEventLabelAssigned(t *testing.T) {
+	automations := []*types.Automation{
+		autoMergeAutomation(1, 101, 101, labelTrigger(10, nil)),
+		autoMergeAutomation(2, 102, 102, labelTrigger(10, ptr.Int64(100))),
+		autoMergeAutomation(3, 103, 103, labelTrigger(10, ptr.Int64(200))),
+		autoMergeAutomation(4, 104, 104, labelTrigger(11, nil)),
+		autoMergeAutomation(5, 105, 0, labelTrigger(10, nil)),
+		autoMergeAutomation(6, 106, 107, labelTrigger(10, nil)),
+	}
+
+	pr := &types.PullReq{ID: 1, TargetRepoID: testRepoID, State: enum.PullReqStateOpen}
+	service, _, authorizer := newTestService(t, pr, automations, autoMergeEnabled)
+
+	// the label was assigned by another automation, so the event principal is the system service.
+	err := service.handleEventLabelAssigned(context.Background(), &events.Event[*pullreqevents.LabelAssignedPayload]{
+		ID: "event",
+		Payload: &pullreqevents.LabelAssignedPayload{
+			Base:    pullreqevents.Base{PullReqID: pr.ID, TargetRepoID: testRepoID, PrincipalID: testSystemID},
+			LabelID: 10,
+			ValueID: ptr.Int64(100),
+		},
+	})
+	require.NoError(t, err)
+
+	// auto merge is requested by the principal who last saved each matching automation.
+	assert.Equal(t, []int64{101, 102, 105, 107}, authorizer.checked)
+}
+
+func TestService_EnableAutoMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		pr       types.PullReq
+		settings map[string]json.RawMessage
+		checked  []int64
+	}{
+		{
+			name:     "enabled",
+			pr:       types.PullReq{ID: 1, State: enum.PullReqStateOpen},
+			settings: autoMergeEnabled,
+			checked:  []int64{102},
+		},
+		{
+			name: "disabled in repo",
+			pr:   types.PullReq{ID: 1, State: enum.PullReqStateOpen},
+		},
+		{
+			name:     "draft",
+			pr:       types.PullReq{ID: 1, State: enum.PullReqStateOpen, IsDraft: true},
+			settings: autoMergeEnabled,
+		},
+		{
+			name:     "already enabled",
+			pr:       types.PullReq{ID: 1, State: enum.PullReqStateOpen, SubState: enum.PullReqSubStateAutoMerge},
+			settings: autoMergeEnabled,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			service, _, authorizer := newTestService(t, &test.pr, nil, test.settings)
+			automation := autoMergeAutomation(1, 101, 102, labelTrigger(10, nil))
+			repo := &types.RepositoryCore{ID: testRepoID, ParentID: testSpaceID, Path: "root/space/repo"}
+
+			err := service.enableAutoMerge(context.Background(), automation, &test.pr, repo)
+			if test.checked != nil {
+				// the fake authorizer denies the push permission.
+				require.ErrorContains(t, err, "can't enable auto merge")
+			} else {
+				require.NoError(t, err)
+			}
+
+			assert.Equal(t, test.checked, authorizer.checked)
+		})
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package automation
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/auth/authz"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/merge"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+type Service struct {
+	authorizer             authz.Authorizer
+	pullreqEvReporter      *pullreqevents.Reporter
+	repoFinder             refcache.RepoFinder
+	spaceStore             store.SpaceStore
+	automationStore        store.AutomationStore
+	pullreqStore           store.PullReqStore
+	activityStore          store.PullReqActivityStore
+	reviewerStore          store.PullReqReviewerStore
+	userGroupReviewerStore store.UserGroupReviewerStore
+	userGroupStore         store.UserGroupStore
+	principalStore         store.PrincipalStore
+	labelSvc               *label.Service
+	mergeService           *merge.Service
+	settings               *settings.Service
+	sseStreamer            sse.Streamer
+}
+
+func New(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pullreqEvReporter *pullreqevents.Reporter,
+	authorizer authz.Authorizer,
+	repoFinder refcache.RepoFinder,
+	spaceStore store.SpaceStore,
+	automationStore store.AutomationStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	reviewerStore store.PullReqReviewerStore,
+	userGroupReviewerStore store.UserGroupReviewerStore,
+	userGroupStore store.UserGroupStore,
+	principalStore store.PrincipalStore,
+	labelSvc *label.Service,
+	mergeService *merge.Service,
+	settings *settings.Service,
+	sseStreamer sse.Streamer,
+) (*Service, error) {
+	service := &Service{
+		authorizer:             authorizer,
+		pullreqEvReporter:      pullreqEvReporter,
+		repoFinder:             repoFinder,
+		spaceStore:             spaceStore,
+		automationStore:        automationStore,
+		pullreqStore:           pullreqStore,
+		activityStore:          activityStore,
+		reviewerStore:          reviewerStore,
+		userGroupReviewerStore: userGroupReviewerStore,
+		userGroupStore:         userGroupStore,
+		principalStore:         principalStore,
+		labelSvc:               labelSvc,
+		mergeService:           mergeService,
+		settings:               settings,
+		sseStreamer:            sseStreamer,
+	}
+
+	const groupPullReqAutomation = "gitness:pullreq:automation"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqAutomation, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(3),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterCreated(service.handleEventCreated)
+			_ = r.RegisterBranchUpdated(service.handleEventBranchUpdated)
+			_ = r.RegisterLabelAssigned(service.handleEventLabelAssigned)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
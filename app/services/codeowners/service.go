@@ -19,7 +19,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/harness/gitness/app/services/usergroup"
@@ -60,6 +62,14 @@ var (
 			"['*', '?', '[', ']', '{', '}', '-', '!', '^']",
 	)
 	ErrFileParseTrailingBackslashInPattern = errors.New("a pattern can't end with a trailing '\\'")
+	ErrFileParseInvalidSection             = errors.New(
+		"a section header must be of the form '[Section]', '[Section][N]' or '^[Section]' " +
+			"with a non-empty name and N greater than zero",
+	)
+
+	// sectionHeaderRegex matches section headers, like "^[Section Name][2] @default-owner # comment".
+	// A line starting with a bracket expression followed by whitespace is always treated as a section header.
+	sectionHeaderRegex = regexp.MustCompile(`^(\^)?\[([^\]]*)\](?:\[(\d+)\])?(?:[ \t]+(.*))?$`)
 )
 
 // TooLargeError represents an error if codeowners file is too large.
@@ -126,10 +136,26 @@ type CodeOwners struct {
 	Entries []Entry
 }
 
+// Section is a named group of CODEOWNERS entries. Each section is evaluated independently:
+// the last matching entry within a section wins, and approvals are counted per section.
+type Section struct {
+	// Name is the name of the section as defined by its first header.
+	Name string
+	// Optional sections don't require an approval for the pull request to be merged.
+	Optional bool
+	// RequiredApprovals is the number of code owner approvals required by the section.
+	RequiredApprovals int
+	// DefaultOwners are used for the entries of the section that don't specify owners.
+	DefaultOwners []string
+}
+
 type Entry struct {
 	// LineNumber is the line number of the code owners entry.
 	LineNumber int64
 
+	// Section is the section the entry belongs to, or nil for entries defined before any section header.
+	Section *Section
+
 	// Pattern is a glob star pattern used to match the entry against a given file path.
 	Pattern string
 	// Owners is the list of owners for the given pattern.
@@ -144,16 +170,30 @@ func (e *Entry) IsOwnershipReset() bool {
 
 type Evaluation struct {
 	EvaluationEntries []EvaluationEntry
-	FileSha           string
+	// Sections holds the approval state of the named sections with applicable entries.
+	Sections []SectionEvaluation
+	FileSha  string
 }
 
 type EvaluationEntry struct {
 	LineNumber           int64
 	Pattern              string
+	Section              *Section
 	UserEvaluations      []UserEvaluation
 	UserGroupEvaluations []UserGroupEvaluation
 }
 
+// SectionEvaluation holds the approval state of a named CODEOWNERS section.
+type SectionEvaluation struct {
+	Name              string
+	Optional          bool
+	RequiredApprovals int
+	// Approvers are the distinct code owners of the section that approved the pull request.
+	Approvers []UserEvaluation
+	// ChangeRequested is true if any code owner of the section requested changes.
+	ChangeRequested bool
+}
+
 type UserGroupEvaluation struct {
 	Identifier  string
 	Name        string
@@ -207,9 +247,13 @@ func (s *Service) get(
 	}, nil
 }
 
+//nolint:gocognit // the parser is easier to follow as a single function
 func (s *Service) parseCodeOwnerFile(content string) ([]Entry, error) {
 	var lineNumber int64
 	var entries []Entry
+	var section *Section
+	sections := map[string]*Section{}
+	isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
 	scanner := bufio.NewScanner(strings.NewReader(content))
 	for scanner.Scan() {
 		lineNumber++
@@ -220,7 +264,23 @@ func (s *Service) parseCodeOwnerFile(content string) ([]Entry, error) {
 			continue
 		}
 
-		isSeparator := func(r rune) bool { return r == ' ' || r == '\t' }
+		if header, ok, err := parseSectionHeader(line); err != nil {
+			return nil, &FileParseError{
+				LineNumber: lineNumber,
+				Line:       originalLine,
+				Err:        err,
+			}
+		} else if ok {
+			// sections with the same name (case-insensitive) are merged, the first header defines the settings.
+			key := strings.ToLower(header.Name)
+			if existing, exists := sections[key]; exists {
+				section = existing
+			} else {
+				section = header
+				sections[key] = header
+			}
+			continue
+		}
 		lineAsRunes := []rune(line)
 		pattern := strings.Builder{}
 
@@ -268,11 +328,17 @@ func (s *Service) parseCodeOwnerFile(content string) ([]Entry, error) {
 			lineAsRunes = lineAsRunes[:i]
 		}
 
+		// could be empty list in case of removing ownership
+		owners := strings.FieldsFunc(string(lineAsRunes), isSeparator)
+		if len(owners) == 0 && section != nil && len(section.DefaultOwners) > 0 {
+			owners = slices.Clone(section.DefaultOwners)
+		}
+
 		entries = append(entries, Entry{
 			LineNumber: lineNumber,
+			Section:    section,
 			Pattern:    pattern.String(),
-			// could be empty list in case of removing ownership
-			Owners: strings.FieldsFunc(string(lineAsRunes), isSeparator),
+			Owners:     owners,
 		})
 	}
 	if err := scanner.Err(); err != nil {
@@ -282,6 +348,41 @@ func (s *Service) parseCodeOwnerFile(content string) ([]Entry, error) {
 	return entries, nil
 }
 
+// parseSectionHeader parses a section header line, like "[Section]", "[Section][2] @owner" or "^[Section]".
+// It returns false if the line isn't a section header.
+func parseSectionHeader(line string) (*Section, bool, error) {
+	matches := sectionHeaderRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return nil, false, nil
+	}
+
+	name := strings.TrimSpace(matches[2])
+	if name == "" {
+		return nil, false, ErrFileParseInvalidSection
+	}
+
+	requiredApprovals := 1
+	if matches[3] != "" {
+		var err error
+		requiredApprovals, err = strconv.Atoi(matches[3])
+		if err != nil || requiredApprovals <= 0 {
+			return nil, false, ErrFileParseInvalidSection
+		}
+	}
+
+	defaultOwners := matches[4]
+	if i := strings.Index(defaultOwners, "#"); i >= 0 {
+		defaultOwners = defaultOwners[:i]
+	}
+
+	return &Section{
+		Name:              name,
+		Optional:          matches[1] != "",
+		RequiredApprovals: requiredApprovals,
+		DefaultOwners:     strings.Fields(defaultOwners),
+	}, true, nil
+}
+
 func (s *Service) getCodeOwnerFile(
 	ctx context.Context,
 	repo *types.RepositoryCore,
@@ -380,24 +481,48 @@ func (s *Service) GetApplicableCodeOwners(
 		return nil, fmt.Errorf("failed to get diff file stat: %w", err)
 	}
 
+	filteredEntries, err := applicableEntries(owners.Entries, diffFileNames.Files)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CodeOwners{
+		FileSHA: owners.FileSHA,
+		Entries: filteredEntries,
+	}, nil
+}
+
+// applicableEntries returns the entries that own any of the provided files, in order of their occurrence.
+// For every file, the last matching entry wins, separately for every section.
+func applicableEntries(entries []Entry, files []string) ([]Entry, error) {
+	sections := map[*Section]struct{}{}
+	for i := range entries {
+		sections[entries[i].Section] = struct{}{}
+	}
+
 	entryIDs := map[int]struct{}{}
-	for _, file := range diffFileNames.Files {
+	for _, file := range files {
 		// last rule that matches wins (hence simply go in reverse order)
-		for i := len(owners.Entries) - 1; i >= 0; i-- {
-			pattern := owners.Entries[i].Pattern
+		matchedSections := map[*Section]struct{}{}
+		for i := len(entries) - 1; i >= 0 && len(matchedSections) < len(sections); i-- {
+			if _, matched := matchedSections[entries[i].Section]; matched {
+				continue
+			}
+
+			pattern := entries[i].Pattern
 			if ok, err := match(pattern, file); err != nil {
 				return nil, fmt.Errorf("failed to match pattern %q for file %q: %w", pattern, file, err)
 			} else if ok {
 				entryIDs[i] = struct{}{}
-				break
+				matchedSections[entries[i].Section] = struct{}{}
 			}
 		}
 	}
 
 	filteredEntries := make([]Entry, 0, len(entryIDs))
 	for i := range entryIDs {
-		if !owners.Entries[i].IsOwnershipReset() {
-			filteredEntries = append(filteredEntries, owners.Entries[i])
+		if !entries[i].IsOwnershipReset() {
+			filteredEntries = append(filteredEntries, entries[i])
 		}
 	}
 
@@ -407,10 +532,7 @@ func (s *Service) GetApplicableCodeOwners(
 		func(i, j int) bool { return filteredEntries[i].LineNumber <= filteredEntries[j].LineNumber },
 	)
 
-	return &CodeOwners{
-		FileSHA: owners.FileSHA,
-		Entries: filteredEntries,
-	}, err
+	return filteredEntries, nil
 }
 
 // Evaluate evaluates the code owners for a given pull request.
@@ -478,6 +600,7 @@ func (s *Service) Evaluate(
 			evaluationEntries = append(evaluationEntries, EvaluationEntry{
 				LineNumber:           entry.LineNumber,
 				Pattern:              entry.Pattern,
+				Section:              entry.Section,
 				UserEvaluations:      userEvaluations,
 				UserGroupEvaluations: userGroupEvaluations,
 			})
@@ -486,10 +609,58 @@ func (s *Service) Evaluate(
 
 	return &Evaluation{
 		EvaluationEntries: evaluationEntries,
+		Sections:          evaluateSections(evaluationEntries),
 		FileSha:           owners.FileSHA,
 	}, nil
 }
 
+// evaluateSections aggregates the reviews of the code owners of all entries of every named section.
+// Sections are returned in order of their first applicable entry.
+func evaluateSections(entries []EvaluationEntry) []SectionEvaluation {
+	var sections []SectionEvaluation
+	sectionIdx := map[*Section]int{}
+	approvers := map[*Section]map[int64]struct{}{}
+
+	for _, entry := range entries {
+		if entry.Section == nil {
+			continue
+		}
+
+		idx, ok := sectionIdx[entry.Section]
+		if !ok {
+			idx = len(sections)
+			sectionIdx[entry.Section] = idx
+			approvers[entry.Section] = map[int64]struct{}{}
+			sections = append(sections, SectionEvaluation{
+				Name:              entry.Section.Name,
+				Optional:          entry.Section.Optional,
+				RequiredApprovals: entry.Section.RequiredApprovals,
+			})
+		}
+
+		evaluations := slices.Clone(entry.UserEvaluations)
+		for _, userGroupEvaluation := range entry.UserGroupEvaluations {
+			evaluations = append(evaluations, userGroupEvaluation.Evaluations...)
+		}
+
+		for _, evaluation := range evaluations {
+			switch evaluation.ReviewDecision {
+			case enum.PullReqReviewDecisionChangeReq:
+				sections[idx].ChangeRequested = true
+			case enum.PullReqReviewDecisionApproved:
+				if _, exists := approvers[entry.Section][evaluation.Owner.ID]; exists {
+					continue
+				}
+				approvers[entry.Section][evaluation.Owner.ID] = struct{}{}
+				sections[idx].Approvers = append(sections[idx].Approvers, evaluation)
+			case enum.PullReqReviewDecisionPending, enum.PullReqReviewDecisionReviewed:
+			}
+		}
+	}
+
+	return sections
+}
+
 func (s *Service) resolveUserGroupCodeOwner(
 	ctx context.Context,
 	owner string,
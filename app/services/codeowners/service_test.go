@@ -20,6 +20,8 @@ import (
 
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 func TestService_ParseCodeOwner(t *testing.T) {
@@ -268,6 +270,179 @@ g   user1@harness.io	#  user2@harness.io
 	}
 }
 
+func TestService_ParseCodeOwnerSections(t *testing.T) {
+	backend := &Section{Name: "Backend", RequiredApprovals: 2, DefaultOwners: []string{"@backend"}}
+	docs := &Section{Name: "Docs", Optional: true, RequiredApprovals: 1, DefaultOwners: []string{}}
+
+	tests := []struct {
+		name    string
+		content string
+		want    []Entry
+		wantErr bool
+	}{
+		{
+			name: "sections",
+			content: `
+* user1@harness.io
+[Backend][2] @backend # backend team
+/app/
+/app/api user2@harness.io
+^[Docs]
+/docs/ user3@harness.io
+[backend]
+/cmd/
+`,
+			want: []Entry{
+				{LineNumber: 2, Pattern: "*", Owners: []string{"user1@harness.io"}},
+				{LineNumber: 4, Section: backend, Pattern: "/app/", Owners: []string{"@backend"}},
+				{LineNumber: 5, Section: backend, Pattern: "/app/api", Owners: []string{"user2@harness.io"}},
+				{LineNumber: 7, Section: docs, Pattern: "/docs/", Owners: []string{"user3@harness.io"}},
+				{LineNumber: 9, Section: backend, Pattern: "/cmd/", Owners: []string{"@backend"}},
+			},
+		},
+		{
+			name:    "empty section name",
+			content: "[ ]\n/app/ user1@harness.io",
+			wantErr: true,
+		},
+		{
+			name:    "zero required approvals",
+			content: "[Backend][0]\n/app/ user1@harness.io",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Service{}
+			got, err := s.parseCodeOwnerFile(tt.content)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCodeOwner() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCodeOwner() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_evaluateSections(t *testing.T) {
+	backend := &Section{Name: "Backend", RequiredApprovals: 2}
+	docs := &Section{Name: "Docs", Optional: true, RequiredApprovals: 1}
+
+	owner1 := types.PrincipalInfo{ID: 1}
+	owner2 := types.PrincipalInfo{ID: 2}
+
+	entries := []EvaluationEntry{
+		{
+			Pattern: "*",
+			UserEvaluations: []UserEvaluation{
+				{Owner: owner1, ReviewDecision: enum.PullReqReviewDecisionChangeReq},
+			},
+		},
+		{
+			Pattern: "/app/",
+			Section: backend,
+			UserEvaluations: []UserEvaluation{
+				{Owner: owner1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+			},
+		},
+		{
+			Pattern: "/docs/",
+			Section: docs,
+			UserEvaluations: []UserEvaluation{
+				{Owner: owner2, ReviewDecision: enum.PullReqReviewDecisionChangeReq},
+			},
+		},
+		{
+			Pattern: "/cmd/",
+			Section: backend,
+			UserEvaluations: []UserEvaluation{
+				{Owner: owner1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+			},
+			UserGroupEvaluations: []UserGroupEvaluation{
+				{
+					Identifier: "backend",
+					Evaluations: []UserEvaluation{
+						{Owner: owner2, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+					},
+				},
+			},
+		},
+	}
+
+	want := []SectionEvaluation{
+		{
+			Name:              "Backend",
+			RequiredApprovals: 2,
+			Approvers: []UserEvaluation{
+				{Owner: owner1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+				{Owner: owner2, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+			},
+		},
+		{
+			Name:              "Docs",
+			Optional:          true,
+			RequiredApprovals: 1,
+			ChangeRequested:   true,
+		},
+	}
+
+	if got := evaluateSections(entries); !reflect.DeepEqual(got, want) {
+		t.Errorf("evaluateSections() got = %+v, want %+v", got, want)
+	}
+}
+
+func Test_applicableEntries(t *testing.T) {
+	backend := &Section{Name: "Backend", RequiredApprovals: 1}
+
+	entries := []Entry{
+		{LineNumber: 1, Pattern: "*", Owners: []string{"user1@harness.io"}},
+		{LineNumber: 2, Pattern: "/docs/", Owners: []string{"user2@harness.io"}},
+		{LineNumber: 4, Section: backend, Pattern: "/app/", Owners: []string{"user3@harness.io"}},
+		{LineNumber: 5, Section: backend, Pattern: "/app/api/", Owners: []string{"user4@harness.io"}},
+		{LineNumber: 6, Section: backend, Pattern: "/app/gen/"},
+	}
+
+	tests := []struct {
+		name  string
+		files []string
+		want  []int64
+	}{
+		{
+			name:  "default section only",
+			files: []string{"README.md"},
+			want:  []int64{1},
+		},
+		{
+			name:  "last match wins per section",
+			files: []string{"app/api/handler.go"},
+			want:  []int64{1, 5},
+		},
+		{
+			name:  "ownership reset in section",
+			files: []string{"app/gen/types.go", "docs/index.md"},
+			want:  []int64{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := applicableEntries(entries, tt.files)
+			if err != nil {
+				t.Fatalf("applicableEntries() error = %v", err)
+			}
+
+			lines := make([]int64, len(got))
+			for i := range got {
+				lines[i] = got[i].LineNumber
+			}
+			if !reflect.DeepEqual(lines, tt.want) {
+				t.Errorf("applicableEntries() got lines = %v, want %v", lines, tt.want)
+			}
+		})
+	}
+}
+
 func Test_match(t *testing.T) {
 	type args struct {
 		pattern            string
@@ -0,0 +1,123 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"bufio"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"strings"
+)
+
+var ErrNoPlainText = errors.New("the message has no plain text body")
+
+// Message is a reply received via email.
+type Message struct {
+	// From is the address of the author of the message.
+	From string
+	// Recipients are the envelope recipients of the message.
+	Recipients []string
+	Subject    string
+	// Text is the plain text body of the message.
+	Text string
+}
+
+// ParseMessage parses the raw message and extracts the author and the plain text body.
+func ParseMessage(r io.Reader, recipients []string) (*Message, error) {
+	msg, err := mail.ReadMessage(bufio.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read message: %w", err)
+	}
+
+	from, err := mail.ParseAddress(msg.Header.Get("From"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address: %w", err)
+	}
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		subject = msg.Header.Get("Subject")
+	}
+
+	text, err := plainText(textproto.MIMEHeader(msg.Header), msg.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Message{
+		From:       from.Address,
+		Recipients: recipients,
+		Subject:    subject,
+		Text:       text,
+	}, nil
+}
+
+// plainText returns the first text/plain part of the body, nested multipart bodies are searched recursively.
+func plainText(header textproto.MIMEHeader, body io.Reader) (string, error) {
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "text/plain"
+	}
+
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return "", fmt.Errorf("invalid content type: %w", err)
+	}
+
+	if strings.HasPrefix(mediaType, "multipart/") {
+		reader := multipart.NewReader(body, params["boundary"])
+		for {
+			part, err := reader.NextRawPart()
+			if errors.Is(err, io.EOF) {
+				return "", ErrNoPlainText
+			}
+			if err != nil {
+				return "", fmt.Errorf("failed to read multipart body: %w", err)
+			}
+
+			text, err := plainText(part.Header, part)
+			if errors.Is(err, ErrNoPlainText) {
+				continue
+			}
+
+			return text, err
+		}
+	}
+
+	if mediaType != "text/plain" {
+		return "", ErrNoPlainText
+	}
+
+	switch strings.ToLower(header.Get("Content-Transfer-Encoding")) {
+	case "quoted-printable":
+		body = quotedprintable.NewReader(body)
+	case "base64":
+		body = base64.NewDecoder(base64.StdEncoding, body)
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read text body: %w", err)
+	}
+
+	return string(data), nil
+}
@@ -0,0 +1,135 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/store"
+
+	"github.com/rs/zerolog/log"
+)
+
+var (
+	ErrSenderMismatch = errors.New("the sender doesn't match the recipient of the notification")
+	ErrEmptyReply     = errors.New("the reply has no text")
+)
+
+// Processor turns replies to comment notifications into replies in the comment thread.
+type Processor struct {
+	signer         *Signer
+	principalStore store.PrincipalStore
+	activityStore  store.PullReqActivityStore
+	pullReqStore   store.PullReqStore
+	repoStore      store.RepoStore
+	pullreqCtrl    *pullreq.Controller
+}
+
+func NewProcessor(
+	signer *Signer,
+	principalStore store.PrincipalStore,
+	activityStore store.PullReqActivityStore,
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	pullreqCtrl *pullreq.Controller,
+) *Processor {
+	return &Processor{
+		signer:         signer,
+		principalStore: principalStore,
+		activityStore:  activityStore,
+		pullReqStore:   pullReqStore,
+		repoStore:      repoStore,
+		pullreqCtrl:    pullreqCtrl,
+	}
+}
+
+// AcceptRecipient returns true if the address is a validly signed reply-to address that isn't expired.
+func (p *Processor) AcceptRecipient(address string) bool {
+	_, _, err := p.signer.Parse(address)
+	return err == nil
+}
+
+// Process adds the text of the reply to the comment thread identified by the reply token.
+// The author of the message has to be the user the notification with the reply token was sent to.
+func (p *Processor) Process(ctx context.Context, msg *Message) error {
+	principalID, threadID, err := p.parseRecipients(msg.Recipients)
+	if err != nil {
+		return err
+	}
+
+	principal, err := p.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to find principal of the reply token: %w", err)
+	}
+
+	if principal.Blocked || !strings.EqualFold(principal.Email, msg.From) {
+		return ErrSenderMismatch
+	}
+
+	text := StripQuotedText(msg.Text)
+	if text == "" {
+		return ErrEmptyReply
+	}
+
+	thread, err := p.activityStore.Find(ctx, threadID)
+	if err != nil {
+		return fmt.Errorf("failed to find comment thread: %w", err)
+	}
+
+	pr, err := p.pullReqStore.Find(ctx, thread.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to find pull request of the comment thread: %w", err)
+	}
+
+	repo, err := p.repoStore.Find(ctx, thread.RepoID)
+	if err != nil {
+		return fmt.Errorf("failed to find repository of the comment thread: %w", err)
+	}
+
+	// the comment is created on behalf of the user, hence all permission checks of the API apply.
+	session := &auth.Session{Principal: *principal}
+	comment, err := p.pullreqCtrl.CommentCreate(ctx, session, repo.Path, pr.Number, &pullreq.CommentCreateInput{
+		ParentID: thread.ID,
+		Text:     text,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	log.Ctx(ctx).Info().
+		Int64("principal_id", principal.ID).
+		Int64("pullreq_id", pr.ID).
+		Int64("activity_id", comment.ID).
+		Msg("created pull request comment from email reply")
+
+	return nil
+}
+
+// parseRecipients returns the principal and the comment thread of the first validly signed recipient.
+func (p *Processor) parseRecipients(recipients []string) (int64, int64, error) {
+	for _, recipient := range recipients {
+		principalID, threadID, err := p.signer.Parse(recipient)
+		if err == nil {
+			return principalID, threadID, nil
+		}
+	}
+
+	return 0, 0, ErrInvalidToken
+}
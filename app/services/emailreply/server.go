@@ -0,0 +1,335 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	commandTimeout = 5 * time.Minute
+	sessionTimeout = 30 * time.Minute
+	maxRecipients  = 100
+	// maxLineLength is the maximum length of a command line (RFC 5321 allows 512 octets including CRLF,
+	// but some clients send longer lines with ESMTP parameters).
+	maxLineLength = 1000
+	// rejectTimeout is the time limit for refusing a connection in case the server is busy.
+	rejectTimeout = 5 * time.Second
+)
+
+var errLineTooLong = errors.New("line too long")
+
+// Handler processes the messages received by the Server.
+type Handler interface {
+	// AcceptRecipient returns true if messages to the address are accepted.
+	AcceptRecipient(address string) bool
+	// Process processes a received message.
+	Process(ctx context.Context, msg *Message) error
+}
+
+// Server is a minimal SMTP server receiving the replies to notification emails.
+// It's meant to be the target of a mail relay or a forwarding rule, hence it neither supports
+// authentication nor TLS and only accepts messages to validly signed reply-to addresses.
+type Server struct {
+	address        string
+	hostname       string
+	maxMessageSize int64
+	maxConnections int
+	handler        Handler
+}
+
+// NewServer returns a new Server listening on the address.
+// At most maxConnections connections are served concurrently, any further connections are refused.
+func NewServer(
+	address string,
+	hostname string,
+	maxMessageSize int64,
+	maxConnections int,
+	handler Handler,
+) *Server {
+	return &Server{
+		address:        address,
+		hostname:       hostname,
+		maxMessageSize: maxMessageSize,
+		maxConnections: maxConnections,
+		handler:        handler,
+	}
+}
+
+// ListenAndServe listens on the address of the server and serves connections until the context is canceled.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", s.address, err)
+	}
+
+	log.Ctx(ctx).Info().Str("address", s.address).Msg("email reply server started")
+
+	return s.Serve(ctx, listener)
+}
+
+// Serve serves the connections of the listener until the context is canceled.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		_ = listener.Close()
+	}()
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// the server doesn't require authentication, hence limit the number of connections served concurrently.
+	sem := make(chan struct{}, s.maxConnections)
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		select {
+		case sem <- struct{}{}:
+		default:
+			reject(ctx, conn)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
+
+// reject refuses the connection as the server is busy.
+func reject(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	_ = conn.SetWriteDeadline(time.Now().Add(rejectTimeout))
+	if _, err := io.WriteString(conn, "421 4.3.2 Too many connections, try again later\r\n"); err != nil {
+		log.Ctx(ctx).Debug().Err(err).Str("remote_addr", conn.RemoteAddr().String()).
+			Msg("failed to refuse smtp connection")
+	}
+}
+
+// lineReader fails all reads once a line read from the underlying reader exceeds the limit,
+// so a client can't exhaust the memory with an endless line. A limit of zero disables the check.
+type lineReader struct {
+	r     io.Reader
+	limit int
+	n     int
+	err   error
+}
+
+func (l *lineReader) Read(p []byte) (int, error) {
+	if l.err != nil {
+		return 0, l.err
+	}
+
+	n, err := l.r.Read(p)
+	for i, b := range p[:n] {
+		if b == '\n' {
+			l.n = 0
+			continue
+		}
+
+		l.n++
+		if l.limit > 0 && l.n > l.limit {
+			l.err = errLineTooLong
+			return i, l.err
+		}
+	}
+
+	return n, err
+}
+
+type session struct {
+	mail       bool
+	from       string
+	recipients []string
+}
+
+func (s *Server) serveConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	logger := log.Ctx(ctx).With().Str("remote_addr", conn.RemoteAddr().String()).Logger()
+	ctx = logger.WithContext(ctx)
+
+	lines := &lineReader{r: conn, limit: maxLineLength}
+	tp := textproto.NewConn(struct {
+		io.Reader
+		io.WriteCloser
+	}{lines, conn})
+	reply := func(format string, args ...any) bool {
+		if err := tp.PrintfLine(format, args...); err != nil {
+			logger.Debug().Err(err).Msg("failed to write smtp reply")
+			return false
+		}
+		return true
+	}
+
+	if !reply("220 %s ESMTP ready", s.hostname) {
+		return
+	}
+
+	sessionDeadline := time.Now().Add(sessionTimeout)
+
+	var sess session
+	for {
+		deadline := time.Now().Add(commandTimeout)
+		if deadline.After(sessionDeadline) {
+			deadline = sessionDeadline
+		}
+		_ = conn.SetDeadline(deadline)
+
+		line, err := tp.ReadLine()
+		// NOTE: the buffered reader returns the truncated line without error in case the limit is hit.
+		if lines.err != nil {
+			reply("500 5.5.2 Line too long")
+			return
+		}
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				logger.Debug().Err(err).Msg("failed to read smtp command")
+			}
+			return
+		}
+
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "HELO":
+			sess = session{}
+			reply("250 %s", s.hostname)
+		case "EHLO":
+			sess = session{}
+			reply("250-%s\r\n250-SIZE %d\r\n250 8BITMIME", s.hostname, s.maxMessageSize)
+		case "MAIL":
+			from, ok := parsePath(arg, "FROM:")
+			if !ok {
+				reply("501 5.5.4 Syntax: MAIL FROM:<address>")
+				continue
+			}
+			sess = session{mail: true, from: from}
+			reply("250 2.1.0 OK")
+		case "RCPT":
+			rcpt, ok := parsePath(arg, "TO:")
+			switch {
+			case !ok:
+				reply("501 5.5.4 Syntax: RCPT TO:<address>")
+			case !sess.mail:
+				reply("503 5.5.1 MAIL command required first")
+			case len(sess.recipients) >= maxRecipients:
+				reply("452 4.5.3 Too many recipients")
+			case !s.handler.AcceptRecipient(rcpt):
+				reply("550 5.1.1 Unknown recipient")
+			default:
+				sess.recipients = append(sess.recipients, rcpt)
+				reply("250 2.1.5 OK")
+			}
+		case "DATA":
+			if len(sess.recipients) == 0 {
+				reply("503 5.5.1 RCPT command required first")
+				continue
+			}
+			if !reply("354 End data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			// the message data is limited by its total size instead.
+			lines.limit = 0
+			result := s.receive(ctx, tp, sess)
+			lines.limit = maxLineLength
+			if !reply("%s", result) {
+				return
+			}
+			sess = session{}
+		case "RSET":
+			sess = session{}
+			reply("250 2.0.0 OK")
+		case "NOOP":
+			reply("250 2.0.0 OK")
+		case "QUIT":
+			reply("221 2.0.0 Bye")
+			return
+		default:
+			reply("502 5.5.2 Command not recognized")
+		}
+	}
+}
+
+// receive reads the message data and processes the message. It returns the reply to send to the client.
+func (s *Server) receive(ctx context.Context, tp *textproto.Conn, sess session) string {
+	dot := tp.DotReader()
+
+	data, err := io.ReadAll(io.LimitReader(dot, s.maxMessageSize+1))
+	if err != nil {
+		return "451 4.3.0 Failed to read message"
+	}
+
+	if int64(len(data)) > s.maxMessageSize {
+		// drain the remaining data, so the connection can be reused.
+		_, _ = io.Copy(io.Discard, dot)
+		return "552 5.3.4 Message too big"
+	}
+
+	msg, err := ParseMessage(bytes.NewReader(data), sess.recipients)
+	if err != nil {
+		log.Ctx(ctx).Debug().Err(err).Msg("failed to parse email reply")
+		return "554 5.6.0 Invalid message"
+	}
+
+	if err = s.handler.Process(ctx, msg); err != nil {
+		log.Ctx(ctx).Warn().Err(err).
+			Str("from", msg.From).
+			Str("envelope_from", sess.from).
+			Msg("failed to process email reply")
+		return "554 5.7.0 Reply rejected"
+	}
+
+	return "250 2.0.0 OK"
+}
+
+// parsePath extracts the address of the "FROM:<address>" and "TO:<address>" arguments.
+func parsePath(arg string, prefix string) (string, bool) {
+	if len(arg) < len(prefix) || !strings.EqualFold(arg[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	path := strings.TrimSpace(arg[len(prefix):])
+	// ignore ESMTP parameters, e.g. "MAIL FROM:<address> SIZE=1000"
+	path, _, _ = strings.Cut(path, " ")
+	if !strings.HasPrefix(path, "<") || !strings.HasSuffix(path, ">") {
+		return "", false
+	}
+
+	return strings.TrimSuffix(strings.TrimPrefix(path, "<"), ">"), true
+}
@@ -0,0 +1,166 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeHandler struct {
+	mx       sync.Mutex
+	messages []*Message
+}
+
+func (h *fakeHandler) AcceptRecipient(address string) bool {
+	return strings.HasPrefix(address, "reply+")
+}
+
+func (h *fakeHandler) Process(_ context.Context, msg *Message) error {
+	h.mx.Lock()
+	defer h.mx.Unlock()
+
+	h.messages = append(h.messages, msg)
+
+	return nil
+}
+
+func startServer(t *testing.T, handler Handler, maxConnections int) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- NewServer(listener.Addr().String(), "example.com", 1024, maxConnections, handler).Serve(ctx, listener)
+	}()
+
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	return listener.Addr().String()
+}
+
+func TestServer(t *testing.T) {
+	handler := &fakeHandler{}
+	address := startServer(t, handler, 10)
+
+	body := "From: John Doe <john@example.com>\r\n" +
+		"To: reply+token@example.com\r\n" +
+		"Subject: =?UTF-8?Q?Re:_[repo]_Fix_=C3=BCmlauts?=\r\n" +
+		"MIME-Version: 1.0\r\n" +
+		"Content-Type: multipart/alternative; boundary=\"b1\"\r\n" +
+		"\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/plain; charset=UTF-8\r\n" +
+		"Content-Transfer-Encoding: quoted-printable\r\n" +
+		"\r\n" +
+		"Looks good =\r\nto me.\r\n" +
+		"\r\n" +
+		"On Mon, Jan 1, 2024 Gitness wrote:\r\n" +
+		"> .hidden line\r\n" +
+		"--b1\r\n" +
+		"Content-Type: text/html; charset=UTF-8\r\n" +
+		"\r\n" +
+		"<p>Looks good to me.</p>\r\n" +
+		"--b1--\r\n"
+
+	err := smtp.SendMail(address, nil, "john@example.com", []string{"reply+token@example.com"}, []byte(body))
+	require.NoError(t, err)
+
+	require.Len(t, handler.messages, 1)
+	msg := handler.messages[0]
+	assert.Equal(t, "john@example.com", msg.From)
+	assert.Equal(t, []string{"reply+token@example.com"}, msg.Recipients)
+	assert.Equal(t, "Re: [repo] Fix ümlauts", msg.Subject)
+	assert.Equal(t, "Looks good to me.", StripQuotedText(msg.Text))
+	assert.Contains(t, msg.Text, "> .hidden line", "dot-stuffing has to be reverted")
+
+	// recipients without a reply token are rejected.
+	err = smtp.SendMail(address, nil, "john@example.com", []string{"someone@example.com"}, []byte(body))
+	assert.ErrorContains(t, err, "550")
+
+	// messages exceeding the size limit are rejected.
+	err = smtp.SendMail(address, nil, "john@example.com", []string{"reply+token@example.com"},
+		[]byte(body+strings.Repeat("x", 1024)))
+	assert.ErrorContains(t, err, "552")
+
+	assert.Len(t, handler.messages, 1)
+}
+
+// dial connects to the server and returns the reader of the replies after the greeting was read.
+func dial(t *testing.T, address string) (net.Conn, *bufio.Reader) {
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	r := bufio.NewReader(conn)
+	greeting, err := r.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(greeting, "220 "), "unexpected greeting %q", greeting)
+
+	return conn, r
+}
+
+func TestServer_LineTooLong(t *testing.T) {
+	address := startServer(t, &fakeHandler{}, 10)
+
+	conn, r := dial(t, address)
+
+	_, err := fmt.Fprintf(conn, "NOOP\r\n")
+	require.NoError(t, err)
+	reply, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "250 2.0.0 OK\r\n", reply)
+
+	_, err = fmt.Fprintf(conn, "NOOP %s\r\n", strings.Repeat("x", maxLineLength))
+	require.NoError(t, err)
+	reply, err = r.ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "500 5.5.2 Line too long\r\n", reply)
+
+	// the connection is closed afterwards.
+	_, err = r.ReadString('\n')
+	assert.Error(t, err)
+}
+
+func TestServer_TooManyConnections(t *testing.T) {
+	address := startServer(t, &fakeHandler{}, 1)
+
+	dial(t, address)
+
+	conn, err := net.Dial("tcp", address)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	r := bufio.NewReader(conn)
+	reply, err := r.ReadString('\n')
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(reply, "421 "), "unexpected reply %q", reply)
+
+	_, err = r.ReadString('\n')
+	assert.Error(t, err)
+}
@@ -0,0 +1,155 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/mail"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tokenSeparator    = "."
+	subAddressDivider = "+"
+
+	// signatureLength is the number of bytes of the HMAC used as signature of the token.
+	// NOTE: The local part of an email address is limited to 64 characters.
+	signatureLength = 15
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid reply token")
+	ErrExpiredToken = errors.New("expired reply token")
+
+	signatureEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+)
+
+// Signer generates and verifies the reply-to addresses of notification emails.
+// The reply token identifies the recipient and the comment thread and is signed,
+// so that nobody can reply on behalf of another user or to a thread the user wasn't notified about.
+// The token also contains the time it was issued at, so that a forwarded notification can't be used forever.
+type Signer struct {
+	localPart string
+	domain    string
+	secret    []byte
+	validity  time.Duration
+}
+
+// NewSigner returns a new Signer generating reply-to addresses as sub-addresses of the provided address.
+// Reply tokens are accepted for the provided validity duration after they were issued.
+func NewSigner(address string, secret string, validity time.Duration) (*Signer, error) {
+	if secret == "" {
+		return nil, errors.New("secret of the reply tokens is required")
+	}
+
+	if validity <= 0 {
+		return nil, errors.New("validity of the reply tokens must be positive")
+	}
+
+	parsed, err := mail.ParseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reply address: %w", err)
+	}
+
+	localPart, domain, ok := strings.Cut(parsed.Address, "@")
+	if !ok || strings.Contains(localPart, subAddressDivider) {
+		return nil, fmt.Errorf("reply address %q must not contain a sub-address", address)
+	}
+
+	return &Signer{
+		localPart: strings.ToLower(localPart),
+		domain:    strings.ToLower(domain),
+		secret:    []byte(secret),
+		validity:  validity,
+	}, nil
+}
+
+// Address returns the signed reply-to address of the recipient for the comment thread.
+func (s *Signer) Address(principalID int64, threadID int64) string {
+	return s.address(principalID, threadID, time.Now())
+}
+
+func (s *Signer) address(principalID int64, threadID int64, issued time.Time) string {
+	payload := strconv.FormatInt(principalID, 36) +
+		tokenSeparator + strconv.FormatInt(threadID, 36) +
+		tokenSeparator + strconv.FormatInt(issued.Unix(), 36)
+
+	return s.localPart + subAddressDivider + payload + tokenSeparator + s.sign(payload) + "@" + s.domain
+}
+
+// Parse verifies the signature and the age of the reply-to address and returns the recipient and the comment thread.
+func (s *Signer) Parse(address string) (int64, int64, error) {
+	return s.parse(address, time.Now())
+}
+
+func (s *Signer) parse(address string, now time.Time) (int64, int64, error) {
+	address = strings.ToLower(strings.Trim(strings.TrimSpace(address), "<>"))
+
+	localPart, domain, ok := strings.Cut(address, "@")
+	if !ok || domain != s.domain {
+		return 0, 0, ErrInvalidToken
+	}
+
+	base, token, ok := strings.Cut(localPart, subAddressDivider)
+	if !ok || base != s.localPart {
+		return 0, 0, ErrInvalidToken
+	}
+
+	parts := strings.Split(token, tokenSeparator)
+	if len(parts) != 4 {
+		return 0, 0, ErrInvalidToken
+	}
+
+	payload := strings.Join(parts[:3], tokenSeparator)
+	if !hmac.Equal([]byte(parts[3]), []byte(s.sign(payload))) {
+		return 0, 0, ErrInvalidToken
+	}
+
+	principalID, err := strconv.ParseInt(parts[0], 36, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+
+	threadID, err := strconv.ParseInt(parts[1], 36, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+
+	issued, err := strconv.ParseInt(parts[2], 36, 64)
+	if err != nil {
+		return 0, 0, ErrInvalidToken
+	}
+
+	if now.Sub(time.Unix(issued, 0)) > s.validity {
+		return 0, 0, ErrExpiredToken
+	}
+
+	return principalID, threadID, nil
+}
+
+// sign returns the signature of the payload.
+// It's lower case, as some mail servers don't preserve the case of the local part of addresses.
+func (s *Signer) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+
+	return strings.ToLower(signatureEncoding.EncodeToString(mac.Sum(nil)[:signatureLength]))
+}
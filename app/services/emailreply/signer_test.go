@@ -0,0 +1,97 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSigner(t *testing.T) {
+	signer, err := NewSigner("Gitness <Reply@Example.com>", "secret", time.Hour)
+	require.NoError(t, err)
+
+	address := signer.Address(42, 123456789)
+	assert.True(t, strings.HasPrefix(address, "reply+"))
+	assert.True(t, strings.HasSuffix(address, "@example.com"))
+
+	local, _, _ := strings.Cut(address, "@")
+	assert.LessOrEqual(t, len(local), 64, "the local part of an address is limited to 64 characters")
+
+	principalID, threadID, err := signer.Parse("<" + strings.ToUpper(address) + ">")
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), principalID)
+	assert.Equal(t, int64(123456789), threadID)
+
+	// a token of another principal can't be forged from a valid token.
+	token := strings.TrimSuffix(strings.TrimPrefix(address, "reply+"), "@example.com")
+	parts := strings.Split(token, ".")
+	_, _, err = signer.Parse("reply+" + "1." + strings.Join(parts[1:], ".") + "@example.com")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	other, err := NewSigner("reply@example.com", "other-secret", time.Hour)
+	require.NoError(t, err)
+	_, _, err = other.Parse(address)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	for _, invalid := range []string{
+		"reply@example.com",
+		"reply+abc@example.com",
+		strings.Replace(address, "@example.com", "@example.org", 1),
+		strings.Replace(address, "reply+", "noreply+", 1),
+	} {
+		_, _, err = signer.Parse(invalid)
+		assert.ErrorIs(t, err, ErrInvalidToken, invalid)
+	}
+
+	_, err = NewSigner("reply+sub@example.com", "secret", time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewSigner("reply@example.com", "", time.Hour)
+	assert.Error(t, err)
+
+	_, err = NewSigner("reply@example.com", "secret", 0)
+	assert.Error(t, err)
+}
+
+func TestSigner_Expiry(t *testing.T) {
+	signer, err := NewSigner("reply@example.com", "secret", time.Hour)
+	require.NoError(t, err)
+
+	issued := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	address := signer.address(42, 7, issued)
+
+	principalID, threadID, err := signer.parse(address, issued.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), principalID)
+	assert.Equal(t, int64(7), threadID)
+
+	_, _, err = signer.parse(address, issued.Add(time.Hour+time.Second))
+	assert.ErrorIs(t, err, ErrExpiredToken)
+
+	// the issue time is signed, an expired token can't be renewed.
+	expired := signer.address(42, 7, issued.Add(-24*time.Hour))
+	renewed := strings.Replace(expired,
+		"."+strconv.FormatInt(issued.Add(-24*time.Hour).Unix(), 36)+".",
+		"."+strconv.FormatInt(issued.Unix(), 36)+".", 1)
+	require.NotEqual(t, expired, renewed)
+	_, _, err = signer.parse(renewed, issued)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
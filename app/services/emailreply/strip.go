@@ -0,0 +1,79 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// replyHeaderRegexp matches the line introducing the quoted message, e.g. "On Mon, Jan 1, 2024 John wrote:".
+	replyHeaderRegexp = regexp.MustCompile(`(?i)^on\s.+\swrote:$`)
+	// replyHeaderStartRegexp and replyHeaderEndRegexp match a reply header wrapped across two lines.
+	replyHeaderStartRegexp = regexp.MustCompile(`(?i)^on\s`)
+	replyHeaderEndRegexp   = regexp.MustCompile(`(?i)\swrote:$`)
+
+	// separatorRegexps match lines that separate the reply from the original message or the signature.
+	separatorRegexps = []*regexp.Regexp{
+		regexp.MustCompile(`(?i)^-{2,}\s*original message\s*-{2,}\s*$`),
+		regexp.MustCompile(`^_{5,}\s*$`),
+		regexp.MustCompile(`^-- ?$`),
+		regexp.MustCompile(`(?i)^from:\s.+$`),
+		regexp.MustCompile(`(?i)^sent from my \w+`),
+	}
+)
+
+// StripQuotedText removes the quoted original message and the signature from the text of a reply.
+func StripQuotedText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(text, "\n")
+
+	kept := make([]string, 0, len(lines))
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		if strings.HasPrefix(trimmed, ">") {
+			continue
+		}
+
+		if isSeparator(trimmed) {
+			break
+		}
+
+		if replyHeaderRegexp.MatchString(trimmed) {
+			break
+		}
+
+		if replyHeaderStartRegexp.MatchString(trimmed) && i+1 < len(lines) &&
+			replyHeaderEndRegexp.MatchString(strings.TrimSpace(lines[i+1])) {
+			break
+		}
+
+		kept = append(kept, strings.TrimRight(line, " \t"))
+	}
+
+	return strings.TrimSpace(strings.Join(kept, "\n"))
+}
+
+func isSeparator(line string) bool {
+	for _, r := range separatorRegexps {
+		if r.MatchString(line) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStripQuotedText(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "plain reply",
+			text: "Looks good to me.\r\n\r\n",
+			want: "Looks good to me.",
+		},
+		{
+			name: "gmail",
+			text: "Agreed, let's merge it.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Gitness <reply@example.com> wrote:\n" +
+				"> @john commented on pull request #1\n> Should we merge it?\n",
+			want: "Agreed, let's merge it.",
+		},
+		{
+			name: "wrapped reply header",
+			text: "Done.\n\nOn Mon, Jan 1, 2024 at 10:00 AM Gitness <\nreply@example.com> wrote:\n> quoted\n",
+			want: "Done.",
+		},
+		{
+			name: "outlook",
+			text: "Will do.\n\n-----Original Message-----\nFrom: Gitness\nSubject: [repo] PR (PR #1)\n",
+			want: "Will do.",
+		},
+		{
+			name: "outlook header block",
+			text: "Will do.\n________________________________\nFrom: Gitness <reply@example.com>\n",
+			want: "Will do.",
+		},
+		{
+			name: "signature",
+			text: "Thanks!\n-- \nJohn Doe\nACME Inc.",
+			want: "Thanks!",
+		},
+		{
+			name: "mobile signature",
+			text: "Ok\n\nSent from my iPhone",
+			want: "Ok",
+		},
+		{
+			name: "inline quotes",
+			text: "> Should we merge it?\nYes.\n> And release?\nNot yet.",
+			want: "Yes.\nNot yet.",
+		},
+		{
+			name: "only quoted text",
+			text: "> Should we merge it?\n",
+			want: "",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, StripQuotedText(test.text))
+		})
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package emailreply
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+// WireSet provides a wire set for this package.
+var WireSet = wire.NewSet(
+	ProvideSigner,
+	ProvideProcessor,
+	ProvideServer,
+)
+
+// ProvideSigner provides the signer of reply-to addresses, it's nil in case email replies are disabled.
+func ProvideSigner(config *types.Config) (*Signer, error) {
+	if !config.EmailReply.Enabled {
+		return nil, nil //nolint:nilnil // email replies are disabled
+	}
+
+	return NewSigner(config.EmailReply.Address, config.EmailReply.Secret, config.EmailReply.TokenValidity)
+}
+
+func ProvideProcessor(
+	signer *Signer,
+	principalStore store.PrincipalStore,
+	activityStore store.PullReqActivityStore,
+	pullReqStore store.PullReqStore,
+	repoStore store.RepoStore,
+	pullreqCtrl *pullreq.Controller,
+) *Processor {
+	return NewProcessor(signer, principalStore, activityStore, pullReqStore, repoStore, pullreqCtrl)
+}
+
+func ProvideServer(config *types.Config, processor *Processor) *Server {
+	hostname := "localhost"
+	if address, err := mail.ParseAddress(config.EmailReply.Address); err == nil {
+		_, hostname, _ = strings.Cut(address.Address, "@")
+	}
+
+	return NewServer(config.EmailReply.ListenAddress, hostname, config.EmailReply.MaxMessageSize,
+		config.EmailReply.MaxConnections, processor)
+}
@@ -37,6 +37,36 @@ type AssignToPullReqOut struct {
 	ActivityType  enum.PullReqLabelActivityType
 }
 
+// ToActivityPayload returns the pull request activity payload describing the label assignment.
+func (out *AssignToPullReqOut) ToActivityPayload() *types.PullRequestActivityLabel {
+	var oldValue *string
+	var oldValueColor *enum.LabelColor
+	if out.OldLabelValue != nil {
+		oldValue = &out.OldLabelValue.Value
+		oldValueColor = &out.OldLabelValue.Color
+	}
+
+	var value *string
+	var valueColor *enum.LabelColor
+	if out.NewLabelValue != nil {
+		value = &out.NewLabelValue.Value
+		valueColor = &out.NewLabelValue.Color
+	}
+
+	return &types.PullRequestActivityLabel{
+		PullRequestActivityLabelBase: types.PullRequestActivityLabelBase{
+			Label:         out.Label.Key,
+			LabelColor:    out.Label.Color,
+			LabelScope:    out.Label.Scope,
+			Value:         value,
+			ValueColor:    valueColor,
+			OldValue:      oldValue,
+			OldValueColor: oldValueColor,
+		},
+		Type: out.ActivityType,
+	}
+}
+
 type WithValue struct {
 	Label *types.Label
 	Value *types.LabelValue
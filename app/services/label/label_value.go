@@ -146,6 +146,10 @@ func (s *Service) ListValues(
 	return values, count, nil
 }
 
+func (s *Service) FindValueByID(ctx context.Context, valueID int64) (*types.LabelValue, error) {
+	return s.labelValueStore.FindByID(ctx, valueID)
+}
+
 func (s *Service) DeleteValue(
 	ctx context.Context,
 	spaceID, repoID *int64,
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/api/controller"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// EnableAutoMerge tries to merge the provided pull request right away. If the pull request can't be merged yet,
+// it's marked for auto merging, so it would be merged as soon as it becomes eligible.
+// It returns the merged pull request only if the merging succeeded, otherwise auto merge has been enabled.
+// If the pull request got merged, closed or marked as draft in the meantime the error would be ErrNotEligible.
+func (s *Service) EnableAutoMerge(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principal *types.Principal,
+	autoMerge *types.AutoMerge,
+) (*types.PullReq, bool, error) {
+	prMerged, branchDeleted, err := s.Merge(ctx, pr, types.AutoMergeInput{
+		Principal:    *principal,
+		MergeMethod:  autoMerge.MergeMethod,
+		Title:        autoMerge.Title,
+		Message:      autoMerge.Message,
+		DeleteBranch: autoMerge.DeleteBranch,
+	})
+	if err != nil &&
+		!errors.Is(err, ErrNotEligible) &&
+		!errors.Is(err, ErrRuleViolation) &&
+		!errors.Is(err, ErrConflict) {
+		return nil, false, fmt.Errorf("failed to merge pull request %d: %w", pr.ID, err)
+	}
+
+	if prMerged != nil && prMerged.MergeMethod != nil && prMerged.MergeSHA != nil {
+		return prMerged, branchDeleted, nil
+	}
+
+	err = controller.TxOptLock(ctx, s.tx, func(ctx context.Context) error {
+		pr, err = s.pullreqStore.Find(ctx, pr.ID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request by ID: %w", err)
+		}
+
+		if pr.Merged != nil || pr.State != enum.PullReqStateOpen || pr.IsDraft {
+			return fmt.Errorf("can enable auto merge only for open, non-draft pull requests: %w", ErrNotEligible)
+		}
+
+		pr.SubState = enum.PullReqSubStateAutoMerge
+		err = s.pullreqStore.Update(ctx, pr)
+		if err != nil {
+			return fmt.Errorf("failed to update pull request: %w", err)
+		}
+
+		err = s.autoMergeStore.Upsert(ctx, autoMerge)
+		if err != nil {
+			return fmt.Errorf("failed to update auto merge state: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to enable auto merge for the pull request: %w", err)
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqAutoMergeEnabled, pr)
+
+	return nil, false, nil
+}
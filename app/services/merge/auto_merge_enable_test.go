@@ -0,0 +1,133 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type autoMergeTx struct{}
+
+func (autoMergeTx) WithTx(ctx context.Context, txFn func(ctx context.Context) error, _ ...any) error {
+	return txFn(ctx)
+}
+
+type autoMergePullReqStore struct {
+	store.PullReqStore
+	pr      types.PullReq
+	updated *types.PullReq
+}
+
+func (s *autoMergePullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	pr := s.pr
+	return &pr, nil
+}
+
+func (s *autoMergePullReqStore) Update(_ context.Context, pr *types.PullReq) error {
+	s.updated = pr
+	return nil
+}
+
+type autoMergeStore struct {
+	store.AutoMergeStore
+	upserted *types.AutoMerge
+}
+
+func (s *autoMergeStore) Upsert(_ context.Context, autoMerge *types.AutoMerge) error {
+	s.upserted = autoMerge
+	return nil
+}
+
+type autoMergeStreamer struct {
+	sse.Streamer
+	published []enum.SSEType
+}
+
+func (s *autoMergeStreamer) Publish(_ context.Context, _ int64, eventType enum.SSEType, _ any) {
+	s.published = append(s.published, eventType)
+}
+
+func TestService_EnableAutoMerge(t *testing.T) {
+	// the pull requests have no source repo, so merging them right away is never possible.
+	tests := []struct {
+		name    string
+		stored  types.PullReq
+		enabled bool
+	}{
+		{
+			name:    "open",
+			stored:  types.PullReq{ID: 1, State: enum.PullReqStateOpen},
+			enabled: true,
+		},
+		{
+			name:   "closed in the meantime",
+			stored: types.PullReq{ID: 1, State: enum.PullReqStateClosed},
+		},
+		{
+			name:   "draft in the meantime",
+			stored: types.PullReq{ID: 1, State: enum.PullReqStateOpen, IsDraft: true},
+		},
+		{
+			name:   "merged in the meantime",
+			stored: types.PullReq{ID: 1, State: enum.PullReqStateMerged, Merged: new(int64)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullreqStore := &autoMergePullReqStore{pr: test.stored}
+			autoMergeStore := &autoMergeStore{}
+			streamer := &autoMergeStreamer{}
+			service := &Service{
+				tx:             autoMergeTx{},
+				pullreqStore:   pullreqStore,
+				autoMergeStore: autoMergeStore,
+				sseStreamer:    streamer,
+			}
+
+			pr := &types.PullReq{ID: 1, State: enum.PullReqStateOpen}
+			autoMerge := &types.AutoMerge{PullReqID: pr.ID, RequestedBy: 2, MergeMethod: enum.MergeMethodSquash}
+
+			prMerged, branchDeleted, err := service.EnableAutoMerge(context.Background(),
+				&types.RepositoryCore{ID: 3, ParentID: 4}, pr, &types.Principal{ID: 2}, autoMerge)
+			assert.Nil(t, prMerged)
+			assert.False(t, branchDeleted)
+
+			if !test.enabled {
+				require.True(t, errors.Is(err, ErrNotEligible))
+				assert.Nil(t, pullreqStore.updated)
+				assert.Nil(t, autoMergeStore.upserted)
+				assert.Empty(t, streamer.published)
+				return
+			}
+
+			require.NoError(t, err)
+			require.NotNil(t, pullreqStore.updated)
+			assert.Equal(t, enum.PullReqSubStateAutoMerge, pullreqStore.updated.SubState)
+			assert.Same(t, autoMerge, autoMergeStore.upserted)
+			assert.Equal(t, []enum.SSEType{enum.SSETypePullReqAutoMergeEnabled}, streamer.published)
+		})
+	}
+}
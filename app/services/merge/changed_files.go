@@ -0,0 +1,70 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/types"
+)
+
+// ChangedFiles returns a function that lists the files changed by the pull request.
+// It's used by protection rules that need per-file change statistics, so the diff is only computed when needed.
+func (s *Service) ChangedFiles(
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+) func(ctx context.Context) ([]protection.ChangedFile, error) {
+	return s.ChangedFilesBetween(repo, pr.MergeBaseSHA, pr.SourceSHA)
+}
+
+// ChangedFilesBetween returns a function that lists the files changed between the merge base and the source commit.
+// It's used before the pull request exists, e.g. to find path reviewers of a new pull request.
+func (s *Service) ChangedFilesBetween(
+	repo *types.RepositoryCore,
+	mergeBaseSHA string,
+	sourceSHA string,
+) func(ctx context.Context) ([]protection.ChangedFile, error) {
+	return func(ctx context.Context) ([]protection.ChangedFile, error) {
+		reader := git.NewStreamReader(s.git.Diff(ctx, &git.DiffParams{
+			ReadParams:   git.CreateReadParams(repo),
+			BaseRef:      mergeBaseSHA,
+			HeadRef:      sourceSHA,
+			MergeBase:    true,
+			IncludePatch: false,
+		}))
+
+		var files []protection.ChangedFile
+		for {
+			file, err := reader.Next()
+			if errors.Is(err, io.EOF) {
+				return files, nil
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read pull request diff: %w", err)
+			}
+
+			files = append(files, protection.ChangedFile{
+				Path:      file.Path,
+				Additions: file.Additions,
+				Deletions: file.Deletions,
+			})
+		}
+	}
+}
@@ -205,6 +205,7 @@ func (s *Service) Merge(
 		Method:              input.MergeMethod,
 		CheckResults:        checkResults,
 		CodeOwners:          codeOwnerWithApproval,
+		ChangedFiles:        s.ChangedFiles(targetRepo, pr),
 	})
 	if err != nil {
 		return nil, false, fmt.Errorf("failed to verify protection rules: %w", err)
@@ -220,6 +221,7 @@ func (s *Service) Merge(
 	// only delete the source branch if it's the source repository is the same as the target repository.
 	deleteSourceBranch :=
 		pr.SourceRepoID != nil && pr.TargetRepoID == *pr.SourceRepoID &&
+			pr.Flow != enum.PullReqFlowAGit &&
 			(ruleOut.DeleteSourceBranch || input.DeleteBranch)
 
 	principalInfo := input.Principal.ToPrincipalInfo()
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/errors"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// VerifyRules evaluates the provided branch protection rules against the pull request without merging it.
+// It's used to find out what the rules would have reported for an already merged pull request.
+func (s *Service) VerifyRules(
+	ctx context.Context,
+	rules protection.BranchProtection,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	actor *types.Principal,
+	isRepoOwner bool,
+) ([]types.RuleViolations, error) {
+	var sourceRepo *types.RepositoryCore
+
+	switch {
+	case pr.SourceRepoID == nil:
+		// the source repo is purged
+	case *pr.SourceRepoID != pr.TargetRepoID:
+		// if the source repo is nil, it's deleted
+		var err error
+		sourceRepo, err = s.repoFinder.FindByID(ctx, *pr.SourceRepoID)
+		if err != nil && !errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to get source repository: %w", err)
+		}
+	default:
+		sourceRepo = targetRepo
+	}
+
+	reviewers, err := s.reviewerStore.List(ctx, pr.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load list of reviewers: %w", err)
+	}
+
+	checkResults, err := s.checkStore.ListResults(ctx, targetRepo.ID, pr.SourceSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list status checks: %w", err)
+	}
+
+	codeOwnerWithApproval, err := s.codeOwners.Evaluate(ctx, targetRepo, pr, reviewers)
+	if err != nil && !errors.Is(err, codeowners.ErrNotFound) {
+		return nil, fmt.Errorf("CODEOWNERS evaluation failed: %w", err)
+	}
+
+	var method enum.MergeMethod
+	if pr.MergeMethod != nil {
+		method = *pr.MergeMethod
+	}
+
+	_, violations, err := rules.MergeVerify(ctx, protection.MergeVerifyInput{
+		ResolveUserGroupIDs: s.userGroupService.ListUserIDsByGroupIDs,
+		MapUserGroupIDs:     s.userGroupService.MapGroupIDsToPrincipals,
+		Actor:               actor,
+		AllowBypass:         true,
+		IsRepoOwner:         isRepoOwner,
+		TargetRepo:          targetRepo,
+		SourceRepo:          sourceRepo,
+		PullReq:             pr,
+		Reviewers:           reviewers,
+		Method:              method,
+		CheckResults:        checkResults,
+		CodeOwners:          codeOwnerWithApproval,
+		ChangedFiles:        s.ChangedFiles(targetRepo, pr),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify protection rules: %w", err)
+	}
+
+	return violations, nil
+}
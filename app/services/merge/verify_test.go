@@ -0,0 +1,210 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package merge
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/services/codeowners"
+	"github.com/harness/gitness/app/services/protection"
+	"github.com/harness/gitness/app/services/usergroup"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type verifyReviewerStore struct {
+	store.PullReqReviewerStore
+	reviewers []*types.PullReqReviewer
+}
+
+func (s verifyReviewerStore) List(context.Context, int64) ([]*types.PullReqReviewer, error) {
+	return s.reviewers, nil
+}
+
+type verifyCheckStore struct {
+	store.CheckStore
+	results []types.CheckResult
+}
+
+func (s verifyCheckStore) ListResults(context.Context, int64, string) ([]types.CheckResult, error) {
+	return s.results, nil
+}
+
+// verifyGit is a repository without a CODEOWNERS file and without changes.
+type verifyGit struct {
+	git.Interface
+}
+
+func (verifyGit) GetTreeNode(context.Context, *git.GetTreeNodeParams) (*git.GetTreeNodeOutput, error) {
+	return nil, errors.NotFound("path not found")
+}
+
+func (verifyGit) Diff(
+	context.Context,
+	*git.DiffParams,
+	...api.FileDiffRequest,
+) (<-chan *git.FileDiff, <-chan error) {
+	ch := make(chan *git.FileDiff)
+	errCh := make(chan error)
+	close(ch)
+	close(errCh)
+	return ch, errCh
+}
+
+type verifyUserGroupService struct {
+	usergroup.Service
+}
+
+func (verifyUserGroupService) ListUserIDsByGroupIDs(context.Context, []int64) ([]int64, error) {
+	return nil, nil
+}
+
+func (verifyUserGroupService) MapGroupIDsToPrincipals(
+	context.Context,
+	[]int64,
+) (map[int64][]*types.Principal, error) {
+	return map[int64][]*types.Principal{}, nil
+}
+
+//nolint:funlen // table test
+func TestService_VerifyRules(t *testing.T) {
+	repo := &types.RepositoryCore{ID: 1, Identifier: "repo", Path: "space/repo", DefaultBranch: "main"}
+	merger := &types.Principal{ID: 42, UID: "merger", Email: "merger@example.com", Type: enum.PrincipalTypeUser}
+	squash := enum.MergeMethodSquash
+
+	approved := []*types.PullReqReviewer{{
+		PrincipalID:    7,
+		ReviewDecision: enum.PullReqReviewDecisionApproved,
+		SHA:            "source",
+		Reviewer:       types.PrincipalInfo{ID: 7},
+	}}
+
+	tests := []struct {
+		name        string
+		definition  string
+		pattern     protection.Pattern
+		reviewers   []*types.PullReqReviewer
+		checks      []types.CheckResult
+		isRepoOwner bool
+		expBlocked  bool
+		expCodes    []string
+	}{
+		{
+			name:       "approval missing",
+			definition: `{"pullreq":{"approvals":{"require_minimum_count":1}}}`,
+			expBlocked: true,
+			expCodes:   []string{"pullreq.approvals.require_minimum_count"},
+		},
+		{
+			name:       "approval present",
+			definition: `{"pullreq":{"approvals":{"require_minimum_count":1}}}`,
+			reviewers:  approved,
+		},
+		{
+			name:        "approval missing bypassed by repo owner",
+			definition:  `{"bypass":{"repo_owners":true},"pullreq":{"approvals":{"require_minimum_count":1}}}`,
+			isRepoOwner: true,
+			expCodes:    []string{"pullreq.approvals.require_minimum_count"},
+		},
+		{
+			name:       "status check missing",
+			definition: `{"pullreq":{"status_checks":{"require_identifiers":["build"]}}}`,
+			checks:     []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusFailure}},
+			expBlocked: true,
+			expCodes:   []string{"pullreq.status_checks.required_identifiers"},
+		},
+		{
+			name:       "status check succeeded",
+			definition: `{"pullreq":{"status_checks":{"require_identifiers":["build"]}}}`,
+			checks:     []types.CheckResult{{Identifier: "build", Status: enum.CheckStatusSuccess}},
+		},
+		{
+			name:       "merge method not allowed",
+			definition: `{"pullreq":{"merge":{"strategies_allowed":["rebase"]}}}`,
+			expBlocked: true,
+			expCodes:   []string{"pullreq.merge.strategies_allowed"},
+		},
+		{
+			name:       "target branch not matching",
+			definition: `{"pullreq":{"approvals":{"require_minimum_count":1}}}`,
+			pattern:    protection.Pattern{Include: []string{"release/*"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx := context.Background()
+
+			manager, err := protection.ProvideManager(nil)
+			require.NoError(t, err)
+
+			s := &Service{
+				git:              verifyGit{},
+				reviewerStore:    verifyReviewerStore{reviewers: test.reviewers},
+				checkStore:       verifyCheckStore{results: test.checks},
+				codeOwners:       codeowners.New(nil, verifyGit{}, codeowners.Config{FilePaths: []string{"CODEOWNERS"}}, nil, nil),
+				userGroupService: verifyUserGroupService{},
+			}
+
+			rules := manager.FilterCreateBranchProtection([]types.RuleInfoInternal{{
+				RuleInfo: types.RuleInfo{
+					RepoPath:   repo.Path,
+					Identifier: "rule",
+					Type:       protection.TypeBranch,
+					State:      enum.RuleStateActive,
+				},
+				Pattern:    test.pattern.JSON(),
+				RepoTarget: (&protection.RepoTarget{}).JSON(),
+				Definition: json.RawMessage(test.definition),
+			}})
+
+			pr := &types.PullReq{
+				ID:           1,
+				Number:       1,
+				State:        enum.PullReqStateMerged,
+				SourceRepoID: &repo.ID,
+				SourceBranch: "feature",
+				SourceSHA:    "source",
+				TargetRepoID: repo.ID,
+				TargetBranch: "main",
+				MergeBaseSHA: "base",
+				MergeMethod:  &squash,
+				MergedBy:     &merger.ID,
+			}
+
+			violations, err := s.VerifyRules(ctx, rules, repo, pr, merger, test.isRepoOwner)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expBlocked, protection.IsCritical(violations))
+
+			var codes []string
+			for _, v := range violations {
+				for _, violation := range v.Violations {
+					codes = append(codes, violation.Code)
+				}
+			}
+			assert.Equal(t, test.expCodes, codes)
+		})
+	}
+}
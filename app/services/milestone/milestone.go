@@ -0,0 +1,309 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package milestone
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// Create creates a new milestone in the space or in the repository.
+func (s *Service) Create(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	in *types.CreateMilestoneInput,
+) (*types.Milestone, error) {
+	now := time.Now().UnixMilli()
+
+	milestone := &types.Milestone{
+		SpaceID:     spaceID,
+		RepoID:      repoID,
+		Title:       in.Title,
+		Description: in.Description,
+		State:       enum.MilestoneStateOpen,
+		DueDate:     in.DueDate,
+		Created:     now,
+		Updated:     now,
+		CreatedBy:   principalID,
+		UpdatedBy:   principalID,
+	}
+
+	err := s.milestoneStore.Create(ctx, milestone)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, errors.Conflictf("Milestone %q already exists", in.Title)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to create milestone: %w", err)
+	}
+
+	return milestone, nil
+}
+
+// Find finds the milestone defined in the space or in the repository.
+func (s *Service) Find(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) (*types.Milestone, error) {
+	milestone, err := s.find(ctx, spaceID, repoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.backfillProgress(ctx, []*types.Milestone{milestone}); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// Update updates the milestone defined in the space or in the repository.
+func (s *Service) Update(
+	ctx context.Context,
+	principalID int64,
+	spaceID, repoID *int64,
+	id int64,
+	in *types.UpdateMilestoneInput,
+) (*types.Milestone, error) {
+	milestone, err := s.find(ctx, spaceID, repoID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !applyChanges(milestone, in) {
+		return milestone, s.backfillProgress(ctx, []*types.Milestone{milestone})
+	}
+
+	milestone.Updated = time.Now().UnixMilli()
+	milestone.UpdatedBy = principalID
+
+	err = s.milestoneStore.Update(ctx, milestone)
+	if errors.Is(err, store.ErrDuplicate) {
+		return nil, errors.Conflictf("Milestone %q already exists", milestone.Title)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update milestone: %w", err)
+	}
+
+	if err := s.backfillProgress(ctx, []*types.Milestone{milestone}); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+// Delete deletes the milestone defined in the space or in the repository.
+// The pull requests associated with the milestone are left without a milestone.
+func (s *Service) Delete(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) error {
+	milestone, err := s.find(ctx, spaceID, repoID, id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.milestoneStore.Delete(ctx, milestone.ID); err != nil {
+		return fmt.Errorf("failed to delete milestone: %w", err)
+	}
+
+	return nil
+}
+
+// List lists milestones of the space or of the repository. If the inherited flag is set,
+// milestones defined in the parent spaces are listed too. For a repository the spaceID
+// must be the ID of the parent space of the repository.
+func (s *Service) List(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, int64, error) {
+	var spaceIDs []int64
+
+	switch {
+	case filter.Inherited:
+		var err error
+		spaceIDs, err = s.spaceStore.GetAncestorIDs(ctx, *spaceID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get parent space ids: %w", err)
+		}
+	case repoID == nil:
+		spaceIDs = []int64{*spaceID}
+	}
+
+	count, err := s.milestoneStore.Count(ctx, repoID, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count milestones: %w", err)
+	}
+
+	milestones, err := s.milestoneStore.List(ctx, repoID, spaceIDs, filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list milestones: %w", err)
+	}
+
+	if err := s.backfillProgress(ctx, milestones); err != nil {
+		return nil, 0, err
+	}
+
+	return milestones, count, nil
+}
+
+// FindForRepo finds a milestone that can be associated with pull requests of the repository:
+// a milestone defined in the repository or in any of the parent spaces of the repository.
+func (s *Service) FindForRepo(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	id int64,
+) (*types.Milestone, error) {
+	milestone, err := s.milestoneStore.Find(ctx, id)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return nil, errors.NotFoundf("Milestone %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	if milestone.RepoID != nil && *milestone.RepoID != repo.ID {
+		return nil, errors.InvalidArgumentf("Milestone %d is not defined in the repository", id)
+	}
+
+	if milestone.SpaceID != nil {
+		spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, repo.ParentID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get parent space ids: %w", err)
+		}
+
+		if !slices.Contains(spaceIDs, *milestone.SpaceID) {
+			return nil, errors.InvalidArgumentf("Milestone %d is not defined in the space tree path", id)
+		}
+	}
+
+	return milestone, nil
+}
+
+// BackfillMany sets the milestone info of the pull requests.
+func (s *Service) BackfillMany(ctx context.Context, pullReqs []*types.PullReq) error {
+	ids := make([]int64, 0, len(pullReqs))
+	for _, pr := range pullReqs {
+		if pr.MilestoneID != nil && !slices.Contains(ids, *pr.MilestoneID) {
+			ids = append(ids, *pr.MilestoneID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	milestones, err := s.milestoneStore.FindMany(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to find milestones of pull requests: %w", err)
+	}
+
+	infoMap := make(map[int64]*types.MilestoneInfo, len(milestones))
+	for _, milestone := range milestones {
+		infoMap[milestone.ID] = milestone.ToMilestoneInfo()
+	}
+
+	for _, pr := range pullReqs {
+		if pr.MilestoneID != nil {
+			pr.Milestone = infoMap[*pr.MilestoneID]
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) find(
+	ctx context.Context,
+	spaceID, repoID *int64,
+	id int64,
+) (*types.Milestone, error) {
+	milestone, err := s.milestoneStore.Find(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find milestone: %w", err)
+	}
+
+	if (repoID != nil && (milestone.RepoID == nil || *milestone.RepoID != *repoID)) ||
+		(spaceID != nil && (milestone.SpaceID == nil || *milestone.SpaceID != *spaceID)) {
+		return nil, errors.NotFoundf("Milestone %d not found", id)
+	}
+
+	return milestone, nil
+}
+
+func (s *Service) backfillProgress(ctx context.Context, milestones []*types.Milestone) error {
+	ids := make([]int64, len(milestones))
+	for i, milestone := range milestones {
+		ids[i] = milestone.ID
+	}
+
+	progressMap, err := s.milestoneStore.MapProgress(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("failed to get progress of milestones: %w", err)
+	}
+
+	for _, milestone := range milestones {
+		milestone.Progress = progressMap[milestone.ID]
+	}
+
+	return nil
+}
+
+// applyChanges applies the input to the milestone and returns true if anything has changed.
+func applyChanges(milestone *types.Milestone, in *types.UpdateMilestoneInput) bool {
+	changed := false
+
+	if in.Title != nil && *in.Title != milestone.Title {
+		milestone.Title = *in.Title
+		changed = true
+	}
+
+	if in.Description != nil && *in.Description != milestone.Description {
+		milestone.Description = *in.Description
+		changed = true
+	}
+
+	if in.State != nil && *in.State != milestone.State {
+		milestone.State = *in.State
+		if milestone.State == enum.MilestoneStateClosed {
+			now := time.Now().UnixMilli()
+			milestone.Closed = &now
+		} else {
+			milestone.Closed = nil
+		}
+		changed = true
+	}
+
+	if in.RemoveDueDate && milestone.DueDate != nil {
+		milestone.DueDate = nil
+		changed = true
+	}
+
+	if in.DueDate != nil && (milestone.DueDate == nil || *in.DueDate != *milestone.DueDate) {
+		milestone.DueDate = in.DueDate
+		changed = true
+	}
+
+	return changed
+}
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqBranchUpdatedPayload struct {
@@ -43,19 +44,26 @@ func (s *Service) notifyPullReqBranchUpdated(
 		)
 	}
 
-	if len(reviewers) == 0 {
-		return nil
-	}
-
-	err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
+	reviewers, err = s.deliverPullReq(ctx, enum.NotificationEventPullReqBranchUpdated, payload.Base, reviewers,
+		pullReqInbox(event.ID, payload.Base, payload.Committer, "pushed new commits to"))
 	if err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.BranchUpdatedEvent,
-			event.Payload.PullReqID,
-			err,
-		)
+		return fmt.Errorf("failed to get recipients for pullReqID %d: %w", event.Payload.PullReqID, err)
 	}
+	if len(reviewers) > 0 {
+		err = s.notificationClient.SendPullReqBranchUpdated(ctx, reviewers, payload)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				pullreqevents.BranchUpdatedEvent,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
+	}
+
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendPullReqBranchUpdated(ctx, nil, payload)
+	})
 
 	return nil
 }
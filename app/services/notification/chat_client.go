@@ -0,0 +1,360 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// chatRequestTimeout is the maximum time a chat platform gets to accept a message.
+	chatRequestTimeout = 30 * time.Second
+	// chatMaxCommentLength is the maximum length of the comment text included in chat messages.
+	chatMaxCommentLength = 1000
+)
+
+var _ Client = (*ChatClient)(nil)
+
+// ChatClient is a Client that posts notifications to the chat channels configured
+// for the spaces of the repository of the pull request.
+// Channels aren't bound to users, so the recipients are ignored and events that are only
+// relevant to individual users (mentions, messages to the pull request author, digests) aren't posted.
+type ChatClient struct {
+	channelStore store.NotificationChannelStore
+	spaceStore   store.SpaceStore
+	encrypter    encrypt.Encrypter
+	httpClient   *http.Client
+}
+
+func NewChatClient(
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	encrypter encrypt.Encrypter,
+	httpClient *http.Client,
+) *ChatClient {
+	return &ChatClient{
+		channelStore: channelStore,
+		spaceStore:   spaceStore,
+		encrypter:    encrypter,
+		httpClient:   httpClient,
+	}
+}
+
+// chatNotification is the platform independent content of a message posted to notification channels.
+type chatNotification struct {
+	Event enum.NotificationEvent
+	Base  *BasePullReqPayload
+	Title string
+	Text  string
+}
+
+// genericChatMessage is the request body posted to channels of type generic.
+type genericChatMessage struct {
+	Event         enum.NotificationEvent `json:"event"`
+	Title         string                 `json:"title"`
+	Text          string                 `json:"text,omitempty"`
+	URL           string                 `json:"url"`
+	RepoPath      string                 `json:"repo_path"`
+	PullReqNumber int64                  `json:"pullreq_number"`
+	SourceBranch  string                 `json:"source_branch"`
+	TargetBranch  string                 `json:"target_branch"`
+}
+
+// mattermostMessage is the request body of mattermost incoming webhooks.
+type mattermostMessage struct {
+	Text string `json:"text"`
+}
+
+func (c *ChatClient) SendCommentPRAuthor(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendCommentMentions(context.Context, []*types.PrincipalInfo, *CommentPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendCommentParticipants(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *CommentPayload,
+) error {
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventCommentCreated,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s commented on %s", payload.Commenter.DisplayName, pullReqName(payload.Base)),
+		Text:  truncateText(payload.Text, chatMaxCommentLength),
+	})
+}
+
+func (c *ChatClient) SendPullReqCreated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventPullReqCreated,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s opened %s", payload.Base.Author.DisplayName, pullReqName(payload.Base)),
+		Text:  fmt.Sprintf("%s → %s", payload.Base.PullReq.SourceBranch, payload.Base.PullReq.TargetBranch),
+	})
+}
+
+func (c *ChatClient) SendReviewerAdded(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewerAddedPayload,
+) error {
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventReviewerAdded,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s was requested to review %s", payload.Reviewer.DisplayName, pullReqName(payload.Base)),
+		Text:  fmt.Sprintf("%s → %s", payload.Base.PullReq.SourceBranch, payload.Base.PullReq.TargetBranch),
+	})
+}
+
+func (c *ChatClient) SendPullReqBranchUpdated(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqBranchUpdatedPayload,
+) error {
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventPullReqBranchUpdated,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s pushed new commits to %s", payload.Committer.DisplayName, pullReqName(payload.Base)),
+		Text:  payload.NewSHA,
+	})
+}
+
+func (c *ChatClient) SendReviewSubmitted(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *ReviewSubmittedPayload,
+) error {
+	action := "reviewed"
+	switch payload.Decision {
+	case enum.PullReqReviewDecisionApproved:
+		action = "approved"
+	case enum.PullReqReviewDecisionChangeReq:
+		action = "requested changes on"
+	case enum.PullReqReviewDecisionPending, enum.PullReqReviewDecisionReviewed:
+	}
+
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventReviewSubmitted,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s %s %s", payload.Reviewer.DisplayName, action, pullReqName(payload.Base)),
+	})
+}
+
+func (c *ChatClient) SendPullReqStateChanged(
+	ctx context.Context,
+	_ []*types.PrincipalInfo,
+	payload *PullReqStateChangedPayload,
+) error {
+	return c.post(ctx, chatNotification{
+		Event: enum.NotificationEventPullReqStateChanged,
+		Base:  payload.Base,
+		Title: fmt.Sprintf("%s %s %s", payload.ChangedBy.DisplayName, payload.State, pullReqName(payload.Base)),
+	})
+}
+
+func (c *ChatClient) SendWebhookDisabled(context.Context, []*types.PrincipalInfo, *WebhookDisabledPayload) error {
+	return nil
+}
+
+func (c *ChatClient) SendDigest(context.Context, []*types.PrincipalInfo, *DigestPayload) error {
+	return nil
+}
+
+// notifyChannels posts a notification to the chat channels of the spaces of the repository.
+// Failures are only logged, as returning them would redeliver the notification to all users on retry.
+// It must be called only after all users got the notification, as a failed user delivery redelivers the event
+// which would repost the message.
+func (s *Service) notifyChannels(ctx context.Context, send func(client Client) error) {
+	if s.chatClient == nil {
+		return
+	}
+
+	if err := send(s.chatClient); err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msg("failed to post notification to chat channels")
+	}
+}
+
+// post sends the notification to all enabled channels of the ancestor spaces of the repository
+// that are subscribed to the event and whose filters match the pull request.
+func (c *ChatClient) post(ctx context.Context, notification chatNotification) error {
+	spaceIDs, err := c.spaceStore.GetAncestorIDs(ctx, notification.Base.Repo.ParentID)
+	if err != nil {
+		return fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	channels, err := c.channelStore.ListEnabled(ctx, spaceIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	var errs []error
+	for _, channel := range channels {
+		if !channelMatches(channel, notification) {
+			continue
+		}
+
+		if err := c.send(ctx, channel, notification); err != nil {
+			errs = append(errs, fmt.Errorf("failed to post to notification channel %q: %w", channel.Identifier, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (c *ChatClient) send(
+	ctx context.Context,
+	channel *types.NotificationChannel,
+	notification chatNotification,
+) error {
+	target, err := c.encrypter.Decrypt([]byte(channel.URL))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt channel url: %w", err)
+	}
+
+	body, err := chatBody(channel.Type, notification)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to serialize message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, chatRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// drain the body to allow reuse of the connection.
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("received response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// chatBody renders the notification into the request body expected by the chat platform of the channel.
+func chatBody(channelType enum.NotificationChannelType, notification chatNotification) (any, error) {
+	base := notification.Base
+
+	switch channelType {
+	case enum.NotificationChannelTypeSlack:
+		return webhook.RenderChatMessage(enum.WebhookFormatSlack,
+			notification.Title, notification.Text, base.PullReqURL, base.Repo.Path)
+	case enum.NotificationChannelTypeTeams:
+		return webhook.RenderChatMessage(enum.WebhookFormatTeams,
+			notification.Title, notification.Text, base.PullReqURL, base.Repo.Path)
+	case enum.NotificationChannelTypeMattermost:
+		text := fmt.Sprintf("**[%s](%s)**", notification.Title, base.PullReqURL)
+		if notification.Text != "" {
+			text += "\n" + notification.Text
+		}
+		return mattermostMessage{Text: text}, nil
+	case enum.NotificationChannelTypeGeneric:
+		return genericChatMessage{
+			Event:         notification.Event,
+			Title:         notification.Title,
+			Text:          notification.Text,
+			URL:           base.PullReqURL,
+			RepoPath:      base.Repo.Path,
+			PullReqNumber: base.PullReq.Number,
+			SourceBranch:  base.PullReq.SourceBranch,
+			TargetBranch:  base.PullReq.TargetBranch,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported notification channel type %q", channelType)
+	}
+}
+
+// channelMatches returns true if the channel is subscribed to the event and its repository and branch patterns
+// match the repository and the target branch of the pull request. Empty patterns match everything.
+func channelMatches(channel *types.NotificationChannel, notification chatNotification) bool {
+	if !slices.Contains(channel.Events, notification.Event) {
+		return false
+	}
+
+	repo := notification.Base.Repo
+	if !matchesAny(channel.RepoPatterns, repo.Path, repo.Identifier) {
+		return false
+	}
+
+	return matchesAny(channel.BranchPatterns, notification.Base.PullReq.TargetBranch)
+}
+
+func matchesAny(patterns []string, values ...string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	for _, pattern := range patterns {
+		for _, value := range values {
+			// ASSUMPTION: patterns are validated when the channel is saved
+			if ok, _ := doublestar.Match(pattern, value); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func pullReqName(base *BasePullReqPayload) string {
+	return fmt.Sprintf("%s #%d: %s", base.Repo.Identifier, base.PullReq.Number, base.PullReq.Title)
+}
+
+func truncateText(s string, limit int) string {
+	runes := []rune(strings.TrimSpace(s))
+	if len(runes) <= limit {
+		return string(runes)
+	}
+	return string(runes[:limit-1]) + "…"
+}
@@ -0,0 +1,122 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChannelMatches(t *testing.T) {
+	notification := chatNotification{
+		Event: enum.NotificationEventReviewerAdded,
+		Base: &BasePullReqPayload{
+			Repo:    &types.Repository{Identifier: "api", Path: "acme/backend/api"},
+			PullReq: &types.PullReq{TargetBranch: "release/1.0"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		channel types.NotificationChannel
+		want    bool
+	}{
+		{
+			name:    "no patterns",
+			channel: types.NotificationChannel{Events: []enum.NotificationEvent{enum.NotificationEventReviewerAdded}},
+			want:    true,
+		},
+		{
+			name:    "other event",
+			channel: types.NotificationChannel{Events: []enum.NotificationEvent{enum.NotificationEventPullReqCreated}},
+			want:    false,
+		},
+		{
+			name: "repo path and branch match",
+			channel: types.NotificationChannel{
+				Events:         []enum.NotificationEvent{enum.NotificationEventReviewerAdded},
+				RepoPatterns:   []string{"acme/**/api"},
+				BranchPatterns: []string{"main", "release/*"},
+			},
+			want: true,
+		},
+		{
+			name: "repo identifier matches",
+			channel: types.NotificationChannel{
+				Events:       []enum.NotificationEvent{enum.NotificationEventReviewerAdded},
+				RepoPatterns: []string{"{web,api}"},
+			},
+			want: true,
+		},
+		{
+			name: "branch doesn't match",
+			channel: types.NotificationChannel{
+				Events:         []enum.NotificationEvent{enum.NotificationEventReviewerAdded},
+				BranchPatterns: []string{"main"},
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, channelMatches(&test.channel, notification))
+		})
+	}
+}
+
+func TestChatBody(t *testing.T) {
+	notification := chatNotification{
+		Event: enum.NotificationEventReviewerAdded,
+		Base: &BasePullReqPayload{
+			Repo:       &types.Repository{Identifier: "api", Path: "acme/api"},
+			PullReq:    &types.PullReq{Number: 7, SourceBranch: "feature", TargetBranch: "main"},
+			PullReqURL: "https://git.example.com/acme/api/pulls/7",
+		},
+		Title: "Jane was requested to review api #7: Fix",
+	}
+
+	body, err := chatBody(enum.NotificationChannelTypeMattermost, notification)
+	require.NoError(t, err)
+	assert.Equal(t, mattermostMessage{
+		Text: "**[Jane was requested to review api #7: Fix](https://git.example.com/acme/api/pulls/7)**",
+	}, body)
+
+	body, err = chatBody(enum.NotificationChannelTypeGeneric, notification)
+	require.NoError(t, err)
+	assert.Equal(t, genericChatMessage{
+		Event:         enum.NotificationEventReviewerAdded,
+		Title:         "Jane was requested to review api #7: Fix",
+		URL:           "https://git.example.com/acme/api/pulls/7",
+		RepoPath:      "acme/api",
+		PullReqNumber: 7,
+		SourceBranch:  "feature",
+		TargetBranch:  "main",
+	}, body)
+
+	for _, channelType := range []enum.NotificationChannelType{
+		enum.NotificationChannelTypeSlack,
+		enum.NotificationChannelTypeTeams,
+	} {
+		body, err = chatBody(channelType, notification)
+		require.NoError(t, err)
+		assert.NotNil(t, body)
+	}
+}
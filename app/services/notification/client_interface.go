@@ -38,6 +38,11 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *CommentPayload,
 	) error
+	SendPullReqCreated(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *PullReqCreatedPayload,
+	) error
 	SendReviewerAdded(
 		ctx context.Context,
 		recipients []*types.PrincipalInfo,
@@ -58,4 +63,14 @@ type Client interface {
 		recipients []*types.PrincipalInfo,
 		payload *PullReqStateChangedPayload,
 	) error
+	SendWebhookDisabled(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *WebhookDisabledPayload,
+	) error
+	SendDigest(
+		ctx context.Context,
+		recipients []*types.PrincipalInfo,
+		payload *DigestPayload,
+	) error
 }
@@ -32,6 +32,10 @@ type CommentPayload struct {
 	Base      *BasePullReqPayload
 	Commenter *types.PrincipalInfo
 	Text      string
+	// ThreadID is the ID of the top-level comment of the thread.
+	ThreadID int64
+	// ReplyTo is the signed address the recipient can reply to in order to comment in the thread.
+	ReplyTo string
 }
 
 func (s *Service) notifyCommentCreated(
@@ -49,7 +53,7 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if len(mentions) > 0 {
-		err = s.notificationClient.SendCommentMentions(ctx, mentions, payload)
+		err = s.sendComment(ctx, mentions, payload, s.notificationClient.SendCommentMentions)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to mentions for event %s for pullReqID %d: %w",
@@ -61,7 +65,7 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if len(participants) > 0 {
-		err = s.notificationClient.SendCommentParticipants(ctx, participants, payload)
+		err = s.sendComment(ctx, participants, payload, s.notificationClient.SendCommentParticipants)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to participants for event %s for pullReqID %d: %w",
@@ -73,11 +77,7 @@ func (s *Service) notifyCommentCreated(
 	}
 
 	if author != nil {
-		err = s.notificationClient.SendCommentPRAuthor(
-			ctx,
-			[]*types.PrincipalInfo{author},
-			payload,
-		)
+		err = s.sendComment(ctx, []*types.PrincipalInfo{author}, payload, s.notificationClient.SendCommentPRAuthor)
 		if err != nil {
 			return fmt.Errorf(
 				"failed to send notification to author for event %s for pullReqID %d: %w",
@@ -88,6 +88,34 @@ func (s *Service) notifyCommentCreated(
 		}
 	}
 
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendCommentParticipants(ctx, nil, payload)
+	})
+
+	return nil
+}
+
+// sendComment sends the comment notification to the recipients. If replies via email are enabled,
+// every recipient gets a separate email with a reply-to address signed for the recipient and the comment thread.
+func (s *Service) sendComment(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *CommentPayload,
+	send func(context.Context, []*types.PrincipalInfo, *CommentPayload) error,
+) error {
+	if s.replySigner == nil {
+		return send(ctx, recipients, payload)
+	}
+
+	for _, recipient := range recipients {
+		recipientPayload := *payload
+		recipientPayload.ReplyTo = s.replySigner.Address(recipient.ID, payload.ThreadID)
+
+		if err := send(ctx, []*types.PrincipalInfo{recipient}, &recipientPayload); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -120,10 +148,16 @@ func (s *Service) processCommentCreatedEvent(
 		return nil, nil, nil, nil, fmt.Errorf("failed to fetch commenter from principalInfoView: %w", err)
 	}
 
+	threadID := activity.ID
+	if activity.ParentID != nil {
+		threadID = *activity.ParentID
+	}
+
 	payload = &CommentPayload{
 		Base:      base,
 		Commenter: commenter,
 		Text:      activity.Text,
+		ThreadID:  threadID,
 	}
 
 	seen := make(map[int64]bool)
@@ -150,9 +184,46 @@ func (s *Service) processCommentCreatedEvent(
 	// process author
 	if !seen[base.Author.ID] {
 		author = base.Author
+		seen[base.Author.ID] = true
+	}
+
+	// process watchers, they are notified like thread participants
+	states, err := s.subscriptionStates(ctx, base)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get notification subscriptions: %w", err)
+	}
+
+	watchers, err := s.watchers(ctx, base, states, seen)
+	if err != nil {
+		return nil, nil, nil, nil, err
+	}
+	participants = append(participants, watchers...)
+
+	// apply notification preferences
+	mentions, err = s.deliver(ctx, gitnessenum.NotificationEventCommentMention, states,
+		maps.Values(mentionsMap), pullReqInbox(event.ID, base, commenter, "mentioned you in"))
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get recipients of mentions: %w", err)
+	}
+
+	participants, err = s.deliver(ctx, gitnessenum.NotificationEventCommentCreated, states, participants,
+		pullReqInbox(event.ID, base, commenter, "commented on"))
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("failed to get recipients of participants: %w", err)
+	}
+
+	if author != nil {
+		authors, err := s.deliver(ctx, gitnessenum.NotificationEventCommentCreated, states,
+			[]*types.PrincipalInfo{author}, pullReqInbox(event.ID, base, commenter, "commented on"))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to get recipients of author: %w", err)
+		}
+		if len(authors) == 0 {
+			author = nil
+		}
 	}
 
-	return payload, maps.Values(mentionsMap), participants, author, nil
+	return payload, mentions, participants, author, nil
 }
 
 func (s *Service) processMentions(
@@ -0,0 +1,229 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	jobTypeDigestDaily   = "notification_digest_daily"
+	jobTypeDigestWeekly  = "notification_digest_weekly"
+	jobMaxDurationDigest = 30 * time.Minute
+)
+
+// DigestPayload contains all notifications queued for a user since the previous digest.
+type DigestPayload struct {
+	Recipient *types.PrincipalInfo
+	Delivery  enum.NotificationDelivery
+	Entries   []*DigestEntry
+	// Count is the total number of notifications contained in the digest.
+	Count int
+}
+
+// Period returns the period covered by the digest, e.g. daily.
+func (p *DigestPayload) Period() string {
+	if p.Delivery == enum.NotificationDeliveryWeeklyDigest {
+		return "weekly"
+	}
+	return "daily"
+}
+
+// DigestEntry groups the notifications of the digest that belong to the same subject, e.g. a pull request.
+type DigestEntry struct {
+	Subject string
+	URL     string
+	Updates []*DigestUpdate
+}
+
+// DigestUpdate is a distinct notification of a digest entry with the number of its occurrences.
+type DigestUpdate struct {
+	Title string
+	Count int
+}
+
+// DigestJob sends the digest emails of all users that chose the delivery for any notification event.
+type DigestJob struct {
+	service  *Service
+	delivery enum.NotificationDelivery
+}
+
+var _ job.Handler = (*DigestJob)(nil)
+
+// Register schedules the recurring digest jobs.
+func (s *Service) Register(ctx context.Context) error {
+	err := s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDigestDaily,
+		jobTypeDigestDaily,
+		s.config.DigestDailyCron,
+		jobMaxDurationDigest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule daily digest job: %w", err)
+	}
+
+	err = s.scheduler.AddRecurring(
+		ctx,
+		jobTypeDigestWeekly,
+		jobTypeDigestWeekly,
+		s.config.DigestWeeklyCron,
+		jobMaxDurationDigest,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to schedule weekly digest job: %w", err)
+	}
+
+	return nil
+}
+
+// registerDigestJobHandlers registers the handlers of the digest jobs.
+func (s *Service) registerDigestJobHandlers(executor *job.Executor) error {
+	err := executor.Register(jobTypeDigestDaily, &DigestJob{
+		service:  s,
+		delivery: enum.NotificationDeliveryDailyDigest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register daily digest job handler: %w", err)
+	}
+
+	err = executor.Register(jobTypeDigestWeekly, &DigestJob{
+		service:  s,
+		delivery: enum.NotificationDeliveryWeeklyDigest,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to register weekly digest job handler: %w", err)
+	}
+
+	return nil
+}
+
+// Handle sends a digest email to every user with queued notifications.
+// Users whose digest couldn't be sent keep their notifications queued for the next run.
+func (j *DigestJob) Handle(ctx context.Context, _ string, _ job.ProgressReporter) (string, error) {
+	principalIDs, err := j.service.digestStore.ListPrincipalIDs(ctx, j.delivery)
+	if err != nil {
+		return "", fmt.Errorf("failed to list principals with queued notifications: %w", err)
+	}
+
+	sent := 0
+	for _, principalID := range principalIDs {
+		if ctx.Err() != nil {
+			return "", ctx.Err()
+		}
+
+		ok, err := j.service.sendDigest(ctx, principalID, j.delivery)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("principal_id", principalID).
+				Msgf("failed to send %s", j.delivery)
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+
+	return strconv.Itoa(sent), nil
+}
+
+// sendDigest sends the digest with all queued notifications to the principal and removes them from the queue.
+func (s *Service) sendDigest(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+) (bool, error) {
+	items, err := s.digestStore.List(ctx, principalID, delivery)
+	if err != nil {
+		return false, fmt.Errorf("failed to list queued notifications: %w", err)
+	}
+	if len(items) == 0 {
+		return false, nil
+	}
+
+	maxID := items[len(items)-1].ID
+
+	principal, err := s.principalStore.Find(ctx, principalID)
+	if err != nil {
+		return false, fmt.Errorf("failed to find principal: %w", err)
+	}
+
+	if !principal.Blocked {
+		err = s.notificationClient.SendDigest(ctx, []*types.PrincipalInfo{principal.ToPrincipalInfo()}, &DigestPayload{
+			Recipient: principal.ToPrincipalInfo(),
+			Delivery:  delivery,
+			Entries:   buildDigestEntries(items),
+			Count:     len(items),
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to send digest: %w", err)
+		}
+	}
+
+	if err = s.digestStore.Delete(ctx, principalID, delivery, maxID); err != nil {
+		return false, fmt.Errorf("failed to remove sent notifications from the queue: %w", err)
+	}
+
+	return !principal.Blocked, nil
+}
+
+// buildDigestEntries groups the queued notifications by pull request, or by subject for notifications unrelated to
+// pull requests, keeping the order of their first occurrence. Repeated notifications with the same title
+// are deduplicated into a single update with the number of occurrences.
+func buildDigestEntries(items []*types.NotificationDigestItem) []*DigestEntry {
+	var entries []*DigestEntry
+	entryByKey := make(map[string]*DigestEntry)
+	updateByTitle := make(map[*DigestEntry]map[string]*DigestUpdate)
+
+	for _, item := range items {
+		key := "subject:" + item.Subject
+		if item.PullReqID != nil {
+			// the pull request title is part of the subject and might change in between.
+			key = "pullreq:" + strconv.FormatInt(*item.PullReqID, 10)
+		}
+
+		entry, ok := entryByKey[key]
+		if !ok {
+			entry = &DigestEntry{}
+			entries = append(entries, entry)
+			entryByKey[key] = entry
+			updateByTitle[entry] = make(map[string]*DigestUpdate)
+		}
+
+		// the latest notification has the most accurate subject.
+		entry.Subject = item.Subject
+		entry.URL = item.URL
+
+		if update, ok := updateByTitle[entry][item.Title]; ok {
+			update.Count++
+			continue
+		}
+
+		update := &DigestUpdate{Title: item.Title, Count: 1}
+		entry.Updates = append(entry.Updates, update)
+		updateByTitle[entry][item.Title] = update
+	}
+
+	return entries
+}
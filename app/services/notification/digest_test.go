@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildDigestEntries(t *testing.T) {
+	pr1, pr2 := int64(1), int64(2)
+	items := []*types.NotificationDigestItem{
+		{ID: 1, PullReqID: &pr1, Subject: "repo #1: old title", Title: "a commented on #1", URL: "url1"},
+		{ID: 2, PullReqID: &pr2, Subject: "repo #2: other", Title: "b opened #2", URL: "url2"},
+		{ID: 3, PullReqID: &pr1, Subject: "repo #1: new title", Title: "a commented on #1", URL: "url1"},
+		{ID: 4, Subject: "space webhook hook", Title: "Webhook hook was disabled", URL: "url3"},
+		{ID: 5, PullReqID: &pr1, Subject: "repo #1: new title", Title: "c merged #1", URL: "url1"},
+		{ID: 6, Subject: "space webhook hook", Title: "Webhook hook was disabled", URL: "url3"},
+	}
+
+	entries := buildDigestEntries(items)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "repo #1: new title", entries[0].Subject)
+	assert.Equal(t, "url1", entries[0].URL)
+	assert.Equal(t, []*DigestUpdate{
+		{Title: "a commented on #1", Count: 2},
+		{Title: "c merged #1", Count: 1},
+	}, entries[0].Updates)
+
+	assert.Equal(t, "repo #2: other", entries[1].Subject)
+	assert.Len(t, entries[1].Updates, 1)
+
+	// notifications unrelated to pull requests are grouped by their subject.
+	assert.Equal(t, "space webhook hook", entries[2].Subject)
+	assert.Equal(t, []*DigestUpdate{{Title: "Webhook hook was disabled", Count: 2}}, entries[2].Updates)
+}
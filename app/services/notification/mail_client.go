@@ -15,32 +15,25 @@
 package notification
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/types"
-)
-
-const (
-	TemplateReviewerAdded        = "reviewer_added.html"
-	TemplateCommentPRAuthor      = "comment_pr_author.html"
-	TemplateCommentMentions      = "comment_mentions.html"
-	TemplateCommentParticipants  = "comment_participants.html"
-	TemplatePullReqBranchUpdated = "pullreq_branch_updated.html"
-	TemplateNameReviewSubmitted  = "review_submitted.html"
-	TemplatePullReqStateChanged  = "pullreq_state_changed.html"
+	"github.com/harness/gitness/types/enum"
 )
 
 type MailClient struct {
 	mailer.Mailer
+	renderer *TemplateRenderer
 }
 
-func NewMailClient(mailer mailer.Mailer) MailClient {
+func NewMailClient(mailer mailer.Mailer, renderer *TemplateRenderer) MailClient {
 	return MailClient{
-		Mailer: mailer,
+		Mailer:   mailer,
+		renderer: renderer,
 	}
 }
 
@@ -49,17 +42,15 @@ func (m MailClient) SendCommentPRAuthor(
 	recipients []*types.PrincipalInfo,
 	payload *CommentPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(
-		TemplateCommentPRAuthor,
-		recipients,
-		payload.Base,
-		payload,
-	)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateCommentPRAuthor, recipients, payload.Base.Repo.Path,
+		payload)
 	if err != nil {
 		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
+	email.ReplyTo = payload.ReplyTo
+
 	return m.Mailer.Send(ctx, *email)
 }
 func (m MailClient) SendCommentMentions(
@@ -67,17 +58,15 @@ func (m MailClient) SendCommentMentions(
 	recipients []*types.PrincipalInfo,
 	payload *CommentPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(
-		TemplateCommentMentions,
-		recipients,
-		payload.Base,
-		payload,
-	)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateCommentMentions, recipients, payload.Base.Repo.Path,
+		payload)
 	if err != nil {
 		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
+	email.ReplyTo = payload.ReplyTo
+
 	return m.Mailer.Send(ctx, *email)
 }
 func (m MailClient) SendCommentParticipants(
@@ -85,17 +74,30 @@ func (m MailClient) SendCommentParticipants(
 	recipients []*types.PrincipalInfo,
 	payload *CommentPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(
-		TemplateCommentParticipants,
-		recipients,
-		payload.Base,
-		payload,
-	)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateCommentParticipants, recipients,
+		payload.Base.Repo.Path, payload)
 	if err != nil {
 		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
 			pullreqevents.CommentCreatedEvent, err)
 	}
 
+	email.ReplyTo = payload.ReplyTo
+
+	return m.Mailer.Send(ctx, *email)
+}
+
+func (m MailClient) SendPullReqCreated(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *PullReqCreatedPayload,
+) error {
+	email, err := m.generateEmail(ctx, enum.NotificationTemplatePullReqCreated, recipients, payload.Base.Repo.Path,
+		payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			pullreqevents.CreatedEvent, err)
+	}
+
 	return m.Mailer.Send(ctx, *email)
 }
 
@@ -104,12 +106,8 @@ func (m MailClient) SendReviewerAdded(
 	recipients []*types.PrincipalInfo,
 	payload *ReviewerAddedPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(
-		TemplateReviewerAdded,
-		recipients,
-		payload.Base,
-		payload,
-	)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateReviewerAdded, recipients, payload.Base.Repo.Path,
+		payload)
 	if err != nil {
 		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
 			pullreqevents.ReviewerAddedEvent, err)
@@ -123,12 +121,8 @@ func (m MailClient) SendPullReqBranchUpdated(
 	recipients []*types.PrincipalInfo,
 	payload *PullReqBranchUpdatedPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(
-		TemplatePullReqBranchUpdated,
-		recipients,
-		payload.Base,
-		payload,
-	)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplatePullReqBranchUpdated, recipients,
+		payload.Base.Repo.Path, payload)
 	if err != nil {
 		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
 			pullreqevents.BranchUpdatedEvent, err)
@@ -142,7 +136,8 @@ func (m MailClient) SendReviewSubmitted(
 	recipients []*types.PrincipalInfo,
 	payload *ReviewSubmittedPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(TemplateNameReviewSubmitted, recipients, payload.Base, payload)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateReviewSubmitted, recipients, payload.Base.Repo.Path,
+		payload)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to generate mail requests after processing %s event: %w",
@@ -158,7 +153,8 @@ func (m MailClient) SendPullReqStateChanged(
 	recipients []*types.PrincipalInfo,
 	payload *PullReqStateChangedPayload,
 ) error {
-	email, err := GenerateEmailFromPayload(TemplatePullReqStateChanged, recipients, payload.Base, payload)
+	email, err := m.generateEmail(ctx, enum.NotificationTemplatePullReqStateChanged, recipients,
+		payload.Base.Repo.Path, payload)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to generate mail requests after processing pullReqState change event: %w",
@@ -169,47 +165,54 @@ func (m MailClient) SendPullReqStateChanged(
 	return m.Mailer.Send(ctx, *email)
 }
 
-func GetSubjectPullRequest(
-	repoIdentifier string,
-	prNum int64,
-	prTitle string,
-) string {
-	return fmt.Sprintf(subjectPullReqEvent, repoIdentifier, prTitle, prNum)
+func (m MailClient) SendWebhookDisabled(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *WebhookDisabledPayload,
+) error {
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateWebhookDisabled, recipients, payload.ParentPath,
+		payload)
+	if err != nil {
+		return fmt.Errorf("failed to generate mail requests after processing %s event: %w",
+			webhookevents.DisabledEvent, err)
+	}
+
+	return m.Mailer.Send(ctx, *email)
 }
 
-func GetHTMLBody(templateName string, data any) ([]byte, error) {
-	tmpl := htmlTemplates[templateName]
-	tmplOutput := bytes.Buffer{}
-	err := tmpl.Execute(&tmplOutput, data)
+func (m MailClient) SendDigest(
+	ctx context.Context,
+	recipients []*types.PrincipalInfo,
+	payload *DigestPayload,
+) error {
+	email, err := m.generateEmail(ctx, enum.NotificationTemplateDigest, recipients, "", payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute template %s", templateName)
+		return fmt.Errorf("failed to generate mail requests for %s: %w", payload.Delivery, err)
 	}
 
-	return tmplOutput.Bytes(), nil
+	return m.Mailer.Send(ctx, *email)
 }
 
-func GenerateEmailFromPayload(
-	templateName string,
+// generateEmail renders the notification email from its template.
+func (m MailClient) generateEmail(
+	ctx context.Context,
+	name enum.NotificationTemplate,
 	recipients []*types.PrincipalInfo,
-	base *BasePullReqPayload,
+	repoRef string,
 	payload any,
 ) (*mailer.Payload, error) {
-	subject := GetSubjectPullRequest(base.Repo.Identifier, base.PullReq.Number,
-		base.PullReq.Title)
-
-	body, err := GetHTMLBody(templateName, payload)
+	rendered, err := m.renderer.Render(ctx, name, payload)
 	if err != nil {
 		return nil, err
 	}
 
-	var email mailer.Payload
-	email.Body = string(body)
-	email.Subject = subject
-	email.RepoRef = base.Repo.Path
-
-	recipientEmails := RetrieveEmailsFromPrincipals(recipients)
-	email.ToRecipients = recipientEmails
-	return &email, nil
+	return &mailer.Payload{
+		ToRecipients: RetrieveEmailsFromPrincipals(recipients),
+		Subject:      rendered.Subject,
+		Body:         rendered.Body,
+		ContentType:  rendered.ContentType.MIMEType(),
+		RepoRef:      repoRef,
+	}, nil
 }
 
 func RetrieveEmailsFromPrincipals(principals []*types.PrincipalInfo) []string {
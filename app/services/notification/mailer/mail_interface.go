@@ -35,6 +35,8 @@ type Payload struct {
 	Body         string
 	ContentType  string
 	RepoRef      string
+	// ReplyTo is the address replies to the mail are sent to, if it differs from the sender.
+	ReplyTo string
 }
 
 func ToGoMail(dto Payload) *gomail.Message {
@@ -42,6 +44,13 @@ func ToGoMail(dto Payload) *gomail.Message {
 	mail.SetHeader("To", dto.ToRecipients...)
 	mail.SetHeader("Cc", dto.CCRecipients...)
 	mail.SetHeader("Subject", dto.Subject)
-	mail.SetBody(mailContentType, dto.Body)
+	if dto.ReplyTo != "" {
+		mail.SetHeader("Reply-To", dto.ReplyTo)
+	}
+	contentType := dto.ContentType
+	if contentType == "" {
+		contentType = mailContentType
+	}
+	mail.SetBody(contentType, dto.Body)
 	return mail
 }
@@ -0,0 +1,289 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apiauth "github.com/harness/gitness/app/api/auth"
+	"github.com/harness/gitness/app/auth"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// subscriptionStates returns the effective subscription state of all principals subscribed to the pull request,
+// its repository or any of the ancestor spaces of the repository. The subscription of the closest resource wins.
+func (s *Service) subscriptionStates(
+	ctx context.Context,
+	base *BasePullReqPayload,
+) (map[int64]enum.NotificationSubscriptionState, error) {
+	// space IDs are ordered from the parent space of the repository up to the root space.
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, base.Repo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ancestor spaces of the repository: %w", err)
+	}
+
+	subscriptions, err := s.subscriptionStore.ListForResources(ctx, spaceIDs, base.Repo.ID, base.PullReq.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notification subscriptions: %w", err)
+	}
+
+	spaceDistance := make(map[int64]int, len(spaceIDs))
+	for i, spaceID := range spaceIDs {
+		spaceDistance[spaceID] = i
+	}
+
+	distance := func(sub types.NotificationSubscription) int {
+		switch sub.ResourceType {
+		case enum.NotificationResourceTypePullReq:
+			return 0
+		case enum.NotificationResourceTypeRepo:
+			return 1
+		case enum.NotificationResourceTypeSpace:
+			return 2 + spaceDistance[sub.ResourceID]
+		}
+		return len(spaceIDs) + 2
+	}
+
+	states := make(map[int64]enum.NotificationSubscriptionState)
+	closest := make(map[int64]int)
+	for _, sub := range subscriptions {
+		d := distance(sub)
+		if current, ok := closest[sub.PrincipalID]; ok && current <= d {
+			continue
+		}
+
+		closest[sub.PrincipalID] = d
+		states[sub.PrincipalID] = sub.State
+	}
+
+	return states, nil
+}
+
+// watchers returns all principals watching the pull request that are still allowed to view the repository,
+// excluding the provided principals.
+func (s *Service) watchers(
+	ctx context.Context,
+	base *BasePullReqPayload,
+	states map[int64]enum.NotificationSubscriptionState,
+	exclude map[int64]bool,
+) ([]*types.PrincipalInfo, error) {
+	var watchers []*types.PrincipalInfo
+	for principalID, state := range states {
+		if state != enum.NotificationSubscriptionStateWatching || exclude[principalID] {
+			continue
+		}
+
+		principal, err := s.principalStore.Find(ctx, principalID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find watcher principal: %w", err)
+		}
+
+		if principal.Blocked {
+			continue
+		}
+
+		if err = apiauth.CheckRepo(ctx, s.authorizer, &auth.Session{
+			Principal: *principal,
+		}, base.Repo.Core(), enum.PermissionRepoView); err != nil {
+			log.Ctx(ctx).Debug().Err(err).Msgf("skipping watcher %s", principal.UID)
+			continue
+		}
+
+		watchers = append(watchers, principal.ToPrincipalInfo())
+	}
+
+	return watchers, nil
+}
+
+// recipientsByDelivery removes duplicate and muted principals from the recipients and
+// groups the remaining recipients by their preferred delivery for the event.
+// Mentions are delivered even if the user muted the resource.
+func (s *Service) recipientsByDelivery(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	states map[int64]enum.NotificationSubscriptionState,
+	recipients []*types.PrincipalInfo,
+) (map[enum.NotificationDelivery][]*types.PrincipalInfo, error) {
+	seen := make(map[int64]bool, len(recipients))
+	filtered := make([]*types.PrincipalInfo, 0, len(recipients))
+	ids := make([]int64, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient == nil || seen[recipient.ID] {
+			continue
+		}
+		seen[recipient.ID] = true
+
+		if event != enum.NotificationEventCommentMention &&
+			states[recipient.ID] == enum.NotificationSubscriptionStateMuted {
+			continue
+		}
+
+		filtered = append(filtered, recipient)
+		ids = append(ids, recipient.ID)
+	}
+
+	deliveries, err := s.preferenceStore.MapDelivery(ctx, event, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch notification preferences: %w", err)
+	}
+
+	_, defaultDelivery := enum.GetAllNotificationDeliveries()
+
+	result := make(map[enum.NotificationDelivery][]*types.PrincipalInfo)
+	for _, recipient := range filtered {
+		delivery, ok := deliveries[recipient.ID]
+		if !ok {
+			delivery = defaultDelivery
+		}
+
+		result[delivery] = append(result[delivery], recipient)
+	}
+
+	return result, nil
+}
+
+// inboxNotification describes the in-app notification created for recipients that prefer in-app delivery.
+// It is also queued for recipients that prefer a digest, grouped by the subject.
+type inboxNotification struct {
+	// EventID identifies the event the notification is created for, redelivered events are ignored.
+	EventID   string
+	RepoID    *int64
+	PullReqID *int64
+	ActorID   *int64
+	Subject   string
+	Title     string
+	URL       string
+}
+
+// pullReqInbox returns the in-app notification of an action of the actor on the pull request.
+func pullReqInbox(
+	eventID string,
+	base *BasePullReqPayload,
+	actor *types.PrincipalInfo,
+	action string,
+) *inboxNotification {
+	inbox := &inboxNotification{
+		EventID:   eventID,
+		RepoID:    &base.Repo.ID,
+		PullReqID: &base.PullReq.ID,
+		Subject:   fmt.Sprintf("%s #%d: %s", base.Repo.Path, base.PullReq.Number, base.PullReq.Title),
+		Title:     fmt.Sprintf("%s #%d: %s", action, base.PullReq.Number, base.PullReq.Title),
+		URL:       base.PullReqURL,
+	}
+	if actor != nil {
+		inbox.ActorID = &actor.ID
+		inbox.Title = actor.DisplayName + " " + inbox.Title
+	}
+
+	return inbox
+}
+
+// deliver creates in-app notifications for the recipients that want the notification of the event
+// delivered in-app, queues it for the recipients that want it delivered with a digest
+// and returns the recipients that want the notification delivered via email.
+func (s *Service) deliver(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	states map[int64]enum.NotificationSubscriptionState,
+	recipients []*types.PrincipalInfo,
+	inbox *inboxNotification,
+) ([]*types.PrincipalInfo, error) {
+	byDelivery, err := s.recipientsByDelivery(ctx, event, states, recipients)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UnixMilli()
+	for _, recipient := range byDelivery[enum.NotificationDeliveryInApp] {
+		notification := &types.Notification{
+			PrincipalID: recipient.ID,
+			Event:       event,
+			EventID:     inbox.EventID,
+			RepoID:      inbox.RepoID,
+			PullReqID:   inbox.PullReqID,
+			ActorID:     inbox.ActorID,
+			Title:       inbox.Title,
+			URL:         inbox.URL,
+			Created:     now,
+			Updated:     now,
+		}
+
+		err = s.notificationStore.Create(ctx, notification)
+		if errors.Is(err, gitness_store.ErrDuplicate) {
+			// the event is redelivered, the recipient has the notification already.
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to create in-app notification: %w", err)
+		}
+
+		s.sseStreamer.PublishUser(ctx, recipient.ID, enum.SSETypeNotificationCreated, notification)
+	}
+
+	for _, delivery := range []enum.NotificationDelivery{
+		enum.NotificationDeliveryDailyDigest,
+		enum.NotificationDeliveryWeeklyDigest,
+	} {
+		for _, recipient := range byDelivery[delivery] {
+			item := &types.NotificationDigestItem{
+				PrincipalID: recipient.ID,
+				Delivery:    delivery,
+				Event:       event,
+				EventID:     inbox.EventID,
+				RepoID:      inbox.RepoID,
+				PullReqID:   inbox.PullReqID,
+				Subject:     inbox.Subject,
+				Title:       inbox.Title,
+				URL:         inbox.URL,
+				Created:     now,
+			}
+
+			err = s.digestStore.Enqueue(ctx, item)
+			if errors.Is(err, gitness_store.ErrDuplicate) {
+				// the event is redelivered, its change is listed in the pending digest already.
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to queue notification for digest: %w", err)
+			}
+		}
+	}
+
+	return byDelivery[enum.NotificationDeliveryEmail], nil
+}
+
+// deliverPullReq delivers a pull request notification to the recipients that don't want it via email
+// and returns the recipients that want the notification of the event delivered via email.
+func (s *Service) deliverPullReq(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	base *BasePullReqPayload,
+	recipients []*types.PrincipalInfo,
+	inbox *inboxNotification,
+) ([]*types.PrincipalInfo, error) {
+	states, err := s.subscriptionStates(ctx, base)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification subscriptions: %w", err)
+	}
+
+	return s.deliver(ctx, event, states, recipients, inbox)
+}
@@ -21,8 +21,13 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
+type PullReqCreatedPayload struct {
+	Base *BasePullReqPayload
+}
+
 func (s *Service) notifyPullReqCreated(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload],
@@ -37,14 +42,31 @@ func (s *Service) notifyPullReqCreated(
 		return fmt.Errorf("failed to get principal infos from cache: %w", err)
 	}
 
+	states, err := s.subscriptionStates(ctx, base)
+	if err != nil {
+		return fmt.Errorf("failed to get notification subscriptions: %w", err)
+	}
+
 	for _, reviewer := range reviewers {
+		// the author gets a notification per added reviewer, all of them are created by the same event.
+		recipients, err := s.deliver(ctx, enum.NotificationEventReviewerAdded, states,
+			[]*types.PrincipalInfo{base.Author, reviewer},
+			pullReqInbox(fmt.Sprintf("%s-%d", event.ID, reviewer.ID), base, reviewer,
+				"was added as a reviewer to"))
+		if err != nil {
+			return fmt.Errorf("failed to get recipients of reviewer added notification: %w", err)
+		}
+		if len(recipients) == 0 {
+			continue
+		}
+
 		payload := &ReviewerAddedPayload{
 			Base:     base,
 			Reviewer: reviewer,
 		}
 		if err := s.notificationClient.SendReviewerAdded(
 			ctx,
-			[]*types.PrincipalInfo{base.Author, reviewer},
+			recipients,
 			payload,
 		); err != nil {
 			return fmt.Errorf(
@@ -56,5 +78,46 @@ func (s *Service) notifyPullReqCreated(
 		}
 	}
 
+	// notify watchers, the author and the reviewers have been notified already.
+	exclude := map[int64]bool{base.Author.ID: true}
+	for id := range reviewers {
+		exclude[id] = true
+	}
+
+	watchers, err := s.watchers(ctx, base, states, exclude)
+	if err != nil {
+		return err
+	}
+
+	recipients, err := s.deliver(ctx, enum.NotificationEventPullReqCreated, states, watchers,
+		pullReqInbox(event.ID, base, base.Author, "opened"))
+	if err != nil {
+		return fmt.Errorf("failed to get recipients of pull request created notification: %w", err)
+	}
+	if len(recipients) > 0 {
+		if err := s.notificationClient.SendPullReqCreated(
+			ctx,
+			recipients,
+			&PullReqCreatedPayload{Base: base},
+		); err != nil {
+			return fmt.Errorf(
+				"failed to send email to watchers for event %s for pullReqID %d: %w",
+				pullreqevents.CreatedEvent,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
+	}
+
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendPullReqCreated(ctx, nil, &PullReqCreatedPayload{Base: base})
+	})
+
+	for _, reviewer := range reviewers {
+		s.notifyChannels(ctx, func(client Client) error {
+			return client.SendReviewerAdded(ctx, nil, &ReviewerAddedPayload{Base: base, Reviewer: reviewer})
+		})
+	}
+
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type PullReqState string
@@ -41,7 +42,8 @@ func (s *Service) notifyPullReqStateMerged(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.MergedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateMerged)
+	payload, recipients, err := s.processPullReqStateChangedEvent(
+		ctx, event.ID, event.Payload.Base, PullReqStateMerged)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -51,18 +53,23 @@ func (s *Service) notifyPullReqStateMerged(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.MergedEvent,
-			payload.Base.PullReq.ID,
-			err,
-		)
+	if len(recipients) > 0 {
+		if err = s.notificationClient.SendPullReqStateChanged(
+			ctx,
+			recipients,
+			payload,
+		); err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				pullreqevents.MergedEvent,
+				payload.Base.PullReq.ID,
+				err,
+			)
+		}
 	}
+
+	s.notifyStateChangedChannels(ctx, payload)
+
 	return nil
 }
 
@@ -70,7 +77,8 @@ func (s *Service) notifyPullReqStateClosed(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ClosedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateClosed)
+	payload, recipients, err := s.processPullReqStateChangedEvent(
+		ctx, event.ID, event.Payload.Base, PullReqStateClosed)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -80,18 +88,23 @@ func (s *Service) notifyPullReqStateClosed(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.ClosedEvent,
-			payload.Base.PullReq.ID,
-			err,
-		)
+	if len(recipients) > 0 {
+		if err = s.notificationClient.SendPullReqStateChanged(
+			ctx,
+			recipients,
+			payload,
+		); err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				pullreqevents.ClosedEvent,
+				payload.Base.PullReq.ID,
+				err,
+			)
+		}
 	}
+
+	s.notifyStateChangedChannels(ctx, payload)
+
 	return nil
 }
 
@@ -99,7 +112,8 @@ func (s *Service) notifyPullReqStateReOpened(
 	ctx context.Context,
 	event *events.Event[*pullreqevents.ReopenedPayload],
 ) error {
-	payload, recipients, err := s.processPullReqStateChangedEvent(ctx, event.Payload.Base, PullReqStateReopened)
+	payload, recipients, err := s.processPullReqStateChangedEvent(
+		ctx, event.ID, event.Payload.Base, PullReqStateReopened)
 	if err != nil {
 		return fmt.Errorf(
 			"failed to process %s event for pullReqID %d: %w",
@@ -109,23 +123,36 @@ func (s *Service) notifyPullReqStateReOpened(
 		)
 	}
 
-	if err = s.notificationClient.SendPullReqStateChanged(
-		ctx,
-		recipients,
-		payload,
-	); err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.ReopenedEvent,
-			payload.Base.PullReq.ID,
-			err,
-		)
+	if len(recipients) > 0 {
+		if err = s.notificationClient.SendPullReqStateChanged(
+			ctx,
+			recipients,
+			payload,
+		); err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				pullreqevents.ReopenedEvent,
+				payload.Base.PullReq.ID,
+				err,
+			)
+		}
 	}
+
+	s.notifyStateChangedChannels(ctx, payload)
+
 	return nil
 }
 
+// notifyStateChangedChannels posts the state change to the chat channels.
+func (s *Service) notifyStateChangedChannels(ctx context.Context, payload *PullReqStateChangedPayload) {
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendPullReqStateChanged(ctx, nil, payload)
+	})
+}
+
 func (s *Service) processPullReqStateChangedEvent(
 	ctx context.Context,
+	eventID string,
 	baseEvent pullreqevents.Base,
 	state PullReqState,
 ) (*PullReqStateChangedPayload, []*types.PrincipalInfo, error) {
@@ -169,9 +196,33 @@ func (s *Service) processPullReqStateChangedEvent(
 
 	recipients[len(reviewers)] = author
 
-	return &PullReqStateChangedPayload{
+	states, err := s.subscriptionStates(ctx, basePayload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get notification subscriptions: %w", err)
+	}
+
+	exclude := map[int64]bool{stateModifierPrincipal.ID: true}
+	for _, recipient := range recipients {
+		exclude[recipient.ID] = true
+	}
+
+	watchers, err := s.watchers(ctx, basePayload, states, exclude)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	payload := &PullReqStateChangedPayload{
 		Base:      basePayload,
 		ChangedBy: stateModifierPrincipal,
 		State:     state,
-	}, recipients, nil
+	}
+
+	recipients, err = s.deliver(ctx, enum.NotificationEventPullReqStateChanged, states,
+		append(recipients, watchers...),
+		pullReqInbox(eventID, basePayload, stateModifierPrincipal, string(state)))
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get recipients for pullReqID %d: %w", baseEvent.PullReqID, err)
+	}
+
+	return payload, recipients, nil
 }
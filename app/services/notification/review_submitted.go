@@ -45,20 +45,34 @@ func (s *Service) notifyReviewSubmitted(
 		)
 	}
 
-	err = s.notificationClient.SendReviewSubmitted(
-		ctx,
-		recipients,
-		notificationPayload,
-	)
-
+	recipients, err = s.deliverPullReq(
+		ctx, enum.NotificationEventReviewSubmitted, notificationPayload.Base, recipients,
+		pullReqInbox(event.ID, notificationPayload.Base, notificationPayload.Reviewer,
+			fmt.Sprintf("submitted a review (%s) on", notificationPayload.Decision)))
 	if err != nil {
-		return fmt.Errorf(
-			"failed to send notification for event %s for pullReqID %d: %w",
-			pullreqevents.ReviewSubmittedEvent,
-			event.Payload.PullReqID,
-			err,
+		return fmt.Errorf("failed to get recipients for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+	if len(recipients) > 0 {
+		err = s.notificationClient.SendReviewSubmitted(
+			ctx,
+			recipients,
+			notificationPayload,
 		)
+
+		if err != nil {
+			return fmt.Errorf(
+				"failed to send notification for event %s for pullReqID %d: %w",
+				pullreqevents.ReviewSubmittedEvent,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
 	}
+
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendReviewSubmitted(ctx, nil, notificationPayload)
+	})
+
 	return nil
 }
 
@@ -21,6 +21,7 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
 )
 
 type ReviewerAddedPayload struct {
@@ -42,16 +43,27 @@ func (s *Service) notifyReviewerAdded(
 		)
 	}
 
-	err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
+	recipients, err = s.deliverPullReq(ctx, enum.NotificationEventReviewerAdded, payload.Base, recipients,
+		pullReqInbox(event.ID, payload.Base, payload.Reviewer, "was added as a reviewer to"))
 	if err != nil {
-		return fmt.Errorf(
-			"failed to send email for event %s for pullReqID %d: %w",
-			pullreqevents.ReviewerAddedEvent,
-			event.Payload.PullReqID,
-			err,
-		)
+		return fmt.Errorf("failed to get recipients for pullReqID %d: %w", event.Payload.PullReqID, err)
+	}
+	if len(recipients) > 0 {
+		err = s.notificationClient.SendReviewerAdded(ctx, recipients, payload)
+		if err != nil {
+			return fmt.Errorf(
+				"failed to send email for event %s for pullReqID %d: %w",
+				pullreqevents.ReviewerAddedEvent,
+				event.Payload.PullReqID,
+				err,
+			)
+		}
 	}
 
+	s.notifyChannels(ctx, func(client Client) error {
+		return client.SendReviewerAdded(ctx, nil, payload)
+	})
+
 	return nil
 }
 
@@ -16,61 +16,25 @@ package notification
 
 import (
 	"context"
-	"embed"
 	"fmt"
-	"html/template"
-	"io/fs"
-	"path"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/emailreply"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/stream"
 	"github.com/harness/gitness/types"
 )
 
 const (
 	eventReaderGroupName = "gitness:notification"
-	templatesDir         = "templates"
-	subjectPullReqEvent  = "[%s] %s (PR #%d)"
 )
 
-var (
-	//go:embed  templates/*
-	files         embed.FS
-	htmlTemplates map[string]*template.Template
-)
-
-func init() {
-	err := LoadTemplates()
-	if err != nil {
-		panic(err)
-	}
-}
-
-func LoadTemplates() error {
-	htmlTemplates = make(map[string]*template.Template)
-	tmplFiles, err := fs.ReadDir(files, templatesDir)
-	if err != nil {
-		return err
-	}
-
-	for _, tmpl := range tmplFiles {
-		if tmpl.IsDir() {
-			continue
-		}
-
-		pt, err := template.ParseFS(files, path.Join(templatesDir, tmpl.Name()))
-		if err != nil {
-			return err
-		}
-
-		htmlTemplates[tmpl.Name()] = pt
-	}
-	return nil
-}
-
 type BasePullReqPayload struct {
 	Repo       *types.Repository
 	PullReq    *types.PullReq
@@ -82,12 +46,22 @@ type Config struct {
 	EventReaderName string
 	Concurrency     int
 	MaxRetries      int
+
+	// DigestDailyCron is the cron schedule of the daily notification digest emails.
+	DigestDailyCron string
+	// DigestWeeklyCron is the cron schedule of the weekly notification digest emails.
+	DigestWeeklyCron string
+
+	// TemplatesDir is the directory with templates overriding the built-in templates of notification emails.
+	TemplatesDir string
 }
 
 type Service struct {
 	config                Config
 	notificationClient    Client
+	chatClient            *ChatClient
 	prReaderFactory       *events.ReaderFactory[*pullreqevents.Reader]
+	webhookReaderFactory  *events.ReaderFactory[*webhookevents.Reader]
 	pullReqStore          store.PullReqStore
 	repoStore             store.RepoStore
 	principalInfoView     store.PrincipalInfoView
@@ -95,14 +69,27 @@ type Service struct {
 	pullReqReviewersStore store.PullReqReviewerStore
 	pullReqActivityStore  store.PullReqActivityStore
 	spacePathStore        store.SpacePathStore
+	webhookStore          store.WebhookStore
+	spaceStore            store.SpaceStore
+	preferenceStore       store.NotificationPreferenceStore
+	subscriptionStore     store.NotificationSubscriptionStore
+	notificationStore     store.NotificationStore
+	digestStore           store.NotificationDigestStore
+	principalStore        store.PrincipalStore
+	authorizer            authz.Authorizer
 	urlProvider           url.Provider
+	sseStreamer           sse.Streamer
+	scheduler             *job.Scheduler
+	replySigner           *emailreply.Signer
 }
 
 func NewService(
 	ctx context.Context,
 	config Config,
 	notificationClient Client,
+	chatClient *ChatClient,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -110,12 +97,26 @@ func NewService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	webhookStore store.WebhookStore,
+	spaceStore store.SpaceStore,
+	preferenceStore store.NotificationPreferenceStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	notificationStore store.NotificationStore,
+	digestStore store.NotificationDigestStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
 	urlProvider url.Provider,
+	sseStreamer sse.Streamer,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	replySigner *emailreply.Signer,
 ) (*Service, error) {
 	service := &Service{
 		config:                config,
 		notificationClient:    notificationClient,
+		chatClient:            chatClient,
 		prReaderFactory:       prReaderFactory,
+		webhookReaderFactory:  webhookReaderFactory,
 		pullReqStore:          pullReqStore,
 		repoStore:             repoStore,
 		principalInfoView:     principalInfoView,
@@ -123,7 +124,22 @@ func NewService(
 		pullReqReviewersStore: pullReqReviewersStore,
 		pullReqActivityStore:  pullReqActivityStore,
 		spacePathStore:        spacePathStore,
+		webhookStore:          webhookStore,
+		spaceStore:            spaceStore,
+		preferenceStore:       preferenceStore,
+		subscriptionStore:     subscriptionStore,
+		notificationStore:     notificationStore,
+		digestStore:           digestStore,
+		principalStore:        principalStore,
+		authorizer:            authorizer,
 		urlProvider:           urlProvider,
+		sseStreamer:           sseStreamer,
+		scheduler:             scheduler,
+		replySigner:           replySigner,
+	}
+
+	if err := service.registerDigestJobHandlers(jobExecutor); err != nil {
+		return nil, err
 	}
 
 	_, err := service.prReaderFactory.Launch(
@@ -155,6 +171,24 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch event reader for %s: %w", eventReaderGroupName, err)
 	}
 
+	_, err = service.webhookReaderFactory.Launch(
+		ctx,
+		eventReaderGroupName,
+		config.EventReaderName,
+		func(r *webhookevents.Reader) error {
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			_ = r.RegisterDisabled(service.notifyWebhookDisabled)
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch webhook event reader for %s: %w", eventReaderGroupName, err)
+	}
+
 	return service, nil
 }
 
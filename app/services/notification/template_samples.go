@@ -0,0 +1,114 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// samplePayload returns the payload used to validate and preview the templates of the notification email.
+func samplePayload(name enum.NotificationTemplate) any {
+	author := &types.PrincipalInfo{ID: 1, UID: "jane", DisplayName: "Jane Doe", Email: "jane@example.com",
+		Type: enum.PrincipalTypeUser}
+	reviewer := &types.PrincipalInfo{ID: 2, UID: "john", DisplayName: "John Smith", Email: "john@example.com",
+		Type: enum.PrincipalTypeUser}
+
+	base := &BasePullReqPayload{
+		Repo: &types.Repository{
+			ID:            1,
+			Identifier:    "hello-world",
+			Path:          "acme/hello-world",
+			DefaultBranch: "main",
+		},
+		PullReq: &types.PullReq{
+			ID:           1,
+			Number:       42,
+			Title:        "Add greeting endpoint",
+			Description:  "Adds an endpoint that greets the caller.",
+			State:        enum.PullReqStateOpen,
+			SourceBranch: "feature/greeting",
+			TargetBranch: "main",
+			CreatedBy:    author.ID,
+		},
+		Author:     author,
+		PullReqURL: "https://git.example.com/acme/hello-world/pulls/42",
+	}
+
+	comment := &CommentPayload{
+		Base:      base,
+		Commenter: reviewer,
+		Text:      "Could you add a test for the empty name?",
+		ThreadID:  1,
+		ReplyTo:   "reply+1.1.signature@git.example.com",
+	}
+
+	switch name {
+	case enum.NotificationTemplatePullReqCreated:
+		return &PullReqCreatedPayload{Base: base}
+	case enum.NotificationTemplateReviewerAdded:
+		return &ReviewerAddedPayload{Base: base, Reviewer: reviewer}
+	case enum.NotificationTemplateCommentPRAuthor,
+		enum.NotificationTemplateCommentMentions,
+		enum.NotificationTemplateCommentParticipants:
+		return comment
+	case enum.NotificationTemplatePullReqBranchUpdated:
+		return &PullReqBranchUpdatedPayload{
+			Base:      base,
+			Committer: author,
+			NewSHA:    "5d2f0c2a9e0b4f7c8a1d3e6b9f2c4a7d1e3b5c8f",
+		}
+	case enum.NotificationTemplateReviewSubmitted:
+		return &ReviewSubmittedPayload{
+			Base:     base,
+			Author:   author,
+			Reviewer: reviewer,
+			Decision: enum.PullReqReviewDecisionApproved,
+		}
+	case enum.NotificationTemplatePullReqStateChanged:
+		return &PullReqStateChangedPayload{Base: base, ChangedBy: reviewer, State: PullReqStateMerged}
+	case enum.NotificationTemplateWebhookDisabled:
+		return &WebhookDisabledPayload{
+			Webhook: &types.Webhook{
+				ID:          1,
+				Identifier:  "deployments",
+				DisplayName: "Deployments",
+				URL:         "https://ci.example.com/hooks/deployments",
+			},
+			ParentPath:          "acme/hello-world",
+			WebhookURL:          "https://git.example.com/acme/hello-world/settings/webhooks/deployments",
+			ConsecutiveFailures: 10,
+			LastError:           "connection refused",
+		}
+	case enum.NotificationTemplateDigest:
+		return &DigestPayload{
+			Recipient: author,
+			Delivery:  enum.NotificationDeliveryDailyDigest,
+			Entries: []*DigestEntry{
+				{
+					Subject: "acme/hello-world #42: Add greeting endpoint",
+					URL:     base.PullReqURL,
+					Updates: []*DigestUpdate{
+						{Title: "John Smith commented on #42: Add greeting endpoint", Count: 2},
+						{Title: "John Smith approved #42: Add greeting endpoint", Count: 1},
+					},
+				},
+			},
+			Count: 3,
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,338 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/check"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	templatesDir = "templates"
+
+	// templateMaxSubjectLength is the maximum length of a subject template.
+	templateMaxSubjectLength = 1024
+	// templateMaxBodyLength is the maximum length of a body template.
+	templateMaxBodyLength = 256 << 10 // 256 KiB
+
+	templateExtSubject = ".subject"
+	templateExtHTML    = ".html"
+	templateExtText    = ".txt"
+
+	subjectPullReqEvent = "[{{.Base.Repo.Identifier}}] {{.Base.PullReq.Title}} (PR #{{.Base.PullReq.Number}})"
+)
+
+var (
+	//go:embed  templates/*
+	files embed.FS
+
+	// builtinSubjects are the subject templates of the built-in templates.
+	builtinSubjects = map[enum.NotificationTemplate]string{
+		enum.NotificationTemplatePullReqCreated:       subjectPullReqEvent,
+		enum.NotificationTemplateReviewerAdded:        subjectPullReqEvent,
+		enum.NotificationTemplateCommentPRAuthor:      subjectPullReqEvent,
+		enum.NotificationTemplateCommentMentions:      subjectPullReqEvent,
+		enum.NotificationTemplateCommentParticipants:  subjectPullReqEvent,
+		enum.NotificationTemplatePullReqBranchUpdated: subjectPullReqEvent,
+		enum.NotificationTemplateReviewSubmitted:      subjectPullReqEvent,
+		enum.NotificationTemplatePullReqStateChanged:  subjectPullReqEvent,
+		enum.NotificationTemplateWebhookDisabled:      "[{{.ParentPath}}] Webhook {{.Webhook.DisplayName}} was disabled",
+		enum.NotificationTemplateDigest:               "Your {{.Period}} notification digest ({{.Count}} updates)",
+	}
+)
+
+// TemplateRenderer renders notification emails from their templates.
+// The built-in templates can be overridden per email by files in the templates directory and by custom
+// templates saved by admins. Custom templates take precedence over the templates directory.
+type TemplateRenderer struct {
+	templateStore store.NotificationTemplateStore
+	// defaults are the built-in templates, overridden by the templates directory.
+	defaults map[enum.NotificationTemplate]*types.NotificationTemplate
+}
+
+func NewTemplateRenderer(
+	templateStore store.NotificationTemplateStore,
+	dir string,
+) (*TemplateRenderer, error) {
+	names, _ := enum.GetAllNotificationTemplates()
+
+	defaults := make(map[enum.NotificationTemplate]*types.NotificationTemplate, len(names))
+	for _, name := range names {
+		tmpl, err := builtinTemplate(name)
+		if err != nil {
+			return nil, err
+		}
+
+		if dir != "" {
+			if err = overrideFromDirectory(tmpl, dir); err != nil {
+				return nil, err
+			}
+		}
+
+		if err = validateTemplate(tmpl); err != nil {
+			return nil, fmt.Errorf("invalid %s template of %s: %w", tmpl.Source, name, err)
+		}
+
+		defaults[name] = tmpl
+	}
+
+	return &TemplateRenderer{
+		templateStore: templateStore,
+		defaults:      defaults,
+	}, nil
+}
+
+// Template returns the template used for the notification email.
+func (r *TemplateRenderer) Template(
+	ctx context.Context,
+	name enum.NotificationTemplate,
+) (*types.NotificationTemplate, error) {
+	custom, err := r.templateStore.Find(ctx, name)
+	if errors.Is(err, gitness_store.ErrResourceNotFound) {
+		tmpl := *r.defaults[name]
+		return &tmpl, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to find custom notification template: %w", err)
+	}
+
+	return custom, nil
+}
+
+// Default returns the template used for the notification email if there's no custom template.
+func (r *TemplateRenderer) Default(name enum.NotificationTemplate) *types.NotificationTemplate {
+	tmpl := *r.defaults[name]
+	return &tmpl
+}
+
+// List returns the templates used for all notification emails.
+func (r *TemplateRenderer) List(ctx context.Context) ([]*types.NotificationTemplate, error) {
+	customs, err := r.templateStore.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list custom notification templates: %w", err)
+	}
+
+	custom := make(map[enum.NotificationTemplate]*types.NotificationTemplate, len(customs))
+	for _, tmpl := range customs {
+		custom[tmpl.Name] = tmpl
+	}
+
+	names, _ := enum.GetAllNotificationTemplates()
+
+	templates := make([]*types.NotificationTemplate, len(names))
+	for i, name := range names {
+		if tmpl, ok := custom[name]; ok {
+			templates[i] = tmpl
+			continue
+		}
+
+		templates[i] = r.Default(name)
+	}
+
+	return templates, nil
+}
+
+// Render renders the notification email with the payload.
+func (r *TemplateRenderer) Render(
+	ctx context.Context,
+	name enum.NotificationTemplate,
+	payload any,
+) (*types.NotificationEmail, error) {
+	tmpl, err := r.Template(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	email, err := render(tmpl, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s template of %s: %w", tmpl.Source, name, err)
+	}
+
+	return email, nil
+}
+
+// Preview validates the template and renders it with a sample payload of the notification email.
+func (r *TemplateRenderer) Preview(tmpl *types.NotificationTemplate) (*types.NotificationEmail, error) {
+	if err := validateTemplate(tmpl); err != nil {
+		return nil, err
+	}
+
+	return render(tmpl, samplePayload(tmpl.Name))
+}
+
+// Validate checks that the template can be rendered with a sample payload of the notification email.
+func (r *TemplateRenderer) Validate(tmpl *types.NotificationTemplate) error {
+	return validateTemplate(tmpl)
+}
+
+func validateTemplate(tmpl *types.NotificationTemplate) error {
+	if strings.TrimSpace(tmpl.Subject) == "" {
+		return check.NewValidationError("The subject template is required.")
+	}
+	if len(tmpl.Subject) > templateMaxSubjectLength {
+		return check.NewValidationErrorf("The subject template can be at most %d characters long.",
+			templateMaxSubjectLength)
+	}
+
+	if strings.TrimSpace(tmpl.Body) == "" {
+		return check.NewValidationError("The body template is required.")
+	}
+	if len(tmpl.Body) > templateMaxBodyLength {
+		return check.NewValidationErrorf("The body template can be at most %d bytes long.", templateMaxBodyLength)
+	}
+
+	email, err := render(tmpl, samplePayload(tmpl.Name))
+	if err != nil {
+		return check.NewValidationErrorf("The template can't be rendered: %s", err)
+	}
+
+	if strings.ContainsAny(email.Subject, "\r\n") {
+		return check.NewValidationError("The subject must be a single line.")
+	}
+
+	return nil
+}
+
+// render executes the subject and body templates with the payload.
+func render(tmpl *types.NotificationTemplate, payload any) (*types.NotificationEmail, error) {
+	subjectTmpl, err := texttemplate.New("subject").Parse(tmpl.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse subject template: %w", err)
+	}
+
+	subject := bytes.Buffer{}
+	if err = subjectTmpl.Execute(&subject, payload); err != nil {
+		return nil, fmt.Errorf("failed to execute subject template: %w", err)
+	}
+
+	body := bytes.Buffer{}
+	switch tmpl.ContentType {
+	case enum.NotificationContentTypeText:
+		bodyTmpl, err := texttemplate.New("body").Parse(tmpl.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse body template: %w", err)
+		}
+		if err = bodyTmpl.Execute(&body, payload); err != nil {
+			return nil, fmt.Errorf("failed to execute body template: %w", err)
+		}
+	case enum.NotificationContentTypeHTML:
+		bodyTmpl, err := htmltemplate.New("body").Parse(tmpl.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse body template: %w", err)
+		}
+		if err = bodyTmpl.Execute(&body, payload); err != nil {
+			return nil, fmt.Errorf("failed to execute body template: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", tmpl.ContentType)
+	}
+
+	return &types.NotificationEmail{
+		Subject:     strings.TrimSpace(subject.String()),
+		Body:        body.String(),
+		ContentType: tmpl.ContentType,
+	}, nil
+}
+
+func builtinTemplate(name enum.NotificationTemplate) (*types.NotificationTemplate, error) {
+	body, err := files.ReadFile(path.Join(templatesDir, string(name)+templateExtHTML))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read built-in template of %s: %w", name, err)
+	}
+
+	return &types.NotificationTemplate{
+		Name:        name,
+		Source:      enum.NotificationTemplateSourceBuiltin,
+		Subject:     builtinSubjects[name],
+		Body:        string(body),
+		ContentType: enum.NotificationContentTypeHTML,
+	}, nil
+}
+
+// overrideFromDirectory overrides the template with the files of the templates directory:
+// <name>.subject for the subject, and either <name>.html or <name>.txt for an HTML or a plain-text body.
+func overrideFromDirectory(tmpl *types.NotificationTemplate, dir string) error {
+	base := filepath.Join(dir, string(tmpl.Name))
+
+	subject, err := readTemplateFile(base + templateExtSubject)
+	if err != nil {
+		return err
+	}
+
+	html, err := readTemplateFile(base + templateExtHTML)
+	if err != nil {
+		return err
+	}
+
+	text, err := readTemplateFile(base + templateExtText)
+	if err != nil {
+		return err
+	}
+
+	if html != nil && text != nil {
+		return fmt.Errorf("templates directory contains both an HTML and a plain-text body of %s", tmpl.Name)
+	}
+
+	if subject == nil && html == nil && text == nil {
+		return nil
+	}
+
+	tmpl.Source = enum.NotificationTemplateSourceDirectory
+
+	if subject != nil {
+		tmpl.Subject = *subject
+	}
+
+	if html != nil {
+		tmpl.Body = *html
+		tmpl.ContentType = enum.NotificationContentTypeHTML
+	}
+
+	if text != nil {
+		tmpl.Body = *text
+		tmpl.ContentType = enum.NotificationContentTypeText
+	}
+
+	return nil
+}
+
+// readTemplateFile returns the content of the template file, or nil if the file doesn't exist.
+func readTemplateFile(name string) (*string, error) {
+	data, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil //nolint:nilnil // a missing file means the template isn't overridden.
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template file: %w", err)
+	}
+
+	content := string(data)
+	return &content, nil
+}
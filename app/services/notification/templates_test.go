@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTemplateStore struct {
+	templates map[enum.NotificationTemplate]*types.NotificationTemplate
+}
+
+func (s *fakeTemplateStore) Find(
+	_ context.Context,
+	name enum.NotificationTemplate,
+) (*types.NotificationTemplate, error) {
+	tmpl, ok := s.templates[name]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return tmpl, nil
+}
+
+func (s *fakeTemplateStore) List(context.Context) ([]*types.NotificationTemplate, error) {
+	templates := make([]*types.NotificationTemplate, 0, len(s.templates))
+	for _, tmpl := range s.templates {
+		templates = append(templates, tmpl)
+	}
+	return templates, nil
+}
+
+func (s *fakeTemplateStore) Upsert(_ context.Context, tmpl *types.NotificationTemplate) error {
+	s.templates[tmpl.Name] = tmpl
+	return nil
+}
+
+func (s *fakeTemplateStore) Delete(_ context.Context, name enum.NotificationTemplate) error {
+	delete(s.templates, name)
+	return nil
+}
+
+func newFakeTemplateStore() *fakeTemplateStore {
+	return &fakeTemplateStore{templates: map[enum.NotificationTemplate]*types.NotificationTemplate{}}
+}
+
+func TestTemplateRenderer_Builtin(t *testing.T) {
+	r, err := NewTemplateRenderer(newFakeTemplateStore(), "")
+	require.NoError(t, err)
+
+	templates, err := r.List(context.Background())
+	require.NoError(t, err)
+
+	names, _ := enum.GetAllNotificationTemplates()
+	require.Len(t, templates, len(names))
+	for _, tmpl := range templates {
+		assert.Equal(t, enum.NotificationTemplateSourceBuiltin, tmpl.Source, tmpl.Name)
+		assert.NoError(t, r.Validate(tmpl), tmpl.Name)
+	}
+
+	email, err := r.Render(context.Background(), enum.NotificationTemplatePullReqCreated,
+		samplePayload(enum.NotificationTemplatePullReqCreated))
+	require.NoError(t, err)
+	assert.Equal(t, "[hello-world] Add greeting endpoint (PR #42)", email.Subject)
+	assert.Equal(t, enum.NotificationContentTypeHTML, email.ContentType)
+}
+
+func TestTemplateRenderer_Directory(t *testing.T) {
+	dir := t.TempDir()
+	name := string(enum.NotificationTemplateWebhookDisabled)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".subject"),
+		[]byte("Webhook {{.Webhook.DisplayName}} is off"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".txt"),
+		[]byte("<b>{{.Webhook.DisplayName}}</b>"), 0o600))
+
+	r, err := NewTemplateRenderer(newFakeTemplateStore(), dir)
+	require.NoError(t, err)
+
+	tmpl := r.Default(enum.NotificationTemplateWebhookDisabled)
+	assert.Equal(t, enum.NotificationTemplateSourceDirectory, tmpl.Source)
+	assert.Equal(t, enum.NotificationContentTypeText, tmpl.ContentType)
+
+	// other templates aren't affected by the templates directory.
+	assert.Equal(t, enum.NotificationTemplateSourceBuiltin,
+		r.Default(enum.NotificationTemplatePullReqCreated).Source)
+
+	email, err := r.Preview(tmpl)
+	require.NoError(t, err)
+	assert.Contains(t, email.Subject, "Webhook ")
+	assert.Contains(t, email.Subject, " is off")
+	// plain-text bodies aren't HTML escaped.
+	assert.Contains(t, email.Body, "<b>")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name+".html"), []byte("<p>hi</p>"), 0o600))
+	_, err = NewTemplateRenderer(newFakeTemplateStore(), dir)
+	assert.Error(t, err)
+}
+
+func TestTemplateRenderer_Custom(t *testing.T) {
+	templateStore := newFakeTemplateStore()
+	r, err := NewTemplateRenderer(templateStore, "")
+	require.NoError(t, err)
+
+	custom := &types.NotificationTemplate{
+		Name:        enum.NotificationTemplatePullReqCreated,
+		Source:      enum.NotificationTemplateSourceCustom,
+		Subject:     "New PR {{.Base.PullReq.Number}}",
+		Body:        "Opened by {{.Base.Author.DisplayName}}",
+		ContentType: enum.NotificationContentTypeText,
+	}
+	require.NoError(t, templateStore.Upsert(context.Background(), custom))
+
+	email, err := r.Render(context.Background(), enum.NotificationTemplatePullReqCreated,
+		samplePayload(enum.NotificationTemplatePullReqCreated))
+	require.NoError(t, err)
+	assert.Equal(t, &types.NotificationEmail{
+		Subject:     "New PR 42",
+		Body:        "Opened by Jane Doe",
+		ContentType: enum.NotificationContentTypeText,
+	}, email)
+
+	// the default is still the built-in template.
+	assert.Equal(t, enum.NotificationTemplateSourceBuiltin,
+		r.Default(enum.NotificationTemplatePullReqCreated).Source)
+}
+
+func TestTemplateRenderer_PreviewInvalid(t *testing.T) {
+	r, err := NewTemplateRenderer(newFakeTemplateStore(), "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+	}{
+		{name: "empty subject", subject: " ", body: "body"},
+		{name: "empty body", subject: "subject", body: ""},
+		{name: "multi-line subject", subject: "a\n{{.Base.PullReq.Title}}", body: "body"},
+		{name: "unknown field", subject: "{{.Base.Unknown}}", body: "body"},
+		{name: "syntax error", subject: "subject", body: "{{if}}"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := r.Preview(&types.NotificationTemplate{
+				Name:        enum.NotificationTemplatePullReqCreated,
+				Subject:     test.subject,
+				Body:        test.body,
+				ContentType: enum.NotificationContentTypeHTML,
+			})
+			assert.Error(t, err)
+		})
+	}
+}
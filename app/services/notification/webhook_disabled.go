@@ -0,0 +1,117 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+type WebhookDisabledPayload struct {
+	Webhook             *types.Webhook
+	ParentPath          string
+	WebhookURL          string
+	ConsecutiveFailures int
+	LastError           string
+}
+
+func (s *Service) notifyWebhookDisabled(
+	ctx context.Context,
+	event *events.Event[*webhookevents.DisabledPayload],
+) error {
+	payload, recipients, err := s.processWebhookDisabledEvent(ctx, event)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to process %s event for webhookID %d: %w",
+			webhookevents.DisabledEvent,
+			event.Payload.WebhookID,
+			err,
+		)
+	}
+
+	recipients, err = s.deliver(ctx, enum.NotificationEventWebhookDisabled, nil, recipients, &inboxNotification{
+		EventID: event.ID,
+		Subject: fmt.Sprintf("%s webhook %s", payload.ParentPath, payload.Webhook.Identifier),
+		Title: fmt.Sprintf("Webhook %s was disabled after %d consecutive failures",
+			payload.Webhook.Identifier, payload.ConsecutiveFailures),
+		URL: payload.WebhookURL,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to get recipients for webhookID %d: %w", event.Payload.WebhookID, err)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	err = s.notificationClient.SendWebhookDisabled(ctx, recipients, payload)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to send email for event %s for webhookID %d: %w",
+			webhookevents.DisabledEvent,
+			event.Payload.WebhookID,
+			err,
+		)
+	}
+
+	return nil
+}
+
+func (s *Service) processWebhookDisabledEvent(
+	ctx context.Context,
+	event *events.Event[*webhookevents.DisabledPayload],
+) (*WebhookDisabledPayload, []*types.PrincipalInfo, error) {
+	webhook, err := s.webhookStore.Find(ctx, event.Payload.WebhookID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch webhook from webhookStore: %w", err)
+	}
+
+	creator, err := s.principalInfoCache.Get(ctx, webhook.CreatedBy)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get webhook creator from principalInfoCache: %w", err)
+	}
+
+	var parentPath, webhookURL string
+	switch webhook.ParentType {
+	case enum.WebhookParentRepo:
+		repo, err := s.repoStore.Find(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch repo from repoStore: %w", err)
+		}
+		parentPath = repo.Path
+		webhookURL = fmt.Sprintf("%s/webhook/%d", s.urlProvider.GenerateUIRepoURL(ctx, repo.Path), webhook.ID)
+	case enum.WebhookParentSpace:
+		spacePath, err := s.spacePathStore.FindPrimaryBySpaceID(ctx, webhook.ParentID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch space path from spacePathStore: %w", err)
+		}
+		parentPath = spacePath.Value
+		webhookURL = fmt.Sprintf("%s/webhook/%d", s.urlProvider.GenerateUISpaceURL(ctx, spacePath.Value), webhook.ID)
+	default:
+		return nil, nil, fmt.Errorf("webhook parent type %s is not supported", webhook.ParentType)
+	}
+
+	return &WebhookDisabledPayload{
+		Webhook:             webhook,
+		ParentPath:          parentPath,
+		WebhookURL:          webhookURL,
+		ConsecutiveFailures: event.Payload.ConsecutiveFailures,
+		LastError:           event.Payload.LastError,
+	}, []*types.PrincipalInfo{creator}, nil
+}
@@ -17,25 +17,36 @@ package notification
 import (
 	"context"
 
+	"github.com/harness/gitness/app/auth/authz"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/emailreply"
 	"github.com/harness/gitness/app/services/notification/mailer"
+	"github.com/harness/gitness/app/services/webhook"
+	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/job"
 
 	"github.com/google/wire"
 )
 
 var WireSet = wire.NewSet(
 	ProvideMailClient,
+	ProvideChatClient,
+	ProvideTemplateRenderer,
 	ProvideNotificationService,
 )
 
 func ProvideNotificationService(
 	ctx context.Context,
 	notificationClient Client,
+	chatClient *ChatClient,
 	pullReqConfig Config,
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	webhookReaderFactory *events.ReaderFactory[*webhookevents.Reader],
 	pullReqStore store.PullReqStore,
 	repoStore store.RepoStore,
 	principalInfoView store.PrincipalInfoView,
@@ -43,13 +54,27 @@ func ProvideNotificationService(
 	pullReqReviewersStore store.PullReqReviewerStore,
 	pullReqActivityStore store.PullReqActivityStore,
 	spacePathStore store.SpacePathStore,
+	webhookStore store.WebhookStore,
+	spaceStore store.SpaceStore,
+	preferenceStore store.NotificationPreferenceStore,
+	subscriptionStore store.NotificationSubscriptionStore,
+	notificationStore store.NotificationStore,
+	digestStore store.NotificationDigestStore,
+	principalStore store.PrincipalStore,
+	authorizer authz.Authorizer,
 	urlProvider url.Provider,
+	sseStreamer sse.Streamer,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	replySigner *emailreply.Signer,
 ) (*Service, error) {
 	return NewService(
 		ctx,
 		pullReqConfig,
 		notificationClient,
+		chatClient,
 		prReaderFactory,
+		webhookReaderFactory,
 		pullReqStore,
 		repoStore,
 		principalInfoView,
@@ -57,10 +82,43 @@ func ProvideNotificationService(
 		pullReqReviewersStore,
 		pullReqActivityStore,
 		spacePathStore,
+		webhookStore,
+		spaceStore,
+		preferenceStore,
+		subscriptionStore,
+		notificationStore,
+		digestStore,
+		principalStore,
+		authorizer,
 		urlProvider,
+		sseStreamer,
+		scheduler,
+		jobExecutor,
+		replySigner,
 	)
 }
 
-func ProvideMailClient(mailer mailer.Mailer) Client {
-	return NewMailClient(mailer)
+func ProvideMailClient(mailer mailer.Mailer, renderer *TemplateRenderer) Client {
+	return NewMailClient(mailer, renderer)
+}
+
+func ProvideTemplateRenderer(
+	templateStore store.NotificationTemplateStore,
+	config Config,
+) (*TemplateRenderer, error) {
+	return NewTemplateRenderer(templateStore, config.TemplatesDir)
+}
+
+func ProvideChatClient(
+	channelStore store.NotificationChannelStore,
+	spaceStore store.SpaceStore,
+	encrypter encrypt.Encrypter,
+	webhookConfig webhook.Config,
+) *ChatClient {
+	return NewChatClient(
+		channelStore,
+		spaceStore,
+		encrypter,
+		webhook.NewHTTPClient(webhookConfig.AllowLoopback, webhookConfig.AllowPrivateNetwork, false),
+	)
 }
@@ -67,6 +67,7 @@ func (s branchRuleSet) MergeVerify(
 			out.RequiresNoChangeRequests = out.RequiresNoChangeRequests || rOut.RequiresNoChangeRequests
 			out.RequiresBypassMessage = out.RequiresBypassMessage || rOut.RequiresBypassMessage
 			out.DefaultReviewerApprovals = append(out.DefaultReviewerApprovals, rOut.DefaultReviewerApprovals...)
+			out.Warnings = append(out.Warnings, backFillRule(rOut.Warnings, r.RuleInfo)...)
 
 			return nil
 		})
@@ -141,6 +142,8 @@ func (s branchRuleSet) CreatePullReqVerify(
 			out.RequestCodeOwners = out.RequestCodeOwners || rOut.RequestCodeOwners
 			out.DefaultReviewerIDs = append(out.DefaultReviewerIDs, rOut.DefaultReviewerIDs...)
 			out.DefaultGroupReviewerIDs = append(out.DefaultGroupReviewerIDs, rOut.DefaultGroupReviewerIDs...)
+			out.PathReviewerIDs = append(out.PathReviewerIDs, rOut.PathReviewerIDs...)
+			out.PathUserGroupReviewerIDs = append(out.PathUserGroupReviewerIDs, rOut.PathUserGroupReviewerIDs...)
 
 			return nil
 		})
@@ -150,6 +153,8 @@ func (s branchRuleSet) CreatePullReqVerify(
 
 	out.DefaultReviewerIDs = deduplicateInt64Slice(out.DefaultReviewerIDs)
 	out.DefaultGroupReviewerIDs = deduplicateInt64Slice(out.DefaultGroupReviewerIDs)
+	out.PathReviewerIDs = deduplicateInt64Slice(out.PathReviewerIDs)
+	out.PathUserGroupReviewerIDs = deduplicateInt64Slice(out.PathUserGroupReviewerIDs)
 
 	return out, violations, nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"path"
 	"strings"
 
 	"github.com/harness/gitness/app/services/codeowners"
@@ -25,6 +26,7 @@ import (
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
+	"github.com/bmatcuk/doublestar/v4"
 	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
@@ -48,6 +50,15 @@ type (
 		Method              enum.MergeMethod // the method can be empty for dry run or dry run rules
 		CheckResults        []types.CheckResult
 		CodeOwners          *codeowners.Evaluation
+		// ChangedFiles lists the files changed by the pull request. It's only called if required by a rule.
+		ChangedFiles func(ctx context.Context) ([]ChangedFile, error)
+	}
+
+	// ChangedFile holds the change statistics of a single file of a pull request.
+	ChangedFile struct {
+		Path      string
+		Additions int64
+		Deletions int64
 	}
 
 	MergeVerifyOutput struct {
@@ -61,6 +72,8 @@ type (
 		RequiresNoChangeRequests            bool
 		RequiresBypassMessage               bool
 		DefaultReviewerApprovals            []*types.DefaultReviewerApprovalsResponse
+		// Warnings are violations of rules configured to warn only. They never block the merge.
+		Warnings []types.RuleViolations
 	}
 
 	RequiredChecksInput struct {
@@ -92,12 +105,16 @@ type (
 		TargetBranch       string
 		RepoID             int64
 		RepoIdentifier     string
+		// ChangedFiles lists the files changed by the pull request. It's only called if required by a rule.
+		ChangedFiles func(ctx context.Context) ([]ChangedFile, error)
 	}
 
 	CreatePullReqVerifyOutput struct {
-		RequestCodeOwners       bool
-		DefaultReviewerIDs      []int64
-		DefaultGroupReviewerIDs []int64
+		RequestCodeOwners        bool
+		DefaultReviewerIDs       []int64
+		DefaultGroupReviewerIDs  []int64
+		PathReviewerIDs          []int64
+		PathUserGroupReviewerIDs []int64
 	}
 )
 
@@ -114,6 +131,9 @@ const (
 	codePullReqApprovalReqDefaultReviewerMinCount       = "pullreq.approvals.require_default_reviewer_minimum_count"
 	codePullReqApprovalReqDefaultReviewerMinCountLatest = "pullreq.approvals.require_default_reviewer_minimum_count:latest_commit" //nolint:lll
 
+	codePullReqApprovalReqPathReviewers       = "pullreq.approvals.require_path_reviewers"
+	codePullReqApprovalReqPathReviewersLatest = "pullreq.approvals.require_path_reviewers:latest_commit"
+
 	codePullReqApprovalReqLatestCommit          = "pullreq.approvals.require_latest_commit"
 	codePullReqApprovalReqChangeRequested       = "pullreq.approvals.require_change_requested"
 	codePullReqApprovalReqChangeRequestedOldSHA = "pullreq.approvals.require_change_requested_old_SHA"
@@ -122,12 +142,20 @@ const (
 	codePullReqApprovalReqCodeOwnersChangeRequested  = "pullreq.approvals.require_code_owners:change_requested"
 	codePullReqApprovalReqCodeOwnersNoLatestApproval = "pullreq.approvals.require_code_owners:no_latest_approval"
 
+	codePullReqApprovalReqCodeOwnersSectionNoApproval       = "pullreq.approvals.require_code_owners:section_no_approval"
+	codePullReqApprovalReqCodeOwnersSectionChangeRequested  = "pullreq.approvals.require_code_owners:section_change_requested"   //nolint:lll
+	codePullReqApprovalReqCodeOwnersSectionNoLatestApproval = "pullreq.approvals.require_code_owners:section_no_latest_approval" //nolint:lll
+
 	codePullReqMergeStrategiesAllowed = "pullreq.merge.strategies_allowed"
 	codePullReqMergeDeleteBranch      = "pullreq.merge.delete_branch"
 	codePullReqMergeBlock             = "pullreq.merge.blocked"
 
 	codePullReqCommentsReqResolveAll      = "pullreq.comments.require_resolve_all"
 	codePullReqStatusChecksReqIdentifiers = "pullreq.status_checks.required_identifiers"
+
+	codePullReqSizeMaxFilesChanged = "pullreq.size.max_files_changed"
+	codePullReqSizeMaxLinesChanged = "pullreq.size.max_lines_changed"
+	codePullReqSizeMaxCommits      = "pullreq.size.max_commits"
 )
 
 //nolint:gocognit,gocyclo,cyclop // well aware of this
@@ -272,8 +300,20 @@ func (v *DefPullReq) MergeVerify(
 		}
 	}
 
+	pathReviewerViolations, err := v.Reviewers.verifyPathReviewers(ctx, in, approvedBy, v.Approvals.RequireLatestCommit)
+	if err != nil {
+		return MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify path reviewers: %w", err)
+	}
+
+	violations.Violations = append(violations.Violations, pathReviewerViolations.Violations...)
+
 	if v.Approvals.RequireCodeOwners {
 		for _, entry := range in.CodeOwners.EvaluationEntries {
+			// entries of named sections are verified per section below.
+			if entry.Section != nil {
+				continue
+			}
+
 			reviewDecision, approvers := getCodeOwnerApprovalStatus(entry)
 
 			if reviewDecision == enum.PullReqReviewDecisionPending {
@@ -300,6 +340,10 @@ func (v *DefPullReq) MergeVerify(
 					"Code owners approval pending on latest commit for %q", entry.Pattern)
 			}
 		}
+
+		for _, section := range in.CodeOwners.Sections {
+			verifyCodeOwnerSection(&violations, section, v.Approvals.RequireLatestCommit, in.PullReq.SourceSHA)
+		}
 	}
 
 	// pullreq.comments
@@ -335,6 +379,21 @@ func (v *DefPullReq) MergeVerify(
 		)
 	}
 
+	// pullreq.size
+
+	sizeViolations, err := v.Size.verify(ctx, in)
+	if err != nil {
+		return MergeVerifyOutput{}, nil, fmt.Errorf("failed to verify pull request size: %w", err)
+	}
+
+	if v.Size.WarnOnly {
+		if len(sizeViolations.Violations) > 0 {
+			out.Warnings = []types.RuleViolations{sizeViolations}
+		}
+	} else {
+		violations.Violations = append(violations.Violations, sizeViolations.Violations...)
+	}
+
 	// pullreq.merge
 
 	out.AllowedMethods = enum.MergeMethods
@@ -381,8 +440,8 @@ func (v *DefPullReq) RequiredChecks(
 }
 
 func (v *DefPullReq) CreatePullReqVerify(
-	context.Context,
-	CreatePullReqVerifyInput,
+	ctx context.Context,
+	in CreatePullReqVerifyInput,
 ) (CreatePullReqVerifyOutput, []types.RuleViolations, error) {
 	var out CreatePullReqVerifyOutput
 
@@ -390,6 +449,23 @@ func (v *DefPullReq) CreatePullReqVerify(
 	out.DefaultReviewerIDs = v.Reviewers.DefaultReviewerIDs
 	out.DefaultGroupReviewerIDs = v.Reviewers.DefaultUserGroupReviewerIDs
 
+	if len(v.Reviewers.PathReviewers) > 0 && in.ChangedFiles != nil {
+		files, err := in.ChangedFiles(ctx)
+		if err != nil {
+			return CreatePullReqVerifyOutput{}, nil, fmt.Errorf("failed to list changed files: %w", err)
+		}
+
+		for i := range v.Reviewers.PathReviewers {
+			entry := &v.Reviewers.PathReviewers[i]
+			if !entry.matchesAny(files) {
+				continue
+			}
+
+			out.PathReviewerIDs = append(out.PathReviewerIDs, entry.ReviewerIDs...)
+			out.PathUserGroupReviewerIDs = append(out.PathUserGroupReviewerIDs, entry.UserGroupReviewerIDs...)
+		}
+	}
+
 	return out, nil, nil
 }
 
@@ -497,9 +573,21 @@ func (v *DefMerge) Sanitize() error {
 }
 
 type DefReviewers struct {
-	RequestCodeOwners           bool    `json:"request_code_owners,omitempty"`
-	DefaultReviewerIDs          []int64 `json:"default_reviewer_ids,omitempty"`
-	DefaultUserGroupReviewerIDs []int64 `json:"default_user_group_reviewer_ids,omitempty"`
+	RequestCodeOwners           bool              `json:"request_code_owners,omitempty"`
+	DefaultReviewerIDs          []int64           `json:"default_reviewer_ids,omitempty"`
+	DefaultUserGroupReviewerIDs []int64           `json:"default_user_group_reviewer_ids,omitempty"`
+	PathReviewers               []DefPathReviewer `json:"path_reviewers,omitempty"`
+}
+
+// DefPathReviewer requires approvals from the listed users and user groups
+// for pull requests changing any file matching one of the patterns.
+// A pattern without a slash is matched against the file name, otherwise against the full path.
+// With zero minimum approvals the reviewers are only requested on pull request creation.
+type DefPathReviewer struct {
+	Patterns             []string `json:"patterns"`
+	ReviewerIDs          []int64  `json:"reviewer_ids,omitempty"`
+	UserGroupReviewerIDs []int64  `json:"user_group_reviewer_ids,omitempty"`
+	MinimumApprovals     int      `json:"minimum_approvals,omitempty"`
 }
 
 func (v *DefReviewers) Sanitize() error {
@@ -511,15 +599,246 @@ func (v *DefReviewers) Sanitize() error {
 		return fmt.Errorf("default user group reviewer IDs error: %w", err)
 	}
 
+	for i := range v.PathReviewers {
+		if err := v.PathReviewers[i].Sanitize(); err != nil {
+			return fmt.Errorf("path reviewers error: %w", err)
+		}
+	}
+
 	return nil
 }
 
+// verifyPathReviewers checks that every path reviewer entry matching a file changed by the pull request
+// has the required number of approvals from its reviewers.
+func (v *DefReviewers) verifyPathReviewers(
+	ctx context.Context,
+	in MergeVerifyInput,
+	approvedBy map[int64]struct{},
+	requireLatestCommit bool,
+) (types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	if len(v.PathReviewers) == 0 || in.ChangedFiles == nil {
+		return violations, nil
+	}
+
+	files, err := in.ChangedFiles(ctx)
+	if err != nil {
+		return violations, fmt.Errorf("failed to list changed files: %w", err)
+	}
+
+	for i := range v.PathReviewers {
+		entry := &v.PathReviewers[i]
+		if !entry.matchesAny(files) {
+			continue
+		}
+
+		reviewerIDs := make(map[int64]struct{})
+		for _, id := range entry.ReviewerIDs {
+			reviewerIDs[id] = struct{}{}
+		}
+
+		if len(entry.UserGroupReviewerIDs) > 0 && in.MapUserGroupIDs != nil {
+			userGroupsMap, err := in.MapUserGroupIDs(ctx, entry.UserGroupReviewerIDs)
+			if err != nil {
+				return violations, fmt.Errorf("failed to map principals to user group ids: %w", err)
+			}
+
+			for _, principals := range userGroupsMap {
+				for _, principal := range principals {
+					reviewerIDs[principal.ID] = struct{}{}
+				}
+			}
+		}
+
+		// the author can't approve own pull request.
+		delete(reviewerIDs, in.PullReq.Author.ID)
+
+		minimum := entry.MinimumApprovals
+		if minimum > len(reviewerIDs) {
+			minimum = len(reviewerIDs)
+		}
+
+		var approvals int
+		for id := range reviewerIDs {
+			if _, ok := approvedBy[id]; ok {
+				approvals++
+			}
+		}
+
+		if approvals >= minimum {
+			continue
+		}
+
+		patterns := strings.Join(entry.Patterns, ", ")
+		if requireLatestCommit {
+			violations.Addf(codePullReqApprovalReqPathReviewersLatest,
+				"Insufficient number of approvals of the latest commit for changes matching %q. "+
+					"Have %d but need at least %d.",
+				patterns, approvals, minimum)
+		} else {
+			violations.Addf(codePullReqApprovalReqPathReviewers,
+				"Insufficient number of approvals for changes matching %q. Have %d but need at least %d.",
+				patterns, approvals, minimum)
+		}
+	}
+
+	return violations, nil
+}
+
+func (v *DefPathReviewer) Sanitize() error {
+	if len(v.Patterns) == 0 {
+		return errors.InvalidArgument("At least one path pattern is required.")
+	}
+
+	for _, pattern := range v.Patterns {
+		if pattern == "" || !doublestar.ValidatePattern(pattern) {
+			return errors.InvalidArgumentf("Invalid path pattern: %q.", pattern)
+		}
+	}
+
+	if len(v.ReviewerIDs) == 0 && len(v.UserGroupReviewerIDs) == 0 {
+		return errors.InvalidArgument("At least one reviewer or user group reviewer is required.")
+	}
+
+	if err := validateIDSlice(v.ReviewerIDs); err != nil {
+		return fmt.Errorf("reviewer IDs error: %w", err)
+	}
+
+	if err := validateIDSlice(v.UserGroupReviewerIDs); err != nil {
+		return fmt.Errorf("user group reviewer IDs error: %w", err)
+	}
+
+	if v.MinimumApprovals < 0 {
+		return errors.InvalidArgument("Minimum approvals must be zero or a positive integer.")
+	}
+
+	return nil
+}
+
+func (v *DefPathReviewer) matchesAny(files []ChangedFile) bool {
+	for _, file := range files {
+		if matchAnyPattern(v.Patterns, file.Path) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// DefSize limits the size of pull requests.
+// Files matching any of the exclude patterns don't count toward the file and line limits.
+// A pattern without a slash is matched against the file name, otherwise against the full path.
+type DefSize struct {
+	MaxFilesChanged int      `json:"max_files_changed,omitempty"`
+	MaxLinesChanged int      `json:"max_lines_changed,omitempty"`
+	MaxCommits      int      `json:"max_commits,omitempty"`
+	ExcludePatterns []string `json:"exclude_patterns,omitempty"`
+	WarnOnly        bool     `json:"warn_only,omitempty"`
+}
+
+func (v *DefSize) Sanitize() error {
+	if v.MaxFilesChanged < 0 || v.MaxLinesChanged < 0 || v.MaxCommits < 0 {
+		return errors.InvalidArgument("Size limits must be zero or a positive integer.")
+	}
+
+	for _, pattern := range v.ExcludePatterns {
+		if pattern == "" || !doublestar.ValidatePattern(pattern) {
+			return errors.InvalidArgumentf("Invalid exclude pattern: %q.", pattern)
+		}
+	}
+
+	return nil
+}
+
+func (v *DefSize) verify(ctx context.Context, in MergeVerifyInput) (types.RuleViolations, error) {
+	var violations types.RuleViolations
+
+	if v.MaxFilesChanged == 0 && v.MaxLinesChanged == 0 && v.MaxCommits == 0 {
+		return violations, nil
+	}
+
+	stats := in.PullReq.Stats.DiffStats
+
+	if v.MaxCommits > 0 && stats.Commits != nil && *stats.Commits > int64(v.MaxCommits) {
+		violations.Addf(codePullReqSizeMaxCommits,
+			"The pull request has %d commits but at most %d are allowed.",
+			*stats.Commits, v.MaxCommits)
+	}
+
+	if v.MaxFilesChanged == 0 && v.MaxLinesChanged == 0 {
+		return violations, nil
+	}
+
+	var filesChanged, linesChanged *int64
+
+	if len(v.ExcludePatterns) > 0 && in.ChangedFiles != nil {
+		files, err := in.ChangedFiles(ctx)
+		if err != nil {
+			return violations, fmt.Errorf("failed to list changed files: %w", err)
+		}
+
+		var fileCount, lineCount int64
+		for _, file := range files {
+			if v.isExcluded(file.Path) {
+				continue
+			}
+			fileCount++
+			lineCount += file.Additions + file.Deletions
+		}
+
+		filesChanged, linesChanged = &fileCount, &lineCount
+	} else {
+		filesChanged = stats.FilesChanged
+		if stats.Additions != nil && stats.Deletions != nil {
+			lineCount := *stats.Additions + *stats.Deletions
+			linesChanged = &lineCount
+		}
+	}
+
+	if v.MaxFilesChanged > 0 && filesChanged != nil && *filesChanged > int64(v.MaxFilesChanged) {
+		violations.Addf(codePullReqSizeMaxFilesChanged,
+			"The pull request changes %d files but at most %d are allowed.",
+			*filesChanged, v.MaxFilesChanged)
+	}
+
+	if v.MaxLinesChanged > 0 && linesChanged != nil && *linesChanged > int64(v.MaxLinesChanged) {
+		violations.Addf(codePullReqSizeMaxLinesChanged,
+			"The pull request changes %d lines but at most %d are allowed.",
+			*linesChanged, v.MaxLinesChanged)
+	}
+
+	return violations, nil
+}
+
+func (v *DefSize) isExcluded(filePath string) bool {
+	return matchAnyPattern(v.ExcludePatterns, filePath)
+}
+
+// matchAnyPattern reports whether the file path matches any of the glob patterns.
+// A pattern without a slash is matched against the file name, otherwise against the full path.
+func matchAnyPattern(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		target := filePath
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(filePath)
+		}
+
+		if ok, _ := doublestar.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
 type DefPullReq struct {
 	Approvals    DefApprovals    `json:"approvals"`
 	Comments     DefComments     `json:"comments"`
 	StatusChecks DefStatusChecks `json:"status_checks"`
 	Merge        DefMerge        `json:"merge"`
 	Reviewers    DefReviewers    `json:"reviewers"`
+	Size         DefSize         `json:"size"`
 }
 
 func (v *DefPullReq) Sanitize() error {
@@ -543,9 +862,55 @@ func (v *DefPullReq) Sanitize() error {
 		return fmt.Errorf("reviewers: %w", err)
 	}
 
+	if err := v.Size.Sanitize(); err != nil {
+		return fmt.Errorf("size: %w", err)
+	}
+
 	return nil
 }
 
+// verifyCodeOwnerSection verifies that a named CODEOWNERS section
+// has the required number of code owner approvals and no requested changes.
+func verifyCodeOwnerSection(
+	violations *types.RuleViolations,
+	section codeowners.SectionEvaluation,
+	requireLatestCommit bool,
+	sourceSHA string,
+) {
+	if section.Optional {
+		return
+	}
+
+	if section.ChangeRequested {
+		violations.Addf(codePullReqApprovalReqCodeOwnersSectionChangeRequested,
+			"Code owners of section %q requested changes", section.Name)
+		return
+	}
+
+	if !requireLatestCommit {
+		if len(section.Approvers) < section.RequiredApprovals {
+			violations.Addf(codePullReqApprovalReqCodeOwnersSectionNoApproval,
+				"Insufficient number of code owner approvals for section %q. Have %d but need at least %d.",
+				section.Name, len(section.Approvers), section.RequiredApprovals)
+		}
+		return
+	}
+
+	var latestApprovals int
+	for _, approver := range section.Approvers {
+		if approver.ReviewSHA == sourceSHA {
+			latestApprovals++
+		}
+	}
+
+	if latestApprovals < section.RequiredApprovals {
+		violations.Addf(codePullReqApprovalReqCodeOwnersSectionNoLatestApproval,
+			"Insufficient number of code owner approvals of the latest commit for section %q. "+
+				"Have %d but need at least %d.",
+			section.Name, latestApprovals, section.RequiredApprovals)
+	}
+}
+
 func getCodeOwnerApprovalStatus(
 	entry codeowners.EvaluationEntry,
 ) (enum.PullReqReviewDecision, []codeowners.UserEvaluation) {
@@ -554,6 +554,125 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				RequiresCodeOwnersApprovalLatest: true,
 			},
 		},
+		{
+			name: codePullReqApprovalReqCodeOwnersSectionNoApproval + "-fail",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					Sections: []codeowners.SectionEvaluation{
+						{
+							Name:              "Backend",
+							RequiredApprovals: 2,
+							Approvers: []codeowners.UserEvaluation{
+								{Owner: reviewer1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							},
+						},
+						{
+							Name:              "Docs",
+							Optional:          true,
+							RequiredApprovals: 1,
+						},
+					},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqCodeOwnersSectionNoApproval},
+			expParams: [][]any{{"Backend", 1, 2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:             enum.MergeMethods,
+				RequiresCodeOwnersApproval: true,
+			},
+		},
+		{
+			name: codePullReqApprovalReqCodeOwnersSectionChangeRequested + "-fail",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					Sections: []codeowners.SectionEvaluation{
+						{
+							Name:              "Backend",
+							RequiredApprovals: 1,
+							Approvers: []codeowners.UserEvaluation{
+								{Owner: reviewer1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							},
+							ChangeRequested: true,
+						},
+					},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqCodeOwnersSectionChangeRequested},
+			expParams: [][]any{{"Backend"}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:             enum.MergeMethods,
+				RequiresCodeOwnersApproval: true,
+			},
+		},
+		{
+			name: codePullReqApprovalReqCodeOwnersSectionNoLatestApproval + "-fail",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true, RequireLatestCommit: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					EvaluationEntries: []codeowners.EvaluationEntry{
+						{
+							Pattern: "app",
+							Section: &codeowners.Section{Name: "Backend", RequiredApprovals: 2},
+							UserEvaluations: []codeowners.UserEvaluation{
+								{Owner: reviewer1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+							},
+						},
+					},
+					Sections: []codeowners.SectionEvaluation{
+						{
+							Name:              "Backend",
+							RequiredApprovals: 2,
+							Approvers: []codeowners.UserEvaluation{
+								{Owner: reviewer1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								{Owner: reviewer2, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+							},
+						},
+					},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqCodeOwnersSectionNoLatestApproval},
+			expParams: [][]any{{"Backend", 1, 2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:                   enum.MergeMethods,
+				RequiresCodeOwnersApprovalLatest: true,
+			},
+		},
+		{
+			name: codePullReqApprovalReqCodeOwnersSectionNoApproval + "-success",
+			def:  DefPullReq{Approvals: DefApprovals{RequireCodeOwners: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{UnresolvedCount: 0, SourceSHA: "abc"},
+				CodeOwners: &codeowners.Evaluation{
+					Sections: []codeowners.SectionEvaluation{
+						{
+							Name:              "Backend",
+							RequiredApprovals: 2,
+							Approvers: []codeowners.UserEvaluation{
+								{Owner: reviewer1, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "abc"},
+								{Owner: reviewer2, ReviewDecision: enum.PullReqReviewDecisionApproved, ReviewSHA: "old"},
+							},
+						},
+					},
+					FileSha: "xyz",
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:             enum.MergeMethods,
+				RequiresCodeOwnersApproval: true,
+			},
+		},
 		{
 			name: codePullReqCommentsReqResolveAll + "-fail",
 			def:  DefPullReq{Comments: DefComments{RequireResolveAll: true}},
@@ -765,6 +884,161 @@ func TestDefPullReq_MergeVerify(t *testing.T) {
 				AllowedMethods: enum.MergeMethods,
 			},
 		},
+		{
+			name: "pullreq.size-fail",
+			def:  DefPullReq{Size: DefSize{MaxFilesChanged: 10, MaxLinesChanged: 100, MaxCommits: 5}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{
+					Stats: types.PullReqStats{DiffStats: types.NewDiffStats(6, 11, 80, 30)},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes: []string{
+				codePullReqSizeMaxCommits,
+				codePullReqSizeMaxFilesChanged,
+				codePullReqSizeMaxLinesChanged,
+			},
+			expParams: [][]any{{int64(6), 5}, {int64(11), 10}, {int64(110), 100}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: "pullreq.size-excluded-success",
+			def: DefPullReq{Size: DefSize{
+				MaxFilesChanged: 2,
+				MaxLinesChanged: 100,
+				ExcludePatterns: []string{"*.lock", "gen/**"},
+			}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{
+					Stats: types.PullReqStats{DiffStats: types.NewDiffStats(1, 4, 1000, 0)},
+				},
+				ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+					return []ChangedFile{
+						{Path: "app/main.go", Additions: 50, Deletions: 10},
+						{Path: "web/yarn.lock", Additions: 500},
+						{Path: "gen/types.go", Additions: 400},
+						{Path: "app/gen/types.go", Additions: 40},
+					}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: "pullreq.size-warn",
+			def:  DefPullReq{Size: DefSize{MaxCommits: 5, WarnOnly: true}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{
+					Stats: types.PullReqStats{DiffStats: types.NewDiffStats(6, 1, 1, 1)},
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+				Warnings: []types.RuleViolations{{
+					Violations: []types.Violation{{
+						Code:    codePullReqSizeMaxCommits,
+						Message: "The pull request has 6 commits but at most 5 are allowed.",
+						Params:  []any{int64(6), 5},
+					}},
+				}},
+			},
+		},
+		{
+			name: codePullReqApprovalReqPathReviewers + "-fail",
+			def: DefPullReq{Reviewers: DefReviewers{PathReviewers: []DefPathReviewer{{
+				Patterns:         []string{"docs/**"},
+				ReviewerIDs:      []int64{reviewer1.ID, reviewer2.ID, reviewer3.ID},
+				MinimumApprovals: 2,
+			}}}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc", Author: reviewer1},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: reviewer2, SHA: "abc"},
+				},
+				ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+					return []ChangedFile{{Path: "docs/api/README.md"}}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqPathReviewers},
+			expParams: [][]any{{"docs/**", 1, 2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqApprovalReqPathReviewers + "-success",
+			def: DefPullReq{Reviewers: DefReviewers{PathReviewers: []DefPathReviewer{{
+				Patterns:         []string{"*.sql"},
+				ReviewerIDs:      []int64{reviewer2.ID, reviewer3.ID},
+				MinimumApprovals: 2,
+			}}}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc", Author: reviewer1},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: reviewer2, SHA: "abc"},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: reviewer3, SHA: "def"},
+				},
+				ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+					return []ChangedFile{{Path: "app/store/database/migrate/0001_init.up.sql"}}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
+		{
+			name: codePullReqApprovalReqPathReviewersLatest + "-fail",
+			def: DefPullReq{
+				Approvals: DefApprovals{RequireLatestCommit: true, RequireMinimumCount: 1},
+				Reviewers: DefReviewers{PathReviewers: []DefPathReviewer{{
+					Patterns:         []string{"*.sql"},
+					ReviewerIDs:      []int64{reviewer2.ID, reviewer3.ID},
+					MinimumApprovals: 2,
+				}}},
+			},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc", Author: reviewer1},
+				Reviewers: []*types.PullReqReviewer{
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: reviewer2, SHA: "abc"},
+					{ReviewDecision: enum.PullReqReviewDecisionApproved, Reviewer: reviewer3, SHA: "def"},
+				},
+				ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+					return []ChangedFile{{Path: "app/store/database/migrate/0001_init.up.sql"}}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expCodes:  []string{codePullReqApprovalReqPathReviewersLatest},
+			expParams: [][]any{{"*.sql", 1, 2}},
+			expOut: MergeVerifyOutput{
+				AllowedMethods:                      enum.MergeMethods,
+				MinimumRequiredApprovalsCountLatest: 1,
+			},
+		},
+		{
+			name: codePullReqApprovalReqPathReviewers + "-no-match",
+			def: DefPullReq{Reviewers: DefReviewers{PathReviewers: []DefPathReviewer{{
+				Patterns:         []string{"docs/**"},
+				ReviewerIDs:      []int64{reviewer2.ID},
+				MinimumApprovals: 1,
+			}}}},
+			in: MergeVerifyInput{
+				PullReq: &types.PullReq{SourceSHA: "abc", Author: reviewer1},
+				ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+					return []ChangedFile{{Path: "app/docs/main.go"}}, nil
+				},
+				Method: enum.MergeMethodMerge,
+			},
+			expOut: MergeVerifyOutput{
+				AllowedMethods: enum.MergeMethods,
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -807,3 +1081,39 @@ func sortEvaluations(approvals []*types.DefaultReviewerApprovalsResponse) {
 		})
 	}
 }
+
+func TestDefPullReq_CreatePullReqVerify(t *testing.T) {
+	def := DefPullReq{Reviewers: DefReviewers{
+		DefaultReviewerIDs: []int64{reviewer1.ID},
+		PathReviewers: []DefPathReviewer{
+			{Patterns: []string{"docs/**"}, ReviewerIDs: []int64{reviewer2.ID}},
+			{Patterns: []string{"*.sql"}, ReviewerIDs: []int64{reviewer3.ID}, UserGroupReviewerIDs: []int64{7}},
+		},
+	}}
+
+	if err := def.Sanitize(); err != nil {
+		t.Fatalf("def invalid: %s", err.Error())
+	}
+
+	out, violations, err := def.CreatePullReqVerify(context.Background(), CreatePullReqVerifyInput{
+		ChangedFiles: func(context.Context) ([]ChangedFile, error) {
+			return []ChangedFile{{Path: "app/main.go"}, {Path: "db/schema.sql"}}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("got an error: %s", err.Error())
+	}
+
+	if len(violations) > 0 {
+		t.Errorf("unexpected violations: %+v", violations)
+	}
+
+	want := CreatePullReqVerifyOutput{
+		DefaultReviewerIDs:       []int64{reviewer1.ID},
+		PathReviewerIDs:          []int64{reviewer3.ID},
+		PathUserGroupReviewerIDs: []int64{7},
+	}
+	if !reflect.DeepEqual(want, out) {
+		t.Errorf("output mismatch: want=%+v got=%+v", want, out)
+	}
+}
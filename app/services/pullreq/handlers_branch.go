@@ -37,7 +37,8 @@ import (
 )
 
 var (
-	errPRNotOpen = errors.New("PR is not open")
+	errPRNotOpen         = errors.New("PR is not open")
+	errSourceRepoMissing = errors.New("PR source repository is missing")
 )
 
 // triggerPREventOnBranchUpdate handles branch update events. For every open pull request
@@ -87,18 +88,6 @@ func (s *Service) updatePullReqOnBranchUpdate(ctx context.Context,
 	}
 
 	s.forEveryOpenPR(ctx, event.Payload.RepoID, event.Payload.Ref, func(pr *types.PullReq) error {
-		targetRepo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
-		if err != nil {
-			return fmt.Errorf("failed to get target repo git info: %w", err)
-		}
-
-		readParams := git.CreateReadParams(targetRepo)
-
-		writeParams, err := createRPCSystemReferencesWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
-		if err != nil {
-			return fmt.Errorf("failed to generate target repo write params: %w", err)
-		}
-
 		oldSHA, err := sha.New(event.Payload.OldSHA)
 		if err != nil {
 			return fmt.Errorf("failed to convert old commit SHA %q: %w",
@@ -115,149 +104,192 @@ func (s *Service) updatePullReqOnBranchUpdate(ctx context.Context,
 			)
 		}
 
-		// Pull git objects from the source repo into the target repo if this is a cross repo pull request.
-
-		if pr.SourceRepoID == nil {
-			return events.NewDiscardEventError(fmt.Errorf("pull request ID=%d has no source repo ID", pr.ID))
+		err = s.UpdateSource(ctx, pr, SourceUpdateInput{
+			PrincipalID: event.Payload.PrincipalID,
+			OldSHA:      oldSHA,
+			NewSHA:      newSHA,
+			Forced:      event.Payload.Forced,
+			CommitTitle: commitTitle,
+			SkipCI:      event.Payload.SkipCI,
+		})
+		if errors.Is(err, errSourceRepoMissing) {
+			return events.NewDiscardEventError(err)
 		}
 
-		if *pr.SourceRepoID != pr.TargetRepoID {
-			sourceRepo, err := s.repoFinder.FindByID(ctx, *pr.SourceRepoID)
-			if errors.Is(err, gitness_store.ErrResourceNotFound) {
-				return events.NewDiscardEventError(fmt.Errorf("pull request ID=%d source repo not found ID", pr.ID))
-			} else if err != nil {
-				return fmt.Errorf("failed to get source repo git info: %w", err)
-			}
-
-			_, err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
-				WriteParams: writeParams,
-				Source:      sourceRepo.GitUID,
-				ObjectSHAs:  []sha.SHA{newSHA},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to fetch git objects from the source repository: %w", err)
-			}
-		}
+		return err
+	})
+	return nil
+}
 
-		// Update pull request's head reference.
+// SourceUpdateInput holds the information about a new commit of the pull request's source.
+type SourceUpdateInput struct {
+	PrincipalID int64
+	OldSHA      sha.SHA
+	NewSHA      sha.SHA
+	Forced      bool
+	CommitTitle string
+	// SkipCI suppresses the pipeline triggers of the branch update (git push -o ci.skip).
+	SkipCI bool
+}
 
-		err = s.git.UpdateRef(ctx, git.UpdateRefParams{
-			WriteParams: writeParams,
-			Name:        strconv.Itoa(int(pr.Number)),
-			Type:        gitenum.RefTypePullReqHead,
-			NewValue:    newSHA,
-			OldValue:    oldSHA,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to update PR head ref after new commit: %w", err)
-		}
+// UpdateSource updates the pull request's head reference, source SHA and merge base after a new commit
+// was pushed to the pull request's source. It writes an activity entry and triggers the pull request
+// Branch Updated event.
+func (s *Service) UpdateSource(ctx context.Context, pr *types.PullReq, in SourceUpdateInput) error {
+	targetRepo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get target repo git info: %w", err)
+	}
 
-		// Check if the merge base has changed
+	readParams := git.CreateReadParams(targetRepo)
 
-		targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
-			ReadParams: readParams,
-			Name:       pr.TargetBranch,
-			Type:       gitenum.RefTypeBranch,
-		})
-		if err != nil {
-			return fmt.Errorf("failed to resolve target branch reference: %w", err)
-		}
+	writeParams, err := createRPCSystemReferencesWriteParams(ctx, s.urlProvider, targetRepo.ID, targetRepo.GitUID)
+	if err != nil {
+		return fmt.Errorf("failed to generate target repo write params: %w", err)
+	}
 
-		targetSHA := targetRef.SHA
+	// Pull git objects from the source repo into the target repo if this is a cross repo pull request.
 
-		mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
-			ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
-			Ref1:       event.Payload.NewSHA,
-			Ref2:       targetSHA.String(),
-		})
-		if errors.IsInvalidArgument(err) || gitapi.IsUnrelatedHistoriesError(err) {
-			in := NonUniqueMergeBaseInput{
-				PullReqStore:      s.pullreqStore,
-				ActivityStore:     s.activityStore,
-				PullReqEvReporter: s.pullreqEvReporter,
-				SSEStreamer:       s.sseStreamer,
-			}
-			err = CloseBecauseNonUniqueMergeBase(ctx, in, targetSHA, newSHA, pr)
-			if err != nil {
-				return fmt.Errorf("failed to close pull request after non-unique merge base: %w", err)
-			}
+	if pr.SourceRepoID == nil {
+		return fmt.Errorf("pull request ID=%d has no source repo ID: %w", pr.ID, errSourceRepoMissing)
+	}
 
-			return nil
+	if *pr.SourceRepoID != pr.TargetRepoID {
+		sourceRepo, err := s.repoFinder.FindByID(ctx, *pr.SourceRepoID)
+		if errors.Is(err, gitness_store.ErrResourceNotFound) {
+			return fmt.Errorf("pull request ID=%d source repo not found: %w", pr.ID, errSourceRepoMissing)
+		} else if err != nil {
+			return fmt.Errorf("failed to get source repo git info: %w", err)
 		}
+
+		_, err = s.git.FetchObjects(ctx, &git.FetchObjectsParams{
+			WriteParams: writeParams,
+			Source:      sourceRepo.GitUID,
+			ObjectSHAs:  []sha.SHA{in.NewSHA},
+		})
 		if err != nil {
-			return fmt.Errorf("failed to get merge base after branch update to=%s for PR=%d: %w",
-				event.Payload.NewSHA, pr.Number, err)
+			return fmt.Errorf("failed to fetch git objects from the source repository: %w", err)
 		}
+	}
 
-		oldMergeBase := pr.MergeBaseSHA
-		newMergeBase := mergeBaseInfo.MergeBaseSHA
+	// Update pull request's head reference.
 
-		// Update the database with the latest source commit SHA and the merge base SHA.
-		pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
-			// to avoid racing conditions with merge
-			if pr.State != enum.PullReqStateOpen {
-				return errPRNotOpen
-			}
-
-			pr.ActivitySeq++
-			if pr.SourceSHA != event.Payload.OldSHA {
-				return fmt.Errorf(
-					"failed to set SourceSHA for PR %d to value '%s', expected SHA '%s' but current pr has '%s'",
-					pr.Number, event.Payload.NewSHA, event.Payload.OldSHA, pr.SourceSHA)
-			}
+	err = s.git.UpdateRef(ctx, git.UpdateRefParams{
+		WriteParams: writeParams,
+		Name:        strconv.Itoa(int(pr.Number)),
+		Type:        gitenum.RefTypePullReqHead,
+		NewValue:    in.NewSHA,
+		OldValue:    in.OldSHA,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update PR head ref after new commit: %w", err)
+	}
 
-			pr.SourceSHA = event.Payload.NewSHA
-			pr.MergeTargetSHA = ptr.String(targetSHA.String())
-			pr.MergeBaseSHA = newMergeBase.String()
+	// Check if the merge base has changed
 
-			// reset merge-check fields for new run
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: readParams,
+		Name:       pr.TargetBranch,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to resolve target branch reference: %w", err)
+	}
 
-			pr.MergeSHA = nil
-			pr.Stats.DiffStats.Commits = nil
-			pr.Stats.DiffStats.FilesChanged = nil
-			pr.MarkAsMergeUnchecked()
+	targetSHA := targetRef.SHA
 
-			return nil
-		})
-		if errors.Is(err, errPRNotOpen) {
-			return nil
+	mergeBaseInfo, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: git.ReadParams{RepoUID: targetRepo.GitUID},
+		Ref1:       in.NewSHA.String(),
+		Ref2:       targetSHA.String(),
+	})
+	if errors.IsInvalidArgument(err) || gitapi.IsUnrelatedHistoriesError(err) {
+		closeIn := NonUniqueMergeBaseInput{
+			PullReqStore:      s.pullreqStore,
+			ActivityStore:     s.activityStore,
+			PullReqEvReporter: s.pullreqEvReporter,
+			SSEStreamer:       s.sseStreamer,
 		}
+		err = CloseBecauseNonUniqueMergeBase(ctx, closeIn, targetSHA, in.NewSHA, pr)
 		if err != nil {
-			return err
+			return fmt.Errorf("failed to close pull request after non-unique merge base: %w", err)
 		}
 
-		payload := &types.PullRequestActivityPayloadBranchUpdate{
-			Old:         event.Payload.OldSHA,
-			New:         event.Payload.NewSHA,
-			Forced:      event.Payload.Forced,
-			CommitTitle: commitTitle,
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get merge base after branch update to=%s for PR=%d: %w",
+			in.NewSHA, pr.Number, err)
+	}
+
+	oldMergeBase := pr.MergeBaseSHA
+	newMergeBase := mergeBaseInfo.MergeBaseSHA
+
+	// Update the database with the latest source commit SHA and the merge base SHA.
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		// to avoid racing conditions with merge
+		if pr.State != enum.PullReqStateOpen {
+			return errPRNotOpen
 		}
 
-		_, err = s.activityStore.CreateWithPayload(ctx, pr, event.Payload.PrincipalID, payload, nil)
-		if err != nil {
-			// non-critical error
-			log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after branch update")
+		pr.ActivitySeq++
+		if pr.SourceSHA != in.OldSHA.String() {
+			return fmt.Errorf(
+				"failed to set SourceSHA for PR %d to value '%s', expected SHA '%s' but current pr has '%s'",
+				pr.Number, in.NewSHA, in.OldSHA, pr.SourceSHA)
 		}
 
-		s.pullreqEvReporter.BranchUpdated(ctx, &pullreqevents.BranchUpdatedPayload{
-			Base: pullreqevents.Base{
-				PullReqID:    pr.ID,
-				SourceRepoID: pr.SourceRepoID,
-				TargetRepoID: pr.TargetRepoID,
-				PrincipalID:  event.Payload.PrincipalID,
-				Number:       pr.Number,
-			},
-			OldSHA:          event.Payload.OldSHA,
-			NewSHA:          event.Payload.NewSHA,
-			OldMergeBaseSHA: oldMergeBase,
-			NewMergeBaseSHA: newMergeBase.String(),
-			Forced:          event.Payload.Forced,
-		})
+		pr.SourceSHA = in.NewSHA.String()
+		pr.MergeTargetSHA = ptr.String(targetSHA.String())
+		pr.MergeBaseSHA = newMergeBase.String()
 
-		s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
+		// reset merge-check fields for new run
+
+		pr.MergeSHA = nil
+		pr.Stats.DiffStats.Commits = nil
+		pr.Stats.DiffStats.FilesChanged = nil
+		pr.MarkAsMergeUnchecked()
 
 		return nil
 	})
+	if errors.Is(err, errPRNotOpen) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	payload := &types.PullRequestActivityPayloadBranchUpdate{
+		Old:         in.OldSHA.String(),
+		New:         in.NewSHA.String(),
+		Forced:      in.Forced,
+		CommitTitle: in.CommitTitle,
+	}
+
+	_, err = s.activityStore.CreateWithPayload(ctx, pr, in.PrincipalID, payload, nil)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity after branch update")
+	}
+
+	s.pullreqEvReporter.BranchUpdated(ctx, &pullreqevents.BranchUpdatedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  in.PrincipalID,
+			Number:       pr.Number,
+		},
+		OldSHA:          in.OldSHA.String(),
+		NewSHA:          in.NewSHA.String(),
+		OldMergeBaseSHA: oldMergeBase,
+		NewMergeBaseSHA: newMergeBase.String(),
+		Forced:          in.Forced,
+		SkipCI:          in.SkipCI,
+	})
+
+	s.sseStreamer.Publish(ctx, targetRepo.ParentID, enum.SSETypePullReqUpdated, pr)
+
 	return nil
 }
 
@@ -363,6 +395,7 @@ func (s *Service) forEveryOpenPR(ctx context.Context,
 		SourceRepoID: repoID,
 		SourceBranch: branch,
 		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Flow:         enum.PullReqFlowGithub, // pull requests of other flows aren't tracking a source branch
 		Sort:         enum.PullReqSortNumber,
 		Order:        enum.OrderAsc,
 	})
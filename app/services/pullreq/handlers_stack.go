@@ -0,0 +1,201 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/githook"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+// retargetStackedPullReqsOnMerged handles pull request merged events. Open pull requests stacked
+// on top of the merged pull request (those targeting its source branch) are retargeted to the target branch
+// of the merged pull request and, if enabled in the repository settings, rebased onto it.
+func (s *Service) retargetStackedPullReqsOnMerged(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.MergedPayload],
+) error {
+	merged, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+	if err != nil {
+		return fmt.Errorf("failed to get merged pull request: %w", err)
+	}
+
+	if !isStackable(merged) {
+		return nil
+	}
+
+	dependents, err := listStackDependents(ctx, s.pullreqStore, merged.TargetRepoID, merged.SourceBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list dependent pull requests: %w", err)
+	}
+
+	if len(dependents) == 0 {
+		return nil
+	}
+
+	repo, err := s.repoFinder.FindByID(ctx, merged.TargetRepoID)
+	if err != nil {
+		return fmt.Errorf("failed to get repo: %w", err)
+	}
+
+	rebase, err := settings.RepoGet(ctx, s.settings, repo.ID,
+		settings.KeyStackedPullReqRebase, settings.DefaultStackedPullReqRebase)
+	if err != nil {
+		return fmt.Errorf("failed to get stacked pull request rebase setting: %w", err)
+	}
+
+	principalID := event.Payload.PrincipalID
+
+	for _, dependent := range dependents {
+		if dependent.SourceBranch == merged.TargetBranch {
+			continue
+		}
+
+		pr, err := s.ChangeTargetBranch(ctx, repo, dependent, principalID, merged.TargetBranch)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_number", dependent.Number).
+				Msg("failed to retarget stacked pull request")
+			continue
+		}
+
+		if !rebase {
+			continue
+		}
+
+		err = s.rebaseStackedPullReq(ctx, repo, pr, principalID)
+		if err != nil {
+			log.Ctx(ctx).Warn().Err(err).
+				Int64("pullreq_number", pr.Number).
+				Msg("failed to rebase stacked pull request")
+		}
+	}
+
+	return nil
+}
+
+// rebaseStackedPullReq rebases the source branch of the pull request onto its target branch.
+// The branch is updated as a regular push of the principal, so the branch protection rules apply.
+func (s *Service) rebaseStackedPullReq(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+) error {
+	principal, err := s.principalInfoCache.Get(ctx, principalID)
+	if err != nil {
+		return fmt.Errorf("failed to get principal info: %w", err)
+	}
+
+	envVars, err := githook.GenerateEnvironmentVariables(
+		ctx,
+		s.urlProvider.GetInternalAPIURL(ctx),
+		repo.ID,
+		principal.ID,
+		false,
+		false,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to generate git hook environment variables: %w", err)
+	}
+
+	sourceSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse source SHA: %w", err)
+	}
+
+	targetSHA, err := sha.New(*pr.MergeTargetSHA)
+	if err != nil {
+		return fmt.Errorf("failed to parse target SHA: %w", err)
+	}
+
+	sourceRef, err := git.GetRefPath(pr.SourceBranch, gitenum.RefTypeBranch)
+	if err != nil {
+		return fmt.Errorf("failed to generate source branch ref name: %w", err)
+	}
+
+	out, err := s.git.Merge(ctx, &git.MergeParams{
+		WriteParams: git.WriteParams{
+			Actor: git.Identity{
+				Name:  principal.DisplayName,
+				Email: principal.Email,
+			},
+			RepoUID: repo.GitUID,
+			EnvVars: envVars,
+		},
+		BaseSHA:               targetSHA,
+		HeadBranch:            pr.SourceBranch,
+		HeadBranchExpectedSHA: sourceSHA,
+		Refs: []git.RefUpdate{{
+			Name: sourceRef,
+			Old:  sourceSHA,
+			New:  sha.SHA{}, // update to the result of the rebase
+		}},
+		Method: gitenum.MergeMethodRebase,
+	})
+	if err != nil {
+		return fmt.Errorf("rebase execution failed: %w", err)
+	}
+
+	if len(out.ConflictFiles) > 0 {
+		log.Ctx(ctx).Info().
+			Int64("pullreq_number", pr.Number).
+			Strs("conflicts", out.ConflictFiles).
+			Msg("stacked pull request can't be rebased because of conflicts")
+
+		s.reportStackRebaseConflict(ctx, repo, pr, principalID, out.ConflictFiles)
+	}
+
+	return nil
+}
+
+// reportStackRebaseConflict writes a pull request activity entry to let the author know
+// that the source branch has to be rebased manually. Same as the target branch change,
+// the entry is written on behalf of the principal that merged the pull request below.
+func (s *Service) reportStackRebaseConflict(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+	conflicts []string,
+) {
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to update pull request activity sequence")
+		return
+	}
+
+	payload := &types.PullRequestActivityPayloadStackRebaseConflict{
+		TargetBranch:  pr.TargetBranch,
+		ConflictFiles: conflicts,
+	}
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, principalID, payload, nil); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for stacked pull request rebase conflict")
+		return
+	}
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+}
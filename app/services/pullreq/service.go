@@ -26,6 +26,7 @@ import (
 	"github.com/harness/gitness/app/githook"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -49,6 +50,7 @@ type Service struct {
 	fileViewStore       store.PullReqFileViewStore
 	sseStreamer         sse.Streamer
 	urlProvider         url.Provider
+	settings            *settings.Service
 
 	cancelMutex        sync.Mutex
 	cancelMergeability map[string]context.CancelFunc
@@ -74,6 +76,7 @@ func New(ctx context.Context,
 	bus pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	settings *settings.Service,
 ) (*Service, error) {
 	service := &Service{
 		pullreqEvReporter:   pullreqEvReporter,
@@ -90,6 +93,7 @@ func New(ctx context.Context,
 		cancelMergeability:  make(map[string]context.CancelFunc),
 		pubsub:              bus,
 		sseStreamer:         sseStreamer,
+		settings:            settings,
 	}
 
 	var err error
@@ -224,6 +228,26 @@ func New(ctx context.Context,
 		return nil, err
 	}
 
+	// retargeting of stacked pull requests
+	const groupPullReqStack = "gitness:pullreq:stack"
+	_, err = pullreqEvReaderFactory.Launch(ctx, groupPullReqStack, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = 30 * time.Second
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(2),
+				))
+
+			_ = r.RegisterMerged(service.retargetStackedPullReqsOnMerged)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
 	return service, nil
 }
 
@@ -22,6 +22,7 @@ import (
 	"github.com/harness/gitness/app/auth"
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/store"
@@ -46,6 +47,9 @@ type ListService struct {
 	repoFinder        refcache.RepoFinder
 	labelSvc          *label.Service
 	protectionManager *protection.Manager
+	assigneeStore     store.PullReqAssigneeStore
+	pCache            store.PrincipalInfoCache
+	milestoneSvc      *milestone.Service
 }
 
 func NewListService(
@@ -58,6 +62,9 @@ func NewListService(
 	repoFinder refcache.RepoFinder,
 	labelSvc *label.Service,
 	protectionManager *protection.Manager,
+	assigneeStore store.PullReqAssigneeStore,
+	pCache store.PrincipalInfoCache,
+	milestoneSvc *milestone.Service,
 ) *ListService {
 	return &ListService{
 		tx:                tx,
@@ -69,6 +76,9 @@ func NewListService(
 		repoFinder:        repoFinder,
 		labelSvc:          labelSvc,
 		protectionManager: protectionManager,
+		assigneeStore:     assigneeStore,
+		pCache:            pCache,
+		milestoneSvc:      milestoneSvc,
 	}
 }
 
@@ -378,6 +388,52 @@ func (c *ListService) backfillRules(
 	return nil
 }
 
+// backfillAssignees sets the assignees of the pull requests.
+func (c *ListService) backfillAssignees(
+	ctx context.Context,
+	list []types.PullReqRepo,
+) error {
+	prIDs := make([]int64, len(list))
+	for i, entry := range list {
+		prIDs[i] = entry.PullRequest.ID
+	}
+
+	assigneeMap, err := c.assigneeStore.MapPrincipalIDs(ctx, prIDs)
+	if err != nil {
+		return fmt.Errorf("failed to list assignees of pull requests: %w", err)
+	}
+
+	if len(assigneeMap) == 0 {
+		return nil
+	}
+
+	var principalIDs []int64
+	for _, ids := range assigneeMap {
+		principalIDs = append(principalIDs, ids...)
+	}
+
+	infoMap, err := c.pCache.Map(ctx, principalIDs)
+	if err != nil {
+		return fmt.Errorf("failed to load assignee principal infos: %w", err)
+	}
+
+	for _, entry := range list {
+		ids := assigneeMap[entry.PullRequest.ID]
+		if len(ids) == 0 {
+			continue
+		}
+
+		entry.PullRequest.Assignees = make([]types.PrincipalInfo, 0, len(ids))
+		for _, id := range ids {
+			if info, ok := infoMap[id]; ok {
+				entry.PullRequest.Assignees = append(entry.PullRequest.Assignees, *info)
+			}
+		}
+	}
+
+	return nil
+}
+
 func (c *ListService) BackfillMetadata(
 	ctx context.Context,
 	list []types.PullReqRepo,
@@ -398,6 +454,19 @@ func (c *ListService) BackfillMetadata(
 		}
 	}
 
+	if err := c.backfillAssignees(ctx, list); err != nil {
+		return fmt.Errorf("failed to backfill assignees: %w", err)
+	}
+
+	pullReqs := make([]*types.PullReq, len(list))
+	for i := range list {
+		pullReqs[i] = list[i].PullRequest
+	}
+
+	if err := c.milestoneSvc.BackfillMany(ctx, pullReqs); err != nil {
+		return fmt.Errorf("failed to backfill milestones: %w", err)
+	}
+
 	if options.IncludeChecks {
 		if err := c.backfillChecks(ctx, list); err != nil {
 			return fmt.Errorf("failed to backfill checks")
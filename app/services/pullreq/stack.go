@@ -0,0 +1,134 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// stackMaxDepth limits the number of ancestors returned for a stacked pull request.
+const stackMaxDepth = 20
+
+// BackfillStack populates the stack of the pull request:
+// the open pull requests below it (ancestors) and the open pull requests targeting its source branch (dependents).
+func (c *ListService) BackfillStack(ctx context.Context, pr *types.PullReq) error {
+	if !isStackable(pr) {
+		return nil
+	}
+
+	dependents, err := listStackDependents(ctx, c.pullreqStore, pr.TargetRepoID, pr.SourceBranch)
+	if err != nil {
+		return fmt.Errorf("failed to list dependent pull requests: %w", err)
+	}
+
+	var ancestors []types.PullReqStackEntry
+
+	visited := map[int64]struct{}{pr.ID: {}}
+	targetBranch := pr.TargetBranch
+	for len(ancestors) < stackMaxDepth {
+		prs, err := c.pullreqStore.List(ctx, &types.PullReqFilter{
+			SourceRepoID: pr.TargetRepoID,
+			SourceBranch: targetBranch,
+			TargetRepoID: pr.TargetRepoID,
+			States:       []enum.PullReqState{enum.PullReqStateOpen},
+			Flow:         enum.PullReqFlowGithub,
+			Size:         1,
+			Sort:         enum.PullReqSortNumber,
+			Order:        enum.OrderAsc,
+
+			ExcludeDescription: true,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list ancestor pull requests: %w", err)
+		}
+
+		if len(prs) == 0 {
+			break
+		}
+
+		parent := prs[0]
+		if _, ok := visited[parent.ID]; ok {
+			break // a cycle
+		}
+		visited[parent.ID] = struct{}{}
+
+		ancestors = append(ancestors, stackEntry(parent))
+		targetBranch = parent.TargetBranch
+	}
+
+	if len(ancestors) == 0 && len(dependents) == 0 {
+		return nil
+	}
+
+	pr.Stack = &types.PullReqStack{
+		Ancestors:  ancestors,
+		Dependents: make([]types.PullReqStackEntry, len(dependents)),
+	}
+	for i, dependent := range dependents {
+		pr.Stack.Dependents[i] = stackEntry(dependent)
+	}
+
+	return nil
+}
+
+// isStackable returns true if the pull request can be a part of a stack of pull requests:
+// Only pull requests with the source and the target branch in the same repository can be stacked.
+func isStackable(pr *types.PullReq) bool {
+	return pr.Flow == enum.PullReqFlowGithub && pr.SourceRepoID != nil && *pr.SourceRepoID == pr.TargetRepoID
+}
+
+// listStackDependents returns open pull requests of the same repository targeting the branch.
+func listStackDependents(
+	ctx context.Context,
+	pullreqStore store.PullReqStore,
+	repoID int64,
+	branch string,
+) ([]*types.PullReq, error) {
+	const largeLimit = 1000
+
+	prs, err := pullreqStore.List(ctx, &types.PullReqFilter{
+		Size:         largeLimit,
+		SourceRepoID: repoID,
+		TargetRepoID: repoID,
+		TargetBranch: branch,
+		States:       []enum.PullReqState{enum.PullReqStateOpen},
+		Flow:         enum.PullReqFlowGithub,
+		Sort:         enum.PullReqSortNumber,
+		Order:        enum.OrderAsc,
+
+		ExcludeDescription: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return prs, nil
+}
+
+func stackEntry(pr *types.PullReq) types.PullReqStackEntry {
+	return types.PullReqStackEntry{
+		Number:       pr.Number,
+		Title:        pr.Title,
+		State:        pr.State,
+		IsDraft:      pr.IsDraft,
+		SourceBranch: pr.SourceBranch,
+		TargetBranch: pr.TargetBranch,
+	}
+}
@@ -0,0 +1,480 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/sse"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/git/sha"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const stackTestRepoID = 1
+
+var (
+	stackTestMainSHA   = sha.Must("1000000000000000000000000000000000000000")
+	stackTestSourceSHA = sha.Must("2000000000000000000000000000000000000000")
+	stackTestBaseSHA   = sha.Must("3000000000000000000000000000000000000000")
+)
+
+// stackPullReqStore keeps the pull requests in memory, List filters them the same way the database does
+// for the filters used to find the ancestors and the dependents of stacked pull requests.
+type stackPullReqStore struct {
+	store.PullReqStore
+	prs []*types.PullReq
+}
+
+func (s *stackPullReqStore) Find(_ context.Context, id int64) (*types.PullReq, error) {
+	for _, pr := range s.prs {
+		if pr.ID == id {
+			prCopy := *pr
+			return &prCopy, nil
+		}
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *stackPullReqStore) List(_ context.Context, filter *types.PullReqFilter) ([]*types.PullReq, error) {
+	var result []*types.PullReq
+	for _, pr := range s.prs {
+		switch {
+		case filter.SourceRepoID != 0 && (pr.SourceRepoID == nil || *pr.SourceRepoID != filter.SourceRepoID),
+			filter.TargetRepoID != 0 && pr.TargetRepoID != filter.TargetRepoID,
+			filter.SourceBranch != "" && pr.SourceBranch != filter.SourceBranch,
+			filter.TargetBranch != "" && pr.TargetBranch != filter.TargetBranch,
+			filter.Flow != "" && pr.Flow != filter.Flow,
+			len(filter.States) > 0 && pr.State != filter.States[0]:
+			continue
+		}
+
+		prCopy := *pr
+		result = append(result, &prCopy)
+		if filter.Size > 0 && len(result) == filter.Size {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (s *stackPullReqStore) UpdateOptLock(
+	_ context.Context,
+	pr *types.PullReq,
+	mutateFn func(pr *types.PullReq) error,
+) (*types.PullReq, error) {
+	for i, stored := range s.prs {
+		if stored.ID != pr.ID {
+			continue
+		}
+
+		updated := *stored
+		if err := mutateFn(&updated); err != nil {
+			return nil, err
+		}
+		updated.Version++
+		s.prs[i] = &updated
+
+		result := updated
+		return &result, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+func (s *stackPullReqStore) UpdateActivitySeq(ctx context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	return s.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.ActivitySeq++
+		return nil
+	})
+}
+
+type stackActivityStore struct {
+	store.PullReqActivityStore
+	payloads map[int64][]types.PullReqActivityPayload
+}
+
+func (s *stackActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	_ int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	if s.payloads == nil {
+		s.payloads = map[int64][]types.PullReqActivityPayload{}
+	}
+	s.payloads[pr.ID] = append(s.payloads[pr.ID], payload)
+	return &types.PullReqActivity{}, nil
+}
+
+type stackGit struct {
+	git.Interface
+	branches  map[string]sha.SHA
+	mergeBase sha.SHA
+	conflicts []string
+	merges    []*git.MergeParams
+}
+
+func (g *stackGit) GetRef(_ context.Context, params git.GetRefParams) (git.GetRefResponse, error) {
+	branchSHA, ok := g.branches[params.Name]
+	if !ok {
+		return git.GetRefResponse{}, errors.NotFoundf("branch %q not found", params.Name)
+	}
+	return git.GetRefResponse{SHA: branchSHA}, nil
+}
+
+func (g *stackGit) MergeBase(context.Context, git.MergeBaseParams) (git.MergeBaseOutput, error) {
+	return git.MergeBaseOutput{MergeBaseSHA: g.mergeBase}, nil
+}
+
+func (g *stackGit) DiffStats(context.Context, *git.DiffParams) (git.DiffStatsOutput, error) {
+	return git.DiffStatsOutput{Commits: 2, FilesChanged: 3, Additions: 4, Deletions: 5}, nil
+}
+
+func (g *stackGit) Merge(_ context.Context, params *git.MergeParams) (git.MergeOutput, error) {
+	g.merges = append(g.merges, params)
+	return git.MergeOutput{ConflictFiles: g.conflicts}, nil
+}
+
+type stackSettingsStore struct {
+	store.SettingsStore
+	values map[string]json.RawMessage
+}
+
+func (s stackSettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+) (json.RawMessage, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+type stackRepoIDCache struct {
+	store.RepoIDCache
+}
+
+func (stackRepoIDCache) Get(_ context.Context, id int64) (*types.RepositoryCore, error) {
+	return &types.RepositoryCore{ID: id, ParentID: 1, GitUID: "repo-uid", Path: "space/repo"}, nil
+}
+
+type stackPrincipalInfoCache struct {
+	store.PrincipalInfoCache
+}
+
+func (stackPrincipalInfoCache) Get(_ context.Context, id int64) (*types.PrincipalInfo, error) {
+	return &types.PrincipalInfo{ID: id, DisplayName: "User", Email: "user@example.com"}, nil
+}
+
+type stackStreamer struct {
+	sse.Streamer
+}
+
+func (stackStreamer) Publish(context.Context, int64, enum.SSEType, any) {}
+
+type stackURLProvider struct {
+	url.Provider
+}
+
+func (stackURLProvider) GetInternalAPIURL(context.Context) string {
+	return "http://localhost:3000"
+}
+
+// stackPullReq returns an open pull request of the test repository.
+func stackPullReq(id int64, source, target string) *types.PullReq {
+	return &types.PullReq{
+		ID:           id,
+		Number:       id,
+		State:        enum.PullReqStateOpen,
+		Flow:         enum.PullReqFlowGithub,
+		SourceRepoID: ptr.Int64(stackTestRepoID),
+		SourceBranch: source,
+		SourceSHA:    stackTestSourceSHA.String(),
+		TargetRepoID: stackTestRepoID,
+		TargetBranch: target,
+	}
+}
+
+func newStackTestService(
+	t *testing.T,
+	prs []*types.PullReq,
+	gitFake *stackGit,
+	settingValues map[string]json.RawMessage,
+) (*Service, *stackPullReqStore, *stackActivityStore) {
+	t.Helper()
+
+	eventSystem, err := events.ProvideSystem(events.Config{
+		Mode:            events.ModeInMemory,
+		MaxStreamLength: 100,
+	}, nil)
+	require.NoError(t, err)
+
+	reporter, err := pullreqevents.NewReporter(eventSystem)
+	require.NoError(t, err)
+
+	pullreqStore := &stackPullReqStore{prs: prs}
+	activityStore := &stackActivityStore{}
+
+	return &Service{
+		pullreqEvReporter:  reporter,
+		git:                gitFake,
+		repoFinder:         refcache.NewRepoFinder(nil, nil, stackRepoIDCache{}, nil, cache.Evictor[*types.RepositoryCore]{}),
+		pullreqStore:       pullreqStore,
+		activityStore:      activityStore,
+		principalInfoCache: stackPrincipalInfoCache{},
+		sseStreamer:        stackStreamer{},
+		urlProvider:        stackURLProvider{},
+		settings:           settings.NewService(stackSettingsStore{values: settingValues}),
+	}, pullreqStore, activityStore
+}
+
+func TestListService_BackfillStack(t *testing.T) {
+	crossRepo := stackPullReq(10, "x", "b")
+	crossRepo.SourceRepoID = ptr.Int64(stackTestRepoID + 1)
+
+	prs := []*types.PullReq{
+		stackPullReq(1, "a", "main"),
+		stackPullReq(2, "b", "a"),
+		stackPullReq(3, "c", "b"),
+		stackPullReq(4, "d", "b"),
+		stackPullReq(5, "y", "z"),
+		stackPullReq(6, "z", "y"),
+		crossRepo,
+	}
+
+	tests := []struct {
+		name           string
+		prID           int64
+		wantAncestors  []int64
+		wantDependents []int64
+	}{
+		{
+			name:           "middle of the stack",
+			prID:           2,
+			wantAncestors:  []int64{1},
+			wantDependents: []int64{3, 4},
+		},
+		{
+			name:          "top of the stack",
+			prID:          3,
+			wantAncestors: []int64{2, 1},
+		},
+		{
+			name:           "bottom of the stack",
+			prID:           1,
+			wantDependents: []int64{2},
+		},
+		{
+			name:           "cycle",
+			prID:           5,
+			wantAncestors:  []int64{6},
+			wantDependents: []int64{6},
+		},
+		{
+			name: "cross repository pull request",
+			prID: 10,
+		},
+	}
+
+	numbers := func(entries []types.PullReqStackEntry) []int64 {
+		var result []int64
+		for _, entry := range entries {
+			result = append(result, entry.Number)
+		}
+		return result
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			pullreqStore := &stackPullReqStore{prs: prs}
+			c := &ListService{pullreqStore: pullreqStore}
+
+			pr, err := pullreqStore.Find(context.Background(), test.prID)
+			require.NoError(t, err)
+
+			require.NoError(t, c.BackfillStack(context.Background(), pr))
+
+			if test.wantAncestors == nil && test.wantDependents == nil {
+				assert.Nil(t, pr.Stack)
+				return
+			}
+
+			require.NotNil(t, pr.Stack)
+			assert.Equal(t, test.wantAncestors, numbers(pr.Stack.Ancestors))
+			assert.Equal(t, test.wantDependents, numbers(pr.Stack.Dependents))
+		})
+	}
+}
+
+func TestService_ChangeTargetBranch(t *testing.T) {
+	ctx := context.Background()
+	repo := &types.RepositoryCore{ID: stackTestRepoID, GitUID: "repo-uid"}
+
+	t.Run("target branch changed", func(t *testing.T) {
+		gitFake := &stackGit{
+			branches:  map[string]sha.SHA{"main": stackTestMainSHA},
+			mergeBase: stackTestBaseSHA,
+		}
+		s, pullreqStore, activityStore := newStackTestService(t,
+			[]*types.PullReq{stackPullReq(2, "b", "a")}, gitFake, nil)
+
+		pr, err := s.ChangeTargetBranch(ctx, repo, pullreqStore.prs[0], 7, "main")
+		require.NoError(t, err)
+
+		assert.Equal(t, "main", pr.TargetBranch)
+		assert.Equal(t, stackTestBaseSHA.String(), pr.MergeBaseSHA)
+		assert.Equal(t, ptr.String(stackTestMainSHA.String()), pr.MergeTargetSHA)
+		assert.Equal(t, enum.MergeCheckStatusUnchecked, pr.MergeCheckStatus)
+		assert.Equal(t, ptr.Int64(2), pr.Stats.DiffStats.Commits)
+		assert.Equal(t, "main", pullreqStore.prs[0].TargetBranch, "the change must be stored")
+
+		require.Len(t, activityStore.payloads[pr.ID], 1)
+		assert.Equal(t, &types.PullRequestActivityPayloadBranchChangeTarget{Old: "a", New: "main"},
+			activityStore.payloads[pr.ID][0])
+	})
+
+	t.Run("no new commits", func(t *testing.T) {
+		gitFake := &stackGit{
+			branches:  map[string]sha.SHA{"main": stackTestMainSHA},
+			mergeBase: stackTestSourceSHA,
+		}
+		s, pullreqStore, activityStore := newStackTestService(t,
+			[]*types.PullReq{stackPullReq(2, "b", "a")}, gitFake, nil)
+
+		_, err := s.ChangeTargetBranch(ctx, repo, pullreqStore.prs[0], 7, "main")
+		require.True(t, errors.IsInvalidArgument(err), "unexpected error: %v", err)
+
+		assert.Equal(t, "a", pullreqStore.prs[0].TargetBranch)
+		assert.Empty(t, activityStore.payloads)
+	})
+
+	t.Run("unknown branch", func(t *testing.T) {
+		s, pullreqStore, _ := newStackTestService(t,
+			[]*types.PullReq{stackPullReq(2, "b", "a")}, &stackGit{}, nil)
+
+		_, err := s.ChangeTargetBranch(ctx, repo, pullreqStore.prs[0], 7, "develop")
+		require.True(t, errors.IsNotFound(err), "unexpected error: %v", err)
+	})
+}
+
+func TestService_RetargetStackedPullReqsOnMerged(t *testing.T) {
+	merged := stackPullReq(1, "a", "main")
+	merged.State = enum.PullReqStateMerged
+
+	tests := []struct {
+		name         string
+		rebase       bool
+		conflicts    []string
+		wantMerges   int
+		wantConflict bool
+	}{
+		{
+			name: "retarget only",
+		},
+		{
+			name:       "retarget and rebase",
+			rebase:     true,
+			wantMerges: 1,
+		},
+		{
+			name:         "rebase conflict",
+			rebase:       true,
+			conflicts:    []string{"a.txt"},
+			wantMerges:   1,
+			wantConflict: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mergedCopy := *merged
+			prs := []*types.PullReq{
+				&mergedCopy,
+				stackPullReq(2, "b", "a"),
+				stackPullReq(3, "main", "a"), // its source is the new target, so it's left alone
+				stackPullReq(4, "c", "b"),
+			}
+
+			gitFake := &stackGit{
+				branches:  map[string]sha.SHA{"main": stackTestMainSHA},
+				mergeBase: stackTestBaseSHA,
+				conflicts: test.conflicts,
+			}
+
+			settingValues := map[string]json.RawMessage{}
+			if test.rebase {
+				settingValues[string(settings.KeyStackedPullReqRebase)] = json.RawMessage("true")
+			}
+
+			s, pullreqStore, activityStore := newStackTestService(t, prs, gitFake, settingValues)
+
+			err := s.retargetStackedPullReqsOnMerged(context.Background(), &events.Event[*pullreqevents.MergedPayload]{
+				ID:        "event-1",
+				Timestamp: time.Now(),
+				Payload: &pullreqevents.MergedPayload{
+					Base: pullreqevents.Base{PullReqID: 1, TargetRepoID: stackTestRepoID, PrincipalID: 7},
+				},
+			})
+			require.NoError(t, err)
+
+			targets := map[int64]string{}
+			for _, pr := range pullreqStore.prs {
+				targets[pr.ID] = pr.TargetBranch
+			}
+			assert.Equal(t, map[int64]string{1: "main", 2: "main", 3: "a", 4: "b"}, targets)
+
+			require.Len(t, gitFake.merges, test.wantMerges)
+			if test.wantMerges > 0 {
+				assert.Equal(t, "b", gitFake.merges[0].HeadBranch)
+				assert.Equal(t, stackTestMainSHA, gitFake.merges[0].BaseSHA)
+				assert.Equal(t, stackTestSourceSHA, gitFake.merges[0].HeadBranchExpectedSHA)
+				assert.Equal(t, "User", gitFake.merges[0].Actor.Name)
+			}
+
+			payloads := activityStore.payloads[2]
+			if !test.wantConflict {
+				assert.Len(t, payloads, 1, "only the target branch change is expected")
+				return
+			}
+
+			require.Len(t, payloads, 2)
+			assert.Equal(t, &types.PullRequestActivityPayloadStackRebaseConflict{
+				TargetBranch:  "main",
+				ConflictFiles: []string{"a.txt"},
+			}, payloads[1])
+		})
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pullreq
+
+import (
+	"context"
+	"fmt"
+
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git"
+	gitenum "github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/rs/zerolog/log"
+)
+
+// ChangeTargetBranch changes the target branch of the pull request. It updates the merge base and the diff stats,
+// writes an activity entry and triggers the pull request Target Branch Changed event.
+func (s *Service) ChangeTargetBranch(
+	ctx context.Context,
+	repo *types.RepositoryCore,
+	pr *types.PullReq,
+	principalID int64,
+	branchName string,
+) (*types.PullReq, error) {
+	readParams := git.CreateReadParams(repo)
+
+	targetRef, err := s.git.GetRef(ctx, git.GetRefParams{
+		ReadParams: readParams,
+		Name:       branchName,
+		Type:       gitenum.RefTypeBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve target branch reference: %w", err)
+	}
+
+	targetSHA := targetRef.SHA
+
+	mergeBase, err := s.git.MergeBase(ctx, git.MergeBaseParams{
+		ReadParams: readParams,
+		Ref1:       pr.SourceSHA,
+		Ref2:       targetSHA.String(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base: %w", err)
+	}
+
+	if mergeBase.MergeBaseSHA.String() == pr.SourceSHA {
+		return nil, errors.InvalidArgument("The source branch doesn't contain any new commits")
+	}
+
+	diffStats, err := s.git.DiffStats(ctx, &git.DiffParams{
+		ReadParams: readParams,
+		BaseRef:    mergeBase.MergeBaseSHA.String(),
+		HeadRef:    pr.SourceSHA,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed get diff stats: %w", err)
+	}
+
+	oldTargetBranch := pr.TargetBranch
+	oldMergeBaseSHA := pr.MergeBaseSHA
+
+	pr, err = s.pullreqStore.UpdateOptLock(ctx, pr, func(pr *types.PullReq) error {
+		pr.MergeSHA = nil
+		pr.MarkAsMergeUnchecked()
+
+		pr.MergeBaseSHA = mergeBase.MergeBaseSHA.String()
+		pr.MergeTargetSHA = ptr.String(targetSHA.String())
+		pr.TargetBranch = branchName
+		pr.Stats.DiffStats = types.NewDiffStats(
+			diffStats.Commits,
+			diffStats.FilesChanged,
+			diffStats.Additions,
+			diffStats.Deletions,
+		)
+
+		pr.ActivitySeq++
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update PR target branch in db with error: %w", err)
+	}
+
+	_, err = s.activityStore.CreateWithPayload(
+		ctx, pr, principalID,
+		&types.PullRequestActivityPayloadBranchChangeTarget{
+			Old: oldTargetBranch,
+			New: branchName,
+		},
+		nil,
+	)
+	if err != nil {
+		// non-critical error
+		log.Ctx(ctx).Err(err).Msgf("failed to write pull request activity for target branch change")
+	}
+
+	s.pullreqEvReporter.TargetBranchChanged(ctx, &pullreqevents.TargetBranchChangedPayload{
+		Base: pullreqevents.Base{
+			PullReqID:    pr.ID,
+			SourceRepoID: pr.SourceRepoID,
+			TargetRepoID: pr.TargetRepoID,
+			PrincipalID:  principalID,
+			Number:       pr.Number,
+		},
+		SourceSHA:       pr.SourceSHA,
+		OldTargetBranch: oldTargetBranch,
+		NewTargetBranch: branchName,
+		OldMergeBaseSHA: oldMergeBaseSHA,
+		NewMergeBaseSHA: mergeBase.MergeBaseSHA.String(),
+	})
+
+	s.sseStreamer.Publish(ctx, repo.ParentID, enum.SSETypePullReqUpdated, pr)
+
+	return pr, nil
+}
@@ -22,8 +22,10 @@ import (
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -58,6 +60,7 @@ func ProvideService(ctx context.Context,
 	pubsub pubsub.PubSub,
 	urlProvider url.Provider,
 	sseStreamer sse.Streamer,
+	settings *settings.Service,
 ) (*Service, error) {
 	return New(ctx,
 		config,
@@ -76,6 +79,7 @@ func ProvideService(ctx context.Context,
 		pubsub,
 		urlProvider,
 		sseStreamer,
+		settings,
 	)
 }
 
@@ -89,6 +93,9 @@ func ProvideListService(
 	repoFinder refcache.RepoFinder,
 	labelSvc *label.Service,
 	protectionManager *protection.Manager,
+	assigneeStore store.PullReqAssigneeStore,
+	principalInfoCache store.PrincipalInfoCache,
+	milestoneSvc *milestone.Service,
 ) *ListService {
 	return NewListService(
 		tx,
@@ -100,5 +107,8 @@ func ProvideListService(
 		repoFinder,
 		labelSvc,
 		protectionManager,
+		assigneeStore,
+		principalInfoCache,
+		milestoneSvc,
 	)
 }
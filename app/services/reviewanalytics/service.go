@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewanalytics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/types"
+)
+
+// Service computes pull request review metrics.
+type Service struct {
+	analyticsStore     store.ReviewAnalyticsStore
+	principalInfoCache store.PrincipalInfoCache
+}
+
+func NewService(
+	analyticsStore store.ReviewAnalyticsStore,
+	principalInfoCache store.PrincipalInfoCache,
+) *Service {
+	return &Service{
+		analyticsStore:     analyticsStore,
+		principalInfoCache: principalInfoCache,
+	}
+}
+
+// Compute returns review metrics of all pull requests matching the filter.
+func (s *Service) Compute(
+	ctx context.Context,
+	filter *types.ReviewAnalyticsFilter,
+) (*types.ReviewAnalytics, error) {
+	prStats, err := s.analyticsStore.ListPullReqStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pull request review stats: %w", err)
+	}
+
+	reviewerStats, err := s.analyticsStore.ListReviewerStats(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reviewer stats: %w", err)
+	}
+
+	reviewerIDs := make([]int64, len(reviewerStats))
+	for i := range reviewerStats {
+		reviewerIDs[i] = reviewerStats[i].PrincipalID
+	}
+
+	reviewerInfos, err := s.principalInfoCache.Map(ctx, reviewerIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load reviewer infos: %w", err)
+	}
+
+	analytics := computePullReqAnalytics(prStats)
+
+	analytics.ReviewerLoad = make([]types.ReviewerLoad, 0, len(reviewerStats))
+	for _, stat := range reviewerStats {
+		reviewer, ok := reviewerInfos[stat.PrincipalID]
+		if !ok {
+			continue
+		}
+
+		analytics.ReviewerLoad = append(analytics.ReviewerLoad, types.ReviewerLoad{
+			Reviewer:        reviewer,
+			Reviews:         stat.Reviews,
+			Approvals:       stat.Approvals,
+			ChangeRequests:  stat.ChangeRequests,
+			PendingRequests: stat.PendingRequests,
+		})
+	}
+
+	return analytics, nil
+}
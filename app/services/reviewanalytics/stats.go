@@ -0,0 +1,130 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewanalytics
+
+import (
+	"sort"
+
+	"github.com/harness/gitness/types"
+)
+
+// sizeBuckets defines the pull request size distribution buckets, by the number of changed lines.
+var sizeBuckets = []types.PullReqSizeBucket{
+	{Name: "xs", MaxLines: 10},
+	{Name: "s", MaxLines: 100},
+	{Name: "m", MaxLines: 500},
+	{Name: "l", MaxLines: 1000},
+	{Name: "xl", MaxLines: 0},
+}
+
+func computePullReqAnalytics(prStats []types.PullReqReviewStats) *types.ReviewAnalytics {
+	var (
+		timesToFirstReview []int64
+		timesToApproval    []int64
+		timesToMerge       []int64
+		reviewRounds       []int64
+	)
+
+	buckets := make([]types.PullReqSizeBucket, len(sizeBuckets))
+	copy(buckets, sizeBuckets)
+
+	analytics := &types.ReviewAnalytics{
+		PullReqCount: int64(len(prStats)),
+	}
+
+	for _, stat := range prStats {
+		if stat.FirstReview != nil {
+			timesToFirstReview = append(timesToFirstReview, *stat.FirstReview-stat.Created)
+			reviewRounds = append(reviewRounds, stat.ReviewRounds)
+		}
+
+		if stat.FirstApproval != nil {
+			timesToApproval = append(timesToApproval, *stat.FirstApproval-stat.Created)
+		}
+
+		if stat.Merged != nil {
+			analytics.MergedCount++
+			timesToMerge = append(timesToMerge, *stat.Merged-stat.Created)
+		}
+
+		if stat.Additions != nil && stat.Deletions != nil {
+			bucketAdd(buckets, *stat.Additions+*stat.Deletions)
+		}
+	}
+
+	analytics.TimeToFirstReview = durationStats(timesToFirstReview)
+	analytics.TimeToApproval = durationStats(timesToApproval)
+	analytics.TimeToMerge = durationStats(timesToMerge)
+	analytics.ReviewRounds = countStats(reviewRounds)
+	analytics.SizeDistribution = buckets
+
+	return analytics
+}
+
+func bucketAdd(buckets []types.PullReqSizeBucket, lines int64) {
+	for i := range buckets {
+		if buckets[i].MaxLines == 0 || lines <= buckets[i].MaxLines {
+			buckets[i].Count++
+			return
+		}
+	}
+}
+
+func durationStats(values []int64) types.DurationStats {
+	if len(values) == 0 {
+		return types.DurationStats{}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	return types.DurationStats{
+		Count:   int64(len(values)),
+		Average: sum(values) / int64(len(values)),
+		Median:  percentile(values, 50),
+		P90:     percentile(values, 90),
+	}
+}
+
+func countStats(values []int64) types.CountStats {
+	if len(values) == 0 {
+		return types.CountStats{}
+	}
+
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+
+	return types.CountStats{
+		Count:   int64(len(values)),
+		Average: float64(sum(values)) / float64(len(values)),
+		Median:  percentile(values, 50),
+		Max:     values[len(values)-1],
+	}
+}
+
+func sum(values []int64) int64 {
+	var total int64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+// percentile returns the nearest-rank percentile p of the sorted values.
+func percentile(sorted []int64, p int) int64 {
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
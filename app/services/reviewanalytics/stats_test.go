@@ -0,0 +1,110 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reviewanalytics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/harness/gitness/types"
+)
+
+func ptr(v int64) *int64 { return &v }
+
+func TestDurationStats(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []int64
+		exp    types.DurationStats
+	}{
+		{
+			name:   "empty",
+			values: nil,
+			exp:    types.DurationStats{},
+		},
+		{
+			name:   "single",
+			values: []int64{5},
+			exp:    types.DurationStats{Count: 1, Average: 5, Median: 5, P90: 5},
+		},
+		{
+			name:   "unsorted",
+			values: []int64{10, 1, 9, 2, 8, 3, 7, 4, 6, 5},
+			exp:    types.DurationStats{Count: 10, Average: 5, Median: 5, P90: 9},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := durationStats(test.values); got != test.exp {
+				t.Errorf("want=%+v got=%+v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestComputePullReqAnalytics(t *testing.T) {
+	prStats := []types.PullReqReviewStats{
+		{
+			PullReqID:     1,
+			Created:       1000,
+			Merged:        ptr(9000),
+			FirstReview:   ptr(2000),
+			FirstApproval: ptr(5000),
+			ReviewRounds:  2,
+			Additions:     ptr(4),
+			Deletions:     ptr(6),
+		},
+		{
+			PullReqID:    2,
+			Created:      1000,
+			FirstReview:  ptr(4000),
+			ReviewRounds: 1,
+			Additions:    ptr(300),
+			Deletions:    ptr(300),
+		},
+		{
+			PullReqID: 3,
+			Created:   1000,
+			Additions: ptr(5000),
+			Deletions: ptr(0),
+		},
+		{
+			PullReqID: 4,
+			Created:   1000,
+		},
+	}
+
+	got := computePullReqAnalytics(prStats)
+
+	exp := &types.ReviewAnalytics{
+		PullReqCount:      4,
+		MergedCount:       1,
+		TimeToFirstReview: types.DurationStats{Count: 2, Average: 2000, Median: 1000, P90: 3000},
+		TimeToApproval:    types.DurationStats{Count: 1, Average: 4000, Median: 4000, P90: 4000},
+		TimeToMerge:       types.DurationStats{Count: 1, Average: 8000, Median: 8000, P90: 8000},
+		ReviewRounds:      types.CountStats{Count: 2, Average: 1.5, Median: 1, Max: 2},
+		SizeDistribution: []types.PullReqSizeBucket{
+			{Name: "xs", MaxLines: 10, Count: 1},
+			{Name: "s", MaxLines: 100, Count: 0},
+			{Name: "m", MaxLines: 500, Count: 0},
+			{Name: "l", MaxLines: 1000, Count: 1},
+			{Name: "xl", MaxLines: 0, Count: 1},
+		},
+	}
+
+	if !reflect.DeepEqual(got, exp) {
+		t.Errorf("want=%+v got=%+v", exp, got)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/harness/gitness/app/api/usererror"
+	ruleevents "github.com/harness/gitness/app/events/rule"
 	"github.com/harness/gitness/app/paths"
 	"github.com/harness/gitness/app/services/protection"
 	"github.com/harness/gitness/audit"
@@ -185,5 +186,14 @@ func (s *Service) Update(ctx context.Context,
 
 	s.sendSSE(ctx, parentID, parentType, enum.SSETypeRuleUpdated, rule)
 
+	s.eventReporter.Updated(ctx, &ruleevents.UpdatedPayload{
+		Base: ruleevents.Base{
+			RuleID:      rule.ID,
+			SpaceID:     rule.SpaceID,
+			RepoID:      rule.RepoID,
+			PrincipalID: principal.ID,
+		},
+	})
+
 	return rule, nil
 }
@@ -79,6 +79,34 @@ func (s *Service) Set(
 	return nil
 }
 
+// SetIfAbsent sets the value of the setting with the given key for the given scope,
+// unless the setting already has a value. It returns true if the value was set.
+func (s *Service) SetIfAbsent(
+	ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key Key,
+	value any,
+) (bool, error) {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal setting value: %w", err)
+	}
+
+	inserted, err := s.settingsStore.InsertIfAbsent(
+		ctx,
+		scope,
+		scopeID,
+		string(key),
+		raw,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to insert setting in store: %w", err)
+	}
+
+	return inserted, nil
+}
+
 // SetMany sets the value of the settings with the given keys for the given scope.
 func (s *Service) SetMany(
 	ctx context.Context,
@@ -48,6 +48,22 @@ func (s *Service) SystemSetMany(
 	)
 }
 
+// SystemSetIfAbsent sets the value of the setting with the given key for the system,
+// unless the setting already has a value. It returns true if the value was set.
+func (s *Service) SystemSetIfAbsent(
+	ctx context.Context,
+	key Key,
+	value any,
+) (bool, error) {
+	return s.SetIfAbsent(
+		ctx,
+		enum.SettingsScopeSystem,
+		0,
+		key,
+		value,
+	)
+}
+
 // SystemGet returns the value of the setting with the given key for the system.
 func (s *Service) SystemGet(
 	ctx context.Context,
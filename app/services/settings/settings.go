@@ -30,4 +30,11 @@ var (
 	DefaultGitLFSEnabled               = true
 	KeyAutoMergeEnabled            Key = "auto_merge_enabled"
 	DefaultAutoMergeEnabled            = false
+	// KeyStackedPullReqRebase [bool] rebases pull requests that were stacked on top of a merged pull request
+	// onto the new target branch, after they have been automatically retargeted.
+	KeyStackedPullReqRebase     Key = "stacked_pullreq_rebase"
+	DefaultStackedPullReqRebase     = false
+	// KeyWebhookSigningKey [string] is the encrypted seed of the Ed25519 key used to sign webhook requests.
+	KeyWebhookSigningKey     Key = "webhook_signing_key"
+	DefaultWebhookSigningKey     = string("")
 )
@@ -0,0 +1,380 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	"github.com/harness/gitness/app/api/usererror"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+)
+
+func (s *Service) handleEventCommentCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.CommentCreatedPayload],
+) error {
+	comment, err := s.activityStore.Find(ctx, event.Payload.ActivityID)
+	if err != nil {
+		return fmt.Errorf("failed to find comment: %w", err)
+	}
+
+	if comment.Deleted != nil || comment.Kind == enum.PullReqActivityKindSystem {
+		return nil
+	}
+
+	commands := parseCommands(comment.Text)
+	if len(commands) == 0 {
+		return nil
+	}
+
+	author, err := s.principalStore.Find(ctx, comment.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to find comment author: %w", err)
+	}
+
+	session := &auth.Session{Principal: *author}
+
+	for _, cmd := range commands {
+		pr, err := s.pullreqStore.Find(ctx, event.Payload.PullReqID)
+		if err != nil {
+			return fmt.Errorf("failed to find pull request: %w", err)
+		}
+
+		targetRepo, err := s.repoFinder.FindByID(ctx, pr.TargetRepoID)
+		if err != nil {
+			return fmt.Errorf("failed to find target repository: %w", err)
+		}
+
+		message, err := s.execute(ctx, session, targetRepo, pr, cmd)
+
+		s.respond(ctx, pr, comment.ID, cmd, message, err)
+	}
+
+	return nil
+}
+
+// execute runs the command as the comment author. All authorization checks are done by the controllers.
+func (s *Service) execute(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	cmd command,
+) (string, error) {
+	switch cmd.Name {
+	case commandApprove:
+		return s.approve(ctx, session, targetRepo, pr)
+	case commandMerge:
+		return s.merge(ctx, session, targetRepo, pr, cmd.Args)
+	case commandLabel:
+		return s.label(ctx, session, targetRepo, pr, cmd.Args)
+	case commandAssign:
+		return s.assign(ctx, session, targetRepo, pr, cmd.Args)
+	case commandRetest:
+		return s.retest(ctx, session, targetRepo, pr, cmd.Args)
+	case commandRebase:
+		return s.rebase(ctx, session, targetRepo, pr)
+	}
+
+	return "", usererror.BadRequestf("Unknown command %q.", cmd.Name)
+}
+
+// respond writes a system activity with the outcome of the command.
+func (s *Service) respond(
+	ctx context.Context,
+	pr *types.PullReq,
+	commentID int64,
+	cmd command,
+	message string,
+	cmdErr error,
+) {
+	if cmdErr != nil {
+		uErr := usererror.Translate(ctx, cmdErr)
+		message = uErr.Message
+		if uErr.Status >= http.StatusInternalServerError {
+			log.Ctx(ctx).Warn().Err(cmdErr).Msgf("failed to execute slash command %q", cmd.Raw)
+			message = "Failed to execute the command."
+		}
+	}
+
+	payload := &types.PullRequestActivityPayloadCommand{
+		CommentID: commentID,
+		Command:   cmd.Raw,
+		Success:   cmdErr == nil,
+		Message:   message,
+	}
+
+	pr, err := s.pullreqStore.UpdateActivitySeq(ctx, pr)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to update pull request activity sequence")
+		return
+	}
+
+	systemPrincipal := bootstrap.NewSystemServiceSession().Principal
+	if _, err = s.activityStore.CreateWithPayload(ctx, pr, systemPrincipal.ID, payload, nil); err != nil {
+		log.Ctx(ctx).Err(err).Msg("failed to write pull request activity for slash command")
+	}
+}
+
+func (s *Service) approve(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+) (string, error) {
+	_, err := s.pullreqCtrl.ReviewSubmit(ctx, session, targetRepo.Path, pr.Number, &pullreq.ReviewSubmitInput{
+		CommitSHA: pr.SourceSHA,
+		Decision:  enum.PullReqReviewDecisionApproved,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Approved by %s.", session.Principal.DisplayName), nil
+}
+
+func (s *Service) merge(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	args []string,
+) (string, error) {
+	if len(args) > 1 {
+		return "", usererror.BadRequest("Usage: /merge [merge|squash|rebase|fast-forward]")
+	}
+
+	method := enum.MergeMethodMerge
+	if len(args) == 1 {
+		var ok bool
+		if method, ok = enum.MergeMethod(strings.ToLower(args[0])).Sanitize(); !ok {
+			return "", usererror.BadRequestf("Unsupported merge method %q.", args[0])
+		}
+	}
+
+	out, violations, err := s.pullreqCtrl.Merge(ctx, session, targetRepo.Path, pr.Number, &pullreq.MergeInput{
+		Method:    method,
+		SourceSHA: pr.SourceSHA,
+	})
+	if err != nil {
+		return "", err
+	}
+	if violations != nil {
+		return "", violationsError(violations.Message, "The pull request can't be merged.")
+	}
+
+	return fmt.Sprintf("Merged using the %s method as commit %s.", method, out.SHA), nil
+}
+
+func (s *Service) label(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	args []string,
+) (string, error) {
+	const usage = "Usage: /label add|remove <label> [value]"
+
+	if len(args) < 2 {
+		return "", usererror.BadRequest(usage)
+	}
+
+	lbl, err := s.findLabel(ctx, targetRepo, args[1])
+	if err != nil {
+		return "", err
+	}
+
+	switch strings.ToLower(args[0]) {
+	case "add":
+		_, err = s.pullreqCtrl.AssignLabel(ctx, session, targetRepo.Path, pr.Number, &types.PullReqLabelAssignInput{
+			LabelID: lbl.ID,
+			Value:   strings.Join(args[2:], " "),
+		})
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Label %q added.", lbl.Key), nil
+	case "remove":
+		if len(args) > 2 {
+			return "", usererror.BadRequest(usage)
+		}
+
+		if err = s.pullreqCtrl.UnassignLabel(ctx, session, targetRepo.Path, pr.Number, lbl.ID); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Label %q removed.", lbl.Key), nil
+	}
+
+	return "", usererror.BadRequest(usage)
+}
+
+// findLabel finds the label by key in the repository or in the closest ancestor space that defines it.
+func (s *Service) findLabel(
+	ctx context.Context,
+	targetRepo *types.RepositoryCore,
+	key string,
+) (*types.Label, error) {
+	lbl, err := s.labelSvc.Find(ctx, nil, &targetRepo.ID, key)
+	if err == nil {
+		return lbl, nil
+	}
+	if !errors.Is(err, store.ErrResourceNotFound) {
+		return nil, fmt.Errorf("failed to find repository label: %w", err)
+	}
+
+	spaceIDs, err := s.spaceStore.GetAncestorIDs(ctx, targetRepo.ParentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get parent space ids: %w", err)
+	}
+
+	for _, spaceID := range spaceIDs {
+		lbl, err = s.labelSvc.Find(ctx, &spaceID, nil, key)
+		if err == nil {
+			return lbl, nil
+		}
+		if !errors.Is(err, store.ErrResourceNotFound) {
+			return nil, fmt.Errorf("failed to find space label: %w", err)
+		}
+	}
+
+	return nil, usererror.NotFoundf("Label %q not found.", key)
+}
+
+func (s *Service) assign(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	args []string,
+) (string, error) {
+	if len(args) != 1 {
+		return "", usererror.BadRequest("Usage: /assign @user")
+	}
+
+	uid := strings.TrimPrefix(args[0], "@")
+
+	assignee, err := s.principalStore.FindByUID(ctx, uid)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", usererror.NotFoundf("User %q not found.", uid)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find assignee: %w", err)
+	}
+
+	_, err = s.pullreqCtrl.AssigneeAdd(ctx, session, targetRepo.Path, pr.Number, &pullreq.AssigneeAddInput{
+		AssigneeID: assignee.ID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Assigned to %s.", assignee.DisplayName), nil
+}
+
+func (s *Service) retest(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+	args []string,
+) (string, error) {
+	if len(args) != 1 {
+		return "", usererror.BadRequest("Usage: /retest <check>")
+	}
+
+	identifier := args[0]
+
+	if pr.SourceRepoID == nil || *pr.SourceRepoID != pr.TargetRepoID {
+		return "", usererror.BadRequest("Checks of pull requests from forked repositories can't be re-run.")
+	}
+
+	check, err := s.checkStore.FindByIdentifier(ctx, targetRepo.ID, pr.SourceSHA, identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", usererror.NotFoundf("Check %q not found for the latest commit.", identifier)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find check: %w", err)
+	}
+
+	if check.Payload.Kind != enum.CheckPayloadKindPipeline {
+		return "", usererror.BadRequestf("Check %q isn't reported by a pipeline and can't be re-run.", identifier)
+	}
+
+	execution, err := s.executionCtrl.Create(ctx, session, targetRepo.Path, identifier, pr.SourceBranch)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("Started execution #%d of pipeline %q.", execution.Number, identifier), nil
+}
+
+func (s *Service) rebase(
+	ctx context.Context,
+	session *auth.Session,
+	targetRepo *types.RepositoryCore,
+	pr *types.PullReq,
+) (string, error) {
+	if pr.SourceRepoID == nil || *pr.SourceRepoID != pr.TargetRepoID {
+		return "", usererror.BadRequest("Pull requests from forked repositories can't be rebased.")
+	}
+
+	headSHA, err := sha.New(pr.SourceSHA)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse source SHA: %w", err)
+	}
+
+	out, violations, err := s.repoCtrl.Rebase(ctx, session, targetRepo.Path, &repo.RebaseInput{
+		BaseBranch:    pr.TargetBranch,
+		HeadBranch:    pr.SourceBranch,
+		HeadCommitSHA: headSHA,
+	})
+	if err != nil {
+		return "", err
+	}
+	if violations != nil {
+		return "", violationsError(violations.Message, "The branch can't be rebased.")
+	}
+
+	if out.AlreadyAncestor {
+		return fmt.Sprintf("Branch %s is already up to date with %s.", pr.SourceBranch, pr.TargetBranch), nil
+	}
+
+	return fmt.Sprintf("Rebased branch %s onto %s.", pr.SourceBranch, pr.TargetBranch), nil
+}
+
+func violationsError(message, fallback string) error {
+	if message == "" {
+		message = fallback
+	}
+
+	return usererror.BadRequest(message)
+}
@@ -0,0 +1,429 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/service"
+	"github.com/harness/gitness/app/auth"
+	"github.com/harness/gitness/app/auth/authz"
+	"github.com/harness/gitness/app/bootstrap"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/store/cache"
+	"github.com/harness/gitness/events"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testSystemID    = int64(1)
+	testAuthorID    = int64(7)
+	testCommentID   = int64(50)
+	testPullReqID   = int64(60)
+	testRepoID      = int64(10)
+	testSpaceID     = int64(2)
+	testRootSpaceID = int64(1)
+	testSourceSHA   = "0123456789abcdef0123456789abcdef01234567"
+)
+
+var setupSystemServiceOnce sync.Once
+
+// setupSystemService bootstraps the system service principal the command replies are written with.
+func setupSystemService(t *testing.T) {
+	t.Helper()
+
+	setupSystemServiceOnce.Do(func() {
+		config := &types.Config{}
+		config.Principal.System.UID = "gitness"
+
+		serviceCtrl := service.NewController(nil, nil, &slashPrincipalStore{})
+		if err := bootstrap.SystemService(context.Background(), config, serviceCtrl); err != nil {
+			t.Fatalf("failed to setup system service: %s", err)
+		}
+	})
+}
+
+func newTestService(t *testing.T) (*Service, *slashActivityStore) {
+	t.Helper()
+
+	setupSystemService(t)
+
+	activityStore := &slashActivityStore{}
+	repoFinder := refcache.NewRepoFinder(nil, slashSpacePathCache{}, slashRepoIDCache{}, slashRepoRefCache{},
+		cache.Evictor[*types.RepositoryCore]{})
+
+	// the controller is only needed up to the access check, which always fails.
+	pullreqCtrl := pullreq.NewController(nil, nil, slashAuthorizer{}, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, repoFinder, nil, nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
+
+	return &Service{
+		repoFinder:     repoFinder,
+		spaceStore:     slashSpaceStore{},
+		pullreqStore:   slashPullReqStore{},
+		activityStore:  activityStore,
+		principalStore: &slashPrincipalStore{},
+		checkStore:     slashCheckStore{},
+		labelSvc:       label.New(nil, nil, slashLabelStore{}, nil, nil, refcache.SpaceFinder{}),
+		pullreqCtrl:    pullreqCtrl,
+	}, activityStore
+}
+
+func testPullReq(forked bool) *types.PullReq {
+	sourceRepoID := testRepoID
+	if forked {
+		sourceRepoID = testRepoID + 1
+	}
+
+	return &types.PullReq{
+		ID:           testPullReqID,
+		Number:       1,
+		SourceRepoID: &sourceRepoID,
+		SourceBranch: "feature",
+		SourceSHA:    testSourceSHA,
+		TargetRepoID: testRepoID,
+		TargetBranch: "main",
+	}
+}
+
+func TestService_HandleEventCommentCreated(t *testing.T) {
+	s, activityStore := newTestService(t)
+
+	err := s.handleEventCommentCreated(context.Background(), &events.Event[*pullreqevents.CommentCreatedPayload]{
+		ID: "1-0",
+		Payload: &pullreqevents.CommentCreatedPayload{
+			Base:       pullreqevents.Base{PullReqID: testPullReqID, TargetRepoID: testRepoID},
+			ActivityID: testCommentID,
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to handle event: %s", err)
+	}
+
+	// the access check of the comment author failed, the error is the reply to the command.
+	exp := []slashReply{{
+		principalID: testSystemID,
+		payload: &types.PullRequestActivityPayloadCommand{
+			CommentID: testCommentID,
+			Command:   "/approve",
+			Success:   false,
+			Message:   "Forbidden",
+		},
+	}}
+	if !equalReplies(activityStore.replies, exp) {
+		t.Errorf("expected replies %+v, got %+v", exp, activityStore.replies)
+	}
+}
+
+func TestService_Execute(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		forked bool
+		exp    string
+	}{
+		{
+			name: "unknown-command",
+			raw:  "/deploy",
+			exp:  `Unknown command "deploy".`,
+		},
+		{
+			name: "merge-too-many-args",
+			raw:  "/merge squash now",
+			exp:  "Usage: /merge [merge|squash|rebase|fast-forward]",
+		},
+		{
+			name: "merge-unsupported-method",
+			raw:  "/merge octopus",
+			exp:  `Unsupported merge method "octopus".`,
+		},
+		{
+			name: "label-missing-args",
+			raw:  "/label add",
+			exp:  "Usage: /label add|remove <label> [value]",
+		},
+		{
+			name: "label-unknown",
+			raw:  "/label add missing",
+			exp:  `Label "missing" not found.`,
+		},
+		{
+			name: "label-unknown-action",
+			raw:  "/label toggle bug",
+			exp:  "Usage: /label add|remove <label> [value]",
+		},
+		{
+			name: "label-remove-with-value",
+			raw:  "/label remove bug high",
+			exp:  "Usage: /label add|remove <label> [value]",
+		},
+		{
+			name: "label-of-space-forbidden",
+			raw:  "/label add docs",
+			exp:  "Forbidden",
+		},
+		{
+			name: "assign-missing-user",
+			raw:  "/assign",
+			exp:  "Usage: /assign @user",
+		},
+		{
+			name: "assign-unknown-user",
+			raw:  "/assign @nobody",
+			exp:  `User "nobody" not found.`,
+		},
+		{
+			name: "assign-forbidden",
+			raw:  "/assign @jane",
+			exp:  "Forbidden",
+		},
+		{
+			name: "retest-missing-check",
+			raw:  "/retest",
+			exp:  "Usage: /retest <check>",
+		},
+		{
+			name:   "retest-forked",
+			raw:    "/retest build",
+			forked: true,
+			exp:    "Checks of pull requests from forked repositories can't be re-run.",
+		},
+		{
+			name: "retest-unknown-check",
+			raw:  "/retest lint",
+			exp:  `Check "lint" not found for the latest commit.`,
+		},
+		{
+			name: "retest-external-check",
+			raw:  "/retest external",
+			exp:  `Check "external" isn't reported by a pipeline and can't be re-run.`,
+		},
+		{
+			name:   "rebase-forked",
+			raw:    "/rebase",
+			forked: true,
+			exp:    "Pull requests from forked repositories can't be rebased.",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, activityStore := newTestService(t)
+			ctx := context.Background()
+
+			// build the command directly, as unknown commands aren't parsed.
+			fields := strings.Fields(test.raw)
+			cmd := command{Name: strings.TrimPrefix(fields[0], "/"), Args: fields[1:], Raw: test.raw}
+
+			session := &auth.Session{Principal: types.Principal{ID: testAuthorID, DisplayName: "Jane Doe"}}
+			targetRepo, err := s.repoFinder.FindByID(ctx, testRepoID)
+			if err != nil {
+				t.Fatalf("failed to find repo: %s", err)
+			}
+			pr := testPullReq(test.forked)
+
+			message, err := s.execute(ctx, session, targetRepo, pr, cmd)
+			if err == nil {
+				t.Fatalf("expected command to fail, got %q", message)
+			}
+
+			s.respond(ctx, pr, testCommentID, cmd, message, err)
+
+			exp := []slashReply{{
+				principalID: testSystemID,
+				payload: &types.PullRequestActivityPayloadCommand{
+					CommentID: testCommentID,
+					Command:   test.raw,
+					Success:   false,
+					Message:   test.exp,
+				},
+			}}
+			if !equalReplies(activityStore.replies, exp) {
+				t.Errorf("expected replies %+v, got %+v", exp, activityStore.replies)
+			}
+		})
+	}
+}
+
+type slashReply struct {
+	principalID int64
+	payload     types.PullReqActivityPayload
+}
+
+func equalReplies(got, exp []slashReply) bool {
+	if len(got) != len(exp) {
+		return false
+	}
+
+	for i := range got {
+		gotPayload, ok := got[i].payload.(*types.PullRequestActivityPayloadCommand)
+		if !ok || got[i].principalID != exp[i].principalID {
+			return false
+		}
+
+		expPayload, _ := exp[i].payload.(*types.PullRequestActivityPayloadCommand)
+		if *gotPayload != *expPayload {
+			return false
+		}
+	}
+
+	return true
+}
+
+// slashAuthorizer denies all access.
+type slashAuthorizer struct {
+	authz.Authorizer
+}
+
+func (slashAuthorizer) Check(
+	context.Context,
+	*auth.Session,
+	*types.Scope,
+	*types.Resource,
+	enum.Permission,
+) (bool, error) {
+	return false, nil
+}
+
+type slashActivityStore struct {
+	store.PullReqActivityStore
+	replies []slashReply
+}
+
+func (s *slashActivityStore) Find(_ context.Context, id int64) (*types.PullReqActivity, error) {
+	return &types.PullReqActivity{
+		ID:        id,
+		CreatedBy: testAuthorID,
+		Kind:      enum.PullReqActivityKindComment,
+		Type:      enum.PullReqActivityTypeComment,
+		Text:      "Looks good.\n/approve",
+	}, nil
+}
+
+func (s *slashActivityStore) CreateWithPayload(
+	_ context.Context,
+	pr *types.PullReq,
+	principalID int64,
+	payload types.PullReqActivityPayload,
+	_ *types.PullReqActivityMetadata,
+) (*types.PullReqActivity, error) {
+	s.replies = append(s.replies, slashReply{principalID: principalID, payload: payload})
+	return &types.PullReqActivity{PullReqID: pr.ID, CreatedBy: principalID}, nil
+}
+
+type slashPullReqStore struct {
+	store.PullReqStore
+}
+
+func (slashPullReqStore) Find(context.Context, int64) (*types.PullReq, error) {
+	return testPullReq(false), nil
+}
+
+func (slashPullReqStore) UpdateActivitySeq(_ context.Context, pr *types.PullReq) (*types.PullReq, error) {
+	pr.ActivitySeq++
+	return pr, nil
+}
+
+type slashPrincipalStore struct {
+	store.PrincipalStore
+}
+
+func (*slashPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if id != testAuthorID {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.Principal{ID: id, UID: "jane", DisplayName: "Jane Doe", Type: enum.PrincipalTypeUser}, nil
+}
+
+func (s *slashPrincipalStore) FindByUID(ctx context.Context, uid string) (*types.Principal, error) {
+	if uid != "jane" {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return s.Find(ctx, testAuthorID)
+}
+
+func (*slashPrincipalStore) FindServiceByUID(_ context.Context, uid string) (*types.Service, error) {
+	return &types.Service{ID: testSystemID, UID: uid, Admin: true}, nil
+}
+
+type slashSpaceStore struct {
+	store.SpaceStore
+}
+
+func (slashSpaceStore) GetAncestorIDs(context.Context, int64) ([]int64, error) {
+	return []int64{testSpaceID, testRootSpaceID}, nil
+}
+
+// slashLabelStore has the label "bug" defined on the repo and the label "docs" on the root space.
+type slashLabelStore struct {
+	store.LabelStore
+}
+
+func (slashLabelStore) Find(_ context.Context, spaceID, repoID *int64, key string) (*types.Label, error) {
+	switch {
+	case repoID != nil && *repoID == testRepoID && key == "bug":
+		return &types.Label{ID: 1, RepoID: repoID, Key: key}, nil
+	case spaceID != nil && *spaceID == testRootSpaceID && key == "docs":
+		return &types.Label{ID: 2, SpaceID: spaceID, Key: key}, nil
+	}
+	return nil, gitness_store.ErrResourceNotFound
+}
+
+type slashCheckStore struct {
+	store.CheckStore
+}
+
+func (slashCheckStore) FindByIdentifier(_ context.Context, _ int64, _ string, identifier string) (types.Check, error) {
+	if identifier == "external" {
+		return types.Check{Identifier: identifier, Payload: types.CheckPayload{Kind: enum.CheckPayloadKindRaw}}, nil
+	}
+	return types.Check{}, gitness_store.ErrResourceNotFound
+}
+
+type slashSpacePathCache struct {
+	store.SpacePathCache
+}
+
+func (slashSpacePathCache) Get(_ context.Context, path string) (*types.SpacePath, error) {
+	if !strings.EqualFold(path, "root/space") {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return &types.SpacePath{Value: path, IsPrimary: true, SpaceID: testSpaceID}, nil
+}
+
+type slashRepoRefCache struct {
+	store.RepoRefCache
+}
+
+func (slashRepoRefCache) Get(context.Context, types.RepoCacheKey) (int64, error) {
+	return testRepoID, nil
+}
+
+type slashRepoIDCache struct {
+	store.RepoIDCache
+}
+
+func (slashRepoIDCache) Get(_ context.Context, id int64) (*types.RepositoryCore, error) {
+	return &types.RepositoryCore{ID: id, ParentID: testSpaceID, Identifier: "repo", Path: "root/space/repo"}, nil
+}
@@ -0,0 +1,86 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"strings"
+)
+
+const (
+	commandApprove = "approve"
+	commandMerge   = "merge"
+	commandLabel   = "label"
+	commandAssign  = "assign"
+	commandRetest  = "retest"
+	commandRebase  = "rebase"
+
+	// maxCommandsPerComment limits the number of commands processed from a single comment.
+	maxCommandsPerComment = 10
+)
+
+var knownCommands = map[string]struct{}{
+	commandApprove: {},
+	commandMerge:   {},
+	commandLabel:   {},
+	commandAssign:  {},
+	commandRetest:  {},
+	commandRebase:  {},
+}
+
+// command is a single slash command found in a comment.
+type command struct {
+	Name string
+	Args []string
+	Raw  string
+}
+
+// parseCommands extracts slash commands from the comment text.
+// A command must start at the beginning of a line. Lines inside of fenced code blocks
+// and lines starting with an unknown command are ignored.
+func parseCommands(text string) []command {
+	var commands []command
+	inCodeBlock := false
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+
+		if strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~") {
+			inCodeBlock = !inCodeBlock
+			continue
+		}
+
+		if inCodeBlock || !strings.HasPrefix(line, "/") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		name := strings.ToLower(strings.TrimPrefix(fields[0], "/"))
+		if _, ok := knownCommands[name]; !ok {
+			continue
+		}
+
+		commands = append(commands, command{
+			Name: name,
+			Args: fields[1:],
+			Raw:  line,
+		})
+
+		if len(commands) == maxCommandsPerComment {
+			break
+		}
+	}
+
+	return commands
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommands(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []command
+	}{
+		{
+			name: "no commands",
+			text: "LGTM, thanks!",
+			want: nil,
+		},
+		{
+			name: "single command",
+			text: "/approve",
+			want: []command{{Name: "approve", Args: []string{}, Raw: "/approve"}},
+		},
+		{
+			name: "commands with arguments between text",
+			text: "Looks good.\n  /merge squash\nand\n/label add docs\n",
+			want: []command{
+				{Name: "merge", Args: []string{"squash"}, Raw: "/merge squash"},
+				{Name: "label", Args: []string{"add", "docs"}, Raw: "/label add docs"},
+			},
+		},
+		{
+			name: "case insensitive name",
+			text: "/Assign @john",
+			want: []command{{Name: "assign", Args: []string{"@john"}, Raw: "/Assign @john"}},
+		},
+		{
+			name: "unknown command and inline command ignored",
+			text: "/usr/bin/env is used\nplease /rebase",
+			want: nil,
+		},
+		{
+			name: "code block ignored",
+			text: "```\n/approve\n```\n/retest build",
+			want: []command{{Name: "retest", Args: []string{"build"}, Raw: "/retest build"}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := parseCommands(test.text)
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %+v, got %+v", test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/stream"
+	"github.com/harness/gitness/types"
+)
+
+// Service executes slash commands found in pull request comments on behalf of the comment author.
+type Service struct {
+	repoFinder     refcache.RepoFinder
+	spaceStore     store.SpaceStore
+	pullreqStore   store.PullReqStore
+	activityStore  store.PullReqActivityStore
+	principalStore store.PrincipalStore
+	checkStore     store.CheckStore
+	labelSvc       *label.Service
+	pullreqCtrl    *pullreq.Controller
+	repoCtrl       *repo.Controller
+	executionCtrl  *execution.Controller
+}
+
+func New(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoFinder refcache.RepoFinder,
+	spaceStore store.SpaceStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	labelSvc *label.Service,
+	pullreqCtrl *pullreq.Controller,
+	repoCtrl *repo.Controller,
+	executionCtrl *execution.Controller,
+) (*Service, error) {
+	service := &Service{
+		repoFinder:     repoFinder,
+		spaceStore:     spaceStore,
+		pullreqStore:   pullreqStore,
+		activityStore:  activityStore,
+		principalStore: principalStore,
+		checkStore:     checkStore,
+		labelSvc:       labelSvc,
+		pullreqCtrl:    pullreqCtrl,
+		repoCtrl:       repoCtrl,
+		executionCtrl:  executionCtrl,
+	}
+
+	const groupPullReqSlashCommands = "gitness:pullreq:slashcommands"
+	_, err := pullreqEvReaderFactory.Launch(ctx, groupPullReqSlashCommands, config.InstanceID,
+		func(r *pullreqevents.Reader) error {
+			const idleTimeout = time.Minute
+			r.Configure(
+				stream.WithConcurrency(1),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(0),
+				))
+
+			_ = r.RegisterCommentCreated(service.handleEventCommentCreated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return service, nil
+}
@@ -0,0 +1,67 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package slashcommand
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/api/controller/execution"
+	"github.com/harness/gitness/app/api/controller/pullreq"
+	"github.com/harness/gitness/app/api/controller/repo"
+	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	"github.com/harness/gitness/app/services/label"
+	"github.com/harness/gitness/app/services/refcache"
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/types"
+
+	"github.com/google/wire"
+)
+
+var WireSet = wire.NewSet(
+	ProvideService,
+)
+
+func ProvideService(
+	ctx context.Context,
+	config *types.Config,
+	pullreqEvReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	repoFinder refcache.RepoFinder,
+	spaceStore store.SpaceStore,
+	pullreqStore store.PullReqStore,
+	activityStore store.PullReqActivityStore,
+	principalStore store.PrincipalStore,
+	checkStore store.CheckStore,
+	labelSvc *label.Service,
+	pullreqCtrl *pullreq.Controller,
+	repoCtrl *repo.Controller,
+	executionCtrl *execution.Controller,
+) (*Service, error) {
+	return New(
+		ctx,
+		config,
+		pullreqEvReaderFactory,
+		repoFinder,
+		spaceStore,
+		pullreqStore,
+		activityStore,
+		principalStore,
+		checkStore,
+		labelSvc,
+		pullreqCtrl,
+		repoCtrl,
+		executionCtrl,
+	)
+}
@@ -33,6 +33,10 @@ func ExtractBranch(ref string) string {
 
 func (s *Service) handleEventBranchCreated(ctx context.Context,
 	event *events.Event[*gitevents.BranchCreatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionBranchCreated,
@@ -51,6 +55,10 @@ func (s *Service) handleEventBranchCreated(ctx context.Context,
 
 func (s *Service) handleEventBranchUpdated(ctx context.Context,
 	event *events.Event[*gitevents.BranchUpdatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionBranchUpdated,
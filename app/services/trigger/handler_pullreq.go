@@ -27,6 +27,10 @@ import (
 
 func (s *Service) handleEventPullReqCreated(ctx context.Context,
 	event *events.Event[*pullreqevents.CreatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionPullReqCreated,
@@ -57,6 +61,10 @@ func (s *Service) handleEventPullReqReopened(ctx context.Context,
 
 func (s *Service) handleEventPullReqBranchUpdated(ctx context.Context,
 	event *events.Event[*pullreqevents.BranchUpdatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionPullReqBranchUpdated,
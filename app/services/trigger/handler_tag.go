@@ -27,6 +27,10 @@ import (
 
 func (s *Service) handleEventTagCreated(ctx context.Context,
 	event *events.Event[*gitevents.TagCreatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionTagCreated,
@@ -46,6 +50,10 @@ func (s *Service) handleEventTagCreated(ctx context.Context,
 
 func (s *Service) handleEventTagUpdated(ctx context.Context,
 	event *events.Event[*gitevents.TagUpdatedPayload]) error {
+	if event.Payload.SkipCI {
+		return nil
+	}
+
 	hook := &triggerer.Hook{
 		Trigger:     enum.TriggerHook,
 		Action:      enum.TriggerActionTagUpdated,
@@ -0,0 +1,595 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	chatColorDefault = 0x6E7781
+	chatColorSuccess = 0x2DA44E
+	chatColorFailure = 0xCF222E
+	chatColorInfo    = 0x0969DA
+	chatColorMerged  = 0x8250DF
+
+	// chatMaxTitleLength is the maximum length of a message title (limited by discord embeds).
+	chatMaxTitleLength = 256
+	// chatMaxTextLength is the maximum length of a message text (kept short to keep messages readable).
+	chatMaxTextLength = 1000
+)
+
+// chatPayload holds the fields of webhook payloads used to render chat messages.
+// All payloads are built from the same segments, so the fields are decoded from the serialized payload
+// and a single structure covers all triggers (including retriggered and registry payloads).
+type chatPayload struct {
+	Trigger           enum.WebhookTrigger `json:"trigger"`
+	Repo              chatRepo            `json:"repo"`
+	Principal         chatPrincipal       `json:"principal"`
+	Ref               chatRef             `json:"ref"`
+	TargetRef         chatRef             `json:"target_ref"`
+	SHA               string              `json:"sha"`
+	HeadCommit        *chatCommit         `json:"head_commit"`
+	TotalCommitsCount int                 `json:"total_commits_count"`
+	PullReq           *chatPullReq        `json:"pull_req"`
+	Comment           *chatComment        `json:"comment"`
+	Status            string              `json:"status"`
+	Reaction          string              `json:"reaction"`
+	ReviewDecision    string              `json:"review_decision"`
+	Label             *chatLabel          `json:"label"`
+	OldTargetBranch   string              `json:"old_target_branch"`
+	Execution         *chatExecution      `json:"execution"`
+	Check             *chatCheck          `json:"check"`
+	OldPath           string              `json:"old_path"`
+	NewIsPublic       bool                `json:"new_is_public"`
+	Rule              *chatRule           `json:"rule"`
+}
+
+type chatRepo struct {
+	Path string `json:"path"`
+	URL  string `json:"url"`
+}
+
+type chatPrincipal struct {
+	UID         string `json:"uid"`
+	DisplayName string `json:"display_name"`
+}
+
+type chatRef struct {
+	Name string `json:"name"`
+}
+
+type chatCommit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+type chatPullReq struct {
+	Number       int64  `json:"number"`
+	Title        string `json:"title"`
+	SourceBranch string `json:"source_branch"`
+	TargetBranch string `json:"target_branch"`
+	URL          string `json:"pr_url"`
+}
+
+type chatComment struct {
+	Text string `json:"text"`
+}
+
+type chatLabel struct {
+	Key   string  `json:"key"`
+	Value *string `json:"value"`
+}
+
+type chatExecution struct {
+	PipelineIdentifier string `json:"pipeline_identifier"`
+	Number             int64  `json:"number"`
+	Status             string `json:"status"`
+	URL                string `json:"url"`
+}
+
+type chatCheck struct {
+	Identifier string `json:"identifier"`
+	SHA        string `json:"sha"`
+	Status     string `json:"status"`
+	Summary    string `json:"summary"`
+	Link       string `json:"link"`
+}
+
+type chatRule struct {
+	Identifier string `json:"identifier"`
+	Type       string `json:"type"`
+	State      string `json:"state"`
+	SpacePath  string `json:"space_path"`
+}
+
+// chatMessage is the platform independent content of a chat message.
+type chatMessage struct {
+	Title   string
+	Text    string
+	URL     string
+	Context string
+	Color   int
+}
+
+// renderChatBody renders the webhook payload into the request body expected by the chat platform.
+func renderChatBody(format enum.WebhookFormat, triggerType enum.WebhookTrigger, body any) (any, error) {
+	raw, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize payload: %w", err)
+	}
+
+	var payload chatPayload
+	if err = json.Unmarshal(raw, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode payload: %w", err)
+	}
+
+	if payload.Trigger == "" {
+		payload.Trigger = triggerType
+	}
+
+	msg := chatMessageFrom(&payload)
+
+	switch format {
+	case enum.WebhookFormatSlack:
+		return slackMessageFrom(msg), nil
+	case enum.WebhookFormatTeams:
+		return teamsMessageFrom(msg), nil
+	case enum.WebhookFormatDiscord:
+		return discordMessageFrom(msg), nil
+	case enum.WebhookFormatNative:
+		return body, nil
+	default:
+		return nil, fmt.Errorf("unsupported webhook format %q", format)
+	}
+}
+
+// RenderChatMessage renders a message with the provided content into the request body expected by the chat platform.
+// It allows other services to post messages that look the same as the chat messages of webhooks.
+func RenderChatMessage(format enum.WebhookFormat, title, text, url, context string) (any, error) {
+	msg := chatMessage{
+		Title:   truncate(title, chatMaxTitleLength),
+		Text:    truncate(text, chatMaxTextLength),
+		URL:     url,
+		Context: context,
+		Color:   chatColorInfo,
+	}
+
+	switch format {
+	case enum.WebhookFormatSlack:
+		return slackMessageFrom(msg), nil
+	case enum.WebhookFormatTeams:
+		return teamsMessageFrom(msg), nil
+	case enum.WebhookFormatDiscord:
+		return discordMessageFrom(msg), nil
+	case enum.WebhookFormatNative:
+		return nil, fmt.Errorf("webhook format %q isn't a chat format", format)
+	default:
+		return nil, fmt.Errorf("unsupported webhook format %q", format)
+	}
+}
+
+//nolint:gocognit,gocyclo,cyclop,funlen // one case per trigger, splitting it doesn't improve readability.
+func chatMessageFrom(p *chatPayload) chatMessage {
+	actor := p.Principal.DisplayName
+	if actor == "" {
+		actor = p.Principal.UID
+	}
+
+	msg := chatMessage{
+		URL:     p.Repo.URL,
+		Context: p.Repo.Path,
+		Color:   chatColorDefault,
+	}
+
+	ref := shortRefName(p.Ref.Name)
+
+	var headCommitText string
+	if p.HeadCommit != nil {
+		headCommitText = firstLine(p.HeadCommit.Message)
+		if p.HeadCommit.URL != "" {
+			msg.URL = p.HeadCommit.URL
+		}
+	}
+
+	var pr string
+	if p.PullReq != nil {
+		pr = fmt.Sprintf("pull request #%d: %s", p.PullReq.Number, p.PullReq.Title)
+		msg.URL = p.PullReq.URL
+	}
+
+	var comment string
+	if p.Comment != nil {
+		comment = p.Comment.Text
+	}
+
+	switch p.Trigger {
+	case enum.WebhookTriggerBranchCreated:
+		msg.Title = fmt.Sprintf("%s created branch %s", actor, ref)
+		msg.Text = headCommitText
+		msg.Color = chatColorSuccess
+	case enum.WebhookTriggerBranchUpdated:
+		msg.Title = fmt.Sprintf("%s pushed %s to %s", actor, pluralize(p.TotalCommitsCount, "commit"), ref)
+		msg.Text = headCommitText
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerBranchDeleted:
+		msg.Title = fmt.Sprintf("%s deleted branch %s", actor, ref)
+		msg.Color = chatColorFailure
+	case enum.WebhookTriggerTagCreated:
+		msg.Title = fmt.Sprintf("%s created tag %s", actor, ref)
+		msg.Text = headCommitText
+		msg.Color = chatColorSuccess
+	case enum.WebhookTriggerTagUpdated:
+		msg.Title = fmt.Sprintf("%s updated tag %s", actor, ref)
+		msg.Text = headCommitText
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerTagDeleted:
+		msg.Title = fmt.Sprintf("%s deleted tag %s", actor, ref)
+		msg.Color = chatColorFailure
+
+	case enum.WebhookTriggerPullReqCreated:
+		msg.Title = fmt.Sprintf("%s opened %s", actor, pr)
+		msg.Text = pullReqBranches(p.PullReq)
+		msg.Color = chatColorSuccess
+	case enum.WebhookTriggerPullReqReopened:
+		msg.Title = fmt.Sprintf("%s reopened %s", actor, pr)
+		msg.Text = pullReqBranches(p.PullReq)
+		msg.Color = chatColorSuccess
+	case enum.WebhookTriggerPullReqBranchUpdated:
+		msg.Title = fmt.Sprintf("%s pushed %s to %s", actor, pluralize(p.TotalCommitsCount, "commit"), pr)
+		msg.Text = headCommitText
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqClosed:
+		msg.Title = fmt.Sprintf("%s closed %s", actor, pr)
+		msg.Color = chatColorFailure
+	case enum.WebhookTriggerPullReqMerged:
+		msg.Title = fmt.Sprintf("%s merged %s", actor, pr)
+		msg.Text = pullReqBranches(p.PullReq)
+		msg.Color = chatColorMerged
+	case enum.WebhookTriggerPullReqUpdated:
+		msg.Title = fmt.Sprintf("%s updated %s", actor, pr)
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqCommentCreated:
+		msg.Title = fmt.Sprintf("%s commented on %s", actor, pr)
+		msg.Text = comment
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqCommentUpdated:
+		msg.Title = fmt.Sprintf("%s edited a comment on %s", actor, pr)
+		msg.Text = comment
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqCommentStatusUpdated:
+		msg.Title = fmt.Sprintf("%s marked a comment on %s as %s", actor, pr, p.Status)
+		msg.Text = comment
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqLabelAssigned:
+		msg.Title = fmt.Sprintf("%s labeled %s", actor, pr)
+		if p.Label != nil {
+			msg.Text = p.Label.Key
+			if p.Label.Value != nil {
+				msg.Text += ": " + *p.Label.Value
+			}
+		}
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqReviewSubmitted:
+		switch enum.PullReqReviewDecision(p.ReviewDecision) {
+		case enum.PullReqReviewDecisionApproved:
+			msg.Title = fmt.Sprintf("%s approved %s", actor, pr)
+			msg.Color = chatColorSuccess
+		case enum.PullReqReviewDecisionChangeReq:
+			msg.Title = fmt.Sprintf("%s requested changes on %s", actor, pr)
+			msg.Color = chatColorFailure
+		default:
+			msg.Title = fmt.Sprintf("%s reviewed %s", actor, pr)
+			msg.Color = chatColorInfo
+		}
+	case enum.WebhookTriggerPullReqTargetBranchChanged:
+		msg.Title = fmt.Sprintf("%s changed the target branch of %s", actor, pr)
+		if p.PullReq != nil {
+			msg.Text = fmt.Sprintf("%s → %s", p.OldTargetBranch, p.PullReq.TargetBranch)
+		}
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerPullReqReactionCreated:
+		msg.Title = fmt.Sprintf("%s reacted with %s on %s", actor, p.Reaction, pr)
+		msg.Text = comment
+
+	case enum.WebhookTriggerExecutionStarted, enum.WebhookTriggerExecutionFinished:
+		if p.Execution == nil {
+			break
+		}
+		msg.URL = p.Execution.URL
+		if p.Trigger == enum.WebhookTriggerExecutionStarted {
+			msg.Title = fmt.Sprintf("Pipeline %s #%d started", p.Execution.PipelineIdentifier, p.Execution.Number)
+			msg.Color = chatColorInfo
+		} else {
+			msg.Title = fmt.Sprintf("Pipeline %s #%d finished with status %s",
+				p.Execution.PipelineIdentifier, p.Execution.Number, p.Execution.Status)
+			msg.Color = ciStatusColor(enum.CIStatus(p.Execution.Status))
+		}
+		msg.Text = fmt.Sprintf("Triggered by %s", actor)
+	case enum.WebhookTriggerCheckReported:
+		if p.Check == nil {
+			break
+		}
+		msg.Title = fmt.Sprintf("Check %s reported %s for commit %s",
+			p.Check.Identifier, p.Check.Status, shortSHA(p.Check.SHA))
+		msg.Text = p.Check.Summary
+		if p.Check.Link != "" {
+			msg.URL = p.Check.Link
+		}
+		msg.Color = ciStatusColor(enum.CIStatus(p.Check.Status))
+
+	case enum.WebhookTriggerRepoCreated:
+		msg.Title = fmt.Sprintf("%s created repository %s", actor, p.Repo.Path)
+		msg.Color = chatColorSuccess
+	case enum.WebhookTriggerRepoDeleted:
+		msg.Title = fmt.Sprintf("%s deleted repository %s", actor, p.Repo.Path)
+		msg.Color = chatColorFailure
+	case enum.WebhookTriggerRepoRenamed:
+		msg.Title = fmt.Sprintf("%s renamed repository %s to %s", actor, p.OldPath, p.Repo.Path)
+		msg.Color = chatColorInfo
+	case enum.WebhookTriggerRepoVisibilityChanged:
+		visibility := "private"
+		if p.NewIsPublic {
+			visibility = "public"
+		}
+		msg.Title = fmt.Sprintf("%s made repository %s %s", actor, p.Repo.Path, visibility)
+		msg.Color = chatColorInfo
+
+	case enum.WebhookTriggerRuleCreated, enum.WebhookTriggerRuleUpdated:
+		if p.Rule == nil {
+			break
+		}
+		action := "created"
+		if p.Trigger == enum.WebhookTriggerRuleUpdated {
+			action = "updated"
+		}
+		msg.Title = fmt.Sprintf("%s %s %s rule %s", actor, action, p.Rule.Type, p.Rule.Identifier)
+		msg.Text = fmt.Sprintf("State: %s", p.Rule.State)
+		if p.Rule.SpacePath != "" {
+			msg.Context = p.Rule.SpacePath
+		}
+		msg.Color = chatColorInfo
+	default:
+	}
+
+	if msg.Title == "" {
+		msg.Title = fmt.Sprintf("%s triggered %s", actor, p.Trigger)
+	}
+
+	msg.Title = truncate(msg.Title, chatMaxTitleLength)
+	msg.Text = truncate(msg.Text, chatMaxTextLength)
+
+	return msg
+}
+
+func ciStatusColor(status enum.CIStatus) int {
+	switch status {
+	case enum.CIStatusSuccess:
+		return chatColorSuccess
+	case enum.CIStatusFailure, enum.CIStatusError, enum.CIStatusKilled:
+		return chatColorFailure
+	case enum.CIStatusPending, enum.CIStatusRunning, enum.CIStatusWaitingOnDeps, enum.CIStatusBlocked,
+		enum.CIStatusSkipped, enum.CIStatusDeclined:
+		return chatColorDefault
+	default:
+		return chatColorDefault
+	}
+}
+
+func pullReqBranches(pr *chatPullReq) string {
+	if pr == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s → %s", pr.SourceBranch, pr.TargetBranch)
+}
+
+func shortRefName(ref string) string {
+	ref = strings.TrimPrefix(ref, "refs/heads/")
+	return strings.TrimPrefix(ref, "refs/tags/")
+}
+
+func shortSHA(sha string) string {
+	const shortSHALength = 8
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+func firstLine(s string) string {
+	line, _, _ := strings.Cut(s, "\n")
+	return line
+}
+
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
+
+func truncate(s string, limit int) string {
+	runes := []rune(s)
+	if len(runes) <= limit {
+		return s
+	}
+	return string(runes[:limit-1]) + "…"
+}
+
+/*
+ * Slack Block Kit
+ */
+
+type slackMessage struct {
+	Text   string       `json:"text"`
+	Blocks []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// slackEscape escapes the control characters of slack mrkdwn.
+func slackEscape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+func slackMessageFrom(msg chatMessage) slackMessage {
+	title := "*" + slackEscape(msg.Title) + "*"
+	if msg.URL != "" {
+		title = fmt.Sprintf("*<%s|%s>*", msg.URL, slackEscape(msg.Title))
+	}
+
+	section := title
+	if msg.Text != "" {
+		section += "\n" + slackEscape(msg.Text)
+	}
+
+	blocks := []slackBlock{
+		{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: section},
+		},
+	}
+
+	if msg.Context != "" {
+		blocks = append(blocks, slackBlock{
+			Type:     "context",
+			Elements: []slackText{{Type: "mrkdwn", Text: slackEscape(msg.Context)}},
+		})
+	}
+
+	return slackMessage{
+		Text:   msg.Title,
+		Blocks: blocks,
+	}
+}
+
+/*
+ * Microsoft Teams Adaptive Card
+ */
+
+type teamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []teamsAttachment `json:"attachments"`
+}
+
+type teamsAttachment struct {
+	ContentType string    `json:"contentType"`
+	Content     teamsCard `json:"content"`
+}
+
+type teamsCard struct {
+	Schema  string        `json:"$schema"`
+	Type    string        `json:"type"`
+	Version string        `json:"version"`
+	Body    []teamsBlock  `json:"body"`
+	Actions []teamsAction `json:"actions,omitempty"`
+}
+
+type teamsBlock struct {
+	Type     string `json:"type"`
+	Text     string `json:"text"`
+	Weight   string `json:"weight,omitempty"`
+	Size     string `json:"size,omitempty"`
+	IsSubtle bool   `json:"isSubtle,omitempty"`
+	Wrap     bool   `json:"wrap"`
+}
+
+type teamsAction struct {
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+func teamsMessageFrom(msg chatMessage) teamsMessage {
+	body := []teamsBlock{
+		{Type: "TextBlock", Text: msg.Title, Weight: "Bolder", Size: "Medium", Wrap: true},
+	}
+	if msg.Text != "" {
+		body = append(body, teamsBlock{Type: "TextBlock", Text: msg.Text, Wrap: true})
+	}
+	if msg.Context != "" {
+		body = append(body, teamsBlock{Type: "TextBlock", Text: msg.Context, IsSubtle: true, Wrap: true})
+	}
+
+	var actions []teamsAction
+	if msg.URL != "" {
+		actions = []teamsAction{{Type: "Action.OpenUrl", Title: "View", URL: msg.URL}}
+	}
+
+	return teamsMessage{
+		Type: "message",
+		Attachments: []teamsAttachment{
+			{
+				ContentType: "application/vnd.microsoft.card.adaptive",
+				Content: teamsCard{
+					Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+					Type:    "AdaptiveCard",
+					Version: "1.4",
+					Body:    body,
+					Actions: actions,
+				},
+			},
+		},
+	}
+}
+
+/*
+ * Discord embed
+ */
+
+type discordMessage struct {
+	Embeds []discordEmbed `json:"embeds"`
+}
+
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Color       int            `json:"color"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+func discordMessageFrom(msg chatMessage) discordMessage {
+	embed := discordEmbed{
+		Title:       msg.Title,
+		Description: msg.Text,
+		URL:         msg.URL,
+		Color:       msg.Color,
+	}
+	if msg.Context != "" {
+		embed.Footer = &discordFooter{Text: msg.Context}
+	}
+
+	return discordMessage{Embeds: []discordEmbed{embed}}
+}
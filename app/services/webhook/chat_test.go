@@ -0,0 +1,192 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestChatMessageFrom(t *testing.T) {
+	base := BaseSegment{
+		Repo:      RepositoryInfo{Path: "space/repo", URL: "https://git.example.com/space/repo"},
+		Principal: PrincipalInfo{UID: "jdoe", DisplayName: "Jane Doe"},
+	}
+
+	tests := []struct {
+		name    string
+		trigger enum.WebhookTrigger
+		body    any
+		exp     chatMessage
+	}{
+		{
+			name:    "branch-updated",
+			trigger: enum.WebhookTriggerBranchUpdated,
+			body: ReferencePayload{
+				BaseSegment:      base,
+				ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: "refs/heads/main"}},
+				ReferenceDetailsSegment: ReferenceDetailsSegment{
+					HeadCommit: &CommitInfo{
+						Message: "Fix login\n\nlonger description",
+						URL:     "https://git.example.com/space/repo/commit/abc",
+					},
+					TotalCommitsCount: 2,
+				},
+			},
+			exp: chatMessage{
+				Title:   "Jane Doe pushed 2 commits to main",
+				Text:    "Fix login",
+				URL:     "https://git.example.com/space/repo/commit/abc",
+				Context: "space/repo",
+				Color:   chatColorInfo,
+			},
+		},
+		{
+			name:    "pullreq-review-changereq",
+			trigger: enum.WebhookTriggerPullReqReviewSubmitted,
+			body: PullReqReviewSubmittedPayload{
+				BaseSegment: base,
+				PullReqSegment: PullReqSegment{PullReq: PullReqInfo{
+					Number: 7,
+					Title:  "Add feature",
+					PrURL:  "https://git.example.com/space/repo/pulls/7",
+				}},
+				PullReqReviewSegment: PullReqReviewSegment{ReviewDecision: enum.PullReqReviewDecisionChangeReq},
+			},
+			exp: chatMessage{
+				Title:   "Jane Doe requested changes on pull request #7: Add feature",
+				URL:     "https://git.example.com/space/repo/pulls/7",
+				Context: "space/repo",
+				Color:   chatColorFailure,
+			},
+		},
+		{
+			name:    "execution-finished",
+			trigger: enum.WebhookTriggerExecutionFinished,
+			body: ExecutionPayload{
+				BaseSegment: base,
+				ExecutionSegment: ExecutionSegment{Execution: ExecutionInfo{
+					PipelineIdentifier: "build",
+					Number:             3,
+					Status:             enum.CIStatusFailure,
+					URL:                "https://git.example.com/space/repo/pipelines/build/execution/3",
+				}},
+			},
+			exp: chatMessage{
+				Title:   "Pipeline build #3 finished with status failure",
+				Text:    "Triggered by Jane Doe",
+				URL:     "https://git.example.com/space/repo/pipelines/build/execution/3",
+				Context: "space/repo",
+				Color:   chatColorFailure,
+			},
+		},
+		{
+			name:    "unknown-payload",
+			trigger: enum.WebhookTrigger("custom"),
+			body:    map[string]any{},
+			exp: chatMessage{
+				Title: " triggered custom",
+				Color: chatColorDefault,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			raw, err := json.Marshal(test.body)
+			if err != nil {
+				t.Fatalf("failed to marshal body: %s", err)
+			}
+
+			var payload chatPayload
+			if err = json.Unmarshal(raw, &payload); err != nil {
+				t.Fatalf("failed to unmarshal body: %s", err)
+			}
+			if payload.Trigger == "" {
+				payload.Trigger = test.trigger
+			}
+
+			if got := chatMessageFrom(&payload); got != test.exp {
+				t.Errorf("want=%+v got=%+v", test.exp, got)
+			}
+		})
+	}
+}
+
+func TestRenderChatBody(t *testing.T) {
+	body := ReferencePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerBranchDeleted,
+			Repo:      RepositoryInfo{Path: "space/repo", URL: "https://git.example.com/space/repo"},
+			Principal: PrincipalInfo{UID: "jdoe", DisplayName: "<Jane>"},
+		},
+		ReferenceSegment: ReferenceSegment{Ref: ReferenceInfo{Name: "refs/heads/feature"}},
+	}
+
+	tests := []struct {
+		format   enum.WebhookFormat
+		contains []string
+	}{
+		{
+			format: enum.WebhookFormatSlack,
+			contains: []string{
+				`"text":"<Jane> deleted branch feature"`,
+				`"text":"*<https://git.example.com/space/repo|&lt;Jane&gt; deleted branch feature>*"`,
+				`"type":"context"`,
+			},
+		},
+		{
+			format: enum.WebhookFormatTeams,
+			contains: []string{
+				`"contentType":"application/vnd.microsoft.card.adaptive"`,
+				`"type":"AdaptiveCard"`,
+				`"url":"https://git.example.com/space/repo"`,
+			},
+		},
+		{
+			format: enum.WebhookFormatDiscord,
+			contains: []string{
+				`"embeds":[{"title":"<Jane> deleted branch feature"`,
+				`"color":13574702`,
+				`"footer":{"text":"space/repo"}`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(string(test.format), func(t *testing.T) {
+			rendered, err := renderChatBody(test.format, enum.WebhookTriggerBranchDeleted, body)
+			if err != nil {
+				t.Fatalf("failed to render body: %s", err)
+			}
+
+			buf := &strings.Builder{}
+			enc := json.NewEncoder(buf)
+			enc.SetEscapeHTML(false)
+			if err = enc.Encode(rendered); err != nil {
+				t.Fatalf("failed to marshal rendered body: %s", err)
+			}
+
+			for _, s := range test.contains {
+				if !strings.Contains(buf.String(), s) {
+					t.Errorf("expected %s to contain %s", buf.String(), s)
+				}
+			}
+		})
+	}
+}
@@ -109,6 +109,26 @@ func CheckTriggers(triggers []enum.WebhookTrigger) error {
 	return nil
 }
 
+// CheckFormat validates the format of a webhook and returns the sanitized format.
+func CheckFormat(format enum.WebhookFormat) (enum.WebhookFormat, error) {
+	sanitized, ok := format.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook format '%s' is invalid.", format)
+	}
+
+	return sanitized, nil
+}
+
+// CheckSignatureMode validates the signature mode of a webhook and returns the sanitized signature mode.
+func CheckSignatureMode(mode enum.WebhookSignatureMode) (enum.WebhookSignatureMode, error) {
+	sanitized, ok := mode.Sanitize()
+	if !ok {
+		return "", check.NewValidationErrorf("The provided webhook signature mode '%s' is invalid.", mode)
+	}
+
+	return sanitized, nil
+}
+
 // CheckExtraHeaders validates the custom headers of a webhook.
 func CheckExtraHeaders(headers []types.ExtraHeader) error {
 	if len(headers) > webhookMaxExtraHeaders {
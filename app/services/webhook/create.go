@@ -69,6 +69,16 @@ func (s *Service) sanitizeCreateInput(in *types.WebhookCreateInput, internal boo
 	if err := CheckTriggers(in.Triggers); err != nil { //nolint:revive
 		return err
 	}
+	format, err := CheckFormat(in.Format)
+	if err != nil {
+		return err
+	}
+	in.Format = format
+	signatureMode, err := CheckSignatureMode(in.SignatureMode)
+	if err != nil {
+		return err
+	}
+	in.SignatureMode = signatureMode
 	if err := CheckExtraHeaders(in.ExtraHeaders); err != nil {
 		return err
 	}
@@ -123,6 +133,8 @@ func (s *Service) Create(
 		Enabled:               in.Enabled,
 		Insecure:              in.Insecure,
 		Triggers:              DeduplicateTriggers(in.Triggers),
+		Format:                in.Format,
+		SignatureMode:         in.SignatureMode,
 		LatestExecutionResult: nil,
 		ExtraHeaders:          in.ExtraHeaders,
 	}
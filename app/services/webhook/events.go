@@ -65,7 +65,7 @@ func (s *Service) triggerForEventWithRepo(
 		return fmt.Errorf("failed to get webhook parent info for parents: %w", err)
 	}
 
-	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+	return s.triggerForEvent(ctx, eventID, parents, triggerType, body)
 }
 
 // triggerForEventWithPullReq triggers all webhooks for the given repo and triggerType
@@ -122,7 +122,7 @@ func (s *Service) triggerForEventWithPullReq(
 		return fmt.Errorf("failed to get webhook parent info: %w", err)
 	}
 
-	return s.WebhookExecutor.TriggerForEvent(ctx, eventID, parents, triggerType, body)
+	return s.triggerForEvent(ctx, eventID, parents, triggerType, body)
 }
 
 // findRepositoryForEvent finds the repository for the provided repoID.
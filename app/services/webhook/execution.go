@@ -18,12 +18,16 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/harness/gitness/errors"
 	"github.com/harness/gitness/types"
 	"github.com/harness/gitness/types/enum"
 
 	"github.com/rs/zerolog/log"
 )
 
+// redeliverMaxExecutions is the maximum number of executions redelivered by a single bulk redelivery.
+const redeliverMaxExecutions = 1000
+
 // FindExecution finds a webhook execution.
 func (s *Service) FindExecution(
 	ctx context.Context,
@@ -102,5 +106,41 @@ func (s *Service) RetriggerExecution(
 			webhook.ID, webhookExecution.ID, executionResult.Execution.ID)
 	}
 
+	s.trackDeliveryOutcome(ctx, webhook.ID, executionResult.Execution)
+
 	return executionResult.Execution, nil
 }
+
+// RedeliverFailedExecutions queues the redelivery of all triggers of the webhook since the provided time
+// (unix millis) whose latest execution didn't succeed. Returns the number of queued redeliveries.
+func (s *Service) RedeliverFailedExecutions(
+	ctx context.Context,
+	parentID int64,
+	parentType enum.WebhookParent,
+	webhookIdentifier string,
+	since int64,
+) (int, error) {
+	webhook, err := s.GetWebhookVerifyOwnership(ctx, parentID, parentType, webhookIdentifier)
+	if err != nil {
+		return 0, err
+	}
+
+	if !webhook.Enabled {
+		return 0, errors.PreconditionFailed("The webhook is disabled and can't redeliver executions.")
+	}
+
+	executions, err := s.webhookExecutionStore.ListLatestFailedForWebhook(ctx, webhook.ID, since,
+		redeliverMaxExecutions)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list failed executions for webhook %d: %w", webhook.ID, err)
+	}
+
+	for i, execution := range executions {
+		// manual redeliveries are queued without delay and get the full budget of automatic redeliveries.
+		if err = s.queueRedelivery(ctx, execution.ID, 0, 0); err != nil {
+			return i, fmt.Errorf("failed to queue redelivery of webhook execution %d: %w", execution.ID, err)
+		}
+	}
+
+	return len(executions), nil
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventCheckReported handles status check reported events
+// and triggers check reported webhooks for the repo of the check.
+// The principal of the payload is the principal that reported the check.
+func (s *Service) handleEventCheckReported(ctx context.Context,
+	event *events.Event[*checkevents.ReportedPayload]) error {
+	check, err := s.checkStore.FindByIdentifier(ctx,
+		event.Payload.RepoID, event.Payload.SHA, event.Payload.Identifier)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("check '%s' for commit '%s' doesn't exist anymore",
+			event.Payload.Identifier, event.Payload.SHA)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get check '%s' for commit '%s': %w",
+			event.Payload.Identifier, event.Payload.SHA, err)
+	}
+
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerCheckReported,
+		event.ID, check.ReportedBy.ID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			checkInfo := checkInfoFrom(&check)
+			// the check could have been reported again since, use the status of the event
+			checkInfo.Status = event.Payload.Status
+
+			return &CheckPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerCheckReported,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				CheckSegment: CheckSegment{
+					Check: checkInfo,
+				},
+			}, nil
+		})
+}
@@ -0,0 +1,85 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventExecutionStarted handles pipeline execution started events
+// and triggers execution started webhooks for the repo of the pipeline.
+func (s *Service) handleEventExecutionStarted(ctx context.Context,
+	event *events.Event[*pipelineevents.StartedPayload]) error {
+	return s.triggerForEventWithExecution(ctx, enum.WebhookTriggerExecutionStarted,
+		event.ID, event.Payload.PipelineID, event.Payload.ExecutionNum)
+}
+
+// handleEventExecutionFinished handles pipeline executed events
+// and triggers execution finished webhooks for the repo of the pipeline.
+func (s *Service) handleEventExecutionFinished(ctx context.Context,
+	event *events.Event[*pipelineevents.ExecutedPayload]) error {
+	return s.triggerForEventWithExecution(ctx, enum.WebhookTriggerExecutionFinished,
+		event.ID, event.Payload.PipelineID, event.Payload.ExecutionNum)
+}
+
+// triggerForEventWithExecution triggers all webhooks for the repo of the pipeline execution.
+// The principal of the payload is the principal that triggered the execution.
+func (s *Service) triggerForEventWithExecution(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	pipelineID int64,
+	executionNum int64,
+) error {
+	pipeline, err := s.pipelineStore.Find(ctx, pipelineID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("pipeline with id '%d' doesn't exist anymore", pipelineID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline for id '%d': %w", pipelineID, err)
+	}
+
+	execution, err := s.executionStore.FindByNumber(ctx, pipelineID, executionNum)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("execution %d of pipeline with id '%d' doesn't exist anymore",
+			executionNum, pipelineID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get execution %d of pipeline with id '%d': %w", executionNum, pipelineID, err)
+	}
+
+	return s.triggerForEventWithRepo(ctx, triggerType,
+		eventID, execution.CreatedBy, execution.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &ExecutionPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   triggerType,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				ExecutionSegment: ExecutionSegment{
+					Execution: executionInfoFrom(ctx, execution, pipeline, repo, s.urlProvider),
+				},
+			}, nil
+		})
+}
@@ -476,6 +476,65 @@ func extractCodeCommentInfoIfAvailable(
 	return (*CodeCommentInfo)(activity.CodeComment)
 }
 
+// PullReqReactionCreatedPayload describes the body of the pullreq reaction created trigger.
+type PullReqReactionCreatedPayload struct {
+	BaseSegment
+	PullReqSegment
+	PullReqReactionSegment
+}
+
+// handleEventPullReqReactionCreated handles reaction created events
+// and triggers pullreq reaction created webhooks for the target repo.
+func (s *Service) handleEventPullReqReactionCreated(
+	ctx context.Context,
+	event *events.Event[*pullreqevents.ReactionCreatedPayload],
+) error {
+	return s.triggerForEventWithPullReq(
+		ctx,
+		enum.WebhookTriggerPullReqReactionCreated,
+		event.ID, event.Payload.PrincipalID,
+		event.Payload.PullReqID,
+		func(
+			principal *types.Principal,
+			pr *types.PullReq,
+			targetRepo,
+			_ *types.Repository,
+		) (any, error) {
+			var comment *CommentInfo
+			if event.Payload.ActivityID != nil {
+				activity, err := s.activityStore.Find(ctx, *event.Payload.ActivityID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to get activity by id for activity id %d: %w",
+						*event.Payload.ActivityID, err)
+				}
+
+				comment = &CommentInfo{
+					Text:     activity.Text,
+					ID:       activity.ID,
+					ParentID: activity.ParentID,
+					Kind:     activity.Kind,
+					Created:  activity.Created,
+					Updated:  activity.Updated,
+				}
+			}
+
+			return &PullReqReactionCreatedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerPullReqReactionCreated,
+					Repo:      repositoryInfoFrom(ctx, targetRepo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				PullReqSegment: PullReqSegment{
+					PullReq: pullReqInfoFrom(ctx, pr, targetRepo, s.urlProvider),
+				},
+				PullReqReactionSegment: PullReqReactionSegment{
+					Reaction: event.Payload.Reaction,
+					Comment:  comment,
+				},
+			}, nil
+		})
+}
+
 // PullReqLabelAssignedPayload describes the body of the pullreq label assignment trigger.
 type PullReqLabelAssignedPayload struct {
 	BaseSegment
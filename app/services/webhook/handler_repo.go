@@ -0,0 +1,126 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	repoevents "github.com/harness/gitness/app/events/repo"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventRepoCreated handles repo created events
+// and triggers repo created webhooks for the repo.
+func (s *Service) handleEventRepoCreated(ctx context.Context,
+	event *events.Event[*repoevents.CreatedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepositoryPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoCreated,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoRenamed handles repo renamed events
+// and triggers repo renamed webhooks for the repo.
+func (s *Service) handleEventRepoRenamed(ctx context.Context,
+	event *events.Event[*repoevents.RenamedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoRenamed,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepositoryRenamedPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoRenamed,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepositoryRenamedSegment: RepositoryRenamedSegment{
+					OldIdentifier: event.Payload.OldIdentifier,
+					OldPath:       event.Payload.OldPath,
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoVisibilityChanged handles repo public access changed events
+// and triggers repo visibility changed webhooks for the repo.
+func (s *Service) handleEventRepoVisibilityChanged(ctx context.Context,
+	event *events.Event[*repoevents.PublicAccessChangedPayload]) error {
+	return s.triggerForEventWithRepo(ctx, enum.WebhookTriggerRepoVisibilityChanged,
+		event.ID, event.Payload.PrincipalID, event.Payload.RepoID,
+		func(principal *types.Principal, repo *types.Repository) (any, error) {
+			return &RepositoryVisibilityPayload{
+				BaseSegment: BaseSegment{
+					Trigger:   enum.WebhookTriggerRepoVisibilityChanged,
+					Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+					Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+				},
+				RepositoryVisibilitySegment: RepositoryVisibilitySegment{
+					OldIsPublic: event.Payload.OldIsPublic,
+					NewIsPublic: event.Payload.NewIsPublic,
+				},
+			}, nil
+		})
+}
+
+// handleEventRepoDeleted handles repo soft deleted events
+// and triggers repo deleted webhooks for the repo.
+// The repo is already deleted, so it and its webhook parents are resolved explicitly.
+func (s *Service) handleEventRepoDeleted(ctx context.Context,
+	event *events.Event[*repoevents.SoftDeletedPayload]) error {
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, event.Payload.PrincipalID)
+	if err != nil {
+		return err
+	}
+
+	repo, err := s.repoStore.FindDeleted(ctx, event.Payload.RepoID, &event.Payload.Deleted)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the repo got purged or restored in the meantime
+		return events.NewDiscardEventErrorf("deleted repo with id '%d' doesn't exist anymore", event.Payload.RepoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get deleted repo for id '%d': %w", event.Payload.RepoID, err)
+	}
+
+	parents, err := s.getParentInfoSpace(ctx, repo.ParentID, true)
+	if err != nil {
+		return fmt.Errorf("failed to get webhook parent info: %w", err)
+	}
+
+	parents = append(parents, types.WebhookParentInfo{
+		ID:   repo.ID,
+		Type: enum.WebhookParentRepo,
+	})
+
+	body := &RepositoryPayload{
+		BaseSegment: BaseSegment{
+			Trigger:   enum.WebhookTriggerRepoDeleted,
+			Repo:      repositoryInfoFrom(ctx, repo, s.urlProvider),
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+	}
+
+	return s.triggerForEvent(ctx, event.ID, parents, enum.WebhookTriggerRepoDeleted, body)
+}
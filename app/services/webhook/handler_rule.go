@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+// handleEventRuleCreated handles rule created events
+// and triggers rule created webhooks for the repo or space of the rule.
+func (s *Service) handleEventRuleCreated(ctx context.Context,
+	event *events.Event[*ruleevents.CreatedPayload]) error {
+	return s.triggerForEventWithRule(ctx, enum.WebhookTriggerRuleCreated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RuleID)
+}
+
+// handleEventRuleUpdated handles rule updated events
+// and triggers rule updated webhooks for the repo or space of the rule.
+func (s *Service) handleEventRuleUpdated(ctx context.Context,
+	event *events.Event[*ruleevents.UpdatedPayload]) error {
+	return s.triggerForEventWithRule(ctx, enum.WebhookTriggerRuleUpdated,
+		event.ID, event.Payload.PrincipalID, event.Payload.RuleID)
+}
+
+// triggerForEventWithRule triggers all webhooks for the repo or space the rule is defined on.
+func (s *Service) triggerForEventWithRule(
+	ctx context.Context,
+	triggerType enum.WebhookTrigger,
+	eventID string,
+	principalID int64,
+	ruleID int64,
+) error {
+	principal, err := s.WebhookExecutor.FindPrincipalForEvent(ctx, principalID)
+	if err != nil {
+		return err
+	}
+
+	rule, err := s.ruleStore.Find(ctx, ruleID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return events.NewDiscardEventErrorf("rule with id '%d' doesn't exist anymore", ruleID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get rule for id '%d': %w", ruleID, err)
+	}
+
+	var (
+		repoInfo  RepositoryInfo
+		spacePath string
+		parents   []types.WebhookParentInfo
+	)
+
+	switch {
+	case rule.RepoID != nil:
+		repo, err := s.findRepositoryForEvent(ctx, *rule.RepoID)
+		if err != nil {
+			return err
+		}
+
+		repoInfo = repositoryInfoFrom(ctx, repo, s.urlProvider)
+
+		parents, err = s.getParentInfoRepo(ctx, repo.ID, true)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook parent info: %w", err)
+		}
+	case rule.SpaceID != nil:
+		space, err := s.spaceStore.Find(ctx, *rule.SpaceID)
+		if errors.Is(err, store.ErrResourceNotFound) {
+			return events.NewDiscardEventErrorf("space with id '%d' doesn't exist anymore", *rule.SpaceID)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to get space for id '%d': %w", *rule.SpaceID, err)
+		}
+
+		spacePath = space.Path
+
+		parents, err = s.getParentInfoSpace(ctx, space.ID, true)
+		if err != nil {
+			return fmt.Errorf("failed to get webhook parent info: %w", err)
+		}
+	default:
+		return events.NewDiscardEventErrorf("rule with id '%d' has no parent", ruleID)
+	}
+
+	body := &RulePayload{
+		BaseSegment: BaseSegment{
+			Trigger:   triggerType,
+			Repo:      repoInfo,
+			Principal: principalInfoFrom(principal.ToPrincipalInfo()),
+		},
+		RuleSegment: RuleSegment{
+			Rule: ruleInfoFrom(rule, spacePath),
+		},
+	}
+
+	return s.triggerForEvent(ctx, eventID, parents, triggerType, body)
+}
@@ -0,0 +1,764 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	checkevents "github.com/harness/gitness/app/events/check"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	gitnessstore "github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/app/url"
+	"github.com/harness/gitness/events"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+)
+
+const (
+	testPrincipalID = int64(7)
+	testSpaceID     = int64(2)
+	testRootSpaceID = int64(1)
+	testRepoID      = int64(10)
+	testPipelineID  = int64(20)
+	testRuleID      = int64(30)
+)
+
+// testHandlerEnv contains the webhook service with all stores the event handlers use,
+// as well as the webhook parents and bodies of all triggered webhooks.
+type testHandlerEnv struct {
+	service       *Service
+	principals    *testPrincipalStore
+	repos         *testRepoStore
+	spaces        *testSpaceStore
+	checks        *testCheckStore
+	pipelines     *testPipelineStore
+	executions    *testExecutionStore
+	rules         *testRuleStore
+	executorStore *testWebhookExecutorStore
+}
+
+func newTestHandlerEnv(t *testing.T) *testHandlerEnv {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(server.Close)
+
+	env := &testHandlerEnv{
+		principals: &testPrincipalStore{principals: map[int64]*types.Principal{
+			testPrincipalID: {ID: testPrincipalID, UID: "jdoe", DisplayName: "Jane Doe", Type: enum.PrincipalTypeUser},
+		}},
+		repos: &testRepoStore{
+			repos: map[int64]*types.Repository{
+				testRepoID: {ID: testRepoID, ParentID: testSpaceID, Identifier: "repo", Path: "root/space/repo"},
+			},
+			deleted: map[int64]*types.Repository{},
+		},
+		spaces: &testSpaceStore{
+			spaces: map[int64]*types.Space{
+				testRootSpaceID: {ID: testRootSpaceID, Path: "root"},
+				testSpaceID:     {ID: testSpaceID, ParentID: testRootSpaceID, Path: "root/space"},
+			},
+			ancestors: map[int64][]int64{
+				testRootSpaceID: {testRootSpaceID},
+				testSpaceID:     {testSpaceID, testRootSpaceID},
+			},
+		},
+		checks:     &testCheckStore{checks: map[string]types.Check{}},
+		pipelines:  &testPipelineStore{pipelines: map[int64]*types.Pipeline{}},
+		executions: &testExecutionStore{executions: map[int64]*types.Execution{}},
+		rules:      &testRuleStore{rules: map[int64]*types.Rule{}},
+		executorStore: &testWebhookExecutorStore{
+			webhooks: []*types.WebhookCore{{ID: 1, Enabled: true}},
+		},
+	}
+
+	urlProvider := &testURLProvider{webhookURL: server.URL}
+
+	env.service = &Service{
+		WebhookExecutor: NewWebhookExecutor(
+			Config{UserAgentIdentity: "Gitness", HeaderIdentity: "Gitness", AllowLoopback: true},
+			urlProvider, nil, nil, nil, env.principals, env.executorStore, nil, RepoTrigger),
+		urlProvider:    urlProvider,
+		spaceStore:     env.spaces,
+		repoStore:      env.repos,
+		principalStore: env.principals,
+		checkStore:     env.checks,
+		pipelineStore:  env.pipelines,
+		executionStore: env.executions,
+		ruleStore:      env.rules,
+	}
+
+	return env
+}
+
+// body decodes the body of the single webhook execution into dst.
+func (env *testHandlerEnv) body(t *testing.T, dst any) {
+	t.Helper()
+
+	if len(env.executorStore.executions) != 1 {
+		t.Fatalf("expected a single webhook execution, got %d", len(env.executorStore.executions))
+	}
+
+	execution := env.executorStore.executions[0]
+	if execution.Result != enum.WebhookExecutionResultSuccess {
+		t.Fatalf("expected the webhook execution to succeed, got %s: %s", execution.Result, execution.Error)
+	}
+
+	if err := json.Unmarshal([]byte(execution.Request.Body), dst); err != nil {
+		t.Fatalf("failed to decode webhook body: %s", err)
+	}
+}
+
+// expectParents verifies that the webhooks of exactly the given parents got triggered.
+func (env *testHandlerEnv) expectParents(t *testing.T, exp ...types.WebhookParentInfo) {
+	t.Helper()
+
+	if len(env.executorStore.parents) != 1 {
+		t.Fatalf("expected webhooks to be listed once, got %d", len(env.executorStore.parents))
+	}
+
+	if got := env.executorStore.parents[0]; !reflect.DeepEqual(got, exp) {
+		t.Errorf("expected parents %v, got %v", exp, got)
+	}
+}
+
+// expectDiscarded verifies that the event got discarded without triggering any webhooks.
+func (env *testHandlerEnv) expectDiscarded(t *testing.T, err error) {
+	t.Helper()
+
+	// discard errors match any other discard error.
+	if !errors.Is(err, events.NewDiscardEventError(nil)) {
+		t.Fatalf("expected the event to be discarded, got %v", err)
+	}
+
+	if len(env.executorStore.parents) != 0 {
+		t.Errorf("expected no webhooks to be triggered, got %v", env.executorStore.parents)
+	}
+}
+
+func repoParents() []types.WebhookParentInfo {
+	return []types.WebhookParentInfo{
+		{ID: testRepoID, Type: enum.WebhookParentRepo},
+		{ID: testSpaceID, Type: enum.WebhookParentSpace},
+		{ID: testRootSpaceID, Type: enum.WebhookParentSpace},
+	}
+}
+
+func TestService_HandleEventCheckReported(t *testing.T) {
+	ctx := context.Background()
+
+	event := &events.Event[*checkevents.ReportedPayload]{
+		ID: "1-0",
+		Payload: &checkevents.ReportedPayload{
+			Base:       checkevents.Base{RepoID: testRepoID, SHA: "abc"},
+			Identifier: "lint",
+			Status:     enum.CheckStatusFailure,
+		},
+	}
+
+	t.Run("payload", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		env.checks.checks["abc/lint"] = types.Check{
+			Identifier: "lint",
+			CommitSHA:  "abc",
+			Status:     enum.CheckStatusSuccess,
+			Summary:    "all good",
+			ReportedBy: &types.PrincipalInfo{ID: testPrincipalID},
+		}
+
+		if err := env.service.handleEventCheckReported(ctx, event); err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		env.expectParents(t, repoParents()...)
+
+		var body CheckPayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerCheckReported {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerCheckReported, body.Trigger)
+		}
+		if body.Principal.ID != testPrincipalID {
+			t.Errorf("expected the reporter %d as principal, got %d", testPrincipalID, body.Principal.ID)
+		}
+		if body.Repo.Path != "root/space/repo" {
+			t.Errorf("expected repo path 'root/space/repo', got '%s'", body.Repo.Path)
+		}
+		if body.Check.Identifier != "lint" || body.Check.SHA != "abc" || body.Check.Summary != "all good" {
+			t.Errorf("unexpected check info %+v", body.Check)
+		}
+		// the check got reported again since, the status of the event is expected.
+		if body.Check.Status != enum.CheckStatusFailure {
+			t.Errorf("expected check status %s, got %s", enum.CheckStatusFailure, body.Check.Status)
+		}
+	})
+
+	t.Run("missing-check", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		env.expectDiscarded(t, env.service.handleEventCheckReported(ctx, event))
+	})
+}
+
+func TestService_HandleEventExecution(t *testing.T) {
+	ctx := context.Background()
+
+	started := &events.Event[*pipelineevents.StartedPayload]{
+		ID: "1-0",
+		Payload: &pipelineevents.StartedPayload{
+			PipelineID:   testPipelineID,
+			RepoID:       testRepoID,
+			ExecutionNum: 3,
+		},
+	}
+	finished := &events.Event[*pipelineevents.ExecutedPayload]{
+		ID: "2-0",
+		Payload: &pipelineevents.ExecutedPayload{
+			PipelineID:   testPipelineID,
+			RepoID:       testRepoID,
+			ExecutionNum: 3,
+			Status:       enum.CIStatusSuccess,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		trigger enum.WebhookTrigger
+		handle  func(*Service) error
+	}{
+		{
+			name:    "started",
+			trigger: enum.WebhookTriggerExecutionStarted,
+			handle:  func(s *Service) error { return s.handleEventExecutionStarted(ctx, started) },
+		},
+		{
+			name:    "finished",
+			trigger: enum.WebhookTriggerExecutionFinished,
+			handle:  func(s *Service) error { return s.handleEventExecutionFinished(ctx, finished) },
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name+"/payload", func(t *testing.T) {
+			env := newTestHandlerEnv(t)
+			env.pipelines.pipelines[testPipelineID] = &types.Pipeline{ID: testPipelineID, Identifier: "build"}
+			env.executions.executions[3] = &types.Execution{
+				PipelineID: testPipelineID,
+				RepoID:     testRepoID,
+				Number:     3,
+				CreatedBy:  testPrincipalID,
+				Status:     enum.CIStatusRunning,
+				Ref:        "refs/heads/main",
+				After:      "abc",
+			}
+
+			if err := test.handle(env.service); err != nil {
+				t.Fatalf("failed to handle event: %s", err)
+			}
+
+			env.expectParents(t, repoParents()...)
+
+			var body ExecutionPayload
+			env.body(t, &body)
+
+			if body.Trigger != test.trigger {
+				t.Errorf("expected trigger %s, got %s", test.trigger, body.Trigger)
+			}
+			if body.Principal.ID != testPrincipalID {
+				t.Errorf("expected the execution creator %d as principal, got %d", testPrincipalID, body.Principal.ID)
+			}
+			exp := ExecutionInfo{
+				PipelineIdentifier: "build",
+				Number:             3,
+				Status:             enum.CIStatusRunning,
+				Ref:                "refs/heads/main",
+				SHA:                "abc",
+				URL:                "ui/root/space/repo/pipelines/build/executions/3",
+			}
+			if body.Execution != exp {
+				t.Errorf("expected execution info %+v, got %+v", exp, body.Execution)
+			}
+		})
+
+		t.Run(test.name+"/missing-pipeline", func(t *testing.T) {
+			env := newTestHandlerEnv(t)
+
+			env.expectDiscarded(t, test.handle(env.service))
+		})
+
+		t.Run(test.name+"/missing-execution", func(t *testing.T) {
+			env := newTestHandlerEnv(t)
+			env.pipelines.pipelines[testPipelineID] = &types.Pipeline{ID: testPipelineID, Identifier: "build"}
+
+			env.expectDiscarded(t, test.handle(env.service))
+		})
+	}
+}
+
+func TestService_HandleEventRepo(t *testing.T) {
+	ctx := context.Background()
+
+	base := repoevents.Base{RepoID: testRepoID, PrincipalID: testPrincipalID}
+
+	t.Run("created", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		err := env.service.handleEventRepoCreated(ctx, &events.Event[*repoevents.CreatedPayload]{
+			ID:      "1-0",
+			Payload: &repoevents.CreatedPayload{Base: base},
+		})
+		if err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		env.expectParents(t, repoParents()...)
+
+		var body RepositoryPayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRepoCreated {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRepoCreated, body.Trigger)
+		}
+		if body.Repo.ID != testRepoID || body.Repo.URL != "ui/root/space/repo" {
+			t.Errorf("unexpected repo info %+v", body.Repo)
+		}
+		if body.Principal.UID != "jdoe" {
+			t.Errorf("expected principal 'jdoe', got '%s'", body.Principal.UID)
+		}
+	})
+
+	t.Run("renamed", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		err := env.service.handleEventRepoRenamed(ctx, &events.Event[*repoevents.RenamedPayload]{
+			ID: "1-0",
+			Payload: &repoevents.RenamedPayload{
+				Base:          base,
+				OldIdentifier: "old",
+				NewIdentifier: "repo",
+				OldPath:       "root/space/old",
+				NewPath:       "root/space/repo",
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		env.expectParents(t, repoParents()...)
+
+		var body RepositoryRenamedPayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRepoRenamed {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRepoRenamed, body.Trigger)
+		}
+		if body.OldIdentifier != "old" || body.OldPath != "root/space/old" {
+			t.Errorf("unexpected old location %+v", body.RepositoryRenamedSegment)
+		}
+		if body.Repo.Path != "root/space/repo" {
+			t.Errorf("expected the new repo path 'root/space/repo', got '%s'", body.Repo.Path)
+		}
+	})
+
+	t.Run("visibility-changed", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		err := env.service.handleEventRepoVisibilityChanged(ctx,
+			&events.Event[*repoevents.PublicAccessChangedPayload]{
+				ID: "1-0",
+				Payload: &repoevents.PublicAccessChangedPayload{
+					Base:        base,
+					OldIsPublic: false,
+					NewIsPublic: true,
+				},
+			})
+		if err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		env.expectParents(t, repoParents()...)
+
+		var body RepositoryVisibilityPayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRepoVisibilityChanged {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRepoVisibilityChanged, body.Trigger)
+		}
+		if body.OldIsPublic || !body.NewIsPublic {
+			t.Errorf("unexpected visibility change %+v", body.RepositoryVisibilitySegment)
+		}
+	})
+
+	t.Run("missing-repo", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		env.expectDiscarded(t, env.service.handleEventRepoCreated(ctx, &events.Event[*repoevents.CreatedPayload]{
+			ID:      "1-0",
+			Payload: &repoevents.CreatedPayload{Base: repoevents.Base{RepoID: 99, PrincipalID: testPrincipalID}},
+		}))
+	})
+
+	t.Run("missing-principal", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		env.expectDiscarded(t, env.service.handleEventRepoCreated(ctx, &events.Event[*repoevents.CreatedPayload]{
+			ID:      "1-0",
+			Payload: &repoevents.CreatedPayload{Base: repoevents.Base{RepoID: testRepoID, PrincipalID: 99}},
+		}))
+	})
+}
+
+func TestService_HandleEventRepoDeleted(t *testing.T) {
+	ctx := context.Background()
+
+	const deleted = int64(1700000000000)
+
+	event := &events.Event[*repoevents.SoftDeletedPayload]{
+		ID: "1-0",
+		Payload: &repoevents.SoftDeletedPayload{
+			Base:     repoevents.Base{RepoID: testRepoID, PrincipalID: testPrincipalID},
+			RepoPath: "root/space/repo",
+			Deleted:  deleted,
+		},
+	}
+
+	t.Run("payload", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		// the repo is only found as deleted repo.
+		env.repos.deleted[deleted] = env.repos.repos[testRepoID]
+		delete(env.repos.repos, testRepoID)
+
+		if err := env.service.handleEventRepoDeleted(ctx, event); err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		// the webhooks of the parent spaces and the repo itself are expected.
+		env.expectParents(t,
+			types.WebhookParentInfo{ID: testSpaceID, Type: enum.WebhookParentSpace},
+			types.WebhookParentInfo{ID: testRootSpaceID, Type: enum.WebhookParentSpace},
+			types.WebhookParentInfo{ID: testRepoID, Type: enum.WebhookParentRepo},
+		)
+
+		var body RepositoryPayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRepoDeleted {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRepoDeleted, body.Trigger)
+		}
+		if body.Repo.ID != testRepoID || body.Repo.Path != "root/space/repo" {
+			t.Errorf("unexpected repo info %+v", body.Repo)
+		}
+		if body.Principal.ID != testPrincipalID {
+			t.Errorf("expected principal %d, got %d", testPrincipalID, body.Principal.ID)
+		}
+	})
+
+	t.Run("purged-or-restored", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		env.expectDiscarded(t, env.service.handleEventRepoDeleted(ctx, event))
+	})
+}
+
+func TestService_HandleEventRule(t *testing.T) {
+	ctx := context.Background()
+
+	repoID := testRepoID
+	spaceID := testSpaceID
+
+	created := func(ruleID int64) *events.Event[*ruleevents.CreatedPayload] {
+		return &events.Event[*ruleevents.CreatedPayload]{
+			ID:      "1-0",
+			Payload: &ruleevents.CreatedPayload{Base: ruleevents.Base{RuleID: ruleID, PrincipalID: testPrincipalID}},
+		}
+	}
+
+	t.Run("repo-rule", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		env.rules.rules[testRuleID] = &types.Rule{
+			ID:         testRuleID,
+			RepoID:     &repoID,
+			Identifier: "protect-main",
+			Type:       enum.RuleTypeBranch,
+			State:      enum.RuleStateActive,
+		}
+
+		if err := env.service.handleEventRuleCreated(ctx, created(testRuleID)); err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		env.expectParents(t, repoParents()...)
+
+		var body RulePayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRuleCreated {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRuleCreated, body.Trigger)
+		}
+		if body.Repo.ID != testRepoID {
+			t.Errorf("expected repo %d, got %d", testRepoID, body.Repo.ID)
+		}
+		if body.Rule.ID != testRuleID || body.Rule.Identifier != "protect-main" || body.Rule.SpacePath != "" {
+			t.Errorf("unexpected rule info %+v", body.Rule)
+		}
+	})
+
+	t.Run("space-rule", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		env.rules.rules[testRuleID] = &types.Rule{
+			ID:         testRuleID,
+			SpaceID:    &spaceID,
+			Identifier: "protect-all",
+			Type:       enum.RuleTypeBranch,
+			State:      enum.RuleStateMonitor,
+		}
+
+		err := env.service.handleEventRuleUpdated(ctx, &events.Event[*ruleevents.UpdatedPayload]{
+			ID:      "1-0",
+			Payload: &ruleevents.UpdatedPayload{Base: ruleevents.Base{RuleID: testRuleID, PrincipalID: testPrincipalID}},
+		})
+		if err != nil {
+			t.Fatalf("failed to handle event: %s", err)
+		}
+
+		// the webhooks of the space of the rule and all its ancestors are expected.
+		env.expectParents(t,
+			types.WebhookParentInfo{ID: testSpaceID, Type: enum.WebhookParentSpace},
+			types.WebhookParentInfo{ID: testRootSpaceID, Type: enum.WebhookParentSpace},
+		)
+
+		var body RulePayload
+		env.body(t, &body)
+
+		if body.Trigger != enum.WebhookTriggerRuleUpdated {
+			t.Errorf("expected trigger %s, got %s", enum.WebhookTriggerRuleUpdated, body.Trigger)
+		}
+		if body.Repo != (RepositoryInfo{}) {
+			t.Errorf("expected no repo for a space rule, got %+v", body.Repo)
+		}
+		if body.Rule.SpacePath != "root/space" || body.Rule.State != enum.RuleStateMonitor {
+			t.Errorf("unexpected rule info %+v", body.Rule)
+		}
+	})
+
+	t.Run("missing-rule", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+
+		env.expectDiscarded(t, env.service.handleEventRuleCreated(ctx, created(testRuleID)))
+	})
+
+	t.Run("missing-space", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		missingSpaceID := int64(99)
+		env.rules.rules[testRuleID] = &types.Rule{ID: testRuleID, SpaceID: &missingSpaceID}
+
+		env.expectDiscarded(t, env.service.handleEventRuleCreated(ctx, created(testRuleID)))
+	})
+
+	t.Run("missing-repo", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		missingRepoID := int64(99)
+		env.rules.rules[testRuleID] = &types.Rule{ID: testRuleID, RepoID: &missingRepoID}
+
+		env.expectDiscarded(t, env.service.handleEventRuleCreated(ctx, created(testRuleID)))
+	})
+
+	t.Run("no-parent", func(t *testing.T) {
+		env := newTestHandlerEnv(t)
+		env.rules.rules[testRuleID] = &types.Rule{ID: testRuleID}
+
+		env.expectDiscarded(t, env.service.handleEventRuleCreated(ctx, created(testRuleID)))
+	})
+}
+
+type testURLProvider struct {
+	url.Provider
+	webhookURL string
+}
+
+func (p *testURLProvider) GetWebhookURL(context.Context, *types.WebhookCore) (string, error) {
+	return p.webhookURL, nil
+}
+
+func (p *testURLProvider) GenerateUIRepoURL(_ context.Context, repoPath string) string {
+	return "ui/" + repoPath
+}
+
+func (p *testURLProvider) GenerateGITCloneURL(_ context.Context, repoPath string) string {
+	return "git/" + repoPath + ".git"
+}
+
+func (p *testURLProvider) GenerateGITCloneSSHURL(_ context.Context, repoPath string) string {
+	return "ssh/" + repoPath + ".git"
+}
+
+func (p *testURLProvider) GenerateUIBuildURL(
+	_ context.Context, repoPath, pipelineIdentifier string, seqNumber int64,
+) string {
+	return fmt.Sprintf("ui/%s/pipelines/%s/executions/%d", repoPath, pipelineIdentifier, seqNumber)
+}
+
+// testWebhookExecutorStore returns the same webhooks for all parents and records all listings and executions.
+type testWebhookExecutorStore struct {
+	WebhookExecutorStore
+	webhooks   []*types.WebhookCore
+	parents    [][]types.WebhookParentInfo
+	executions []*types.WebhookExecutionCore
+}
+
+func (s *testWebhookExecutorStore) ListWebhooks(
+	_ context.Context,
+	parents []types.WebhookParentInfo,
+) ([]*types.WebhookCore, error) {
+	s.parents = append(s.parents, parents)
+	return s.webhooks, nil
+}
+
+func (s *testWebhookExecutorStore) ListForTrigger(context.Context, string) ([]*types.WebhookExecutionCore, error) {
+	return nil, nil
+}
+
+func (s *testWebhookExecutorStore) CreateWebhookExecution(
+	_ context.Context,
+	execution *types.WebhookExecutionCore,
+) error {
+	s.executions = append(s.executions, execution)
+	return nil
+}
+
+func (s *testWebhookExecutorStore) UpdateOptLock(
+	_ context.Context,
+	hook *types.WebhookCore,
+	_ *types.WebhookExecutionCore,
+) (*types.WebhookCore, error) {
+	return hook, nil
+}
+
+type testPrincipalStore struct {
+	gitnessstore.PrincipalStore
+	principals map[int64]*types.Principal
+}
+
+func (s *testPrincipalStore) Find(_ context.Context, id int64) (*types.Principal, error) {
+	if principal, ok := s.principals[id]; ok {
+		return principal, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+// testRepoStore keeps active repos by ID and deleted repos by their deletion time.
+type testRepoStore struct {
+	gitnessstore.RepoStore
+	repos   map[int64]*types.Repository
+	deleted map[int64]*types.Repository
+}
+
+func (s *testRepoStore) Find(_ context.Context, id int64) (*types.Repository, error) {
+	if repo, ok := s.repos[id]; ok {
+		return repo, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (s *testRepoStore) FindDeleted(_ context.Context, id int64, deleted *int64) (*types.Repository, error) {
+	if repo, ok := s.deleted[*deleted]; ok && repo.ID == id {
+		return repo, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+type testSpaceStore struct {
+	gitnessstore.SpaceStore
+	spaces    map[int64]*types.Space
+	ancestors map[int64][]int64
+}
+
+func (s *testSpaceStore) Find(_ context.Context, id int64) (*types.Space, error) {
+	if space, ok := s.spaces[id]; ok {
+		return space, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+func (s *testSpaceStore) GetAncestorIDs(_ context.Context, spaceID int64) ([]int64, error) {
+	return s.ancestors[spaceID], nil
+}
+
+// testCheckStore keeps checks by "<sha>/<identifier>".
+type testCheckStore struct {
+	gitnessstore.CheckStore
+	checks map[string]types.Check
+}
+
+func (s *testCheckStore) FindByIdentifier(
+	_ context.Context,
+	_ int64,
+	commitSHA string,
+	identifier string,
+) (types.Check, error) {
+	if check, ok := s.checks[commitSHA+"/"+identifier]; ok {
+		return check, nil
+	}
+	return types.Check{}, store.ErrResourceNotFound
+}
+
+type testPipelineStore struct {
+	gitnessstore.PipelineStore
+	pipelines map[int64]*types.Pipeline
+}
+
+func (s *testPipelineStore) Find(_ context.Context, id int64) (*types.Pipeline, error) {
+	if pipeline, ok := s.pipelines[id]; ok {
+		return pipeline, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+// testExecutionStore keeps the executions of a single pipeline by their number.
+type testExecutionStore struct {
+	gitnessstore.ExecutionStore
+	executions map[int64]*types.Execution
+}
+
+func (s *testExecutionStore) FindByNumber(_ context.Context, _ int64, num int64) (*types.Execution, error) {
+	if execution, ok := s.executions[num]; ok {
+		return execution, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
+
+type testRuleStore struct {
+	gitnessstore.RuleStore
+	rules map[int64]*types.Rule
+}
+
+func (s *testRuleStore) Find(_ context.Context, id int64) (*types.Rule, error) {
+	if rule, ok := s.rules[id]; ok {
+		return rule, nil
+	}
+	return nil, store.ErrResourceNotFound
+}
@@ -30,7 +30,9 @@ var (
 	errPrivateNetworkNotAllowed = errors.New("private network not allowed")
 )
 
-func newHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
+// NewHTTPClient returns an http client that refuses to send data to loopback
+// and private network addresses unless explicitly allowed.
+func NewHTTPClient(allowLoopback bool, allowPrivateNetwork bool, disableSSLVerification bool) *http.Client {
 	// Clone http.DefaultTransport (used by http.DefaultClient)
 	tr := http.DefaultTransport.(*http.Transport).Clone() //nolint:errcheck
 
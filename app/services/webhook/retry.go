@@ -0,0 +1,300 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/job"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/rs/zerolog/log"
+	"go.uber.org/multierr"
+)
+
+const (
+	jobTypeWebhookRetry    = "webhook_retry"
+	jobWebhookRetryTimeout = time.Minute
+)
+
+// RetryJob redelivers webhook executions that failed with a retriable error.
+type RetryJob struct {
+	service *Service
+}
+
+var _ job.Handler = (*RetryJob)(nil)
+
+type retryJobInput struct {
+	ExecutionID int64 `json:"execution_id"`
+	// Attempt is the number of the automatic redelivery, starting with 1.
+	// NOTE: Manually requested redeliveries use 0 to get the full attempt budget for automatic redeliveries.
+	Attempt int `json:"attempt"`
+}
+
+// Handle redelivers the webhook execution and queues the next redelivery in case of a retriable error.
+func (j *RetryJob) Handle(ctx context.Context, data string, _ job.ProgressReporter) (string, error) {
+	var input retryJobInput
+	if err := json.Unmarshal([]byte(data), &input); err != nil {
+		return "", fmt.Errorf("failed to unmarshal job input json: %w", err)
+	}
+
+	s := j.service
+
+	execution, err := s.webhookExecutionStore.Find(ctx, input.ExecutionID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		// the execution got purged in the meantime - nothing to redeliver.
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook execution: %w", err)
+	}
+
+	webhook, err := s.webhookStore.Find(ctx, execution.WebhookID)
+	if errors.Is(err, store.ErrResourceNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	// don't redeliver to webhooks that got disabled in the meantime.
+	if !webhook.Enabled {
+		return "", nil
+	}
+
+	result, err := s.WebhookExecutor.RetriggerWebhookExecution(ctx, execution.ID)
+	if errors.Is(err, ErrWebhookNotRetriggerable) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to redeliver webhook execution: %w", err)
+	}
+
+	s.processExecutionResult(ctx, webhook.ID, result.Execution, input.Attempt)
+
+	return string(result.Execution.Result), nil
+}
+
+// triggerForEvent triggers all webhooks for the given parents and triggerType using the eventID to generate
+// a deterministic triggerID. Executions that fail with a retriable error are queued for a delayed redelivery,
+// hence the event itself is only reprocessed in case the webhooks couldn't be triggered at all.
+func (s *Service) triggerForEvent(
+	ctx context.Context,
+	eventID string,
+	parents []types.WebhookParentInfo,
+	triggerType enum.WebhookTrigger,
+	body any,
+) error {
+	triggerID := generateTriggerIDFromEventID(eventID)
+
+	results, err := s.WebhookExecutor.triggerWebhooksFor(ctx, parents, triggerID, triggerType, body)
+	if err != nil {
+		return fmt.Errorf(
+			"failed to trigger %s (id: '%s') for webhooks %#v: %w",
+			triggerType, triggerID, parents, err,
+		)
+	}
+
+	// Combine all errors into a single error to log (to reduce number of logs)
+	var errs error
+	for _, result := range results {
+		if result.Skipped() {
+			continue
+		}
+
+		if result.Execution.Result != enum.WebhookExecutionResultSuccess {
+			errs = multierr.Append(errs,
+				fmt.Errorf("execution %d of webhook %d resulted in %s: %w",
+					result.Execution.ID, result.Webhook.ID, result.Execution.Result, result.Err))
+		}
+
+		s.processExecutionResult(ctx, result.Webhook.ID, result.Execution, 0)
+	}
+
+	if errs != nil {
+		log.Ctx(ctx).Warn().Err(errs).Msgf("webhook execution for %#v had errors", parents)
+	}
+
+	return nil
+}
+
+// processExecutionResult queues a redelivery for executions with a retriable error (if the attempt budget allows)
+// and otherwise tracks the outcome of the delivery for the webhook.
+func (s *Service) processExecutionResult(
+	ctx context.Context,
+	webhookID int64,
+	execution *types.WebhookExecutionCore,
+	attempt int,
+) {
+	if execution == nil || execution.ID == 0 {
+		return
+	}
+
+	if execution.Result == enum.WebhookExecutionResultRetriableError &&
+		execution.Retriggerable && attempt < s.config.RetryMaxAttempts {
+		err := s.scheduleRetry(ctx, execution.ID, attempt+1)
+		if err == nil {
+			return
+		}
+
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to queue redelivery of webhook execution %d", execution.ID)
+	}
+
+	s.trackDeliveryOutcome(ctx, webhookID, execution)
+}
+
+// trackDeliveryOutcome updates the consecutive failure count of the webhook based on the execution result.
+// NOTE: errors are only logged, as the execution itself already happened.
+func (s *Service) trackDeliveryOutcome(
+	ctx context.Context,
+	webhookID int64,
+	execution *types.WebhookExecutionCore,
+) {
+	var err error
+	if execution.Result == enum.WebhookExecutionResultSuccess {
+		err = s.resetFailures(ctx, webhookID)
+	} else {
+		err = s.recordFailure(ctx, webhookID, execution.Error)
+	}
+
+	if err != nil {
+		log.Ctx(ctx).Warn().Err(err).Msgf("failed to update delivery outcome of webhook %d", webhookID)
+	}
+}
+
+// scheduleRetry queues a redelivery of the webhook execution after the backoff delay of the attempt.
+func (s *Service) scheduleRetry(ctx context.Context, executionID int64, attempt int) error {
+	return s.queueRedelivery(ctx, executionID, attempt, retryDelay(attempt, s.config.RetryBaseDelay,
+		s.config.RetryMaxDelay))
+}
+
+func (s *Service) queueRedelivery(ctx context.Context, executionID int64, attempt int, delay time.Duration) error {
+	data, err := json.Marshal(retryJobInput{
+		ExecutionID: executionID,
+		Attempt:     attempt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal job input json: %w", err)
+	}
+
+	jobUID, err := job.UID()
+	if err != nil {
+		return fmt.Errorf("failed to generate job uid: %w", err)
+	}
+
+	return s.scheduler.RunJob(ctx, job.Definition{
+		UID:        "webhook-retry-" + jobUID,
+		Type:       jobTypeWebhookRetry,
+		MaxRetries: 0,
+		Timeout:    jobWebhookRetryTimeout,
+		Data:       string(data),
+		Delay:      delay,
+	})
+}
+
+// resetFailures resets the consecutive failure count of the webhook after a successful delivery.
+func (s *Service) resetFailures(ctx context.Context, webhookID int64) error {
+	webhook, err := s.webhookStore.Find(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	if webhook.ConsecutiveFailures == 0 {
+		return nil
+	}
+
+	_, err = s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		hook.ConsecutiveFailures = 0
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset consecutive failures: %w", err)
+	}
+
+	return nil
+}
+
+// recordFailure increases the consecutive failure count of the webhook
+// and disables the webhook once the count reaches the configured threshold.
+func (s *Service) recordFailure(ctx context.Context, webhookID int64, lastError string) error {
+	webhook, err := s.webhookStore.Find(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("failed to find webhook: %w", err)
+	}
+
+	var disabled bool
+	webhook, err = s.webhookStore.UpdateOptLock(ctx, webhook, func(hook *types.Webhook) error {
+		disabled = false
+		hook.ConsecutiveFailures++
+
+		threshold := int64(s.config.AutoDisableThreshold)
+		if threshold > 0 && hook.Enabled && hook.ConsecutiveFailures >= threshold {
+			hook.Enabled = false
+			disabled = true
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to increase consecutive failures: %w", err)
+	}
+
+	if !disabled {
+		return nil
+	}
+
+	log.Ctx(ctx).Info().Msgf("webhook %d got disabled after %d consecutive failed deliveries",
+		webhook.ID, webhook.ConsecutiveFailures)
+
+	s.eventReporter.Disabled(ctx, &webhookevents.DisabledPayload{
+		Base: webhookevents.Base{
+			WebhookID:   webhook.ID,
+			PrincipalID: webhook.CreatedBy,
+		},
+		ConsecutiveFailures: int(webhook.ConsecutiveFailures),
+		LastError:           lastError,
+	})
+
+	return nil
+}
+
+// retryDelay returns the delay before the provided redelivery attempt (starting with 1).
+// The delay doubles with every attempt (capped at maxDelay) and is randomized by up to half its value
+// to avoid redelivering failed executions of the same outage all at once.
+func retryDelay(attempt int, baseDelay, maxDelay time.Duration) time.Duration {
+	delay := baseDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+
+	//nolint:gosec // the jitter doesn't have to be cryptographically secure.
+	return half + rand.N(half+1)
+}
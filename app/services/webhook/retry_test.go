@@ -0,0 +1,48 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRetryDelay(t *testing.T) {
+	const (
+		baseDelay = 30 * time.Second
+		maxDelay  = 10 * time.Minute
+	)
+
+	tests := []struct {
+		attempt int
+		exp     time.Duration
+	}{
+		{attempt: 1, exp: 30 * time.Second},
+		{attempt: 2, exp: time.Minute},
+		{attempt: 3, exp: 2 * time.Minute},
+		{attempt: 5, exp: 8 * time.Minute},
+		{attempt: 6, exp: maxDelay},
+		{attempt: 100, exp: maxDelay},
+	}
+
+	for _, test := range tests {
+		for range 10 {
+			got := retryDelay(test.attempt, baseDelay, maxDelay)
+			if got < test.exp/2 || got > test.exp {
+				t.Errorf("attempt %d: expected delay in [%s, %s], got %s", test.attempt, test.exp/2, test.exp, got)
+			}
+		}
+	}
+}
@@ -21,8 +21,13 @@ import (
 	"net/http"
 	"time"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -30,6 +35,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 	"github.com/harness/gitness/stream"
@@ -53,6 +59,16 @@ type Config struct {
 	AllowPrivateNetwork bool
 	AllowLoopback       bool
 	InternalSecret      string
+
+	// RetryMaxAttempts is the maximum number of redeliveries of an execution that failed with a retriable error.
+	RetryMaxAttempts int
+	// RetryBaseDelay is the delay before the first redelivery, it doubles with every further attempt.
+	RetryBaseDelay time.Duration
+	// RetryMaxDelay caps the delay between two redeliveries.
+	RetryMaxDelay time.Duration
+	// AutoDisableThreshold is the number of consecutive failed deliveries after which a webhook gets disabled.
+	// NOTE: A value of 0 disables the feature.
+	AutoDisableThreshold int
 }
 
 func (c *Config) Prepare() error {
@@ -71,11 +87,23 @@ func (c *Config) Prepare() error {
 	if c.MaxRetries < 0 {
 		return errors.New("Config.MaxRetries can't be negative")
 	}
+	if c.RetryMaxAttempts < 0 {
+		return errors.New("Config.RetryMaxAttempts can't be negative")
+	}
+	if c.RetryMaxAttempts > 0 && c.RetryBaseDelay <= 0 {
+		return errors.New("Config.RetryBaseDelay has to be a positive duration")
+	}
+	if c.AutoDisableThreshold < 0 {
+		return errors.New("Config.AutoDisableThreshold can't be negative")
+	}
 
 	// Backfill data
 	if c.HeaderIdentity == "" {
 		c.HeaderIdentity = c.UserAgentIdentity
 	}
+	if c.RetryMaxDelay < c.RetryBaseDelay {
+		c.RetryMaxDelay = c.RetryBaseDelay
+	}
 
 	return nil
 }
@@ -119,6 +147,7 @@ type WebhookExecutor struct {
 	secretService              secret.Service
 	principalStore             store.PrincipalStore
 	webhookExecutorStore       WebhookExecutorStore
+	signingKey                 *SigningKey
 	source                     string
 }
 
@@ -130,20 +159,22 @@ func NewWebhookExecutor(
 	secretService secret.Service,
 	principalStore store.PrincipalStore,
 	webhookExecutorStore WebhookExecutorStore,
+	signingKey *SigningKey,
 	source string,
 ) *WebhookExecutor {
 	return &WebhookExecutor{
 		webhookExecutorStore:       webhookExecutorStore,
-		secureHTTPClient:           newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
-		insecureHTTPClient:         newHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
-		secureHTTPClientInternal:   newHTTPClient(config.AllowLoopback, true, false),
-		insecureHTTPClientInternal: newHTTPClient(config.AllowLoopback, true, true),
+		secureHTTPClient:           NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, false),
+		insecureHTTPClient:         NewHTTPClient(config.AllowLoopback, config.AllowPrivateNetwork, true),
+		secureHTTPClientInternal:   NewHTTPClient(config.AllowLoopback, true, false),
+		insecureHTTPClientInternal: NewHTTPClient(config.AllowLoopback, true, true),
 		config:                     config,
 		webhookURLProvider:         webhookURLProvider,
 		encrypter:                  encrypter,
 		spacePathStore:             spacePathStore,
 		secretService:              secretService,
 		principalStore:             principalStore,
+		signingKey:                 signingKey,
 		source:                     source,
 	}
 }
@@ -167,6 +198,12 @@ type Service struct {
 	config                Config
 	auditService          audit.Service
 	sseStreamer           sse.Streamer
+	pipelineStore         store.PipelineStore
+	executionStore        store.ExecutionStore
+	checkStore            store.CheckStore
+	ruleStore             store.RuleStore
+	scheduler             *job.Scheduler
+	eventReporter         *webhookevents.Reporter
 }
 
 func NewService(
@@ -175,6 +212,10 @@ func NewService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	ruleReaderFactory *events.ReaderFactory[*ruleevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -192,6 +233,14 @@ func NewService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	checkStore store.CheckStore,
+	ruleStore store.RuleStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	eventReporter *webhookevents.Reporter,
+	signingKey *SigningKey,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service Config is invalid: %w", err)
@@ -201,7 +250,7 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 	}
 	executor := NewWebhookExecutor(config, webhookURLProvider, encrypter, spacePathStore,
-		secretService, principalStore, webhookExecutorStore, RepoTrigger)
+		secretService, principalStore, webhookExecutorStore, signingKey, RepoTrigger)
 
 	service := &Service{
 		WebhookExecutor:       executor,
@@ -221,6 +270,16 @@ func NewService(
 		labelValueStore:       labelValueStore,
 		auditService:          auditService,
 		sseStreamer:           sseStreamer,
+		pipelineStore:         pipelineStore,
+		executionStore:        executionStore,
+		checkStore:            checkStore,
+		ruleStore:             ruleStore,
+		scheduler:             scheduler,
+		eventReporter:         eventReporter,
+	}
+
+	if err := jobExecutor.Register(jobTypeWebhookRetry, &RetryJob{service: service}); err != nil {
+		return nil, fmt.Errorf("failed to register webhook retry job: %w", err)
 	}
 
 	_, err := gitReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
@@ -271,6 +330,7 @@ func NewService(
 			_ = r.RegisterReviewSubmitted(service.handleEventPullReqReviewSubmitted)
 			_ = r.RegisterCommentStatusUpdated(service.handleEventPullReqCommentStatusUpdated)
 			_ = r.RegisterTargetBranchChanged(service.handleEventPullReqTargetBranchChanged)
+			_ = r.RegisterReactionCreated(service.handleEventPullReqReactionCreated)
 
 			return nil
 		})
@@ -278,5 +338,86 @@ func NewService(
 		return nil, fmt.Errorf("failed to launch pr event reader for webhooks: %w", err)
 	}
 
+	_, err = pipelineReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *pipelineevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterStarted(service.handleEventExecutionStarted)
+			_ = r.RegisterExecuted(service.handleEventExecutionFinished)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch pipeline event reader for webhooks: %w", err)
+	}
+
+	_, err = checkReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *checkevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterReported(service.handleEventCheckReported)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch check event reader for webhooks: %w", err)
+	}
+
+	_, err = repoReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *repoevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRepoCreated)
+			_ = r.RegisterRenamed(service.handleEventRepoRenamed)
+			_ = r.RegisterPublicAccessChanged(service.handleEventRepoVisibilityChanged)
+			_ = r.RegisterSoftDeleted(service.handleEventRepoDeleted)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch repo event reader for webhooks: %w", err)
+	}
+
+	_, err = ruleReaderFactory.Launch(ctx, eventsReaderGroupName, config.EventReaderName,
+		func(r *ruleevents.Reader) error {
+			const idleTimeout = 1 * time.Minute
+			r.Configure(
+				stream.WithConcurrency(config.Concurrency),
+				stream.WithHandlerOptions(
+					stream.WithIdleTimeout(idleTimeout),
+					stream.WithMaxRetries(config.MaxRetries),
+				))
+
+			// register events
+			_ = r.RegisterCreated(service.handleEventRuleCreated)
+			_ = r.RegisterUpdated(service.handleEventRuleUpdated)
+
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to launch rule event reader for webhooks: %w", err)
+	}
+
 	return service, nil
 }
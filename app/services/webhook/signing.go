@@ -0,0 +1,191 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/encrypt"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// signatureVersionHMAC identifies signatures generated with HMAC-SHA256 using the webhook secret.
+	signatureVersionHMAC = "v1"
+	// signatureVersionEd25519 identifies signatures generated with the Ed25519 signing key of the instance.
+	signatureVersionEd25519 = "v1a"
+)
+
+// SigningKey is the Ed25519 key of the instance used to sign requests of webhooks with the ed25519 signature mode.
+// Receivers verify such requests using the published public key, without the need of a shared secret.
+type SigningKey struct {
+	privateKey ed25519.PrivateKey
+}
+
+func NewSigningKey(privateKey ed25519.PrivateKey) *SigningKey {
+	return &SigningKey{
+		privateKey: privateKey,
+	}
+}
+
+// PublicKey returns the public key used to verify signatures of the signing key.
+func (k *SigningKey) PublicKey() ed25519.PublicKey {
+	//nolint:errcheck // an ed25519 private key always returns an ed25519 public key.
+	return k.privateKey.Public().(ed25519.PublicKey)
+}
+
+// Sign signs the provided data.
+func (k *SigningKey) Sign(data []byte) []byte {
+	return ed25519.Sign(k.privateKey, data)
+}
+
+// Info returns the public information of the signing key.
+func (k *SigningKey) Info() (*types.WebhookSigningKey, error) {
+	publicKey := k.PublicKey()
+
+	der, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal public key: %w", err)
+	}
+
+	return &types.WebhookSigningKey{
+		Algorithm:    string(enum.WebhookSignatureModeEd25519),
+		PublicKey:    base64.StdEncoding.EncodeToString(publicKey),
+		PublicKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})),
+	}, nil
+}
+
+// loadSigningKey loads the signing key of the instance from the system settings.
+// In case there is no signing key yet, a new key is generated and stored (encrypted) in the system settings.
+// The key is only stored if no other instance stored one in the meantime, and it's always read back,
+// so all instances end up signing with the same key.
+func loadSigningKey(
+	ctx context.Context,
+	settingsSrv *settings.Service,
+	encrypter encrypt.Encrypter,
+) (*SigningKey, error) {
+	encryptedSeed := settings.DefaultWebhookSigningKey
+	_, err := settingsSrv.SystemGet(ctx, settings.KeyWebhookSigningKey, &encryptedSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find webhook signing key: %w", err)
+	}
+
+	if encryptedSeed == "" {
+		seed := make([]byte, ed25519.SeedSize)
+		if _, err = rand.Read(seed); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook signing key: %w", err)
+		}
+
+		encrypted, err := encrypter.Encrypt(base64.StdEncoding.EncodeToString(seed))
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt webhook signing key: %w", err)
+		}
+
+		_, err = settingsSrv.SystemSetIfAbsent(ctx, settings.KeyWebhookSigningKey,
+			base64.StdEncoding.EncodeToString(encrypted))
+		if err != nil {
+			return nil, fmt.Errorf("failed to store webhook signing key: %w", err)
+		}
+
+		_, err = settingsSrv.SystemGet(ctx, settings.KeyWebhookSigningKey, &encryptedSeed)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find stored webhook signing key: %w", err)
+		}
+	}
+
+	encrypted, err := base64.StdEncoding.DecodeString(encryptedSeed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode webhook signing key: %w", err)
+	}
+
+	seedBase64, err := encrypter.Decrypt(encrypted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt webhook signing key: %w", err)
+	}
+
+	seed, err := base64.StdEncoding.DecodeString(seedBase64)
+	if err != nil || len(seed) != ed25519.SeedSize {
+		return nil, fmt.Errorf("webhook signing key is invalid")
+	}
+
+	return NewSigningKey(ed25519.NewKeyFromSeed(seed)), nil
+}
+
+// generateDeliveryID returns the delivery id of the webhook request for the trigger.
+// The id is derived from the trigger id, hence redeliveries of the same trigger (retries and retriggers)
+// use the same delivery id, which allows receivers to deduplicate them.
+func generateDeliveryID(triggerID string, webhookID int64) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(triggerID+"/"+strconv.FormatInt(webhookID, 10))).String()
+}
+
+// generateSignatures generates the versioned signatures of a webhook request.
+// The signed content contains the delivery id and timestamp of the request alongside the body,
+// which allows receivers to reject replayed requests. The result is a space separated list of
+// "<version>,<base64 signature>" entries (compatible with the Standard Webhooks specification).
+func generateSignatures(
+	mode enum.WebhookSignatureMode,
+	secret string,
+	signingKey *SigningKey,
+	deliveryID string,
+	timestamp int64,
+	body []byte,
+) string {
+	content := make([]byte, 0, len(deliveryID)+len(body)+32)
+	content = append(content, deliveryID...)
+	content = append(content, '.')
+	content = strconv.AppendInt(content, timestamp, 10)
+	content = append(content, '.')
+	content = append(content, body...)
+
+	var signatures []string
+
+	if secret != "" {
+		mac := hmac.New(sha256.New, []byte(secret))
+		// NOTE: hash.Write never returns an error
+		_, _ = mac.Write(content)
+		signatures = append(signatures,
+			signatureVersionHMAC+","+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	}
+
+	if mode == enum.WebhookSignatureModeEd25519 && signingKey != nil {
+		signatures = append(signatures,
+			signatureVersionEd25519+","+base64.StdEncoding.EncodeToString(signingKey.Sign(content)))
+	}
+
+	return strings.Join(signatures, " ")
+}
+
+// SigningKeyInfo returns the public information of the signing key used for webhooks with the ed25519 signature mode.
+func (s *Service) SigningKeyInfo() (*types.WebhookSigningKey, error) {
+	if s.WebhookExecutor.signingKey == nil {
+		return nil, fmt.Errorf("webhook signing key is not configured")
+	}
+
+	return s.WebhookExecutor.signingKey.Info()
+}
@@ -0,0 +1,221 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/google/uuid"
+)
+
+func TestGenerateSignatures(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	signingKey := NewSigningKey(ed25519.NewKeyFromSeed(seed))
+
+	const (
+		secret     = "secret"
+		deliveryID = "delivery"
+		timestamp  = int64(1700000000)
+	)
+	body := []byte(`{"trigger":"branch_created"}`)
+	content := []byte("delivery.1700000000." + string(body))
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write(content)
+	expectedHMAC := "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	tests := []struct {
+		name     string
+		mode     enum.WebhookSignatureMode
+		secret   string
+		key      *SigningKey
+		versions []string
+	}{
+		{name: "no secret", mode: enum.WebhookSignatureModeHMAC},
+		{name: "hmac", mode: enum.WebhookSignatureModeHMAC, secret: secret, key: signingKey, versions: []string{"v1"}},
+		{name: "ed25519", mode: enum.WebhookSignatureModeEd25519, key: signingKey, versions: []string{"v1a"}},
+		{name: "ed25519 without key", mode: enum.WebhookSignatureModeEd25519},
+		{
+			name:     "ed25519 with secret",
+			mode:     enum.WebhookSignatureModeEd25519,
+			secret:   secret,
+			key:      signingKey,
+			versions: []string{"v1", "v1a"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := generateSignatures(test.mode, test.secret, test.key, deliveryID, timestamp, body)
+			if len(test.versions) == 0 {
+				if header != "" {
+					t.Fatalf("expected no signatures, got %q", header)
+				}
+				return
+			}
+
+			signatures := strings.Split(header, " ")
+			if len(signatures) != len(test.versions) {
+				t.Fatalf("expected %d signatures, got %q", len(test.versions), header)
+			}
+
+			for i, signature := range signatures {
+				version, value, _ := strings.Cut(signature, ",")
+				if version != test.versions[i] {
+					t.Fatalf("expected version %q, got %q", test.versions[i], version)
+				}
+
+				switch version {
+				case "v1":
+					if signature != expectedHMAC {
+						t.Errorf("expected hmac signature %q, got %q", expectedHMAC, signature)
+					}
+				case "v1a":
+					raw, err := base64.StdEncoding.DecodeString(value)
+					if err != nil {
+						t.Fatalf("failed to decode signature: %v", err)
+					}
+					if !ed25519.Verify(signingKey.PublicKey(), content, raw) {
+						t.Errorf("ed25519 signature verification failed")
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateDeliveryID(t *testing.T) {
+	id := generateDeliveryID("event-1", 1)
+	if _, err := uuid.Parse(id); err != nil {
+		t.Fatalf("expected delivery id to be a uuid, got %q: %v", id, err)
+	}
+
+	if again := generateDeliveryID("event-1", 1); again != id {
+		t.Errorf("expected redelivery to reuse delivery id %q, got %q", id, again)
+	}
+
+	if other := generateDeliveryID("event-2", 1); other == id {
+		t.Errorf("expected different triggers to have different delivery ids")
+	}
+
+	if other := generateDeliveryID("event-1", 2); other == id {
+		t.Errorf("expected different webhooks to have different delivery ids")
+	}
+}
+
+// plainEncrypter stores values unencrypted.
+type plainEncrypter struct{}
+
+func (plainEncrypter) Encrypt(plaintext string) ([]byte, error) {
+	return []byte(plaintext), nil
+}
+
+func (plainEncrypter) Decrypt(ciphertext []byte) (string, error) {
+	return string(ciphertext), nil
+}
+
+// signingKeySettingsStore keeps system settings in memory.
+// The concurrent value, if set, is stored by InsertIfAbsent before inserting, to simulate a concurrent instance.
+type signingKeySettingsStore struct {
+	store.SettingsStore
+	values     map[string]json.RawMessage
+	concurrent json.RawMessage
+}
+
+func (s *signingKeySettingsStore) Find(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+) (json.RawMessage, error) {
+	value, ok := s.values[key]
+	if !ok {
+		return nil, gitness_store.ErrResourceNotFound
+	}
+	return value, nil
+}
+
+func (s *signingKeySettingsStore) InsertIfAbsent(
+	_ context.Context,
+	_ enum.SettingsScope,
+	_ int64,
+	key string,
+	value json.RawMessage,
+) (bool, error) {
+	if s.concurrent != nil {
+		s.values[key] = s.concurrent
+	}
+
+	if _, ok := s.values[key]; ok {
+		return false, nil
+	}
+
+	s.values[key] = value
+	return true, nil
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	ctx := context.Background()
+	key := string(settings.KeyWebhookSigningKey)
+
+	seed := make([]byte, ed25519.SeedSize)
+	seed[0] = 1
+	stored, _ := json.Marshal(base64.StdEncoding.EncodeToString([]byte(base64.StdEncoding.EncodeToString(seed))))
+	storedKey := NewSigningKey(ed25519.NewKeyFromSeed(seed))
+
+	t.Run("generated", func(t *testing.T) {
+		settingsStore := &signingKeySettingsStore{values: map[string]json.RawMessage{}}
+
+		signingKey, err := loadSigningKey(ctx, settings.NewService(settingsStore), plainEncrypter{})
+		if err != nil {
+			t.Fatalf("failed to load signing key: %v", err)
+		}
+		if _, ok := settingsStore.values[key]; !ok {
+			t.Fatalf("expected generated signing key to be stored")
+		}
+
+		again, err := loadSigningKey(ctx, settings.NewService(settingsStore), plainEncrypter{})
+		if err != nil {
+			t.Fatalf("failed to load signing key: %v", err)
+		}
+		if !again.PublicKey().Equal(signingKey.PublicKey()) {
+			t.Errorf("expected the stored signing key to be loaded")
+		}
+	})
+
+	t.Run("stored concurrently", func(t *testing.T) {
+		settingsStore := &signingKeySettingsStore{values: map[string]json.RawMessage{}, concurrent: stored}
+
+		signingKey, err := loadSigningKey(ctx, settings.NewService(settingsStore), plainEncrypter{})
+		if err != nil {
+			t.Fatalf("failed to load signing key: %v", err)
+		}
+		if !signingKey.PublicKey().Equal(storedKey.PublicKey()) {
+			t.Errorf("expected the signing key stored by the other instance to be used")
+		}
+	})
+}
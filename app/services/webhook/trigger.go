@@ -24,6 +24,7 @@ import (
 	"net"
 	"net/http"
 	"slices"
+	"strconv"
 	"time"
 
 	gitnessstore "github.com/harness/gitness/app/store"
@@ -322,6 +323,16 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 		bBuff.Write(bBytes)
 
 	default:
+		// chat platforms expect their own message format - render the payload accordingly.
+		if webhook.Format != "" && webhook.Format != enum.WebhookFormatNative {
+			body, err = renderChatBody(webhook.Format, triggerType, body)
+			if err != nil {
+				execution.Error = "an error occurred preparing the request body"
+				execution.Result = enum.WebhookExecutionResultFatalError
+				return nil, fmt.Errorf("failed to render body for format %s: %w", webhook.Format, err)
+			}
+		}
+
 		// all other types we json serialize
 		err := json.NewEncoder(bBuff).Encode(body)
 		if err != nil {
@@ -390,6 +401,16 @@ func (w *WebhookExecutor) prepareHTTPRequest(
 		req.Header.Add(w.toXHeader("Signature"), hmac)
 	}
 
+	// add versioned signatures that cover delivery id and timestamp to allow receivers to detect replays.
+	deliveryID := generateDeliveryID(execution.TriggerID, webhook.ID)
+	timestamp := time.Now().Unix()
+	if signatures := generateSignatures(webhook.SignatureMode, secretValue, w.signingKey,
+		deliveryID, timestamp, bBuff.Bytes()); signatures != "" {
+		req.Header.Add(w.toXHeader("Webhook-Delivery-Id"), deliveryID)
+		req.Header.Add(w.toXHeader("Webhook-Timestamp"), strconv.FormatInt(timestamp, 10))
+		req.Header.Add(w.toXHeader("Webhook-Signature"), signatures)
+	}
+
 	// Create a copy of headers for execution history with masked values
 	headersForExecution := req.Header.Clone()
 	if webhook.ExtraHeaders != nil {
@@ -584,7 +605,10 @@ func GitnessWebhookToWebhookCore(webhook *types.Webhook) *types.WebhookCore {
 		Enabled:               webhook.Enabled,
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
+		Format:                webhook.Format,
+		SignatureMode:         webhook.SignatureMode,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
 		ExtraHeaders:          webhook.ExtraHeaders,
 	}
 }
@@ -608,7 +632,10 @@ func CoreWebhookToGitnessWebhook(webhook *types.WebhookCore) *types.Webhook {
 		Enabled:               webhook.Enabled,
 		Insecure:              webhook.Insecure,
 		Triggers:              webhook.Triggers,
+		Format:                webhook.Format,
+		SignatureMode:         webhook.SignatureMode,
 		LatestExecutionResult: webhook.LatestExecutionResult,
+		ConsecutiveFailures:   webhook.ConsecutiveFailures,
 		ExtraHeaders:          webhook.ExtraHeaders,
 	}
 }
@@ -85,6 +85,13 @@ type PullReqCommentStatusUpdatedSegment struct {
 	Status enum.PullReqCommentStatus `json:"status"`
 }
 
+// PullReqReactionSegment contains details for all pull req reaction related payloads for webhooks.
+type PullReqReactionSegment struct {
+	Reaction enum.PullReqReaction `json:"reaction"`
+	// Comment is set if the reaction is to a pull request comment.
+	Comment *CommentInfo `json:"comment,omitempty"`
+}
+
 // PullReqLabelSegment contains details for all pull req label related payloads for webhooks.
 type PullReqLabelSegment struct {
 	LabelInfo LabelInfo `json:"label"`
@@ -110,6 +117,69 @@ type PullReqTargetBrancheChangedSegment struct {
 	OldMergeBaseSHA string `json:"old_merge_base_sha"`
 }
 
+// ExecutionSegment contains details for all pipeline execution related payloads for webhooks.
+type ExecutionSegment struct {
+	Execution ExecutionInfo `json:"execution"`
+}
+
+// CheckSegment contains details for all status check related payloads for webhooks.
+type CheckSegment struct {
+	Check CheckInfo `json:"check"`
+}
+
+// RepositoryRenamedSegment contains details of the previous repository location for webhooks.
+type RepositoryRenamedSegment struct {
+	OldIdentifier string `json:"old_identifier"`
+	OldPath       string `json:"old_path"`
+}
+
+// RepositoryVisibilitySegment contains details of the repository visibility change for webhooks.
+type RepositoryVisibilitySegment struct {
+	OldIsPublic bool `json:"old_is_public"`
+	NewIsPublic bool `json:"new_is_public"`
+}
+
+// RuleSegment contains details for all protection rule related payloads for webhooks.
+type RuleSegment struct {
+	Rule RuleInfo `json:"rule"`
+}
+
+// ExecutionPayload describes the payload of pipeline execution related webhook triggers.
+type ExecutionPayload struct {
+	BaseSegment
+	ExecutionSegment
+}
+
+// CheckPayload describes the payload of status check related webhook triggers.
+type CheckPayload struct {
+	BaseSegment
+	CheckSegment
+}
+
+// RepositoryPayload describes the payload of repository created and deleted webhook triggers.
+type RepositoryPayload struct {
+	BaseSegment
+}
+
+// RepositoryRenamedPayload describes the payload of repository renamed webhook triggers.
+type RepositoryRenamedPayload struct {
+	BaseSegment
+	RepositoryRenamedSegment
+}
+
+// RepositoryVisibilityPayload describes the payload of repository visibility changed webhook triggers.
+type RepositoryVisibilityPayload struct {
+	BaseSegment
+	RepositoryVisibilitySegment
+}
+
+// RulePayload describes the payload of protection rule related webhook triggers.
+// The repo of the base segment is empty for rules defined on a space.
+type RulePayload struct {
+	BaseSegment
+	RuleSegment
+}
+
 // RepositoryInfo describes the repo related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type RepositoryInfo struct {
@@ -195,6 +265,103 @@ func pullReqInfoFrom(
 	}
 }
 
+// ExecutionInfo describes the pipeline execution related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type ExecutionInfo struct {
+	PipelineIdentifier string            `json:"pipeline_identifier"`
+	Number             int64             `json:"number"`
+	Status             enum.CIStatus     `json:"status"`
+	Error              string            `json:"error,omitempty"`
+	Event              enum.TriggerEvent `json:"event,omitempty"`
+	Trigger            string            `json:"trigger,omitempty"`
+	Ref                string            `json:"ref,omitempty"`
+	SHA                string            `json:"sha,omitempty"`
+	Source             string            `json:"source,omitempty"`
+	Target             string            `json:"target,omitempty"`
+	Created            int64             `json:"created"`
+	Started            int64             `json:"started,omitempty"`
+	Finished           int64             `json:"finished,omitempty"`
+	URL                string            `json:"url"`
+}
+
+// executionInfoFrom gets the ExecutionInfo from a types.Execution.
+func executionInfoFrom(
+	ctx context.Context,
+	execution *types.Execution,
+	pipeline *types.Pipeline,
+	repo *types.Repository,
+	urlProvider url.Provider,
+) ExecutionInfo {
+	return ExecutionInfo{
+		PipelineIdentifier: pipeline.Identifier,
+		Number:             execution.Number,
+		Status:             execution.Status,
+		Error:              execution.Error,
+		Event:              execution.Event,
+		Trigger:            execution.Trigger,
+		Ref:                execution.Ref,
+		SHA:                execution.After,
+		Source:             execution.Source,
+		Target:             execution.Target,
+		Created:            execution.Created,
+		Started:            execution.Started,
+		Finished:           execution.Finished,
+		URL:                urlProvider.GenerateUIBuildURL(ctx, repo.Path, pipeline.Identifier, execution.Number),
+	}
+}
+
+// CheckInfo describes the status check related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type CheckInfo struct {
+	Identifier string           `json:"identifier"`
+	SHA        string           `json:"sha"`
+	Status     enum.CheckStatus `json:"status"`
+	Summary    string           `json:"summary"`
+	Link       string           `json:"link"`
+	Started    int64            `json:"started,omitempty"`
+	Ended      int64            `json:"ended,omitempty"`
+}
+
+// checkInfoFrom gets the CheckInfo from a types.Check.
+func checkInfoFrom(check *types.Check) CheckInfo {
+	return CheckInfo{
+		Identifier: check.Identifier,
+		SHA:        check.CommitSHA,
+		Status:     check.Status,
+		Summary:    check.Summary,
+		Link:       check.Link,
+		Started:    check.Started,
+		Ended:      check.Ended,
+	}
+}
+
+// RuleInfo describes the protection rule related info for a webhook payload.
+// NOTE: don't use types package as we want webhook payload to be independent from API calls.
+type RuleInfo struct {
+	ID          int64          `json:"id"`
+	Identifier  string         `json:"identifier"`
+	Description string         `json:"description"`
+	Type        enum.RuleType  `json:"type"`
+	State       enum.RuleState `json:"state"`
+	SpacePath   string         `json:"space_path,omitempty"`
+	Created     int64          `json:"created"`
+	Updated     int64          `json:"updated"`
+}
+
+// ruleInfoFrom gets the RuleInfo from a types.Rule.
+func ruleInfoFrom(rule *types.Rule, spacePath string) RuleInfo {
+	return RuleInfo{
+		ID:          rule.ID,
+		Identifier:  rule.Identifier,
+		Description: rule.Description,
+		Type:        rule.Type,
+		State:       rule.State,
+		SpacePath:   spacePath,
+		Created:     rule.Created,
+		Updated:     rule.Updated,
+	}
+}
+
 // PrincipalInfo describes the principal related info for a webhook payload.
 // NOTE: don't use types package as we want webhook payload to be independent from API calls.
 type PrincipalInfo struct {
@@ -64,6 +64,20 @@ func (s *Service) sanitizeUpdateInput(in *types.WebhookUpdateInput) error {
 			return err
 		}
 	}
+	if in.Format != nil {
+		format, err := CheckFormat(*in.Format)
+		if err != nil {
+			return err
+		}
+		in.Format = &format
+	}
+	if in.SignatureMode != nil {
+		signatureMode, err := CheckSignatureMode(*in.SignatureMode)
+		if err != nil {
+			return err
+		}
+		in.SignatureMode = &signatureMode
+	}
 	if in.ExtraHeaders != nil {
 		if err := CheckExtraHeaders(in.ExtraHeaders); err != nil {
 			return err
@@ -116,11 +130,21 @@ func (s *Service) Update(
 		hook.Secret = string(encryptedSecret)
 	}
 	if in.Enabled != nil {
+		// give re-enabled webhooks a fresh start, otherwise the next failure would disable them again.
+		if *in.Enabled && !hook.Enabled {
+			hook.ConsecutiveFailures = 0
+		}
 		hook.Enabled = *in.Enabled
 	}
 	if in.Insecure != nil {
 		hook.Insecure = *in.Insecure
 	}
+	if in.Format != nil {
+		hook.Format = *in.Format
+	}
+	if in.SignatureMode != nil {
+		hook.SignatureMode = *in.SignatureMode
+	}
 	if in.Triggers != nil {
 		hook.Triggers = DeduplicateTriggers(in.Triggers)
 	}
@@ -17,8 +17,14 @@ package webhook
 import (
 	"context"
 
+	checkevents "github.com/harness/gitness/app/events/check"
 	gitevents "github.com/harness/gitness/app/events/git"
+	pipelineevents "github.com/harness/gitness/app/events/pipeline"
 	pullreqevents "github.com/harness/gitness/app/events/pullreq"
+	repoevents "github.com/harness/gitness/app/events/repo"
+	ruleevents "github.com/harness/gitness/app/events/rule"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
+	"github.com/harness/gitness/app/services/settings"
 	"github.com/harness/gitness/app/sse"
 	"github.com/harness/gitness/app/store"
 	"github.com/harness/gitness/app/url"
@@ -26,6 +32,7 @@ import (
 	"github.com/harness/gitness/encrypt"
 	"github.com/harness/gitness/events"
 	"github.com/harness/gitness/git"
+	"github.com/harness/gitness/job"
 	"github.com/harness/gitness/secret"
 	"github.com/harness/gitness/store/database/dbtx"
 
@@ -36,6 +43,7 @@ import (
 var WireSet = wire.NewSet(
 	ProvideService,
 	ProvideURLProvider,
+	ProvideSigningKey,
 )
 
 func ProvideService(
@@ -44,6 +52,10 @@ func ProvideService(
 	tx dbtx.Transactor,
 	gitReaderFactory *events.ReaderFactory[*gitevents.Reader],
 	prReaderFactory *events.ReaderFactory[*pullreqevents.Reader],
+	pipelineReaderFactory *events.ReaderFactory[*pipelineevents.Reader],
+	checkReaderFactory *events.ReaderFactory[*checkevents.Reader],
+	repoReaderFactory *events.ReaderFactory[*repoevents.Reader],
+	ruleReaderFactory *events.ReaderFactory[*ruleevents.Reader],
 	webhookStore store.WebhookStore,
 	webhookExecutionStore store.WebhookExecutionStore,
 	spaceStore store.SpaceStore,
@@ -61,6 +73,14 @@ func ProvideService(
 	sseStreamer sse.Streamer,
 	secretService secret.Service,
 	spacePathStore store.SpacePathStore,
+	pipelineStore store.PipelineStore,
+	executionStore store.ExecutionStore,
+	checkStore store.CheckStore,
+	ruleStore store.RuleStore,
+	scheduler *job.Scheduler,
+	jobExecutor *job.Executor,
+	eventReporter *webhookevents.Reporter,
+	signingKey *SigningKey,
 ) (*Service, error) {
 	return NewService(
 		ctx,
@@ -68,6 +88,10 @@ func ProvideService(
 		tx,
 		gitReaderFactory,
 		prReaderFactory,
+		pipelineReaderFactory,
+		checkReaderFactory,
+		repoReaderFactory,
+		ruleReaderFactory,
 		webhookStore,
 		webhookExecutionStore,
 		spaceStore, repoStore,
@@ -84,9 +108,26 @@ func ProvideService(
 		sseStreamer,
 		secretService,
 		spacePathStore,
+		pipelineStore,
+		executionStore,
+		checkStore,
+		ruleStore,
+		scheduler,
+		jobExecutor,
+		eventReporter,
+		signingKey,
 	)
 }
 
 func ProvideURLProvider(ctx context.Context) URLProvider {
 	return NewURLProvider(ctx)
 }
+
+// ProvideSigningKey provides the Ed25519 signing key of the instance used for webhook signatures.
+func ProvideSigningKey(
+	ctx context.Context,
+	settingsSrv *settings.Service,
+	encrypter encrypt.Encrypter,
+) (*SigningKey, error) {
+	return loadSigningKey(ctx, settingsSrv, encrypter)
+}
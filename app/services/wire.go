@@ -18,6 +18,7 @@ import (
 	"github.com/harness/gitness/app/services/aitaskevent"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
+	"github.com/harness/gitness/app/services/emailreply"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspacedeleteevent"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -31,6 +32,7 @@ import (
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/pullreq"
 	"github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/slashcommand"
 	"github.com/harness/gitness/app/services/trigger"
 	"github.com/harness/gitness/app/services/webhook"
 	"github.com/harness/gitness/job"
@@ -54,6 +56,7 @@ type Services struct {
 	RepoSizeCalculator             *repo.SizeCalculator
 	Repo                           *repo.Service
 	Cleanup                        *cleanup.Service
+	EmailReply                     *emailreply.Server
 	Notification                   *notification.Service
 	Keywordsearch                  *keywordsearch.Service
 	GitspaceService                *GitspaceServices
@@ -64,6 +67,7 @@ type Services struct {
 	Branch                         *branch.Service
 	registryAsyncProcessingService *registryasyncprocessing.Service
 	languageAnalyzer               languageanalyzer.LanguageAnalyzer
+	slashCommand                   *slashcommand.Service
 }
 
 type GitspaceServices struct {
@@ -105,6 +109,7 @@ func ProvideServices(
 	repoSizeCalculator *repo.SizeCalculator,
 	repo *repo.Service,
 	cleanupSvc *cleanup.Service,
+	emailReplyServer *emailreply.Server,
 	notificationSvc *notification.Service,
 	keywordsearchSvc *keywordsearch.Service,
 	gitspaceSvc *GitspaceServices,
@@ -116,6 +121,7 @@ func ProvideServices(
 	registryAsyncProcessingService *registryasyncprocessing.Service,
 	registryJobRpmRegistryIndex *handler.JobRpmRegistryIndex,
 	languageAnalyzer languageanalyzer.LanguageAnalyzer,
+	slashCommandSvc *slashcommand.Service,
 ) Services {
 	return Services{
 		Webhook:                        webhooksSvc,
@@ -126,6 +132,7 @@ func ProvideServices(
 		RepoSizeCalculator:             repoSizeCalculator,
 		Repo:                           repo,
 		Cleanup:                        cleanupSvc,
+		EmailReply:                     emailReplyServer,
 		Notification:                   notificationSvc,
 		Keywordsearch:                  keywordsearchSvc,
 		GitspaceService:                gitspaceSvc,
@@ -136,5 +143,6 @@ func ProvideServices(
 		Branch:                         branchSvc,
 		registryAsyncProcessingService: registryAsyncProcessingService,
 		languageAnalyzer:               languageAnalyzer,
+		slashCommand:                   slashCommandSvc,
 	}
 }
@@ -37,6 +37,12 @@ type Streamer interface {
 
 	// Stream streams the events on a space ID.
 	Stream(ctx context.Context, spaceID int64) (<-chan *Event, <-chan error, func(context.Context) error)
+
+	// PublishUser publishes an event to a given principal ID.
+	PublishUser(ctx context.Context, principalID int64, eventType enum.SSEType, data any)
+
+	// StreamUser streams the events on a principal ID.
+	StreamUser(ctx context.Context, principalID int64) (<-chan *Event, <-chan error, func(context.Context) error)
 }
 
 type pubsubStreamer struct {
@@ -56,6 +62,24 @@ func (e *pubsubStreamer) Publish(
 	spaceID int64,
 	eventType enum.SSEType,
 	data any,
+) {
+	e.publish(ctx, getSpaceTopic(spaceID), eventType, data)
+}
+
+func (e *pubsubStreamer) PublishUser(
+	ctx context.Context,
+	principalID int64,
+	eventType enum.SSEType,
+	data any,
+) {
+	e.publish(ctx, getUserTopic(principalID), eventType, data)
+}
+
+func (e *pubsubStreamer) publish(
+	ctx context.Context,
+	topic string,
+	eventType enum.SSEType,
+	data any,
 ) {
 	dataSerialized, err := json.Marshal(data)
 	if err != nil {
@@ -70,7 +94,6 @@ func (e *pubsubStreamer) Publish(
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to serialize event: %v", err.Error())
 	}
 	namespaceOption := pubsub.WithPublishNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	err = e.pubsub.Publish(ctx, topic, serializedEvent, namespaceOption)
 	if err != nil {
 		log.Ctx(ctx).Warn().Err(err).Msgf("failed to publish %s event", eventType)
@@ -80,6 +103,20 @@ func (e *pubsubStreamer) Publish(
 func (e *pubsubStreamer) Stream(
 	ctx context.Context,
 	spaceID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getSpaceTopic(spaceID))
+}
+
+func (e *pubsubStreamer) StreamUser(
+	ctx context.Context,
+	principalID int64,
+) (<-chan *Event, <-chan error, func(context.Context) error) {
+	return e.stream(ctx, getUserTopic(principalID))
+}
+
+func (e *pubsubStreamer) stream(
+	ctx context.Context,
+	topic string,
 ) (<-chan *Event, <-chan error, func(context.Context) error) {
 	chEvent := make(chan *Event, 100) // TODO: check best size here
 	chErr := make(chan error)
@@ -98,7 +135,6 @@ func (e *pubsubStreamer) Stream(
 		return nil
 	}
 	namespaceOption := pubsub.WithChannelNamespace(e.namespace)
-	topic := getSpaceTopic(spaceID)
 	consumer := e.pubsub.Subscribe(ctx, topic, g, namespaceOption)
 	cleanupFN := func(_ context.Context) error {
 		return consumer.Close()
@@ -111,3 +147,8 @@ func (e *pubsubStreamer) Stream(
 func getSpaceTopic(spaceID int64) string {
 	return "spaces:" + strconv.Itoa(int(spaceID))
 }
+
+// getUserTopic creates the namespace name which will be `users:<id>`.
+func getUserTopic(principalID int64) string {
+	return "users:" + strconv.FormatInt(principalID, 10)
+}
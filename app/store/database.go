@@ -380,6 +380,16 @@ type (
 			key string,
 			value json.RawMessage,
 		) error
+
+		// InsertIfAbsent inserts the value of the setting with the given key for the provided scope,
+		// unless the setting already exists. It returns true if the value was inserted.
+		InsertIfAbsent(
+			ctx context.Context,
+			scope enum.SettingsScope,
+			scopeID int64,
+			key string,
+			value json.RawMessage,
+		) (bool, error)
 	}
 
 	// MembershipStore defines the membership data storage.
@@ -599,6 +609,237 @@ type (
 		List(ctx context.Context, prID int64, principalID int64) ([]*types.PullReqFileView, error)
 	}
 
+	PullReqReactionStore interface {
+		// Create adds the reaction. It returns false if the principal has already reacted with the same reaction.
+		Create(ctx context.Context, reaction *types.PullReqReaction) (bool, error)
+
+		// Delete removes the reaction. It returns false if the reaction doesn't exist.
+		Delete(ctx context.Context, reaction *types.PullReqReaction) (bool, error)
+
+		// List lists all reactions to the pull request description and to the pull request comments.
+		List(ctx context.Context, prID int64) ([]*types.PullReqReaction, error)
+
+		// ListForTarget lists the reactions to the pull request description (if activityID is nil) or to a comment.
+		ListForTarget(ctx context.Context, prID int64, activityID *int64) ([]*types.PullReqReaction, error)
+	}
+
+	// PullReqAssigneeStore defines the pull request assignee storage.
+	PullReqAssigneeStore interface {
+		// Create adds the assignee. It returns false if the principal is already assigned to the pull request.
+		Create(ctx context.Context, assignee *types.PullReqAssignee) (bool, error)
+
+		// Delete removes the assignee. It returns false if the principal isn't assigned to the pull request.
+		Delete(ctx context.Context, prID, principalID int64) (bool, error)
+
+		// List returns all assignees of the pull request.
+		List(ctx context.Context, prID int64) ([]*types.PullReqAssignee, error)
+
+		// MapPrincipalIDs returns principal IDs of the assignees of the pull requests, mapped by pull request ID.
+		MapPrincipalIDs(ctx context.Context, prIDs []int64) (map[int64][]int64, error)
+	}
+
+	// MilestoneStore defines the milestone storage.
+	MilestoneStore interface {
+		// Create creates a new milestone.
+		Create(ctx context.Context, milestone *types.Milestone) error
+
+		// Update updates the milestone.
+		Update(ctx context.Context, milestone *types.Milestone) error
+
+		// Find finds the milestone by ID.
+		Find(ctx context.Context, id int64) (*types.Milestone, error)
+
+		// FindMany finds the milestones by IDs.
+		FindMany(ctx context.Context, ids []int64) ([]*types.Milestone, error)
+
+		// Delete deletes the milestone. Pull requests associated with it are left without a milestone.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists milestones defined in the repository (if repoID is not nil) and in the spaces.
+		List(
+			ctx context.Context,
+			repoID *int64,
+			spaceIDs []int64,
+			filter *types.MilestoneFilter,
+		) ([]*types.Milestone, error)
+
+		// Count returns number of milestones defined in the repository (if repoID is not nil) and in the spaces.
+		Count(ctx context.Context, repoID *int64, spaceIDs []int64, filter *types.MilestoneFilter) (int64, error)
+
+		// MapProgress returns the pull request counts of the milestones, mapped by milestone ID.
+		MapProgress(ctx context.Context, ids []int64) (map[int64]types.MilestoneProgress, error)
+	}
+
+	// ReviewAnalyticsStore defines database interface for pull request review metrics.
+	ReviewAnalyticsStore interface {
+		// ListPullReqStats returns review timestamps and size of all pull requests matching the filter.
+		ListPullReqStats(ctx context.Context, filter *types.ReviewAnalyticsFilter) ([]types.PullReqReviewStats, error)
+
+		// ListReviewerStats returns submitted reviews and pending review requests, grouped by reviewer,
+		// of all pull requests matching the filter.
+		ListReviewerStats(ctx context.Context, filter *types.ReviewAnalyticsFilter) ([]types.ReviewerStats, error)
+	}
+
+	// AutomationStore defines database interface for pull request automations.
+	AutomationStore interface {
+		// Create creates a new automation.
+		Create(ctx context.Context, automation *types.Automation) error
+
+		// Update updates the automation.
+		Update(ctx context.Context, automation *types.Automation) error
+
+		// Find finds the automation by ID.
+		Find(ctx context.Context, id int64) (*types.Automation, error)
+
+		// Delete deletes the automation.
+		Delete(ctx context.Context, id int64) error
+
+		// List lists automations defined in the repository (if repoID is not nil) and in the spaces.
+		List(
+			ctx context.Context,
+			repoID *int64,
+			spaceIDs []int64,
+			filter *types.AutomationFilter,
+		) ([]*types.Automation, error)
+
+		// Count returns number of automations defined in the repository (if repoID is not nil) and in the spaces.
+		Count(ctx context.Context, repoID *int64, spaceIDs []int64, filter *types.AutomationFilter) (int64, error)
+	}
+
+	// NotificationStore defines database interface for the in-app notification inbox of users.
+	NotificationStore interface {
+		// Create creates a new in-app notification.
+		// It returns store.ErrDuplicate if the principal already got the notification of the event.
+		Create(ctx context.Context, notification *types.Notification) error
+
+		// List lists the in-app notifications of the principal, the newest first.
+		List(ctx context.Context, principalID int64, filter *types.NotificationFilter) ([]*types.Notification, error)
+
+		// Count returns the number of in-app notifications of the principal.
+		Count(ctx context.Context, principalID int64, filter *types.NotificationFilter) (int64, error)
+
+		// MarkRead marks the in-app notifications of the principal as read.
+		// If no notification IDs are provided, all notifications of the principal are marked as read.
+		MarkRead(ctx context.Context, principalID int64, ids []int64) (int64, error)
+	}
+
+	// NotificationDigestStore defines database interface for the queue of notifications
+	// that are delivered to users as periodic digest emails.
+	NotificationDigestStore interface {
+		// Enqueue adds the item to the queue of the next digest of the principal.
+		// It returns store.ErrDuplicate if the pending digest of the principal contains the item of the event already.
+		Enqueue(ctx context.Context, item *types.NotificationDigestItem) error
+
+		// ListPrincipalIDs returns the IDs of all principals with queued items for the digest delivery.
+		ListPrincipalIDs(ctx context.Context, delivery enum.NotificationDelivery) ([]int64, error)
+
+		// List returns the queued items of the principal for the digest delivery, the oldest first.
+		List(
+			ctx context.Context,
+			principalID int64,
+			delivery enum.NotificationDelivery,
+		) ([]*types.NotificationDigestItem, error)
+
+		// Delete removes the queued items of the principal for the digest delivery
+		// up to and including the provided ID.
+		Delete(ctx context.Context, principalID int64, delivery enum.NotificationDelivery, maxID int64) error
+	}
+
+	// NotificationChannelStore defines database interface for the chat notification channels of spaces.
+	NotificationChannelStore interface {
+		// Find finds the notification channel by id.
+		Find(ctx context.Context, id int64) (*types.NotificationChannel, error)
+
+		// FindByIdentifier finds the notification channel of the space by its identifier.
+		FindByIdentifier(ctx context.Context, spaceID int64, identifier string) (*types.NotificationChannel, error)
+
+		// Create creates a new notification channel.
+		Create(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Update updates an existing notification channel.
+		Update(ctx context.Context, channel *types.NotificationChannel) error
+
+		// Delete deletes the notification channel with the given id.
+		Delete(ctx context.Context, id int64) error
+
+		// List returns all notification channels of the space ordered by identifier.
+		List(ctx context.Context, spaceID int64) ([]*types.NotificationChannel, error)
+
+		// ListEnabled returns the enabled notification channels of the provided spaces.
+		ListEnabled(ctx context.Context, spaceIDs []int64) ([]*types.NotificationChannel, error)
+	}
+
+	// NotificationTemplateStore defines database interface for the custom templates of notification emails.
+	NotificationTemplateStore interface {
+		// Find finds the custom template of the notification email.
+		Find(ctx context.Context, name enum.NotificationTemplate) (*types.NotificationTemplate, error)
+
+		// List returns all custom templates of notification emails.
+		List(ctx context.Context) ([]*types.NotificationTemplate, error)
+
+		// Upsert creates or replaces the custom template of the notification email.
+		Upsert(ctx context.Context, tmpl *types.NotificationTemplate) error
+
+		// Delete removes the custom template of the notification email.
+		Delete(ctx context.Context, name enum.NotificationTemplate) error
+	}
+
+	// NotificationPreferenceStore defines database interface for notification preferences of users.
+	NotificationPreferenceStore interface {
+		// List returns all notification preferences the principal has configured.
+		List(ctx context.Context, principalID int64) ([]types.NotificationPreference, error)
+
+		// MapDelivery returns the configured delivery of the event for each of the provided principals.
+		// Principals without a configured delivery for the event are not part of the result.
+		MapDelivery(
+			ctx context.Context,
+			event enum.NotificationEvent,
+			principalIDs []int64,
+		) (map[int64]enum.NotificationDelivery, error)
+
+		// Upsert creates or updates the notification preference of the principal for the event.
+		Upsert(ctx context.Context, pref *types.NotificationPreference) error
+	}
+
+	// NotificationSubscriptionStore defines database interface for users watching or muting
+	// spaces, repositories and pull requests.
+	NotificationSubscriptionStore interface {
+		// Find finds the subscription of the principal for the resource.
+		Find(
+			ctx context.Context,
+			principalID int64,
+			resourceType enum.NotificationResourceType,
+			resourceID int64,
+		) (*types.NotificationSubscription, error)
+
+		// Upsert creates or updates the subscription of the principal for the resource.
+		Upsert(ctx context.Context, sub *types.NotificationSubscription) error
+
+		// Delete deletes the subscription of the principal for the resource.
+		Delete(
+			ctx context.Context,
+			principalID int64,
+			resourceType enum.NotificationResourceType,
+			resourceID int64,
+		) error
+
+		// List lists the subscriptions of the principal.
+		List(
+			ctx context.Context,
+			principalID int64,
+			filter *types.NotificationSubscriptionFilter,
+		) ([]types.NotificationSubscription, error)
+
+		// ListForResources lists the subscriptions of all principals for the pull request,
+		// the repository and the spaces.
+		ListForResources(
+			ctx context.Context,
+			spaceIDs []int64,
+			repoID int64,
+			pullReqID int64,
+		) ([]types.NotificationSubscription, error)
+	}
+
 	AutoMergeStore interface {
 		Find(ctx context.Context, pullreqID int64) (*types.AutoMerge, error)
 		Delete(ctx context.Context, pullreqID int64) (bool, error)
@@ -722,6 +963,15 @@ type (
 
 		// ListForTrigger lists the webhook executions for a given trigger id.
 		ListForTrigger(ctx context.Context, triggerID string) ([]*types.WebhookExecution, error)
+
+		// ListLatestFailedForWebhook lists the latest execution of every trigger of a webhook since the provided
+		// time (unix millis), for which the latest execution didn't succeed and can be retriggered.
+		ListLatestFailedForWebhook(
+			ctx context.Context,
+			webhookID int64,
+			since int64,
+			limit int,
+		) ([]*types.WebhookExecution, error)
 	}
 
 	CheckStore interface {
@@ -1485,6 +1735,9 @@ type (
 		// Find finds a branch by repo ID and branch name.
 		Find(ctx context.Context, repoID int64, name string) (*types.BranchTable, error)
 
+		// ListRecentlyUpdated lists the most recently updated branches of a repository.
+		ListRecentlyUpdated(ctx context.Context, repoID int64, limit uint64) ([]types.BranchTable, error)
+
 		// Delete deletes a branch by repo ID and branch name.
 		Delete(ctx context.Context, repoID int64, name string) error
 
@@ -0,0 +1,356 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.AutomationStore = (*AutomationStore)(nil)
+
+// NewAutomationStore returns a new AutomationStore.
+func NewAutomationStore(db *sqlx.DB) *AutomationStore {
+	return &AutomationStore{
+		db: db,
+	}
+}
+
+// AutomationStore implements store.AutomationStore backed by a relational database.
+type AutomationStore struct {
+	db *sqlx.DB
+}
+
+type automation struct {
+	ID          int64                      `db:"automation_id"`
+	SpaceID     null.Int                   `db:"automation_space_id"`
+	RepoID      null.Int                   `db:"automation_repo_id"`
+	Name        string                     `db:"automation_name"`
+	Description string                     `db:"automation_description"`
+	Disabled    bool                       `db:"automation_disabled"`
+	TriggerType enum.AutomationTriggerType `db:"automation_trigger_type"`
+	Trigger     string                     `db:"automation_trigger"`
+	Action      string                     `db:"automation_action"`
+	Created     int64                      `db:"automation_created"`
+	Updated     int64                      `db:"automation_updated"`
+	CreatedBy   int64                      `db:"automation_created_by"`
+	UpdatedBy   int64                      `db:"automation_updated_by"`
+}
+
+const (
+	automationColumns = `
+		 automation_id
+		,automation_space_id
+		,automation_repo_id
+		,automation_name
+		,automation_description
+		,automation_disabled
+		,automation_trigger_type
+		,automation_trigger
+		,automation_action
+		,automation_created
+		,automation_updated
+		,automation_created_by
+		,automation_updated_by`
+
+	automationSelectBase = `
+	SELECT` + automationColumns + `
+	FROM automations`
+)
+
+// Create creates a new automation.
+func (s *AutomationStore) Create(ctx context.Context, a *types.Automation) error {
+	const sqlQuery = `
+	INSERT INTO automations (
+		 automation_space_id
+		,automation_repo_id
+		,automation_name
+		,automation_description
+		,automation_disabled
+		,automation_trigger_type
+		,automation_trigger
+		,automation_action
+		,automation_created
+		,automation_updated
+		,automation_created_by
+		,automation_updated_by
+	) VALUES (
+		 :automation_space_id
+		,:automation_repo_id
+		,:automation_name
+		,:automation_description
+		,:automation_disabled
+		,:automation_trigger_type
+		,:automation_trigger
+		,:automation_action
+		,:automation_created
+		,:automation_updated
+		,:automation_created_by
+		,:automation_updated_by
+	) RETURNING automation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	internal, err := mapToInternalAutomation(a)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, internal)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind automation object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&a.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create automation")
+	}
+
+	return nil
+}
+
+// Update updates the automation.
+func (s *AutomationStore) Update(ctx context.Context, a *types.Automation) error {
+	const sqlQuery = `
+	UPDATE automations
+	SET
+		 automation_name = :automation_name
+		,automation_description = :automation_description
+		,automation_disabled = :automation_disabled
+		,automation_trigger_type = :automation_trigger_type
+		,automation_trigger = :automation_trigger
+		,automation_action = :automation_action
+		,automation_updated = :automation_updated
+		,automation_updated_by = :automation_updated_by
+	WHERE automation_id = :automation_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	internal, err := mapToInternalAutomation(a)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, internal)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind automation object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update automation")
+	}
+
+	return nil
+}
+
+// Find finds the automation by ID.
+func (s *AutomationStore) Find(ctx context.Context, id int64) (*types.Automation, error) {
+	const sqlQuery = automationSelectBase + `
+	WHERE automation_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &automation{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find automation")
+	}
+
+	return mapToAutomation(dst)
+}
+
+// Delete deletes the automation.
+func (s *AutomationStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM automations
+	WHERE automation_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete automation")
+	}
+
+	return nil
+}
+
+// List lists automations defined in the repository (if repoID is not nil) and in the spaces.
+func (s *AutomationStore) List(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.AutomationFilter,
+) ([]*types.Automation, error) {
+	stmt := database.Builder.
+		Select(automationColumns).
+		From("automations")
+
+	stmt = applyAutomationFilter(stmt, repoID, spaceIDs, filter)
+
+	if filter.Size > 0 {
+		stmt = stmt.Limit(database.Limit(filter.Size))
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
+
+	stmt = stmt.OrderBy("automation_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*automation
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list automations")
+	}
+
+	result := make([]*types.Automation, len(dst))
+	for i, a := range dst {
+		if result[i], err = mapToAutomation(a); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// Count returns number of automations defined in the repository (if repoID is not nil) and in the spaces.
+func (s *AutomationStore) Count(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.AutomationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("automations")
+
+	stmt = applyAutomationFilter(stmt, repoID, spaceIDs, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count automations")
+	}
+
+	return count, nil
+}
+
+func applyAutomationFilter(
+	stmt squirrel.SelectBuilder,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.AutomationFilter,
+) squirrel.SelectBuilder {
+	scopes := squirrel.Or{}
+	if repoID != nil {
+		scopes = append(scopes, squirrel.Eq{"automation_repo_id": *repoID})
+	}
+	if len(spaceIDs) > 0 {
+		scopes = append(scopes, squirrel.Eq{"automation_space_id": spaceIDs})
+	}
+
+	if len(scopes) == 0 {
+		// no scope provided, return nothing
+		return stmt.Where("1 = 0")
+	}
+
+	stmt = stmt.Where(scopes)
+
+	if filter.TriggerType != "" {
+		stmt = stmt.Where("automation_trigger_type = ?", filter.TriggerType)
+	}
+
+	if filter.ExcludeDisabled {
+		stmt = stmt.Where("automation_disabled = FALSE")
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("automation_name", filter.Query))
+	}
+
+	return stmt
+}
+
+func mapToAutomation(a *automation) (*types.Automation, error) {
+	result := &types.Automation{
+		ID:          a.ID,
+		SpaceID:     a.SpaceID.Ptr(),
+		RepoID:      a.RepoID.Ptr(),
+		Name:        a.Name,
+		Description: a.Description,
+		Disabled:    a.Disabled,
+		Created:     a.Created,
+		Updated:     a.Updated,
+		CreatedBy:   a.CreatedBy,
+		UpdatedBy:   a.UpdatedBy,
+	}
+
+	if err := json.Unmarshal([]byte(a.Trigger), &result.Trigger); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal automation trigger: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(a.Action), &result.Action); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal automation action: %w", err)
+	}
+
+	return result, nil
+}
+
+func mapToInternalAutomation(a *types.Automation) (*automation, error) {
+	trigger, err := json.Marshal(a.Trigger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal automation trigger: %w", err)
+	}
+
+	action, err := json.Marshal(a.Action)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal automation action: %w", err)
+	}
+
+	return &automation{
+		ID:          a.ID,
+		SpaceID:     null.IntFromPtr(a.SpaceID),
+		RepoID:      null.IntFromPtr(a.RepoID),
+		Name:        a.Name,
+		Description: a.Description,
+		Disabled:    a.Disabled,
+		TriggerType: a.Trigger.Type,
+		Trigger:     string(trigger),
+		Action:      string(action),
+		Created:     a.Created,
+		Updated:     a.Updated,
+		CreatedBy:   a.CreatedBy,
+		UpdatedBy:   a.UpdatedBy,
+	}, nil
+}
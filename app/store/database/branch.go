@@ -162,6 +162,33 @@ func (s *branchStore) Find(ctx context.Context, repoID int64, name string) (*typ
 	return &result, nil
 }
 
+// ListRecentlyUpdated lists the most recently updated branches of a repository.
+func (s *branchStore) ListRecentlyUpdated(
+	ctx context.Context,
+	repoID int64,
+	limit uint64,
+) ([]types.BranchTable, error) {
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	const sqlQuery = branchSelectBase + `
+		WHERE branch_repo_id = $1
+		ORDER BY branch_updated DESC
+		LIMIT $2
+	`
+
+	dst := make([]*branch, 0, limit)
+	if err := db.SelectContext(ctx, &dst, sqlQuery, repoID, limit); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list recently updated branches")
+	}
+
+	result := make([]types.BranchTable, len(dst))
+	for i, b := range dst {
+		result[i] = b.ToType()
+	}
+
+	return result, nil
+}
+
 // Delete deletes a branch by repo ID and branch name.
 func (s *branchStore) Delete(ctx context.Context, repoID int64, name string) error {
 	db := dbtx.GetAccessor(ctx, s.db)
@@ -0,0 +1,30 @@
+CREATE TABLE pullreq_reactions (
+ pullreq_reaction_id SERIAL PRIMARY KEY
+,pullreq_reaction_pullreq_id INTEGER NOT NULL
+,pullreq_reaction_activity_id INTEGER
+,pullreq_reaction_principal_id INTEGER NOT NULL
+,pullreq_reaction_reaction TEXT NOT NULL
+,pullreq_reaction_created BIGINT NOT NULL
+,CONSTRAINT fk_pullreq_reaction_pullreq_id FOREIGN KEY (pullreq_reaction_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reaction_activity_id FOREIGN KEY (pullreq_reaction_activity_id)
+    REFERENCES pullreq_activities (pullreq_activity_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_reaction_principal_id FOREIGN KEY (pullreq_reaction_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+-- reactions to the pull request description (no activity)
+CREATE UNIQUE INDEX pullreq_reactions_pullreq_id_principal_id_reaction
+    ON pullreq_reactions(pullreq_reaction_pullreq_id, pullreq_reaction_principal_id, pullreq_reaction_reaction)
+    WHERE pullreq_reaction_activity_id IS NULL;
+
+-- reactions to pull request comments
+CREATE UNIQUE INDEX pullreq_reactions_activity_id_principal_id_reaction
+    ON pullreq_reactions(pullreq_reaction_activity_id, pullreq_reaction_principal_id, pullreq_reaction_reaction)
+    WHERE pullreq_reaction_activity_id IS NOT NULL;
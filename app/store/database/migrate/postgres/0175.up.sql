@@ -0,0 +1,71 @@
+CREATE TABLE milestones (
+ milestone_id SERIAL PRIMARY KEY
+,milestone_space_id INTEGER
+,milestone_repo_id INTEGER
+,milestone_title TEXT NOT NULL
+,milestone_description TEXT NOT NULL DEFAULT ''
+,milestone_state TEXT NOT NULL DEFAULT 'open'
+,milestone_due_date BIGINT
+,milestone_closed BIGINT
+,milestone_created BIGINT NOT NULL
+,milestone_updated BIGINT NOT NULL
+,milestone_created_by INTEGER NOT NULL
+,milestone_updated_by INTEGER NOT NULL
+,CONSTRAINT fk_milestone_space_id FOREIGN KEY (milestone_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_milestone_repo_id FOREIGN KEY (milestone_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_milestone_created_by FOREIGN KEY (milestone_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_milestone_updated_by FOREIGN KEY (milestone_updated_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT chk_milestone_space_or_repo
+    CHECK (milestone_space_id IS NULL OR milestone_repo_id IS NULL)
+);
+
+CREATE UNIQUE INDEX milestones_space_id_title
+    ON milestones(milestone_space_id, LOWER(milestone_title))
+    WHERE milestone_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX milestones_repo_id_title
+    ON milestones(milestone_repo_id, LOWER(milestone_title))
+    WHERE milestone_repo_id IS NOT NULL;
+
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_milestone_id INTEGER
+    REFERENCES milestones (milestone_id) ON DELETE SET NULL;
+
+CREATE INDEX pullreqs_milestone_id
+    ON pullreqs(pullreq_milestone_id)
+    WHERE pullreq_milestone_id IS NOT NULL;
+
+CREATE TABLE pullreq_assignees (
+ pullreq_assignee_pullreq_id INTEGER NOT NULL
+,pullreq_assignee_principal_id INTEGER NOT NULL
+,pullreq_assignee_created_by INTEGER NOT NULL
+,pullreq_assignee_created BIGINT NOT NULL
+,CONSTRAINT pk_pullreq_assignees PRIMARY KEY (pullreq_assignee_pullreq_id, pullreq_assignee_principal_id)
+,CONSTRAINT fk_pullreq_assignee_pullreq_id FOREIGN KEY (pullreq_assignee_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_assignee_principal_id FOREIGN KEY (pullreq_assignee_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_pullreq_assignee_created_by FOREIGN KEY (pullreq_assignee_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE INDEX pullreq_assignees_principal_id
+    ON pullreq_assignees(pullreq_assignee_principal_id);
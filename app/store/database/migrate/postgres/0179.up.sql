@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_signature_mode TEXT NOT NULL DEFAULT 'hmac';
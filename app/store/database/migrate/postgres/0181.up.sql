@@ -0,0 +1,37 @@
+CREATE TABLE notifications (
+ notification_id SERIAL PRIMARY KEY
+,notification_principal_id INTEGER NOT NULL
+,notification_event TEXT NOT NULL
+,notification_event_id TEXT NOT NULL DEFAULT ''
+,notification_repo_id INTEGER
+,notification_pullreq_id INTEGER
+,notification_actor_id INTEGER
+,notification_title TEXT NOT NULL
+,notification_url TEXT NOT NULL DEFAULT ''
+,notification_read BOOLEAN NOT NULL DEFAULT FALSE
+,notification_created BIGINT NOT NULL
+,notification_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_principal_id FOREIGN KEY (notification_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_repo_id FOREIGN KEY (notification_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_pullreq_id FOREIGN KEY (notification_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_actor_id FOREIGN KEY (notification_actor_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
+
+CREATE INDEX notifications_principal_id_read
+    ON notifications(notification_principal_id, notification_read);
+
+CREATE UNIQUE INDEX notifications_principal_id_event_id_event
+    ON notifications(notification_principal_id, notification_event_id, notification_event)
+    WHERE notification_event_id <> '';
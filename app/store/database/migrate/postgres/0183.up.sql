@@ -0,0 +1,26 @@
+CREATE TABLE notification_channels (
+ notification_channel_id SERIAL PRIMARY KEY
+,notification_channel_space_id INTEGER NOT NULL
+,notification_channel_identifier TEXT NOT NULL
+,notification_channel_display_name TEXT NOT NULL
+,notification_channel_type TEXT NOT NULL
+,notification_channel_url TEXT NOT NULL
+,notification_channel_enabled BOOLEAN NOT NULL
+,notification_channel_events TEXT NOT NULL
+,notification_channel_repo_patterns TEXT NOT NULL DEFAULT '[]'
+,notification_channel_branch_patterns TEXT NOT NULL DEFAULT '[]'
+,notification_channel_created_by INTEGER NOT NULL
+,notification_channel_created BIGINT NOT NULL
+,notification_channel_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_channel_space_id FOREIGN KEY (notification_channel_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_channel_created_by FOREIGN KEY (notification_channel_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+);
+
+CREATE UNIQUE INDEX notification_channels_space_id_identifier
+    ON notification_channels(notification_channel_space_id, LOWER(notification_channel_identifier));
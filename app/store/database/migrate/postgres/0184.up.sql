@@ -0,0 +1,12 @@
+CREATE TABLE notification_templates (
+ notification_template_name TEXT PRIMARY KEY
+,notification_template_subject TEXT NOT NULL
+,notification_template_body TEXT NOT NULL
+,notification_template_content_type TEXT NOT NULL
+,notification_template_updated_by INTEGER
+,notification_template_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_template_updated_by FOREIGN KEY (notification_template_updated_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE SET NULL
+);
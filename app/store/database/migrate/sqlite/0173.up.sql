@@ -0,0 +1,2 @@
+ALTER TABLE pullreqs
+    ADD COLUMN pullreq_flow TEXT NOT NULL DEFAULT 'github';
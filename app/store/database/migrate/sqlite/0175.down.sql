@@ -0,0 +1,4 @@
+DROP TABLE pullreq_assignees;
+DROP INDEX pullreqs_milestone_id;
+ALTER TABLE pullreqs DROP COLUMN pullreq_milestone_id;
+DROP TABLE milestones;
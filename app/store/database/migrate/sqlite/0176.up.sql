@@ -0,0 +1,41 @@
+CREATE TABLE automations (
+ automation_id INTEGER PRIMARY KEY AUTOINCREMENT
+,automation_space_id INTEGER
+,automation_repo_id INTEGER
+,automation_name TEXT NOT NULL
+,automation_description TEXT NOT NULL DEFAULT ''
+,automation_disabled BOOLEAN NOT NULL DEFAULT FALSE
+,automation_trigger_type TEXT NOT NULL
+,automation_trigger TEXT NOT NULL
+,automation_action TEXT NOT NULL
+,automation_created BIGINT NOT NULL
+,automation_updated BIGINT NOT NULL
+,automation_created_by INTEGER NOT NULL
+,automation_updated_by INTEGER NOT NULL
+,CONSTRAINT fk_automation_space_id FOREIGN KEY (automation_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_automation_repo_id FOREIGN KEY (automation_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_automation_created_by FOREIGN KEY (automation_created_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT fk_automation_updated_by FOREIGN KEY (automation_updated_by)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE NO ACTION
+,CONSTRAINT chk_automation_space_or_repo
+    CHECK (automation_space_id IS NULL OR automation_repo_id IS NULL)
+);
+
+CREATE INDEX automations_space_id
+    ON automations(automation_space_id)
+    WHERE automation_space_id IS NOT NULL;
+
+CREATE INDEX automations_repo_id
+    ON automations(automation_repo_id)
+    WHERE automation_repo_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_format TEXT NOT NULL DEFAULT 'native';
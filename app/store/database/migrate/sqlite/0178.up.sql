@@ -0,0 +1 @@
+ALTER TABLE webhooks ADD COLUMN webhook_consecutive_failures INTEGER NOT NULL DEFAULT 0;
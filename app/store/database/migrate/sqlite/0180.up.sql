@@ -0,0 +1,67 @@
+CREATE TABLE notification_preferences (
+ notification_preference_principal_id INTEGER NOT NULL
+,notification_preference_event TEXT NOT NULL
+,notification_preference_delivery TEXT NOT NULL
+,notification_preference_updated BIGINT NOT NULL
+,CONSTRAINT pk_notification_preferences
+    PRIMARY KEY (notification_preference_principal_id, notification_preference_event)
+,CONSTRAINT fk_notification_preference_principal_id FOREIGN KEY (notification_preference_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE TABLE notification_subscriptions (
+ notification_subscription_id INTEGER PRIMARY KEY AUTOINCREMENT
+,notification_subscription_principal_id INTEGER NOT NULL
+,notification_subscription_space_id INTEGER
+,notification_subscription_repo_id INTEGER
+,notification_subscription_pullreq_id INTEGER
+,notification_subscription_state TEXT NOT NULL
+,notification_subscription_created BIGINT NOT NULL
+,notification_subscription_updated BIGINT NOT NULL
+,CONSTRAINT fk_notification_subscription_principal_id FOREIGN KEY (notification_subscription_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_subscription_space_id FOREIGN KEY (notification_subscription_space_id)
+    REFERENCES spaces (space_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_subscription_repo_id FOREIGN KEY (notification_subscription_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_notification_subscription_pullreq_id FOREIGN KEY (notification_subscription_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT chk_notification_subscription_single_resource
+    CHECK ((notification_subscription_space_id IS NOT NULL)
+        + (notification_subscription_repo_id IS NOT NULL)
+        + (notification_subscription_pullreq_id IS NOT NULL) = 1)
+);
+
+CREATE UNIQUE INDEX notification_subscriptions_principal_id_space_id
+    ON notification_subscriptions(notification_subscription_principal_id, notification_subscription_space_id)
+    WHERE notification_subscription_space_id IS NOT NULL;
+
+CREATE UNIQUE INDEX notification_subscriptions_principal_id_repo_id
+    ON notification_subscriptions(notification_subscription_principal_id, notification_subscription_repo_id)
+    WHERE notification_subscription_repo_id IS NOT NULL;
+
+CREATE UNIQUE INDEX notification_subscriptions_principal_id_pullreq_id
+    ON notification_subscriptions(notification_subscription_principal_id, notification_subscription_pullreq_id)
+    WHERE notification_subscription_pullreq_id IS NOT NULL;
+
+CREATE INDEX notification_subscriptions_space_id
+    ON notification_subscriptions(notification_subscription_space_id)
+    WHERE notification_subscription_space_id IS NOT NULL;
+
+CREATE INDEX notification_subscriptions_repo_id
+    ON notification_subscriptions(notification_subscription_repo_id)
+    WHERE notification_subscription_repo_id IS NOT NULL;
+
+CREATE INDEX notification_subscriptions_pullreq_id
+    ON notification_subscriptions(notification_subscription_pullreq_id)
+    WHERE notification_subscription_pullreq_id IS NOT NULL;
@@ -0,0 +1,32 @@
+CREATE TABLE notification_digest_items (
+ digest_item_id INTEGER PRIMARY KEY AUTOINCREMENT
+,digest_item_principal_id INTEGER NOT NULL
+,digest_item_delivery TEXT NOT NULL
+,digest_item_event TEXT NOT NULL
+,digest_item_event_id TEXT NOT NULL DEFAULT ''
+,digest_item_repo_id INTEGER
+,digest_item_pullreq_id INTEGER
+,digest_item_subject TEXT NOT NULL DEFAULT ''
+,digest_item_title TEXT NOT NULL
+,digest_item_url TEXT NOT NULL DEFAULT ''
+,digest_item_created BIGINT NOT NULL
+,CONSTRAINT fk_digest_item_principal_id FOREIGN KEY (digest_item_principal_id)
+    REFERENCES principals (principal_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_digest_item_repo_id FOREIGN KEY (digest_item_repo_id)
+    REFERENCES repositories (repo_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+,CONSTRAINT fk_digest_item_pullreq_id FOREIGN KEY (digest_item_pullreq_id)
+    REFERENCES pullreqs (pullreq_id) MATCH SIMPLE
+    ON UPDATE NO ACTION
+    ON DELETE CASCADE
+);
+
+CREATE INDEX notification_digest_items_delivery_principal_id
+    ON notification_digest_items(digest_item_delivery, digest_item_principal_id);
+
+CREATE UNIQUE INDEX notification_digest_items_principal_id_event_id_event
+    ON notification_digest_items(digest_item_principal_id, digest_item_event_id, digest_item_event)
+    WHERE digest_item_event_id <> '';
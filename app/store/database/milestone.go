@@ -0,0 +1,405 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.MilestoneStore = (*MilestoneStore)(nil)
+
+// NewMilestoneStore returns a new MilestoneStore.
+func NewMilestoneStore(db *sqlx.DB) *MilestoneStore {
+	return &MilestoneStore{
+		db: db,
+	}
+}
+
+// MilestoneStore implements store.MilestoneStore backed by a relational database.
+type MilestoneStore struct {
+	db *sqlx.DB
+}
+
+type milestone struct {
+	ID          int64               `db:"milestone_id"`
+	SpaceID     null.Int            `db:"milestone_space_id"`
+	RepoID      null.Int            `db:"milestone_repo_id"`
+	Title       string              `db:"milestone_title"`
+	Description string              `db:"milestone_description"`
+	State       enum.MilestoneState `db:"milestone_state"`
+	DueDate     null.Int            `db:"milestone_due_date"`
+	Closed      null.Int            `db:"milestone_closed"`
+	Created     int64               `db:"milestone_created"`
+	Updated     int64               `db:"milestone_updated"`
+	CreatedBy   int64               `db:"milestone_created_by"`
+	UpdatedBy   int64               `db:"milestone_updated_by"`
+}
+
+const (
+	milestoneColumns = `
+		 milestone_id
+		,milestone_space_id
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_state
+		,milestone_due_date
+		,milestone_closed
+		,milestone_created
+		,milestone_updated
+		,milestone_created_by
+		,milestone_updated_by`
+
+	milestoneSelectBase = `
+	SELECT` + milestoneColumns + `
+	FROM milestones`
+)
+
+// Create creates a new milestone.
+func (s *MilestoneStore) Create(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+	INSERT INTO milestones (
+		 milestone_space_id
+		,milestone_repo_id
+		,milestone_title
+		,milestone_description
+		,milestone_state
+		,milestone_due_date
+		,milestone_closed
+		,milestone_created
+		,milestone_updated
+		,milestone_created_by
+		,milestone_updated_by
+	) VALUES (
+		 :milestone_space_id
+		,:milestone_repo_id
+		,:milestone_title
+		,:milestone_description
+		,:milestone_state
+		,:milestone_due_date
+		,:milestone_closed
+		,:milestone_created
+		,:milestone_updated
+		,:milestone_created_by
+		,:milestone_updated_by
+	) RETURNING milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&m.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create milestone")
+	}
+
+	return nil
+}
+
+// Update updates the milestone.
+func (s *MilestoneStore) Update(ctx context.Context, m *types.Milestone) error {
+	const sqlQuery = `
+	UPDATE milestones
+	SET
+		 milestone_title = :milestone_title
+		,milestone_description = :milestone_description
+		,milestone_state = :milestone_state
+		,milestone_due_date = :milestone_due_date
+		,milestone_closed = :milestone_closed
+		,milestone_updated = :milestone_updated
+		,milestone_updated_by = :milestone_updated_by
+	WHERE milestone_id = :milestone_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalMilestone(m))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind milestone object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update milestone")
+	}
+
+	return nil
+}
+
+// Find finds the milestone by ID.
+func (s *MilestoneStore) Find(ctx context.Context, id int64) (*types.Milestone, error) {
+	const sqlQuery = milestoneSelectBase + `
+	WHERE milestone_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &milestone{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find milestone")
+	}
+
+	return mapToMilestone(dst), nil
+}
+
+// FindMany finds the milestones by IDs.
+func (s *MilestoneStore) FindMany(ctx context.Context, ids []int64) ([]*types.Milestone, error) {
+	if len(ids) == 0 {
+		return []*types.Milestone{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones").
+		Where(squirrel.Eq{"milestone_id": ids})
+
+	return s.list(ctx, stmt)
+}
+
+// Delete deletes the milestone. Pull requests associated with it are left without a milestone.
+func (s *MilestoneStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+	DELETE FROM milestones
+	WHERE milestone_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete milestone")
+	}
+
+	return nil
+}
+
+// List lists milestones defined in the repository (if repoID is not nil) and in the spaces.
+func (s *MilestoneStore) List(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.MilestoneFilter,
+) ([]*types.Milestone, error) {
+	stmt := database.Builder.
+		Select(milestoneColumns).
+		From("milestones")
+
+	stmt = applyMilestoneFilter(stmt, repoID, spaceIDs, filter)
+
+	stmt = stmt.Limit(database.Limit(filter.Size))
+	stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+
+	sort, _ := filter.Sort.Sanitize()
+	switch sort {
+	case enum.MilestoneSortDueDate:
+		order := filter.Order
+		if order == enum.OrderDefault {
+			order = enum.OrderAsc // the closest due date first
+		}
+
+		// milestones without a due date are listed last
+		stmt = stmt.OrderBy("milestone_due_date IS NULL", "milestone_due_date "+order.String())
+	case enum.MilestoneSortTitle:
+		stmt = stmt.OrderBy("LOWER(milestone_title) " + filter.Order.String())
+	case enum.MilestoneSortCreated, enum.MilestoneSortUpdated:
+		// NOTE: string concatenation is safe because the
+		// sort attribute is an enum and is not user-defined.
+		stmt = stmt.OrderBy("milestone_" + string(sort) + " " + filter.Order.String())
+	}
+
+	stmt = stmt.OrderBy("milestone_id")
+
+	return s.list(ctx, stmt)
+}
+
+// Count returns number of milestones defined in the repository (if repoID is not nil) and in the spaces.
+func (s *MilestoneStore) Count(
+	ctx context.Context,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.MilestoneFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("milestones")
+
+	stmt = applyMilestoneFilter(stmt, repoID, spaceIDs, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count milestones")
+	}
+
+	return count, nil
+}
+
+// MapProgress returns the pull request counts of the milestones, mapped by milestone ID.
+func (s *MilestoneStore) MapProgress(ctx context.Context, ids []int64) (map[int64]types.MilestoneProgress, error) {
+	if len(ids) == 0 {
+		return map[int64]types.MilestoneProgress{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("pullreq_milestone_id, pullreq_state, COUNT(*)").
+		From("pullreqs").
+		Where(squirrel.Eq{"pullreq_milestone_id": ids}).
+		GroupBy("pullreq_milestone_id", "pullreq_state")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to count pull requests of milestones")
+	}
+	defer rows.Close()
+
+	result := make(map[int64]types.MilestoneProgress, len(ids))
+	for rows.Next() {
+		var milestoneID, count int64
+		var state enum.PullReqState
+		if err = rows.Scan(&milestoneID, &state, &count); err != nil {
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan pull request count of milestone")
+		}
+
+		progress := result[milestoneID]
+		switch state {
+		case enum.PullReqStateOpen:
+			progress.Open += count
+		case enum.PullReqStateMerged:
+			progress.Merged += count
+		case enum.PullReqStateClosed:
+			progress.Closed += count
+		}
+		progress.Total += count
+
+		result[milestoneID] = progress
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to read pull request counts of milestones")
+	}
+
+	return result, nil
+}
+
+func applyMilestoneFilter(
+	stmt squirrel.SelectBuilder,
+	repoID *int64,
+	spaceIDs []int64,
+	filter *types.MilestoneFilter,
+) squirrel.SelectBuilder {
+	scopes := squirrel.Or{}
+	if repoID != nil {
+		scopes = append(scopes, squirrel.Eq{"milestone_repo_id": *repoID})
+	}
+	if len(spaceIDs) > 0 {
+		scopes = append(scopes, squirrel.Eq{"milestone_space_id": spaceIDs})
+	}
+
+	if len(scopes) == 0 {
+		// no scope provided, return nothing
+		return stmt.Where("1 = 0")
+	}
+
+	stmt = stmt.Where(scopes)
+
+	if len(filter.States) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"milestone_state": filter.States})
+	}
+
+	if filter.Query != "" {
+		stmt = stmt.Where(PartialMatch("milestone_title", filter.Query))
+	}
+
+	return stmt
+}
+
+func (s *MilestoneStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.Milestone, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*milestone
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list milestones")
+	}
+
+	result := make([]*types.Milestone, len(dst))
+	for i, m := range dst {
+		result[i] = mapToMilestone(m)
+	}
+
+	return result, nil
+}
+
+func mapToMilestone(m *milestone) *types.Milestone {
+	return &types.Milestone{
+		ID:          m.ID,
+		SpaceID:     m.SpaceID.Ptr(),
+		RepoID:      m.RepoID.Ptr(),
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     m.DueDate.Ptr(),
+		Closed:      m.Closed.Ptr(),
+		Created:     m.Created,
+		Updated:     m.Updated,
+		CreatedBy:   m.CreatedBy,
+		UpdatedBy:   m.UpdatedBy,
+	}
+}
+
+func mapToInternalMilestone(m *types.Milestone) *milestone {
+	return &milestone{
+		ID:          m.ID,
+		SpaceID:     null.IntFromPtr(m.SpaceID),
+		RepoID:      null.IntFromPtr(m.RepoID),
+		Title:       m.Title,
+		Description: m.Description,
+		State:       m.State,
+		DueDate:     null.IntFromPtr(m.DueDate),
+		Closed:      null.IntFromPtr(m.Closed),
+		Created:     m.Created,
+		Updated:     m.Updated,
+		CreatedBy:   m.CreatedBy,
+		UpdatedBy:   m.UpdatedBy,
+	}
+}
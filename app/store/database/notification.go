@@ -0,0 +1,260 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationStore = (*NotificationStore)(nil)
+
+// NewNotificationStore returns a new NotificationStore.
+func NewNotificationStore(db *sqlx.DB) *NotificationStore {
+	return &NotificationStore{
+		db: db,
+	}
+}
+
+// NotificationStore implements store.NotificationStore backed by a relational database.
+type NotificationStore struct {
+	db *sqlx.DB
+}
+
+type notification struct {
+	ID          int64                  `db:"notification_id"`
+	PrincipalID int64                  `db:"notification_principal_id"`
+	Event       enum.NotificationEvent `db:"notification_event"`
+	EventID     string                 `db:"notification_event_id"`
+	RepoID      null.Int               `db:"notification_repo_id"`
+	PullReqID   null.Int               `db:"notification_pullreq_id"`
+	ActorID     null.Int               `db:"notification_actor_id"`
+	Title       string                 `db:"notification_title"`
+	URL         string                 `db:"notification_url"`
+	Read        bool                   `db:"notification_read"`
+	Created     int64                  `db:"notification_created"`
+	Updated     int64                  `db:"notification_updated"`
+}
+
+const (
+	notificationColumns = `
+		 notification_id
+		,notification_principal_id
+		,notification_event
+		,notification_event_id
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_actor_id
+		,notification_title
+		,notification_url
+		,notification_read
+		,notification_created
+		,notification_updated`
+)
+
+// Create creates a new in-app notification.
+// It returns store.ErrDuplicate if the principal already got the notification of the event.
+func (s *NotificationStore) Create(ctx context.Context, n *types.Notification) error {
+	const sqlQuery = `
+	INSERT INTO notifications (
+		 notification_principal_id
+		,notification_event
+		,notification_event_id
+		,notification_repo_id
+		,notification_pullreq_id
+		,notification_actor_id
+		,notification_title
+		,notification_url
+		,notification_read
+		,notification_created
+		,notification_updated
+	) VALUES (
+		 :notification_principal_id
+		,:notification_event
+		,:notification_event_id
+		,:notification_repo_id
+		,:notification_pullreq_id
+		,:notification_actor_id
+		,:notification_title
+		,:notification_url
+		,:notification_read
+		,:notification_created
+		,:notification_updated
+	) RETURNING notification_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalNotification(n))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&n.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to create notification")
+	}
+
+	return nil
+}
+
+// List lists the in-app notifications of the principal, the newest first.
+func (s *NotificationStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) ([]*types.Notification, error) {
+	stmt := database.Builder.
+		Select(notificationColumns).
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	if filter.UnreadOnly {
+		stmt = stmt.Where("notification_read = FALSE")
+	}
+
+	if filter.Size > 0 {
+		stmt = stmt.Limit(database.Limit(filter.Size))
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
+
+	stmt = stmt.OrderBy("notification_id DESC")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notification
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notifications")
+	}
+
+	result := make([]*types.Notification, len(dst))
+	for i, n := range dst {
+		result[i] = mapToNotification(n)
+	}
+
+	return result, nil
+}
+
+// Count returns the number of in-app notifications of the principal.
+func (s *NotificationStore) Count(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationFilter,
+) (int64, error) {
+	stmt := database.Builder.
+		Select("COUNT(*)").
+		From("notifications").
+		Where("notification_principal_id = ?", principalID)
+
+	if filter.UnreadOnly {
+		stmt = stmt.Where("notification_read = FALSE")
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var count int64
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&count); err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to count notifications")
+	}
+
+	return count, nil
+}
+
+// MarkRead marks the in-app notifications of the principal as read.
+// If no notification IDs are provided, all notifications of the principal are marked as read.
+func (s *NotificationStore) MarkRead(ctx context.Context, principalID int64, ids []int64) (int64, error) {
+	stmt := database.Builder.
+		Update("notifications").
+		Set("notification_read", true).
+		Set("notification_updated", time.Now().UnixMilli()).
+		Where("notification_principal_id = ?", principalID).
+		Where("notification_read = FALSE")
+
+	if len(ids) > 0 {
+		stmt = stmt.Where(squirrel.Eq{"notification_id": ids})
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return 0, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to mark notifications as read")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated notifications")
+	}
+
+	return count, nil
+}
+
+func mapToNotification(n *notification) *types.Notification {
+	return &types.Notification{
+		ID:          n.ID,
+		PrincipalID: n.PrincipalID,
+		Event:       n.Event,
+		EventID:     n.EventID,
+		RepoID:      n.RepoID.Ptr(),
+		PullReqID:   n.PullReqID.Ptr(),
+		ActorID:     n.ActorID.Ptr(),
+		Title:       n.Title,
+		URL:         n.URL,
+		Read:        n.Read,
+		Created:     n.Created,
+		Updated:     n.Updated,
+	}
+}
+
+func mapToInternalNotification(n *types.Notification) *notification {
+	return &notification{
+		ID:          n.ID,
+		PrincipalID: n.PrincipalID,
+		Event:       n.Event,
+		EventID:     n.EventID,
+		RepoID:      null.IntFromPtr(n.RepoID),
+		PullReqID:   null.IntFromPtr(n.PullReqID),
+		ActorID:     null.IntFromPtr(n.ActorID),
+		Title:       n.Title,
+		URL:         n.URL,
+		Read:        n.Read,
+		Created:     n.Created,
+		Updated:     n.Updated,
+	}
+}
@@ -0,0 +1,360 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/harness/gitness/app/store"
+	gitness_store "github.com/harness/gitness/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationChannelStore = (*NotificationChannelStore)(nil)
+
+const notificationChannelEventsSeparator = ","
+
+// NewNotificationChannelStore returns a new NotificationChannelStore.
+func NewNotificationChannelStore(db *sqlx.DB) *NotificationChannelStore {
+	return &NotificationChannelStore{
+		db: db,
+	}
+}
+
+// NotificationChannelStore implements store.NotificationChannelStore backed by a relational database.
+type NotificationChannelStore struct {
+	db *sqlx.DB
+}
+
+type notificationChannel struct {
+	ID             int64                        `db:"notification_channel_id"`
+	SpaceID        int64                        `db:"notification_channel_space_id"`
+	Identifier     string                       `db:"notification_channel_identifier"`
+	DisplayName    string                       `db:"notification_channel_display_name"`
+	Type           enum.NotificationChannelType `db:"notification_channel_type"`
+	URL            string                       `db:"notification_channel_url"`
+	Enabled        bool                         `db:"notification_channel_enabled"`
+	Events         string                       `db:"notification_channel_events"`
+	RepoPatterns   string                       `db:"notification_channel_repo_patterns"`
+	BranchPatterns string                       `db:"notification_channel_branch_patterns"`
+	CreatedBy      int64                        `db:"notification_channel_created_by"`
+	Created        int64                        `db:"notification_channel_created"`
+	Updated        int64                        `db:"notification_channel_updated"`
+}
+
+const (
+	notificationChannelColumns = `
+		 notification_channel_id
+		,notification_channel_space_id
+		,notification_channel_identifier
+		,notification_channel_display_name
+		,notification_channel_type
+		,notification_channel_url
+		,notification_channel_enabled
+		,notification_channel_events
+		,notification_channel_repo_patterns
+		,notification_channel_branch_patterns
+		,notification_channel_created_by
+		,notification_channel_created
+		,notification_channel_updated`
+
+	notificationChannelSelectBase = `
+	SELECT` + notificationChannelColumns + `
+	FROM notification_channels`
+)
+
+// Find finds the notification channel by id.
+func (s *NotificationChannelStore) Find(ctx context.Context, id int64) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, id); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel")
+	}
+
+	return mapToNotificationChannel(dst)
+}
+
+// FindByIdentifier finds the notification channel of the space by its identifier.
+func (s *NotificationChannelStore) FindByIdentifier(
+	ctx context.Context,
+	spaceID int64,
+	identifier string,
+) (*types.NotificationChannel, error) {
+	const sqlQuery = notificationChannelSelectBase + `
+		WHERE notification_channel_space_id = $1 AND LOWER(notification_channel_identifier) = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationChannel{}
+	if err := db.GetContext(ctx, dst, sqlQuery, spaceID, strings.ToLower(identifier)); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification channel by identifier")
+	}
+
+	return mapToNotificationChannel(dst)
+}
+
+// Create creates a new notification channel.
+func (s *NotificationChannelStore) Create(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+	INSERT INTO notification_channels (
+		 notification_channel_space_id
+		,notification_channel_identifier
+		,notification_channel_display_name
+		,notification_channel_type
+		,notification_channel_url
+		,notification_channel_enabled
+		,notification_channel_events
+		,notification_channel_repo_patterns
+		,notification_channel_branch_patterns
+		,notification_channel_created_by
+		,notification_channel_created
+		,notification_channel_updated
+	) VALUES (
+		 :notification_channel_space_id
+		,:notification_channel_identifier
+		,:notification_channel_display_name
+		,:notification_channel_type
+		,:notification_channel_url
+		,:notification_channel_enabled
+		,:notification_channel_events
+		,:notification_channel_repo_patterns
+		,:notification_channel_branch_patterns
+		,:notification_channel_created_by
+		,:notification_channel_created
+		,:notification_channel_updated
+	) RETURNING notification_channel_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapToInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&channel.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to insert notification channel")
+	}
+
+	return nil
+}
+
+// Update updates an existing notification channel.
+func (s *NotificationChannelStore) Update(ctx context.Context, channel *types.NotificationChannel) error {
+	const sqlQuery = `
+	UPDATE notification_channels
+	SET
+		 notification_channel_identifier = :notification_channel_identifier
+		,notification_channel_display_name = :notification_channel_display_name
+		,notification_channel_type = :notification_channel_type
+		,notification_channel_url = :notification_channel_url
+		,notification_channel_enabled = :notification_channel_enabled
+		,notification_channel_events = :notification_channel_events
+		,notification_channel_repo_patterns = :notification_channel_repo_patterns
+		,notification_channel_branch_patterns = :notification_channel_branch_patterns
+		,notification_channel_updated = :notification_channel_updated
+	WHERE notification_channel_id = :notification_channel_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dbChannel, err := mapToInternalNotificationChannel(channel)
+	if err != nil {
+		return err
+	}
+
+	query, args, err := db.BindNamed(sqlQuery, dbChannel)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification channel object")
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to update notification channel")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to get number of updated rows")
+	}
+
+	if count == 0 {
+		return gitness_store.ErrResourceNotFound
+	}
+
+	return nil
+}
+
+// Delete deletes the notification channel with the given id.
+func (s *NotificationChannelStore) Delete(ctx context.Context, id int64) error {
+	const sqlQuery = `
+		DELETE FROM notification_channels
+		WHERE notification_channel_id = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, id); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification channel")
+	}
+
+	return nil
+}
+
+// List returns all notification channels of the space ordered by identifier.
+func (s *NotificationChannelStore) List(ctx context.Context, spaceID int64) ([]*types.NotificationChannel, error) {
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where("notification_channel_space_id = ?", spaceID).
+		OrderBy("LOWER(notification_channel_identifier)")
+
+	return s.list(ctx, stmt)
+}
+
+// ListEnabled returns the enabled notification channels of the provided spaces.
+func (s *NotificationChannelStore) ListEnabled(
+	ctx context.Context,
+	spaceIDs []int64,
+) ([]*types.NotificationChannel, error) {
+	if len(spaceIDs) == 0 {
+		return []*types.NotificationChannel{}, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationChannelColumns).
+		From("notification_channels").
+		Where(squirrel.Eq{"notification_channel_space_id": spaceIDs}).
+		Where("notification_channel_enabled = ?", true).
+		OrderBy("notification_channel_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationChannelStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.NotificationChannel, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*notificationChannel, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification channels")
+	}
+
+	channels := make([]*types.NotificationChannel, len(dst))
+	for i := range dst {
+		if channels[i], err = mapToNotificationChannel(dst[i]); err != nil {
+			return nil, err
+		}
+	}
+
+	return channels, nil
+}
+
+func mapToInternalNotificationChannel(channel *types.NotificationChannel) (*notificationChannel, error) {
+	repoPatterns, err := json.Marshal(nonNilStrings(channel.RepoPatterns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize repo patterns: %w", err)
+	}
+
+	branchPatterns, err := json.Marshal(nonNilStrings(channel.BranchPatterns))
+	if err != nil {
+		return nil, fmt.Errorf("failed to serialize branch patterns: %w", err)
+	}
+
+	events := make([]string, len(channel.Events))
+	for i := range channel.Events {
+		events[i] = string(channel.Events[i])
+	}
+
+	return &notificationChannel{
+		ID:             channel.ID,
+		SpaceID:        channel.SpaceID,
+		Identifier:     channel.Identifier,
+		DisplayName:    channel.DisplayName,
+		Type:           channel.Type,
+		URL:            channel.URL,
+		Enabled:        channel.Enabled,
+		Events:         strings.Join(events, notificationChannelEventsSeparator),
+		RepoPatterns:   string(repoPatterns),
+		BranchPatterns: string(branchPatterns),
+		CreatedBy:      channel.CreatedBy,
+		Created:        channel.Created,
+		Updated:        channel.Updated,
+	}, nil
+}
+
+func mapToNotificationChannel(channel *notificationChannel) (*types.NotificationChannel, error) {
+	events := []enum.NotificationEvent{}
+	if channel.Events != "" {
+		for _, event := range strings.Split(channel.Events, notificationChannelEventsSeparator) {
+			// ASSUMPTION: event is valid value (as we wrote it to DB)
+			events = append(events, enum.NotificationEvent(event))
+		}
+	}
+
+	var repoPatterns, branchPatterns []string
+	if err := json.Unmarshal([]byte(channel.RepoPatterns), &repoPatterns); err != nil {
+		return nil, fmt.Errorf("failed to deserialize repo patterns: %w", err)
+	}
+	if err := json.Unmarshal([]byte(channel.BranchPatterns), &branchPatterns); err != nil {
+		return nil, fmt.Errorf("failed to deserialize branch patterns: %w", err)
+	}
+
+	return &types.NotificationChannel{
+		ID:             channel.ID,
+		SpaceID:        channel.SpaceID,
+		Identifier:     channel.Identifier,
+		DisplayName:    channel.DisplayName,
+		Type:           channel.Type,
+		URL:            channel.URL,
+		Enabled:        channel.Enabled,
+		Events:         events,
+		RepoPatterns:   nonNilStrings(repoPatterns),
+		BranchPatterns: nonNilStrings(branchPatterns),
+		CreatedBy:      channel.CreatedBy,
+		Created:        channel.Created,
+		Updated:        channel.Updated,
+	}, nil
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
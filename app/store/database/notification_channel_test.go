@@ -0,0 +1,106 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationChannelStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+
+	channelStore := database.NewNotificationChannelStore(db)
+
+	channel := &types.NotificationChannel{
+		SpaceID:      1,
+		Identifier:   "Reviews",
+		DisplayName:  "Reviews",
+		Type:         enum.NotificationChannelTypeSlack,
+		URL:          "https://hooks.slack.com/services/x",
+		Enabled:      true,
+		Events:       []enum.NotificationEvent{enum.NotificationEventReviewerAdded},
+		RepoPatterns: []string{"space/{a,b}*"},
+		CreatedBy:    userID,
+		Created:      1000,
+		Updated:      1000,
+	}
+	require.NoError(t, channelStore.Create(ctx, channel))
+
+	found, err := channelStore.FindByIdentifier(ctx, 1, "reviews")
+	require.NoError(t, err)
+	assert.Equal(t, channel.ID, found.ID)
+	assert.Equal(t, []string{"space/{a,b}*"}, found.RepoPatterns)
+	assert.Equal(t, []string{}, found.BranchPatterns)
+	assert.Equal(t, []enum.NotificationEvent{enum.NotificationEventReviewerAdded}, found.Events)
+
+	err = channelStore.Create(ctx, &types.NotificationChannel{
+		SpaceID:    1,
+		Identifier: "REVIEWS",
+		Type:       enum.NotificationChannelTypeGeneric,
+		CreatedBy:  userID,
+	})
+	assert.ErrorIs(t, err, store.ErrDuplicate)
+
+	disabled := &types.NotificationChannel{
+		SpaceID:    2,
+		Identifier: "builds",
+		Type:       enum.NotificationChannelTypeTeams,
+		Events:     []enum.NotificationEvent{},
+		CreatedBy:  userID,
+	}
+	require.NoError(t, channelStore.Create(ctx, disabled))
+
+	channels, err := channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, channel.ID, channels[0].ID)
+
+	disabled.Enabled = true
+	disabled.BranchPatterns = []string{"main"}
+	require.NoError(t, channelStore.Update(ctx, disabled))
+
+	channels, err = channelStore.ListEnabled(ctx, []int64{1, 2})
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, []string{"main"}, channels[1].BranchPatterns)
+	assert.Empty(t, channels[1].Events)
+
+	require.NoError(t, channelStore.Delete(ctx, channel.ID))
+
+	channels, err = channelStore.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+
+	_, err = channelStore.Find(ctx, channel.ID)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+}
@@ -0,0 +1,232 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationDigestStore = (*NotificationDigestStore)(nil)
+
+// NewNotificationDigestStore returns a new NotificationDigestStore.
+func NewNotificationDigestStore(db *sqlx.DB) *NotificationDigestStore {
+	return &NotificationDigestStore{
+		db: db,
+	}
+}
+
+// NotificationDigestStore implements store.NotificationDigestStore backed by a relational database.
+type NotificationDigestStore struct {
+	db *sqlx.DB
+}
+
+type notificationDigestItem struct {
+	ID          int64                     `db:"digest_item_id"`
+	PrincipalID int64                     `db:"digest_item_principal_id"`
+	Delivery    enum.NotificationDelivery `db:"digest_item_delivery"`
+	Event       enum.NotificationEvent    `db:"digest_item_event"`
+	EventID     string                    `db:"digest_item_event_id"`
+	RepoID      null.Int                  `db:"digest_item_repo_id"`
+	PullReqID   null.Int                  `db:"digest_item_pullreq_id"`
+	Subject     string                    `db:"digest_item_subject"`
+	Title       string                    `db:"digest_item_title"`
+	URL         string                    `db:"digest_item_url"`
+	Created     int64                     `db:"digest_item_created"`
+}
+
+const (
+	notificationDigestItemColumns = `
+		 digest_item_id
+		,digest_item_principal_id
+		,digest_item_delivery
+		,digest_item_event
+		,digest_item_event_id
+		,digest_item_repo_id
+		,digest_item_pullreq_id
+		,digest_item_subject
+		,digest_item_title
+		,digest_item_url
+		,digest_item_created`
+)
+
+// Enqueue adds the item to the queue of the next digest of the principal.
+// It returns store.ErrDuplicate if the pending digest of the principal contains the item of the event already.
+func (s *NotificationDigestStore) Enqueue(ctx context.Context, item *types.NotificationDigestItem) error {
+	const sqlQuery = `
+	INSERT INTO notification_digest_items (
+		 digest_item_principal_id
+		,digest_item_delivery
+		,digest_item_event
+		,digest_item_event_id
+		,digest_item_repo_id
+		,digest_item_pullreq_id
+		,digest_item_subject
+		,digest_item_title
+		,digest_item_url
+		,digest_item_created
+	) VALUES (
+		 :digest_item_principal_id
+		,:digest_item_delivery
+		,:digest_item_event
+		,:digest_item_event_id
+		,:digest_item_repo_id
+		,:digest_item_pullreq_id
+		,:digest_item_subject
+		,:digest_item_title
+		,:digest_item_url
+		,:digest_item_created
+	) RETURNING digest_item_id`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalNotificationDigestItem(item))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification digest item object")
+	}
+
+	if err = db.QueryRowContext(ctx, query, args...).Scan(&item.ID); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to enqueue notification digest item")
+	}
+
+	return nil
+}
+
+// ListPrincipalIDs returns the IDs of all principals with queued items for the digest delivery.
+func (s *NotificationDigestStore) ListPrincipalIDs(
+	ctx context.Context,
+	delivery enum.NotificationDelivery,
+) ([]int64, error) {
+	stmt := database.Builder.
+		Select("DISTINCT digest_item_principal_id").
+		From("notification_digest_items").
+		Where("digest_item_delivery = ?", delivery).
+		OrderBy("digest_item_principal_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var ids []int64
+	if err = db.SelectContext(ctx, &ids, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list principals with queued digest items")
+	}
+
+	return ids, nil
+}
+
+// List returns the queued items of the principal for the digest delivery, the oldest first.
+func (s *NotificationDigestStore) List(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+) ([]*types.NotificationDigestItem, error) {
+	stmt := database.Builder.
+		Select(notificationDigestItemColumns).
+		From("notification_digest_items").
+		Where("digest_item_principal_id = ?", principalID).
+		Where("digest_item_delivery = ?", delivery).
+		OrderBy("digest_item_id")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationDigestItem
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification digest items")
+	}
+
+	result := make([]*types.NotificationDigestItem, len(dst))
+	for i, item := range dst {
+		result[i] = mapToNotificationDigestItem(item)
+	}
+
+	return result, nil
+}
+
+// Delete removes the queued items of the principal for the digest delivery up to and including the provided ID.
+func (s *NotificationDigestStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	delivery enum.NotificationDelivery,
+	maxID int64,
+) error {
+	stmt := database.Builder.
+		Delete("notification_digest_items").
+		Where("digest_item_principal_id = ?", principalID).
+		Where("digest_item_delivery = ?", delivery).
+		Where("digest_item_id <= ?", maxID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification digest items")
+	}
+
+	return nil
+}
+
+func mapToNotificationDigestItem(item *notificationDigestItem) *types.NotificationDigestItem {
+	return &types.NotificationDigestItem{
+		ID:          item.ID,
+		PrincipalID: item.PrincipalID,
+		Delivery:    item.Delivery,
+		Event:       item.Event,
+		EventID:     item.EventID,
+		RepoID:      item.RepoID.Ptr(),
+		PullReqID:   item.PullReqID.Ptr(),
+		Subject:     item.Subject,
+		Title:       item.Title,
+		URL:         item.URL,
+		Created:     item.Created,
+	}
+}
+
+func mapToInternalNotificationDigestItem(item *types.NotificationDigestItem) *notificationDigestItem {
+	return &notificationDigestItem{
+		ID:          item.ID,
+		PrincipalID: item.PrincipalID,
+		Delivery:    item.Delivery,
+		Event:       item.Event,
+		EventID:     item.EventID,
+		RepoID:      null.IntFromPtr(item.RepoID),
+		PullReqID:   null.IntFromPtr(item.PullReqID),
+		Subject:     item.Subject,
+		Title:       item.Title,
+		URL:         item.URL,
+		Created:     item.Created,
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDigestStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	digestStore := database.NewNotificationDigestStore(db)
+
+	enqueue := func(delivery enum.NotificationDelivery, title string) *types.NotificationDigestItem {
+		repoID := int64(1)
+		item := &types.NotificationDigestItem{
+			PrincipalID: userID,
+			Delivery:    delivery,
+			Event:       enum.NotificationEventCommentCreated,
+			RepoID:      &repoID,
+			Subject:     "space/repo #1: test",
+			Title:       title,
+			Created:     1000,
+		}
+		require.NoError(t, digestStore.Enqueue(ctx, item))
+		return item
+	}
+
+	first := enqueue(enum.NotificationDeliveryDailyDigest, "a commented on #1: test")
+	enqueue(enum.NotificationDeliveryWeeklyDigest, "b commented on #1: test")
+
+	ids, err := digestStore.ListPrincipalIDs(ctx, enum.NotificationDeliveryDailyDigest)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{userID}, ids)
+
+	items, err := digestStore.List(ctx, userID, enum.NotificationDeliveryDailyDigest)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, first.ID, items[0].ID)
+	assert.Nil(t, items[0].PullReqID)
+
+	// items queued after the digest was rendered are kept for the next digest.
+	second := enqueue(enum.NotificationDeliveryDailyDigest, "c commented on #1: test")
+
+	require.NoError(t, digestStore.Delete(ctx, userID, enum.NotificationDeliveryDailyDigest, first.ID))
+
+	items, err = digestStore.List(ctx, userID, enum.NotificationDeliveryDailyDigest)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.Equal(t, second.ID, items[0].ID)
+
+	items, err = digestStore.List(ctx, userID, enum.NotificationDeliveryWeeklyDigest)
+	require.NoError(t, err)
+	assert.Len(t, items, 1)
+
+	// a redelivered event isn't listed twice in the pending digest
+	item := &types.NotificationDigestItem{
+		PrincipalID: userID,
+		Delivery:    enum.NotificationDeliveryDailyDigest,
+		Event:       enum.NotificationEventCommentCreated,
+		EventID:     "event-1",
+		Title:       "d commented on #1: test",
+		Created:     2000,
+	}
+	require.NoError(t, digestStore.Enqueue(ctx, item))
+
+	dup := *item
+	dup.ID = 0
+	assert.ErrorIs(t, digestStore.Enqueue(ctx, &dup), store.ErrDuplicate)
+
+	items, err = digestStore.List(ctx, userID, enum.NotificationDeliveryDailyDigest)
+	require.NoError(t, err)
+	assert.Len(t, items, 2)
+}
@@ -0,0 +1,174 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationPreferenceStore = (*NotificationPreferenceStore)(nil)
+
+// NewNotificationPreferenceStore returns a new NotificationPreferenceStore.
+func NewNotificationPreferenceStore(db *sqlx.DB) *NotificationPreferenceStore {
+	return &NotificationPreferenceStore{
+		db: db,
+	}
+}
+
+// NotificationPreferenceStore implements store.NotificationPreferenceStore backed by a relational database.
+type NotificationPreferenceStore struct {
+	db *sqlx.DB
+}
+
+type notificationPreference struct {
+	PrincipalID int64                     `db:"notification_preference_principal_id"`
+	Event       enum.NotificationEvent    `db:"notification_preference_event"`
+	Delivery    enum.NotificationDelivery `db:"notification_preference_delivery"`
+	Updated     int64                     `db:"notification_preference_updated"`
+}
+
+const notificationPreferenceColumns = `
+	 notification_preference_principal_id
+	,notification_preference_event
+	,notification_preference_delivery
+	,notification_preference_updated`
+
+// List returns all notification preferences the principal has configured.
+func (s *NotificationPreferenceStore) List(
+	ctx context.Context,
+	principalID int64,
+) ([]types.NotificationPreference, error) {
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where("notification_preference_principal_id = ?", principalID).
+		OrderBy("notification_preference_event")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences")
+	}
+
+	result := make([]types.NotificationPreference, len(dst))
+	for i, p := range dst {
+		result[i] = mapToNotificationPreference(p)
+	}
+
+	return result, nil
+}
+
+// MapDelivery returns the configured delivery of the event for each of the provided principals.
+// Principals without a configured delivery for the event are not part of the result.
+func (s *NotificationPreferenceStore) MapDelivery(
+	ctx context.Context,
+	event enum.NotificationEvent,
+	principalIDs []int64,
+) (map[int64]enum.NotificationDelivery, error) {
+	result := make(map[int64]enum.NotificationDelivery)
+	if len(principalIDs) == 0 {
+		return result, nil
+	}
+
+	stmt := database.Builder.
+		Select(notificationPreferenceColumns).
+		From("notification_preferences").
+		Where("notification_preference_event = ?", event).
+		Where(squirrel.Eq{"notification_preference_principal_id": principalIDs})
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationPreference
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification preferences for event")
+	}
+
+	for _, p := range dst {
+		result[p.PrincipalID] = p.Delivery
+	}
+
+	return result, nil
+}
+
+// Upsert creates or updates the notification preference of the principal for the event.
+func (s *NotificationPreferenceStore) Upsert(ctx context.Context, pref *types.NotificationPreference) error {
+	const sqlQuery = `
+	INSERT INTO notification_preferences (` + notificationPreferenceColumns + `
+	) VALUES (
+		 :notification_preference_principal_id
+		,:notification_preference_event
+		,:notification_preference_delivery
+		,:notification_preference_updated
+	)
+	ON CONFLICT (notification_preference_principal_id, notification_preference_event) DO
+	UPDATE SET
+		 notification_preference_delivery = EXCLUDED.notification_preference_delivery
+		,notification_preference_updated = EXCLUDED.notification_preference_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	pref.Updated = time.Now().UnixMilli()
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalNotificationPreference(pref))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification preference object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification preference")
+	}
+
+	return nil
+}
+
+func mapToNotificationPreference(p *notificationPreference) types.NotificationPreference {
+	return types.NotificationPreference{
+		PrincipalID: p.PrincipalID,
+		Event:       p.Event,
+		Delivery:    p.Delivery,
+		Updated:     p.Updated,
+	}
+}
+
+func mapToInternalNotificationPreference(p *types.NotificationPreference) *notificationPreference {
+	return &notificationPreference{
+		PrincipalID: p.PrincipalID,
+		Event:       p.Event,
+		Delivery:    p.Delivery,
+		Updated:     p.Updated,
+	}
+}
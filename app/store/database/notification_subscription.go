@@ -0,0 +1,318 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.NotificationSubscriptionStore = (*NotificationSubscriptionStore)(nil)
+
+// NewNotificationSubscriptionStore returns a new NotificationSubscriptionStore.
+func NewNotificationSubscriptionStore(db *sqlx.DB) *NotificationSubscriptionStore {
+	return &NotificationSubscriptionStore{
+		db: db,
+	}
+}
+
+// NotificationSubscriptionStore implements store.NotificationSubscriptionStore backed by a relational database.
+type NotificationSubscriptionStore struct {
+	db *sqlx.DB
+}
+
+type notificationSubscription struct {
+	ID          int64                              `db:"notification_subscription_id"`
+	PrincipalID int64                              `db:"notification_subscription_principal_id"`
+	SpaceID     null.Int                           `db:"notification_subscription_space_id"`
+	RepoID      null.Int                           `db:"notification_subscription_repo_id"`
+	PullReqID   null.Int                           `db:"notification_subscription_pullreq_id"`
+	State       enum.NotificationSubscriptionState `db:"notification_subscription_state"`
+	Created     int64                              `db:"notification_subscription_created"`
+	Updated     int64                              `db:"notification_subscription_updated"`
+}
+
+const (
+	notificationSubscriptionColumns = `
+		 notification_subscription_id
+		,notification_subscription_principal_id
+		,notification_subscription_space_id
+		,notification_subscription_repo_id
+		,notification_subscription_pullreq_id
+		,notification_subscription_state
+		,notification_subscription_created
+		,notification_subscription_updated`
+)
+
+// Find finds the subscription of the principal for the resource.
+func (s *NotificationSubscriptionStore) Find(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.NotificationResourceType,
+	resourceID int64,
+) (*types.NotificationSubscription, error) {
+	column, err := notificationSubscriptionResourceColumn(resourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where("notification_subscription_principal_id = ?", principalID).
+		Where(column+" = ?", resourceID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationSubscription{}
+	if err = db.GetContext(ctx, dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification subscription")
+	}
+
+	return mapToNotificationSubscription(dst), nil
+}
+
+// Upsert creates or updates the subscription of the principal for the resource.
+func (s *NotificationSubscriptionStore) Upsert(ctx context.Context, sub *types.NotificationSubscription) error {
+	column, err := notificationSubscriptionResourceColumn(sub.ResourceType)
+	if err != nil {
+		return err
+	}
+
+	internal := mapToInternalNotificationSubscription(sub)
+
+	stmt := database.Builder.
+		Insert("notification_subscriptions").
+		Columns(
+			"notification_subscription_principal_id",
+			"notification_subscription_space_id",
+			"notification_subscription_repo_id",
+			"notification_subscription_pullreq_id",
+			"notification_subscription_state",
+			"notification_subscription_created",
+			"notification_subscription_updated",
+		).
+		Values(
+			internal.PrincipalID,
+			internal.SpaceID,
+			internal.RepoID,
+			internal.PullReqID,
+			internal.State,
+			internal.Created,
+			internal.Updated,
+		).
+		Suffix(fmt.Sprintf(`ON CONFLICT (notification_subscription_principal_id, %[1]s) WHERE %[1]s IS NOT NULL DO
+		UPDATE SET
+			 notification_subscription_state = EXCLUDED.notification_subscription_state
+			,notification_subscription_updated = EXCLUDED.notification_subscription_updated
+		RETURNING notification_subscription_created`, column))
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if err = db.QueryRowContext(ctx, sql, args...).Scan(&sub.Created); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification subscription")
+	}
+
+	return nil
+}
+
+// Delete deletes the subscription of the principal for the resource.
+func (s *NotificationSubscriptionStore) Delete(
+	ctx context.Context,
+	principalID int64,
+	resourceType enum.NotificationResourceType,
+	resourceID int64,
+) error {
+	column, err := notificationSubscriptionResourceColumn(resourceType)
+	if err != nil {
+		return err
+	}
+
+	stmt := database.Builder.
+		Delete("notification_subscriptions").
+		Where("notification_subscription_principal_id = ?", principalID).
+		Where(column+" = ?", resourceID)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err = db.ExecContext(ctx, sql, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification subscription")
+	}
+
+	return nil
+}
+
+// List lists the subscriptions of the principal.
+func (s *NotificationSubscriptionStore) List(
+	ctx context.Context,
+	principalID int64,
+	filter *types.NotificationSubscriptionFilter,
+) ([]types.NotificationSubscription, error) {
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where("notification_subscription_principal_id = ?", principalID)
+
+	if filter.ResourceType != "" {
+		column, err := notificationSubscriptionResourceColumn(filter.ResourceType)
+		if err != nil {
+			return nil, err
+		}
+
+		stmt = stmt.Where(column + " IS NOT NULL")
+	}
+
+	if filter.Size > 0 {
+		stmt = stmt.Limit(database.Limit(filter.Size))
+		stmt = stmt.Offset(database.Offset(filter.Page, filter.Size))
+	}
+
+	stmt = stmt.OrderBy("notification_subscription_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListForResources lists the subscriptions of all principals for the pull request,
+// the repository and the spaces.
+func (s *NotificationSubscriptionStore) ListForResources(
+	ctx context.Context,
+	spaceIDs []int64,
+	repoID int64,
+	pullReqID int64,
+) ([]types.NotificationSubscription, error) {
+	resources := squirrel.Or{
+		squirrel.Eq{"notification_subscription_repo_id": repoID},
+	}
+	if pullReqID > 0 {
+		resources = append(resources, squirrel.Eq{"notification_subscription_pullreq_id": pullReqID})
+	}
+	if len(spaceIDs) > 0 {
+		resources = append(resources, squirrel.Eq{"notification_subscription_space_id": spaceIDs})
+	}
+
+	stmt := database.Builder.
+		Select(notificationSubscriptionColumns).
+		From("notification_subscriptions").
+		Where(resources).
+		OrderBy("notification_subscription_id")
+
+	return s.list(ctx, stmt)
+}
+
+func (s *NotificationSubscriptionStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]types.NotificationSubscription, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*notificationSubscription
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification subscriptions")
+	}
+
+	result := make([]types.NotificationSubscription, len(dst))
+	for i, sub := range dst {
+		result[i] = *mapToNotificationSubscription(sub)
+	}
+
+	return result, nil
+}
+
+func notificationSubscriptionResourceColumn(resourceType enum.NotificationResourceType) (string, error) {
+	switch resourceType {
+	case enum.NotificationResourceTypeSpace:
+		return "notification_subscription_space_id", nil
+	case enum.NotificationResourceTypeRepo:
+		return "notification_subscription_repo_id", nil
+	case enum.NotificationResourceTypePullReq:
+		return "notification_subscription_pullreq_id", nil
+	default:
+		return "", fmt.Errorf("notification resource type %q is not supported", resourceType)
+	}
+}
+
+func mapToNotificationSubscription(sub *notificationSubscription) *types.NotificationSubscription {
+	result := &types.NotificationSubscription{
+		PrincipalID: sub.PrincipalID,
+		State:       sub.State,
+		Created:     sub.Created,
+		Updated:     sub.Updated,
+	}
+
+	switch {
+	case sub.SpaceID.Valid:
+		result.ResourceType = enum.NotificationResourceTypeSpace
+		result.ResourceID = sub.SpaceID.Int64
+	case sub.RepoID.Valid:
+		result.ResourceType = enum.NotificationResourceTypeRepo
+		result.ResourceID = sub.RepoID.Int64
+	case sub.PullReqID.Valid:
+		result.ResourceType = enum.NotificationResourceTypePullReq
+		result.ResourceID = sub.PullReqID.Int64
+	}
+
+	return result
+}
+
+func mapToInternalNotificationSubscription(sub *types.NotificationSubscription) *notificationSubscription {
+	result := &notificationSubscription{
+		PrincipalID: sub.PrincipalID,
+		State:       sub.State,
+		Created:     sub.Created,
+		Updated:     sub.Updated,
+	}
+
+	switch sub.ResourceType {
+	case enum.NotificationResourceTypeSpace:
+		result.SpaceID = null.IntFrom(sub.ResourceID)
+	case enum.NotificationResourceTypeRepo:
+		result.RepoID = null.IntFrom(sub.ResourceID)
+	case enum.NotificationResourceTypePullReq:
+		result.PullReqID = null.IntFrom(sub.ResourceID)
+	}
+
+	return result
+}
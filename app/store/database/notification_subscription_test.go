@@ -0,0 +1,92 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationSubscriptionStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	subscriptionStore := database.NewNotificationSubscriptionStore(db)
+
+	spaceSub := &types.NotificationSubscription{
+		PrincipalID:  userID,
+		ResourceType: enum.NotificationResourceTypeSpace,
+		ResourceID:   1,
+		State:        enum.NotificationSubscriptionStateWatching,
+		Created:      1000,
+		Updated:      1000,
+	}
+	require.NoError(t, subscriptionStore.Upsert(ctx, spaceSub))
+
+	repoSub := &types.NotificationSubscription{
+		PrincipalID:  userID,
+		ResourceType: enum.NotificationResourceTypeRepo,
+		ResourceID:   1,
+		State:        enum.NotificationSubscriptionStateWatching,
+		Created:      1000,
+		Updated:      1000,
+	}
+	require.NoError(t, subscriptionStore.Upsert(ctx, repoSub))
+
+	// updating the subscription keeps the original creation time
+	repoSub.State = enum.NotificationSubscriptionStateMuted
+	repoSub.Created = 2000
+	repoSub.Updated = 2000
+	require.NoError(t, subscriptionStore.Upsert(ctx, repoSub))
+	assert.Equal(t, int64(1000), repoSub.Created)
+
+	found, err := subscriptionStore.Find(ctx, userID, enum.NotificationResourceTypeRepo, 1)
+	require.NoError(t, err)
+	assert.Equal(t, enum.NotificationSubscriptionStateMuted, found.State)
+	assert.Equal(t, int64(2000), found.Updated)
+
+	list, err := subscriptionStore.ListForResources(ctx, []int64{1}, 1, 0)
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	list, err = subscriptionStore.List(ctx, userID, &types.NotificationSubscriptionFilter{
+		ResourceType: enum.NotificationResourceTypeSpace,
+	})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	assert.Equal(t, enum.NotificationResourceTypeSpace, list[0].ResourceType)
+	assert.Equal(t, int64(1), list[0].ResourceID)
+
+	require.NoError(t, subscriptionStore.Delete(ctx, userID, enum.NotificationResourceTypeRepo, 1))
+
+	_, err = subscriptionStore.Find(ctx, userID, enum.NotificationResourceTypeRepo, 1)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+}
@@ -0,0 +1,179 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+)
+
+var _ store.NotificationTemplateStore = (*NotificationTemplateStore)(nil)
+
+// NewNotificationTemplateStore returns a new NotificationTemplateStore.
+func NewNotificationTemplateStore(db *sqlx.DB) *NotificationTemplateStore {
+	return &NotificationTemplateStore{
+		db: db,
+	}
+}
+
+// NotificationTemplateStore implements store.NotificationTemplateStore backed by a relational database.
+type NotificationTemplateStore struct {
+	db *sqlx.DB
+}
+
+type notificationTemplate struct {
+	Name        enum.NotificationTemplate    `db:"notification_template_name"`
+	Subject     string                       `db:"notification_template_subject"`
+	Body        string                       `db:"notification_template_body"`
+	ContentType enum.NotificationContentType `db:"notification_template_content_type"`
+	UpdatedBy   null.Int                     `db:"notification_template_updated_by"`
+	Updated     int64                        `db:"notification_template_updated"`
+}
+
+const (
+	notificationTemplateSelectBase = `
+	SELECT
+		 notification_template_name
+		,notification_template_subject
+		,notification_template_body
+		,notification_template_content_type
+		,notification_template_updated_by
+		,notification_template_updated
+	FROM notification_templates`
+)
+
+// Find finds the custom template of the notification email.
+func (s *NotificationTemplateStore) Find(
+	ctx context.Context,
+	name enum.NotificationTemplate,
+) (*types.NotificationTemplate, error) {
+	const sqlQuery = notificationTemplateSelectBase + `
+	WHERE notification_template_name = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := &notificationTemplate{}
+	if err := db.GetContext(ctx, dst, sqlQuery, name); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to find notification template")
+	}
+
+	return mapToNotificationTemplate(dst), nil
+}
+
+// List returns all custom templates of notification emails.
+func (s *NotificationTemplateStore) List(ctx context.Context) ([]*types.NotificationTemplate, error) {
+	const sqlQuery = notificationTemplateSelectBase + `
+	ORDER BY notification_template_name`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*notificationTemplate, 0)
+	if err := db.SelectContext(ctx, &dst, sqlQuery); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list notification templates")
+	}
+
+	templates := make([]*types.NotificationTemplate, len(dst))
+	for i := range dst {
+		templates[i] = mapToNotificationTemplate(dst[i])
+	}
+
+	return templates, nil
+}
+
+// Upsert creates or replaces the custom template of the notification email.
+func (s *NotificationTemplateStore) Upsert(ctx context.Context, tmpl *types.NotificationTemplate) error {
+	const sqlQuery = `
+	INSERT INTO notification_templates (
+		 notification_template_name
+		,notification_template_subject
+		,notification_template_body
+		,notification_template_content_type
+		,notification_template_updated_by
+		,notification_template_updated
+	) VALUES (
+		 :notification_template_name
+		,:notification_template_subject
+		,:notification_template_body
+		,:notification_template_content_type
+		,:notification_template_updated_by
+		,:notification_template_updated
+	)
+	ON CONFLICT (notification_template_name) DO
+	UPDATE SET
+		 notification_template_subject = EXCLUDED.notification_template_subject
+		,notification_template_body = EXCLUDED.notification_template_body
+		,notification_template_content_type = EXCLUDED.notification_template_content_type
+		,notification_template_updated_by = EXCLUDED.notification_template_updated_by
+		,notification_template_updated = EXCLUDED.notification_template_updated`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, args, err := db.BindNamed(sqlQuery, mapToInternalNotificationTemplate(tmpl))
+	if err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to bind notification template object")
+	}
+
+	if _, err = db.ExecContext(ctx, query, args...); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to upsert notification template")
+	}
+
+	return nil
+}
+
+// Delete removes the custom template of the notification email.
+func (s *NotificationTemplateStore) Delete(ctx context.Context, name enum.NotificationTemplate) error {
+	const sqlQuery = `
+	DELETE FROM notification_templates
+	WHERE notification_template_name = $1`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	if _, err := db.ExecContext(ctx, sqlQuery, name); err != nil {
+		return database.ProcessSQLErrorf(ctx, err, "Failed to delete notification template")
+	}
+
+	return nil
+}
+
+func mapToInternalNotificationTemplate(tmpl *types.NotificationTemplate) *notificationTemplate {
+	return &notificationTemplate{
+		Name:        tmpl.Name,
+		Subject:     tmpl.Subject,
+		Body:        tmpl.Body,
+		ContentType: tmpl.ContentType,
+		UpdatedBy:   null.IntFromPtr(tmpl.UpdatedBy),
+		Updated:     tmpl.Updated,
+	}
+}
+
+func mapToNotificationTemplate(tmpl *notificationTemplate) *types.NotificationTemplate {
+	return &types.NotificationTemplate{
+		Name:        tmpl.Name,
+		Source:      enum.NotificationTemplateSourceCustom,
+		Subject:     tmpl.Subject,
+		Body:        tmpl.Body,
+		ContentType: tmpl.ContentType,
+		UpdatedBy:   tmpl.UpdatedBy.Ptr(),
+		Updated:     tmpl.Updated,
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationTemplateStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, _, _, _ := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+
+	templateStore := database.NewNotificationTemplateStore(db)
+
+	updatedBy := userID
+	tmpl := &types.NotificationTemplate{
+		Name:        enum.NotificationTemplateReviewerAdded,
+		Subject:     "Review requested",
+		Body:        "<p>{{.Reviewer.DisplayName}}</p>",
+		ContentType: enum.NotificationContentTypeHTML,
+		UpdatedBy:   &updatedBy,
+		Updated:     1000,
+	}
+	require.NoError(t, templateStore.Upsert(ctx, tmpl))
+
+	tmpl.Body = "{{.Reviewer.DisplayName}}"
+	tmpl.ContentType = enum.NotificationContentTypeText
+	tmpl.Updated = 2000
+	require.NoError(t, templateStore.Upsert(ctx, tmpl))
+
+	found, err := templateStore.Find(ctx, enum.NotificationTemplateReviewerAdded)
+	require.NoError(t, err)
+	assert.Equal(t, enum.NotificationTemplateSourceCustom, found.Source)
+	assert.Equal(t, "{{.Reviewer.DisplayName}}", found.Body)
+	assert.Equal(t, enum.NotificationContentTypeText, found.ContentType)
+	assert.Equal(t, int64(2000), found.Updated)
+	require.NotNil(t, found.UpdatedBy)
+	assert.Equal(t, userID, *found.UpdatedBy)
+
+	templates, err := templateStore.List(ctx)
+	require.NoError(t, err)
+	assert.Len(t, templates, 1)
+
+	require.NoError(t, templateStore.Delete(ctx, enum.NotificationTemplateReviewerAdded))
+
+	_, err = templateStore.Find(ctx, enum.NotificationTemplateReviewerAdded)
+	assert.ErrorIs(t, err, store.ErrResourceNotFound)
+}
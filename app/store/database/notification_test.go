@@ -0,0 +1,116 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/store"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	notificationStore := database.NewNotificationStore(db)
+
+	repoID := int64(1)
+	for i := 0; i < 3; i++ {
+		n := &types.Notification{
+			PrincipalID: userID,
+			Event:       enum.NotificationEventPullReqCreated,
+			RepoID:      &repoID,
+			ActorID:     &userID,
+			Title:       "admin opened #1: test",
+			URL:         "http://localhost/repo/pulls/1",
+			Created:     int64(1000 + i),
+			Updated:     int64(1000 + i),
+		}
+		require.NoError(t, notificationStore.Create(ctx, n))
+		assert.NotZero(t, n.ID)
+	}
+
+	list, err := notificationStore.List(ctx, userID, &types.NotificationFilter{})
+	require.NoError(t, err)
+	require.Len(t, list, 3)
+	assert.Greater(t, list[0].ID, list[1].ID, "the newest notification must be listed first")
+	assert.Equal(t, &repoID, list[0].RepoID)
+	assert.Nil(t, list[0].PullReqID)
+
+	updated, err := notificationStore.MarkRead(ctx, userID, []int64{list[0].ID})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), updated)
+
+	unread, err := notificationStore.Count(ctx, userID, &types.NotificationFilter{UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), unread)
+
+	list, err = notificationStore.List(ctx, userID, &types.NotificationFilter{UnreadOnly: true})
+	require.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	// notifications of other principals are never touched
+	updated, err = notificationStore.MarkRead(ctx, userID+1, nil)
+	require.NoError(t, err)
+	assert.Zero(t, updated)
+
+	updated, err = notificationStore.MarkRead(ctx, userID, nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+
+	total, err := notificationStore.Count(ctx, userID, &types.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+
+	// a redelivered event doesn't create the notification again
+	n := &types.Notification{
+		PrincipalID: userID,
+		Event:       enum.NotificationEventPullReqCreated,
+		EventID:     "event-1",
+		Title:       "admin opened #2: test",
+		Created:     2000,
+		Updated:     2000,
+	}
+	require.NoError(t, notificationStore.Create(ctx, n))
+
+	dup := *n
+	dup.ID = 0
+	err = notificationStore.Create(ctx, &dup)
+	assert.ErrorIs(t, err, store.ErrDuplicate)
+
+	other := *n
+	other.ID = 0
+	other.Event = enum.NotificationEventReviewerAdded
+	require.NoError(t, notificationStore.Create(ctx, &other), "other events of the same ID are created")
+
+	total, err = notificationStore.Count(ctx, userID, &types.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+}
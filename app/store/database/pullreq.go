@@ -82,8 +82,12 @@ type pullReq struct {
 	TargetRepoID int64    `db:"pullreq_target_repo_id"`
 	TargetBranch string   `db:"pullreq_target_branch"`
 
+	Flow enum.PullReqFlow `db:"pullreq_flow"`
+
 	ActivitySeq int64 `db:"pullreq_activity_seq"`
 
+	MilestoneID null.Int `db:"pullreq_milestone_id"`
+
 	MergedBy    null.Int    `db:"pullreq_merged_by"`
 	Merged      null.Int    `db:"pullreq_merged"`
 	MergeMethod null.String `db:"pullreq_merge_method"`
@@ -125,7 +129,9 @@ const (
 		,pullreq_source_sha
 		,pullreq_target_repo_id
 		,pullreq_target_branch
+		,pullreq_flow
 		,pullreq_activity_seq
+		,pullreq_milestone_id
 		,pullreq_merged_by
 		,pullreq_merged
 		,pullreq_merge_method
@@ -226,7 +232,9 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_source_sha
 		,pullreq_target_repo_id
 		,pullreq_target_branch
+		,pullreq_flow
 		,pullreq_activity_seq
+		,pullreq_milestone_id
 		,pullreq_merged_by
 		,pullreq_merged
 		,pullreq_merge_method
@@ -262,7 +270,9 @@ func (s *PullReqStore) Create(ctx context.Context, pr *types.PullReq) error {
 		,:pullreq_source_sha
 		,:pullreq_target_repo_id
 		,:pullreq_target_branch
+		,:pullreq_flow
 		,:pullreq_activity_seq
+		,:pullreq_milestone_id
 		,:pullreq_merged_by
 		,:pullreq_merged
 		,:pullreq_merge_method
@@ -311,6 +321,7 @@ func (s *PullReqStore) Update(ctx context.Context, pr *types.PullReq) error {
 		,pullreq_title = :pullreq_title
 		,pullreq_description = :pullreq_description
 		,pullreq_activity_seq = :pullreq_activity_seq
+		,pullreq_milestone_id = :pullreq_milestone_id
 		,pullreq_source_sha = :pullreq_source_sha
 		,pullreq_target_branch = :pullreq_target_branch
 		,pullreq_merged_by = :pullreq_merged_by
@@ -650,6 +661,7 @@ func (s *PullReqStore) ListOpenByBranchName(
 	stmt = stmt.Where("pullreq_source_repo_id = ?", repoID)
 	stmt = stmt.Where("pullreq_state = ?", enum.PullReqStateOpen)
 	stmt = stmt.Where(squirrel.Eq{"pullreq_source_branch": branchNames})
+	stmt = stmt.Where("pullreq_flow = ?", enum.PullReqFlowGithub)
 	stmt = stmt.OrderBy("pullreq_updated desc")
 
 	sql, args, err := stmt.ToSql()
@@ -746,6 +758,10 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 		*stmt = stmt.Where("pullreq_target_branch = ?", opts.TargetBranch)
 	}
 
+	if opts.Flow != "" {
+		*stmt = stmt.Where("pullreq_flow = ?", opts.Flow)
+	}
+
 	if opts.Query != "" {
 		*stmt = stmt.Where(PartialMatch("pullreq_title", opts.Query))
 	}
@@ -827,6 +843,16 @@ func (s *PullReqStore) applyFilter(stmt *squirrel.SelectBuilder, opts *types.Pul
 		}
 	}
 
+	if len(opts.AssigneeID) > 0 {
+		*stmt = stmt.Where(squirrel.Expr("EXISTS (SELECT 1 FROM pullreq_assignees"+
+			" WHERE pullreq_assignee_pullreq_id = pullreq_id AND ?)",
+			squirrel.Eq{"pullreq_assignee_principal_id": opts.AssigneeID}))
+	}
+
+	if len(opts.MilestoneID) > 0 {
+		*stmt = stmt.Where(squirrel.Eq{"pullreq_milestone_id": opts.MilestoneID})
+	}
+
 	if opts.MentionedID > 0 {
 		*stmt = stmt.InnerJoin("pullreq_activities act_ment ON act_ment.pullreq_activity_pullreq_id = pullreq_id")
 		*stmt = stmt.Where("act_ment.pullreq_activity_deleted IS NULL")
@@ -906,7 +932,9 @@ func mapPullReq(pr *pullReq) *types.PullReq {
 		SourceSHA:               pr.SourceSHA,
 		TargetRepoID:            pr.TargetRepoID,
 		TargetBranch:            pr.TargetBranch,
+		Flow:                    pr.Flow,
 		ActivitySeq:             pr.ActivitySeq,
+		MilestoneID:             pr.MilestoneID.Ptr(),
 		MergedBy:                pr.MergedBy.Ptr(),
 		Merged:                  pr.Merged.Ptr(),
 		MergeMethod:             (*enum.MergeMethod)(pr.MergeMethod.Ptr()),
@@ -957,7 +985,9 @@ func mapInternalPullReq(pr *types.PullReq) *pullReq {
 		SourceSHA:               pr.SourceSHA,
 		TargetRepoID:            pr.TargetRepoID,
 		TargetBranch:            pr.TargetBranch,
+		Flow:                    pr.Flow,
 		ActivitySeq:             pr.ActivitySeq,
+		MilestoneID:             null.IntFromPtr(pr.MilestoneID),
 		MergedBy:                null.IntFromPtr(pr.MergedBy),
 		Merged:                  null.IntFromPtr(pr.Merged),
 		MergeMethod:             null.StringFromPtr((*string)(pr.MergeMethod)),
@@ -0,0 +1,208 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqAssigneeStore = (*PullReqAssigneeStore)(nil)
+
+const maxPullRequestAssignees = 100
+
+// NewPullReqAssigneeStore returns a new PullReqAssigneeStore.
+func NewPullReqAssigneeStore(db *sqlx.DB,
+	pCache store.PrincipalInfoCache) *PullReqAssigneeStore {
+	return &PullReqAssigneeStore{
+		db:     db,
+		pCache: pCache,
+	}
+}
+
+// PullReqAssigneeStore implements store.PullReqAssigneeStore backed by a relational database.
+type PullReqAssigneeStore struct {
+	db     *sqlx.DB
+	pCache store.PrincipalInfoCache
+}
+
+type pullReqAssignee struct {
+	PullReqID   int64 `db:"pullreq_assignee_pullreq_id"`
+	PrincipalID int64 `db:"pullreq_assignee_principal_id"`
+	CreatedBy   int64 `db:"pullreq_assignee_created_by"`
+	Created     int64 `db:"pullreq_assignee_created"`
+}
+
+const (
+	pullReqAssigneeColumns = `
+		 pullreq_assignee_pullreq_id
+		,pullreq_assignee_principal_id
+		,pullreq_assignee_created_by
+		,pullreq_assignee_created`
+)
+
+// Create adds the assignee. It returns false if the principal is already assigned to the pull request.
+func (s *PullReqAssigneeStore) Create(ctx context.Context, assignee *types.PullReqAssignee) (bool, error) {
+	const sqlQuery = `
+	INSERT INTO pullreq_assignees (
+		 pullreq_assignee_pullreq_id
+		,pullreq_assignee_principal_id
+		,pullreq_assignee_created_by
+		,pullreq_assignee_created
+	) VALUES (
+		 :pullreq_assignee_pullreq_id
+		,:pullreq_assignee_principal_id
+		,:pullreq_assignee_created_by
+		,:pullreq_assignee_created
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, &pullReqAssignee{
+		PullReqID:   assignee.PullReqID,
+		PrincipalID: assignee.PrincipalID,
+		CreatedBy:   assignee.CreatedBy,
+		Created:     assignee.Created,
+	})
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to bind pull request assignee object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted rows")
+	}
+
+	return count > 0, nil
+}
+
+// Delete removes the assignee. It returns false if the principal isn't assigned to the pull request.
+func (s *PullReqAssigneeStore) Delete(ctx context.Context, prID, principalID int64) (bool, error) {
+	const sqlQuery = `
+	DELETE FROM pullreq_assignees
+	WHERE pullreq_assignee_pullreq_id = $1 AND
+	      pullreq_assignee_principal_id = $2`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sqlQuery, prID, principalID)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// List returns all assignees of the pull request.
+func (s *PullReqAssigneeStore) List(ctx context.Context, prID int64) ([]*types.PullReqAssignee, error) {
+	stmt := database.Builder.
+		Select(pullReqAssigneeColumns).
+		From("pullreq_assignees").
+		Where("pullreq_assignee_pullreq_id = ?", prID).
+		OrderBy("pullreq_assignee_created asc").
+		Limit(maxPullRequestAssignees) // memory safety limit
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := make([]*pullReqAssignee, 0)
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request assignee list query")
+	}
+
+	ids := make([]int64, 0, 2*len(dst))
+	for _, v := range dst {
+		ids = append(ids, v.CreatedBy, v.PrincipalID)
+	}
+
+	infoMap, err := s.pCache.Map(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PR principal infos: %w", err)
+	}
+
+	result := make([]*types.PullReqAssignee, len(dst))
+	for i, v := range dst {
+		result[i] = &types.PullReqAssignee{
+			PullReqID:   v.PullReqID,
+			PrincipalID: v.PrincipalID,
+			CreatedBy:   v.CreatedBy,
+			Created:     v.Created,
+		}
+		if addedBy, ok := infoMap[v.CreatedBy]; ok {
+			result[i].AddedBy = *addedBy
+		}
+		if assignee, ok := infoMap[v.PrincipalID]; ok {
+			result[i].Assignee = *assignee
+		}
+	}
+
+	return result, nil
+}
+
+// MapPrincipalIDs returns principal IDs of the assignees of the pull requests, mapped by pull request ID.
+func (s *PullReqAssigneeStore) MapPrincipalIDs(ctx context.Context, prIDs []int64) (map[int64][]int64, error) {
+	if len(prIDs) == 0 {
+		return map[int64][]int64{}, nil
+	}
+
+	stmt := database.Builder.
+		Select("pullreq_assignee_pullreq_id, pullreq_assignee_principal_id").
+		From("pullreq_assignees").
+		Where(squirrel.Eq{"pullreq_assignee_pullreq_id": prIDs}).
+		OrderBy("pullreq_assignee_created asc")
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqAssignee
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed executing pull request assignee map query")
+	}
+
+	result := make(map[int64][]int64, len(prIDs))
+	for _, v := range dst {
+		result[v.PullReqID] = append(result[v.PullReqID], v.PrincipalID)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,212 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.PullReqReactionStore = (*PullReqReactionStore)(nil)
+
+// NewPullReqReactionStore returns a new PullReqReactionStore.
+func NewPullReqReactionStore(db *sqlx.DB) *PullReqReactionStore {
+	return &PullReqReactionStore{
+		db: db,
+	}
+}
+
+// PullReqReactionStore implements store.PullReqReactionStore backed by a relational database.
+type PullReqReactionStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReaction struct {
+	ID          int64                `db:"pullreq_reaction_id"`
+	PullReqID   int64                `db:"pullreq_reaction_pullreq_id"`
+	ActivityID  *int64               `db:"pullreq_reaction_activity_id"`
+	PrincipalID int64                `db:"pullreq_reaction_principal_id"`
+	Reaction    enum.PullReqReaction `db:"pullreq_reaction_reaction"`
+	Created     int64                `db:"pullreq_reaction_created"`
+}
+
+const (
+	pullReqReactionColumns = `
+		 pullreq_reaction_id
+		,pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_principal_id
+		,pullreq_reaction_reaction
+		,pullreq_reaction_created`
+)
+
+// Create adds the reaction. It returns false if the principal has already reacted with the same reaction.
+func (s *PullReqReactionStore) Create(ctx context.Context, reaction *types.PullReqReaction) (bool, error) {
+	const sqlQuery = `
+	INSERT INTO pullreq_reactions (
+		 pullreq_reaction_pullreq_id
+		,pullreq_reaction_activity_id
+		,pullreq_reaction_principal_id
+		,pullreq_reaction_reaction
+		,pullreq_reaction_created
+	) VALUES (
+		 :pullreq_reaction_pullreq_id
+		,:pullreq_reaction_activity_id
+		,:pullreq_reaction_principal_id
+		,:pullreq_reaction_reaction
+		,:pullreq_reaction_created
+	)
+	ON CONFLICT DO NOTHING`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	query, arg, err := db.BindNamed(sqlQuery, mapToInternalPullReqReaction(reaction))
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to bind pullreq reaction object")
+	}
+
+	result, err := db.ExecContext(ctx, query, arg...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted rows")
+	}
+
+	return count > 0, nil
+}
+
+// Delete removes the reaction. It returns false if the reaction doesn't exist.
+func (s *PullReqReactionStore) Delete(ctx context.Context, reaction *types.PullReqReaction) (bool, error) {
+	stmt := database.Builder.
+		Delete("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", reaction.PullReqID).
+		Where("pullreq_reaction_principal_id = ?", reaction.PrincipalID).
+		Where("pullreq_reaction_reaction = ?", reaction.Reaction)
+
+	if reaction.ActivityID == nil {
+		stmt = stmt.Where("pullreq_reaction_activity_id IS NULL")
+	} else {
+		stmt = stmt.Where("pullreq_reaction_activity_id = ?", *reaction.ActivityID)
+	}
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Delete query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of deleted rows")
+	}
+
+	return count > 0, nil
+}
+
+// List lists all reactions to the pull request description and to the pull request comments.
+func (s *PullReqReactionStore) List(ctx context.Context, prID int64) ([]*types.PullReqReaction, error) {
+	stmt := database.Builder.
+		Select(pullReqReactionColumns).
+		From("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", prID).
+		OrderBy("pullreq_reaction_id")
+
+	return s.list(ctx, stmt)
+}
+
+// ListForTarget lists the reactions to the pull request description (if activityID is nil) or to a comment.
+func (s *PullReqReactionStore) ListForTarget(
+	ctx context.Context,
+	prID int64,
+	activityID *int64,
+) ([]*types.PullReqReaction, error) {
+	stmt := database.Builder.
+		Select(pullReqReactionColumns).
+		From("pullreq_reactions").
+		Where("pullreq_reaction_pullreq_id = ?", prID).
+		OrderBy("pullreq_reaction_id")
+
+	if activityID == nil {
+		stmt = stmt.Where("pullreq_reaction_activity_id IS NULL")
+	} else {
+		stmt = stmt.Where("pullreq_reaction_activity_id = ?", *activityID)
+	}
+
+	return s.list(ctx, stmt)
+}
+
+func (s *PullReqReactionStore) list(
+	ctx context.Context,
+	stmt squirrel.SelectBuilder,
+) ([]*types.PullReqReaction, error) {
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []*pullReqReaction
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to execute list query")
+	}
+
+	result := make([]*types.PullReqReaction, len(dst))
+	for i, r := range dst {
+		result[i] = mapToPullReqReaction(r)
+	}
+
+	return result, nil
+}
+
+func mapToInternalPullReqReaction(r *types.PullReqReaction) *pullReqReaction {
+	return &pullReqReaction{
+		ID:          r.ID,
+		PullReqID:   r.PullReqID,
+		ActivityID:  r.ActivityID,
+		PrincipalID: r.PrincipalID,
+		Reaction:    r.Reaction,
+		Created:     r.Created,
+	}
+}
+
+func mapToPullReqReaction(r *pullReqReaction) *types.PullReqReaction {
+	return &types.PullReqReaction{
+		ID:          r.ID,
+		PullReqID:   r.PullReqID,
+		ActivityID:  r.ActivityID,
+		PrincipalID: r.PrincipalID,
+		Reaction:    r.Reaction,
+		Created:     r.Created,
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database
+
+import (
+	"context"
+	"sort"
+
+	"github.com/harness/gitness/app/store"
+	"github.com/harness/gitness/store/database"
+	"github.com/harness/gitness/store/database/dbtx"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/jmoiron/sqlx"
+	"github.com/pkg/errors"
+)
+
+var _ store.ReviewAnalyticsStore = (*ReviewAnalyticsStore)(nil)
+
+// NewReviewAnalyticsStore returns a new ReviewAnalyticsStore.
+func NewReviewAnalyticsStore(db *sqlx.DB) *ReviewAnalyticsStore {
+	return &ReviewAnalyticsStore{
+		db: db,
+	}
+}
+
+// ReviewAnalyticsStore implements store.ReviewAnalyticsStore backed by a relational database.
+type ReviewAnalyticsStore struct {
+	db *sqlx.DB
+}
+
+type pullReqReviewStats struct {
+	PullReqID     int64    `db:"pullreq_id"`
+	Created       int64    `db:"pullreq_created"`
+	Merged        null.Int `db:"pullreq_merged"`
+	FirstReview   null.Int `db:"first_review"`
+	FirstApproval null.Int `db:"first_approval"`
+	ReviewRounds  int64    `db:"review_rounds"`
+	Additions     null.Int `db:"pullreq_additions"`
+	Deletions     null.Int `db:"pullreq_deletions"`
+}
+
+// Reviews submitted by the pull request author are not counted.
+const pullReqReviewStatsColumns = `
+	 pullreq_id
+	,pullreq_created
+	,pullreq_merged
+	,(SELECT MIN(pullreq_review_created) FROM pullreq_reviews
+		WHERE pullreq_review_pullreq_id = pullreq_id
+		AND pullreq_review_created_by <> pullreq_created_by) AS first_review
+	,(SELECT MIN(pullreq_review_created) FROM pullreq_reviews
+		WHERE pullreq_review_pullreq_id = pullreq_id
+		AND pullreq_review_created_by <> pullreq_created_by
+		AND pullreq_review_decision = 'approved') AS first_approval
+	,(SELECT COUNT(DISTINCT pullreq_review_sha) FROM pullreq_reviews
+		WHERE pullreq_review_pullreq_id = pullreq_id) AS review_rounds
+	,pullreq_additions
+	,pullreq_deletions`
+
+// ListPullReqStats returns review timestamps and size of all pull requests matching the filter.
+func (s *ReviewAnalyticsStore) ListPullReqStats(
+	ctx context.Context,
+	filter *types.ReviewAnalyticsFilter,
+) ([]types.PullReqReviewStats, error) {
+	stmt := database.Builder.
+		Select(pullReqReviewStatsColumns).
+		From("pullreqs")
+
+	stmt = applyReviewAnalyticsFilter(stmt, filter)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	var dst []pullReqReviewStats
+	if err = db.SelectContext(ctx, &dst, sql, args...); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pull request review stats")
+	}
+
+	result := make([]types.PullReqReviewStats, len(dst))
+	for i, stats := range dst {
+		result[i] = types.PullReqReviewStats{
+			PullReqID:     stats.PullReqID,
+			Created:       stats.Created,
+			Merged:        stats.Merged.Ptr(),
+			FirstReview:   stats.FirstReview.Ptr(),
+			FirstApproval: stats.FirstApproval.Ptr(),
+			ReviewRounds:  stats.ReviewRounds,
+			Additions:     stats.Additions.Ptr(),
+			Deletions:     stats.Deletions.Ptr(),
+		}
+	}
+
+	return result, nil
+}
+
+// ListReviewerStats returns submitted reviews and pending review requests, grouped by reviewer,
+// of all pull requests matching the filter. The result is sorted by the number of reviews, descending.
+func (s *ReviewAnalyticsStore) ListReviewerStats(
+	ctx context.Context,
+	filter *types.ReviewAnalyticsFilter,
+) ([]types.ReviewerStats, error) {
+	// subqueries use question placeholders, the outer statement converts them
+	pullReqIDs := applyReviewAnalyticsFilter(squirrel.Select("pullreq_id").From("pullreqs"), filter)
+
+	reviewsStmt := database.Builder.
+		Select(`
+			 pullreq_review_created_by
+			,COUNT(*)
+			,SUM(CASE WHEN pullreq_review_decision = 'approved' THEN 1 ELSE 0 END)
+			,SUM(CASE WHEN pullreq_review_decision = 'changereq' THEN 1 ELSE 0 END)`).
+		From("pullreq_reviews").
+		Where(squirrel.Expr("pullreq_review_pullreq_id IN (?)", pullReqIDs)).
+		GroupBy("pullreq_review_created_by")
+
+	pendingStmt := database.Builder.
+		Select("pullreq_reviewer_principal_id, COUNT(*)").
+		From("pullreq_reviewers").
+		InnerJoin("pullreqs ON pullreq_id = pullreq_reviewer_pullreq_id").
+		Where("pullreq_state = ?", enum.PullReqStateOpen).
+		Where("pullreq_reviewer_review_decision = ?", enum.PullReqReviewDecisionPending).
+		Where(squirrel.Expr("pullreq_reviewer_pullreq_id IN (?)", pullReqIDs)).
+		GroupBy("pullreq_reviewer_principal_id")
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	statsMap := make(map[int64]*types.ReviewerStats)
+	getStats := func(principalID int64) *types.ReviewerStats {
+		stats, ok := statsMap[principalID]
+		if !ok {
+			stats = &types.ReviewerStats{PrincipalID: principalID}
+			statsMap[principalID] = stats
+		}
+		return stats
+	}
+
+	sql, args, err := reviewsStmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	rows, err := db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list reviewer stats")
+	}
+
+	for rows.Next() {
+		var principalID, reviews, approvals, changeRequests int64
+		if err = rows.Scan(&principalID, &reviews, &approvals, &changeRequests); err != nil {
+			_ = rows.Close()
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan reviewer stats")
+		}
+
+		stats := getStats(principalID)
+		stats.Reviews = reviews
+		stats.Approvals = approvals
+		stats.ChangeRequests = changeRequests
+	}
+	if err = rows.Close(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to close reviewer stats rows")
+	}
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list reviewer stats")
+	}
+
+	sql, args, err = pendingStmt.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to convert query to sql")
+	}
+
+	rows, err = db.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pending review requests")
+	}
+
+	for rows.Next() {
+		var principalID, pending int64
+		if err = rows.Scan(&principalID, &pending); err != nil {
+			_ = rows.Close()
+			return nil, database.ProcessSQLErrorf(ctx, err, "Failed to scan pending review requests")
+		}
+
+		getStats(principalID).PendingRequests = pending
+	}
+	if err = rows.Close(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to close pending review request rows")
+	}
+	if err = rows.Err(); err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Failed to list pending review requests")
+	}
+
+	result := make([]types.ReviewerStats, 0, len(statsMap))
+	for _, stats := range statsMap {
+		result = append(result, *stats)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Reviews != result[j].Reviews {
+			return result[i].Reviews > result[j].Reviews
+		}
+		if result[i].PendingRequests != result[j].PendingRequests {
+			return result[i].PendingRequests > result[j].PendingRequests
+		}
+		return result[i].PrincipalID < result[j].PrincipalID
+	})
+
+	return result, nil
+}
+
+func applyReviewAnalyticsFilter(
+	stmt squirrel.SelectBuilder,
+	filter *types.ReviewAnalyticsFilter,
+) squirrel.SelectBuilder {
+	if filter.RepoID > 0 {
+		stmt = stmt.Where("pullreq_target_repo_id = ?", filter.RepoID)
+	}
+
+	if len(filter.SpaceIDs) > 0 {
+		stmt = stmt.Where(squirrel.Expr("pullreq_target_repo_id IN (?)", squirrel.
+			Select("repo_id").
+			From("repositories").
+			Where(squirrel.Eq{"repo_parent_id": filter.SpaceIDs}).
+			Where("repo_deleted IS NULL")))
+	}
+
+	if filter.CreatedGt > 0 {
+		stmt = stmt.Where("pullreq_created > ?", filter.CreatedGt)
+	}
+
+	if filter.CreatedLt > 0 {
+		stmt = stmt.Where("pullreq_created < ?", filter.CreatedLt)
+	}
+
+	if len(filter.LabelID) == 0 && len(filter.ValueID) == 0 {
+		return stmt
+	}
+
+	// the pull request must have all the labels and values, same as in the pull request list
+	labels := squirrel.
+		Select("pullreq_label_pullreq_id").
+		From("pullreq_labels").
+		Where(squirrel.Or{
+			squirrel.Eq{"pullreq_label_label_id": filter.LabelID},
+			squirrel.Eq{"pullreq_label_label_value_id": filter.ValueID},
+		}).
+		GroupBy("pullreq_label_pullreq_id").
+		Having("COUNT(pullreq_label_pullreq_id) = ?", len(filter.LabelID)+len(filter.ValueID))
+
+	return stmt.Where(squirrel.Expr("pullreq_id IN (?)", labels))
+}
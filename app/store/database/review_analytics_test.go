@@ -0,0 +1,132 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/gotidy/ptr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewAnalyticsStore(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	for _, id := range []int64{2, 3} {
+		uid := "reviewer_" + strconv.FormatInt(id, 10)
+		require.NoError(t, principalStore.CreateUser(ctx, &types.User{ID: id, UID: uid, Email: uid + "@example.com"}))
+	}
+
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 2, 1)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+	createRepo(ctx, t, repoStore, 2, 2, 0)
+
+	pullReqStore := database.NewPullReqStore(db, nil)
+	reviewStore := database.NewPullReqReviewStore(db)
+	reviewerStore := database.NewPullReqReviewerStore(db, nil)
+	analyticsStore := database.NewReviewAnalyticsStore(db)
+
+	merged := int64(9000)
+	prs := []*types.PullReq{
+		{
+			ID: 1, Number: 1, CreatedBy: userID, Created: 1000, Updated: 1000,
+			State: enum.PullReqStateMerged, Merged: &merged,
+			SourceRepoID: ptr.Int64(1), TargetRepoID: 1, SourceBranch: "a", TargetBranch: "main",
+			Stats: types.PullReqStats{DiffStats: types.NewDiffStats(1, 1, 4, 6)},
+		},
+		{
+			ID: 2, Number: 1, CreatedBy: userID, Created: 2000, Updated: 2000,
+			State:        enum.PullReqStateOpen,
+			SourceRepoID: ptr.Int64(2), TargetRepoID: 2, SourceBranch: "b", TargetBranch: "main",
+		},
+	}
+	for _, pr := range prs {
+		require.NoError(t, pullReqStore.Create(ctx, pr))
+	}
+
+	reviews := []*types.PullReqReview{
+		// the author's own review is not counted as the first review
+		{CreatedBy: userID, Created: 1500, PullReqID: 1, Decision: enum.PullReqReviewDecisionReviewed, SHA: "sha1"},
+		{CreatedBy: 2, Created: 2000, PullReqID: 1, Decision: enum.PullReqReviewDecisionChangeReq, SHA: "sha1"},
+		{CreatedBy: 2, Created: 5000, PullReqID: 1, Decision: enum.PullReqReviewDecisionApproved, SHA: "sha2"},
+		{CreatedBy: 3, Created: 6000, PullReqID: 1, Decision: enum.PullReqReviewDecisionApproved, SHA: "sha2"},
+	}
+	for _, review := range reviews {
+		review.Updated = review.Created
+		require.NoError(t, reviewStore.Create(ctx, review))
+	}
+
+	require.NoError(t, reviewerStore.Create(ctx, &types.PullReqReviewer{
+		PullReqID: 2, PrincipalID: 3, CreatedBy: userID, Created: 2000, Updated: 2000, RepoID: 2,
+		Type: enum.PullReqReviewerTypeRequested, ReviewDecision: enum.PullReqReviewDecisionPending,
+	}))
+
+	t.Run("pull request stats", func(t *testing.T) {
+		stats, err := analyticsStore.ListPullReqStats(ctx, &types.ReviewAnalyticsFilter{SpaceIDs: []int64{1, 2}})
+		require.NoError(t, err)
+		require.Len(t, stats, 2)
+
+		assert.Equal(t, types.PullReqReviewStats{
+			PullReqID:     1,
+			Created:       1000,
+			Merged:        &merged,
+			FirstReview:   ptr.Int64(2000),
+			FirstApproval: ptr.Int64(5000),
+			ReviewRounds:  2,
+			Additions:     ptr.Int64(4),
+			Deletions:     ptr.Int64(6),
+		}, stats[0])
+		assert.Equal(t, types.PullReqReviewStats{PullReqID: 2, Created: 2000}, stats[1])
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		stats, err := analyticsStore.ListPullReqStats(ctx, &types.ReviewAnalyticsFilter{RepoID: 2})
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, int64(2), stats[0].PullReqID)
+
+		stats, err = analyticsStore.ListPullReqStats(ctx, &types.ReviewAnalyticsFilter{
+			CreatedFilter: types.CreatedFilter{CreatedLt: 1500},
+			SpaceIDs:      []int64{1, 2},
+		})
+		require.NoError(t, err)
+		require.Len(t, stats, 1)
+		assert.Equal(t, int64(1), stats[0].PullReqID)
+	})
+
+	t.Run("reviewer stats", func(t *testing.T) {
+		stats, err := analyticsStore.ListReviewerStats(ctx, &types.ReviewAnalyticsFilter{SpaceIDs: []int64{1, 2}})
+		require.NoError(t, err)
+		assert.Equal(t, []types.ReviewerStats{
+			{PrincipalID: 2, Reviews: 2, Approvals: 1, ChangeRequests: 1},
+			{PrincipalID: 3, Reviews: 1, Approvals: 1, PendingRequests: 1},
+			{PrincipalID: userID, Reviews: 1},
+		}, stats)
+	})
+}
@@ -156,29 +156,9 @@ func (s *SettingsStore) Upsert(ctx context.Context,
 	key string,
 	value json.RawMessage,
 ) error {
-	stmt := database.Builder.
-		Insert("").
-		Into("settings").
-		Columns(
-			"setting_space_id",
-			"setting_repo_id",
-			"setting_key",
-			"setting_value",
-		)
-
-	switch scope {
-	case enum.SettingsScopeSpace:
-		stmt = stmt.Values(null.IntFrom(scopeID), null.Int{}, key, value)
-		stmt = stmt.Suffix(`ON CONFLICT (setting_space_id, LOWER(setting_key)) WHERE setting_space_id IS NOT NULL DO`)
-	case enum.SettingsScopeRepo:
-		stmt = stmt.Values(null.Int{}, null.IntFrom(scopeID), key, value)
-		stmt = stmt.Suffix(`ON CONFLICT (setting_repo_id, LOWER(setting_key)) WHERE setting_repo_id IS NOT NULL DO`)
-	case enum.SettingsScopeSystem:
-		stmt = stmt.Values(null.Int{}, null.Int{}, key, value)
-		stmt = stmt.Suffix(`ON CONFLICT (LOWER(setting_key)) 
-			WHERE setting_repo_id IS NULL AND setting_space_id IS NULL DO`)
-	default:
-		return fmt.Errorf("setting scope %q is not supported", scope)
+	stmt, err := insertSettingStmt(scope, scopeID, key, value)
+	if err != nil {
+		return err
 	}
 
 	stmt = stmt.Suffix(`
@@ -205,3 +185,72 @@ func (s *SettingsStore) Upsert(ctx context.Context,
 
 	return nil
 }
+
+func (s *SettingsStore) InsertIfAbsent(ctx context.Context,
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+	value json.RawMessage,
+) (bool, error) {
+	stmt, err := insertSettingStmt(scope, scopeID, key, value)
+	if err != nil {
+		return false, err
+	}
+
+	stmt = stmt.Suffix(`NOTHING`)
+
+	sql, args, err := stmt.ToSql()
+	if err != nil {
+		return false, fmt.Errorf("failed to convert query to sql: %w", err)
+	}
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	result, err := db.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Insert query failed")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, database.ProcessSQLErrorf(ctx, err, "Failed to get number of inserted rows")
+	}
+
+	return count > 0, nil
+}
+
+// insertSettingStmt returns the insert statement of the setting, ending with the "ON CONFLICT ... DO" clause
+// for the scope. The caller has to complete the conflict action.
+func insertSettingStmt(
+	scope enum.SettingsScope,
+	scopeID int64,
+	key string,
+	value json.RawMessage,
+) (squirrel.InsertBuilder, error) {
+	stmt := database.Builder.
+		Insert("").
+		Into("settings").
+		Columns(
+			"setting_space_id",
+			"setting_repo_id",
+			"setting_key",
+			"setting_value",
+		)
+
+	switch scope {
+	case enum.SettingsScopeSpace:
+		stmt = stmt.Values(null.IntFrom(scopeID), null.Int{}, key, value)
+		stmt = stmt.Suffix(`ON CONFLICT (setting_space_id, LOWER(setting_key)) WHERE setting_space_id IS NOT NULL DO`)
+	case enum.SettingsScopeRepo:
+		stmt = stmt.Values(null.Int{}, null.IntFrom(scopeID), key, value)
+		stmt = stmt.Suffix(`ON CONFLICT (setting_repo_id, LOWER(setting_key)) WHERE setting_repo_id IS NOT NULL DO`)
+	case enum.SettingsScopeSystem:
+		stmt = stmt.Values(null.Int{}, null.Int{}, key, value)
+		stmt = stmt.Suffix(`ON CONFLICT (LOWER(setting_key)) 
+			WHERE setting_repo_id IS NULL AND setting_space_id IS NULL DO`)
+	default:
+		return stmt, fmt.Errorf("setting scope %q is not supported", scope)
+	}
+
+	return stmt, nil
+}
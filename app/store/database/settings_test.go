@@ -0,0 +1,53 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsStore_InsertIfAbsent(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	settingsStore := database.NewSettingsStore(db)
+	ctx := context.Background()
+
+	inserted, err := settingsStore.InsertIfAbsent(ctx, enum.SettingsScopeSystem, 0, "key", json.RawMessage(`"first"`))
+	require.NoError(t, err)
+	assert.True(t, inserted)
+
+	inserted, err = settingsStore.InsertIfAbsent(ctx, enum.SettingsScopeSystem, 0, "KEY", json.RawMessage(`"second"`))
+	require.NoError(t, err)
+	assert.False(t, inserted, "an existing setting must not be overwritten")
+
+	value, err := settingsStore.Find(ctx, enum.SettingsScopeSystem, 0, "key")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"first"`, string(value))
+
+	require.NoError(t, settingsStore.Upsert(ctx, enum.SettingsScopeSystem, 0, "key", json.RawMessage(`"third"`)))
+
+	value, err = settingsStore.Find(ctx, enum.SettingsScopeSystem, 0, "key")
+	require.NoError(t, err)
+	assert.JSONEq(t, `"third"`, string(value))
+}
@@ -69,7 +69,10 @@ type webhook struct {
 	Enabled               bool        `db:"webhook_enabled"`
 	Insecure              bool        `db:"webhook_insecure"`
 	Triggers              string      `db:"webhook_triggers"`
+	Format                string      `db:"webhook_format"`
+	SignatureMode         string      `db:"webhook_signature_mode"`
 	LatestExecutionResult null.String `db:"webhook_latest_execution_result"`
+	ConsecutiveFailures   int64       `db:"webhook_consecutive_failures"`
 	ExtraHeaders          null.String `db:"webhook_extra_headers"`
 }
 
@@ -90,7 +93,10 @@ const (
 		,webhook_enabled
 		,webhook_insecure
 		,webhook_triggers
+		,webhook_format
+		,webhook_signature_mode
 		,webhook_latest_execution_result
+		,webhook_consecutive_failures
 		,webhook_type
 		,webhook_scope
 		,webhook_extra_headers`
@@ -179,7 +185,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled
 			,webhook_insecure
 			,webhook_triggers
+			,webhook_format
+			,webhook_signature_mode
 			,webhook_latest_execution_result
+			,webhook_consecutive_failures
 			,webhook_type
 			,webhook_scope
 			,webhook_extra_headers
@@ -197,7 +206,10 @@ func (s *WebhookStore) Create(ctx context.Context, hook *types.Webhook) error {
 			,:webhook_enabled
 			,:webhook_insecure
 			,:webhook_triggers
+			,:webhook_format
+			,:webhook_signature_mode
 			,:webhook_latest_execution_result
+			,:webhook_consecutive_failures
 			,:webhook_type
 			,:webhook_scope
 			,:webhook_extra_headers
@@ -237,7 +249,10 @@ func (s *WebhookStore) Update(ctx context.Context, hook *types.Webhook) error {
 			,webhook_enabled = :webhook_enabled
 			,webhook_insecure = :webhook_insecure
 			,webhook_triggers = :webhook_triggers
+			,webhook_format = :webhook_format
+			,webhook_signature_mode = :webhook_signature_mode
 			,webhook_latest_execution_result = :webhook_latest_execution_result
+			,webhook_consecutive_failures = :webhook_consecutive_failures
 			,webhook_extra_headers = :webhook_extra_headers
 		WHERE webhook_id = :webhook_id and webhook_version = :webhook_version - 1`
 
@@ -495,7 +510,10 @@ func mapToWebhook(hook *webhook) (*types.Webhook, error) {
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersFromString(hook.Triggers),
+		Format:                enum.WebhookFormat(hook.Format),
+		SignatureMode:         enum.WebhookSignatureMode(hook.SignatureMode),
 		LatestExecutionResult: (*enum.WebhookExecutionResult)(hook.LatestExecutionResult.Ptr()),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
 		Type:                  hook.Type,
 		ExtraHeaders:          extraHeadersFromString(hook.ExtraHeaders.String),
 	}
@@ -533,7 +551,10 @@ func mapToInternalWebhook(hook *types.Webhook) (*webhook, error) {
 		Enabled:               hook.Enabled,
 		Insecure:              hook.Insecure,
 		Triggers:              triggersToString(hook.Triggers),
+		Format:                string(hook.Format),
+		SignatureMode:         string(hook.SignatureMode),
 		LatestExecutionResult: null.StringFromPtr((*string)(hook.LatestExecutionResult)),
+		ConsecutiveFailures:   hook.ConsecutiveFailures,
 		Type:                  hook.Type,
 		ExtraHeaders:          extraHeadersToNullString(hook.ExtraHeaders),
 	}
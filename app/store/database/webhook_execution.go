@@ -213,6 +213,37 @@ func (s *WebhookExecutionStore) ListForWebhook(ctx context.Context, webhookID in
 	return mapToWebhookExecutions(dst), nil
 }
 
+// ListLatestFailedForWebhook lists the latest execution of every trigger of a webhook since the provided time,
+// for which the latest execution didn't succeed and can be retriggered.
+func (s *WebhookExecutionStore) ListLatestFailedForWebhook(
+	ctx context.Context,
+	webhookID int64,
+	since int64,
+	limit int,
+) ([]*types.WebhookExecution, error) {
+	const sqlQuery = webhookExecutionSelectBase + `
+	WHERE webhook_execution_id IN (
+		SELECT MAX(webhook_execution_id)
+		FROM webhook_executions
+		WHERE webhook_execution_webhook_id = $1 AND webhook_execution_created >= $2
+		GROUP BY webhook_execution_trigger_id
+	)
+	AND webhook_execution_result <> $3
+	AND webhook_execution_retriggerable
+	ORDER BY webhook_execution_id
+	LIMIT $4`
+
+	db := dbtx.GetAccessor(ctx, s.db)
+
+	dst := []*webhookExecution{}
+	err := db.SelectContext(ctx, &dst, sqlQuery, webhookID, since, enum.WebhookExecutionResultSuccess, limit)
+	if err != nil {
+		return nil, database.ProcessSQLErrorf(ctx, err, "Select query failed")
+	}
+
+	return mapToWebhookExecutions(dst), nil
+}
+
 // CountForWebhook counts the total number of webhook executions for a given webhook ID.
 func (s *WebhookExecutionStore) CountForWebhook(
 	ctx context.Context,
@@ -0,0 +1,102 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package database_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/app/store/database"
+	"github.com/harness/gitness/types"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWebhookExecutionStore_ListLatestFailedForWebhook(t *testing.T) {
+	db, teardown := setupDB(t)
+	defer teardown()
+
+	principalStore, spaceStore, spacePathStore, repoStore := setupStores(t, db)
+
+	ctx := context.Background()
+
+	createUser(ctx, t, principalStore)
+	createSpace(ctx, t, spaceStore, spacePathStore, userID, 1, 0)
+	createRepo(ctx, t, repoStore, 1, 1, 0)
+
+	webhookStore := database.NewWebhookStore(db)
+	executionStore := database.NewWebhookExecutionStore(db)
+
+	hook := &types.Webhook{
+		ParentID:   1,
+		ParentType: enum.WebhookParentRepo,
+		CreatedBy:  userID,
+		Identifier: "hook",
+		URL:        "https://example.com",
+		Enabled:    true,
+		Type:       enum.WebhookTypeExternal,
+		Format:     enum.WebhookFormatNative,
+	}
+	require.NoError(t, webhookStore.Create(ctx, hook))
+
+	executions := []struct {
+		triggerID     string
+		created       int64
+		result        enum.WebhookExecutionResult
+		retriggerable bool
+	}{
+		// redelivered successfully
+		{"a", 1000, enum.WebhookExecutionResultRetriableError, true},
+		{"a", 2000, enum.WebhookExecutionResultSuccess, true},
+		// failed
+		{"b", 1500, enum.WebhookExecutionResultFatalError, true},
+		// failed before the provided time
+		{"c", 500, enum.WebhookExecutionResultRetriableError, true},
+		// failed without a request body
+		{"d", 1200, enum.WebhookExecutionResultFatalError, false},
+		// failed multiple times, only the latest execution is returned
+		{"e", 1100, enum.WebhookExecutionResultFatalError, true},
+		{"e", 1300, enum.WebhookExecutionResultRetriableError, true},
+	}
+
+	ids := map[string]int64{}
+	for _, e := range executions {
+		execution := &types.WebhookExecution{
+			WebhookID:     hook.ID,
+			TriggerType:   enum.WebhookTriggerBranchCreated,
+			TriggerID:     e.triggerID,
+			Result:        e.result,
+			Created:       e.created,
+			Retriggerable: e.retriggerable,
+		}
+		require.NoError(t, executionStore.Create(ctx, execution))
+		ids[e.triggerID] = execution.ID
+	}
+
+	list, err := executionStore.ListLatestFailedForWebhook(ctx, hook.ID, 1000, 10)
+	require.NoError(t, err)
+
+	got := make([]int64, len(list))
+	for i, execution := range list {
+		got[i] = execution.ID
+	}
+	assert.Equal(t, []int64{ids["b"], ids["e"]}, got)
+
+	list, err = executionStore.ListLatestFailedForWebhook(ctx, hook.ID, 1000, 1)
+	require.NoError(t, err)
+	assert.Len(t, list, 1)
+}
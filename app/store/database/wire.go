@@ -55,6 +55,17 @@ var WireSet = wire.NewSet(
 	ProvidePullReqReviewStore,
 	ProvidePullReqReviewerStore,
 	ProvidePullReqFileViewStore,
+	ProvidePullReqReactionStore,
+	ProvidePullReqAssigneeStore,
+	ProvideMilestoneStore,
+	ProvideAutomationStore,
+	ProvideNotificationStore,
+	ProvideNotificationChannelStore,
+	ProvideNotificationDigestStore,
+	ProvideNotificationPreferenceStore,
+	ProvideNotificationSubscriptionStore,
+	ProvideNotificationTemplateStore,
+	ProvideReviewAnalyticsStore,
 	ProvideAutoMergeStore,
 	ProvideWebhookStore,
 	ProvideWebhookExecutionStore,
@@ -323,6 +334,64 @@ func ProvidePullReqFileViewStore(db *sqlx.DB) store.PullReqFileViewStore {
 	return NewPullReqFileViewStore(db)
 }
 
+// ProvidePullReqReactionStore provides a pull request reaction store.
+func ProvidePullReqReactionStore(db *sqlx.DB) store.PullReqReactionStore {
+	return NewPullReqReactionStore(db)
+}
+
+// ProvidePullReqAssigneeStore provides a pull request assignee store.
+func ProvidePullReqAssigneeStore(
+	db *sqlx.DB,
+	principalInfoCache store.PrincipalInfoCache,
+) store.PullReqAssigneeStore {
+	return NewPullReqAssigneeStore(db, principalInfoCache)
+}
+
+// ProvideNotificationStore provides a notification store.
+func ProvideNotificationStore(db *sqlx.DB) store.NotificationStore {
+	return NewNotificationStore(db)
+}
+
+// ProvideNotificationChannelStore provides a notification channel store.
+func ProvideNotificationChannelStore(db *sqlx.DB) store.NotificationChannelStore {
+	return NewNotificationChannelStore(db)
+}
+
+// ProvideNotificationDigestStore provides a notification digest store.
+func ProvideNotificationDigestStore(db *sqlx.DB) store.NotificationDigestStore {
+	return NewNotificationDigestStore(db)
+}
+
+// ProvideNotificationTemplateStore provides a notification template store.
+func ProvideNotificationTemplateStore(db *sqlx.DB) store.NotificationTemplateStore {
+	return NewNotificationTemplateStore(db)
+}
+
+// ProvideNotificationPreferenceStore provides a notification preference store.
+func ProvideNotificationPreferenceStore(db *sqlx.DB) store.NotificationPreferenceStore {
+	return NewNotificationPreferenceStore(db)
+}
+
+// ProvideNotificationSubscriptionStore provides a notification subscription store.
+func ProvideNotificationSubscriptionStore(db *sqlx.DB) store.NotificationSubscriptionStore {
+	return NewNotificationSubscriptionStore(db)
+}
+
+// ProvideAutomationStore provides an automation store.
+func ProvideAutomationStore(db *sqlx.DB) store.AutomationStore {
+	return NewAutomationStore(db)
+}
+
+// ProvideReviewAnalyticsStore provides a review analytics store.
+func ProvideReviewAnalyticsStore(db *sqlx.DB) store.ReviewAnalyticsStore {
+	return NewReviewAnalyticsStore(db)
+}
+
+// ProvideMilestoneStore provides a milestone store.
+func ProvideMilestoneStore(db *sqlx.DB) store.MilestoneStore {
+	return NewMilestoneStore(db)
+}
+
 func ProvideAutoMergeStore(db *sqlx.DB) store.AutoMergeStore {
 	return NewAutoMergeStore(db)
 }
@@ -61,6 +61,9 @@ type Provider interface {
 	// GenerateUIRepoURL returns the url for the UI screen of a repository.
 	GenerateUIRepoURL(ctx context.Context, repoPath string) string
 
+	// GenerateUISpaceURL returns the url for the UI screen of a space.
+	GenerateUISpaceURL(ctx context.Context, spacePath string) string
+
 	// GenerateUIPRURL returns the url for the UI screen of an existing pr.
 	GenerateUIPRURL(ctx context.Context, repoPath string, prID int64) string
 
@@ -229,6 +232,10 @@ func (p *provider) GenerateUIRepoURL(_ context.Context, repoPath string) string
 	return p.uiURL.JoinPath(repoPath).String()
 }
 
+func (p *provider) GenerateUISpaceURL(_ context.Context, spacePath string) string {
+	return p.uiURL.JoinPath("spaces", spacePath).String()
+}
+
 func (p *provider) GenerateUIPRURL(_ context.Context, repoPath string, prID int64) string {
 	return p.uiURL.JoinPath(repoPath, "pulls", fmt.Sprint(prID)).String()
 }
@@ -419,6 +419,31 @@ func TestProvider_GenerateUIRepoURL(t *testing.T) {
 	}
 }
 
+func TestProvider_GenerateUISpaceURL(t *testing.T) {
+	p, err := NewProvider(
+		"http://internal.example.com",
+		"http://container.example.com",
+		"http://api.example.com",
+		"http://git.example.com",
+		"ssh://git.example.com:22",
+		"git",
+		false,
+		"http://ui.example.com",
+		"http://registry.example.com",
+	)
+	if err != nil {
+		t.Fatalf("NewProvider() error = %v", err)
+	}
+
+	ctx := context.Background()
+	got := p.GenerateUISpaceURL(ctx, "org/space")
+	want := "http://ui.example.com/spaces/org/space"
+
+	if got != want {
+		t.Errorf("GenerateUISpaceURL() = %v, want %v", got, want)
+	}
+}
+
 func TestProvider_GenerateUIPRURL(t *testing.T) {
 	p, err := NewProvider(
 		"http://internal.example.com",
@@ -334,6 +334,11 @@ func ProvideWebhookConfig(config *types.Config) webhook.Config {
 		AllowPrivateNetwork: config.Webhook.AllowPrivateNetwork,
 		AllowLoopback:       config.Webhook.AllowLoopback,
 		InternalSecret:      config.Webhook.InternalSecret,
+
+		RetryMaxAttempts:     config.Webhook.RetryMaxAttempts,
+		RetryBaseDelay:       config.Webhook.RetryBaseDelay,
+		RetryMaxDelay:        config.Webhook.RetryMaxDelay,
+		AutoDisableThreshold: config.Webhook.AutoDisableThreshold,
 	}
 }
 
@@ -342,6 +347,11 @@ func ProvideNotificationConfig(config *types.Config) notification.Config {
 		EventReaderName: config.InstanceID,
 		Concurrency:     config.Notification.Concurrency,
 		MaxRetries:      config.Notification.MaxRetries,
+
+		DigestDailyCron:  config.Notification.DigestDailyCron,
+		DigestWeeklyCron: config.Notification.DigestWeeklyCron,
+
+		TemplatesDir: config.Notification.TemplatesDir,
 	}
 }
 
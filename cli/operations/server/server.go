@@ -105,6 +105,11 @@ func (c *command) run(*kingpin.ParseContext) error {
 			return err
 		}
 
+		if err := system.services.Notification.Register(gCtx); err != nil {
+			log.Error().Err(err).Msg("failed to register notification digest jobs")
+			return err
+		}
+
 		return system.services.JobScheduler.Run(gCtx)
 	})
 
@@ -141,6 +146,16 @@ func (c *command) run(*kingpin.ParseContext) error {
 		})
 	}
 
+	if config.EmailReply.Enabled {
+		g.Go(func() error {
+			// notification emails advertise the reply-to address, hence the server stops if replies can't be received.
+			if err := system.services.EmailReply.ListenAndServe(gCtx); err != nil {
+				return fmt.Errorf("email reply server failed: %w", err)
+			}
+			return nil
+		})
+	}
+
 	log.Info().
 		Str("host", config.HTTP.Host).
 		Int("port", config.HTTP.Port).
@@ -31,6 +31,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	controllerlogs "github.com/harness/gitness/app/api/controller/logs"
 	"github.com/harness/gitness/app/api/controller/migrate"
+	controllernotification "github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -65,6 +66,7 @@ import (
 	repoevents "github.com/harness/gitness/app/events/repo"
 	ruleevents "github.com/harness/gitness/app/events/rule"
 	userevents "github.com/harness/gitness/app/events/user"
+	webhookevents "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -88,11 +90,13 @@ import (
 	"github.com/harness/gitness/app/server"
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/dotrange"
+	"github.com/harness/gitness/app/services/emailreply"
 	"github.com/harness/gitness/app/services/exporter"
 	gitspacedeleteeventservice "github.com/harness/gitness/app/services/gitspacedeleteevent"
 	"github.com/harness/gitness/app/services/gitspaceevent"
@@ -108,6 +112,7 @@ import (
 	"github.com/harness/gitness/app/services/merge"
 	"github.com/harness/gitness/app/services/metric"
 	migrateservice "github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
@@ -117,9 +122,11 @@ import (
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	reposervice "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	secretservice "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/slashcommand"
 	spaceSvc "github.com/harness/gitness/app/services/space"
 	"github.com/harness/gitness/app/services/tokengenerator"
 	"github.com/harness/gitness/app/services/trigger"
@@ -196,7 +203,11 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		merge.WireSet,
 		controllerwebhook.WireSet,
 		controllerwebhook.ProvidePreprocessor,
+		controllernotification.WireSet,
 		svclabel.WireSet,
+		milestone.WireSet,
+		automation.WireSet,
+		reviewanalytics.WireSet,
 		serviceaccount.WireSet,
 		user.WireSet,
 		upload.WireSet,
@@ -218,6 +229,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		pullreqevents.WireSet,
 		repoevents.WireSet,
 		ruleevents.WireSet,
+		webhookevents.WireSet,
 		userevents.WireSet,
 		storage.WireSet,
 		api.WireSet,
@@ -246,6 +258,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		job.WireSet,
 		cliserver.ProvideCleanupConfig,
 		cleanup.WireSet,
+		emailreply.WireSet,
 		codecomments.WireSet,
 		protection.WireSet,
 		checkcontroller.WireSet,
@@ -285,6 +298,7 @@ func initSystem(ctx context.Context, config *types.Config) (*cliserver.System, e
 		rules.ProvideValidator,
 		controllerkeywordsearch.WireSet,
 		settings.WireSet,
+		slashcommand.WireSet,
 		usergroup.WireSet,
 		openapi.WireSet,
 		repo.ProvideRepoCheck,
@@ -20,6 +20,7 @@ import (
 	"github.com/harness/gitness/app/api/controller/limiter"
 	logs2 "github.com/harness/gitness/app/api/controller/logs"
 	migrate2 "github.com/harness/gitness/app/api/controller/migrate"
+	notification2 "github.com/harness/gitness/app/api/controller/notification"
 	"github.com/harness/gitness/app/api/controller/pipeline"
 	"github.com/harness/gitness/app/api/controller/plugin"
 	"github.com/harness/gitness/app/api/controller/principal"
@@ -42,18 +43,19 @@ import (
 	"github.com/harness/gitness/app/auth/authz"
 	"github.com/harness/gitness/app/bootstrap"
 	"github.com/harness/gitness/app/connector"
-	events13 "github.com/harness/gitness/app/events/aitask"
-	events12 "github.com/harness/gitness/app/events/check"
-	events11 "github.com/harness/gitness/app/events/git"
-	events5 "github.com/harness/gitness/app/events/gitspace"
-	events8 "github.com/harness/gitness/app/events/gitspacedelete"
-	events6 "github.com/harness/gitness/app/events/gitspaceinfra"
-	events7 "github.com/harness/gitness/app/events/gitspaceoperations"
-	events9 "github.com/harness/gitness/app/events/pipeline"
-	events10 "github.com/harness/gitness/app/events/pullreq"
+	events14 "github.com/harness/gitness/app/events/aitask"
+	events7 "github.com/harness/gitness/app/events/check"
+	events6 "github.com/harness/gitness/app/events/git"
+	events8 "github.com/harness/gitness/app/events/gitspace"
+	events11 "github.com/harness/gitness/app/events/gitspacedelete"
+	events9 "github.com/harness/gitness/app/events/gitspaceinfra"
+	events10 "github.com/harness/gitness/app/events/gitspaceoperations"
+	events12 "github.com/harness/gitness/app/events/pipeline"
+	events5 "github.com/harness/gitness/app/events/pullreq"
 	events3 "github.com/harness/gitness/app/events/repo"
 	events4 "github.com/harness/gitness/app/events/rule"
 	events2 "github.com/harness/gitness/app/events/user"
+	events13 "github.com/harness/gitness/app/events/webhook"
 	"github.com/harness/gitness/app/gitspace/infrastructure"
 	"github.com/harness/gitness/app/gitspace/logutil"
 	"github.com/harness/gitness/app/gitspace/orchestrator"
@@ -78,11 +80,13 @@ import (
 	"github.com/harness/gitness/app/services"
 	"github.com/harness/gitness/app/services/aitaskevent"
 	"github.com/harness/gitness/app/services/autolink"
+	"github.com/harness/gitness/app/services/automation"
 	"github.com/harness/gitness/app/services/branch"
 	"github.com/harness/gitness/app/services/cleanup"
 	"github.com/harness/gitness/app/services/codecomments"
 	"github.com/harness/gitness/app/services/codeowners"
 	"github.com/harness/gitness/app/services/dotrange"
+	"github.com/harness/gitness/app/services/emailreply"
 	"github.com/harness/gitness/app/services/exporter"
 	"github.com/harness/gitness/app/services/gitspace"
 	"github.com/harness/gitness/app/services/gitspacedeleteevent"
@@ -101,6 +105,7 @@ import (
 	"github.com/harness/gitness/app/services/merge"
 	"github.com/harness/gitness/app/services/metric"
 	"github.com/harness/gitness/app/services/migrate"
+	"github.com/harness/gitness/app/services/milestone"
 	"github.com/harness/gitness/app/services/notification"
 	"github.com/harness/gitness/app/services/notification/mailer"
 	"github.com/harness/gitness/app/services/protection"
@@ -110,9 +115,11 @@ import (
 	"github.com/harness/gitness/app/services/refcache"
 	"github.com/harness/gitness/app/services/remoteauth"
 	repo2 "github.com/harness/gitness/app/services/repo"
+	"github.com/harness/gitness/app/services/reviewanalytics"
 	"github.com/harness/gitness/app/services/rules"
 	secret3 "github.com/harness/gitness/app/services/secret"
 	"github.com/harness/gitness/app/services/settings"
+	"github.com/harness/gitness/app/services/slashcommand"
 	"github.com/harness/gitness/app/services/space"
 	"github.com/harness/gitness/app/services/tokengenerator"
 	trigger2 "github.com/harness/gitness/app/services/trigger"
@@ -366,7 +373,54 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	autolinkService := autolink.ProvideAutoLink(transactor, spaceStore, repoStore, autoLinkStore)
 	dotrangeService := dotrange.ProvideService(gitInterface, repoFinder, provider, authorizer)
 	repoLangStore := database.ProvideRepoLangStore(db)
-	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, linkedRepoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, jobRepositoryLink, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService, autolinkService, dotrangeService, connectorService, repoLangStore)
+	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
+	codeCommentView := database.ProvideCodeCommentView(db)
+	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
+	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
+	userGroupReviewerStore := database.ProvideUserGroupReviewerStore(db, principalInfoCache, userGroupStore)
+	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
+	pullReqReactionStore := database.ProvidePullReqReactionStore(db)
+	pullReqAssigneeStore := database.ProvidePullReqAssigneeStore(db, principalInfoCache)
+	autoMergeStore := database.ProvideAutoMergeStore(db)
+	reporter3, err := events5.ProvideReporter(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	migrator := codecomments.ProvideMigrator(gitInterface)
+	readerFactory, err := events6.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	eventsReaderFactory, err := events5.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter3, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer, settingsService)
+	if err != nil {
+		return nil, err
+	}
+	milestoneStore := database.ProvideMilestoneStore(db)
+	milestoneService := milestone.ProvideMilestone(spaceStore, milestoneStore)
+	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, pullReqStore, checkStore, repoFinder, labelService, protectionManager, pullReqAssigneeStore, principalInfoCache, milestoneService)
+	readerFactory2, err := events7.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	mergeService, err := merge.ProvideService(ctx, config, gitInterface, transactor, reporter3, readerFactory2, eventsReaderFactory, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, pullReqReviewerStore, principalInfoCache, principalStore, autoMergeStore, protectionManager, codeownersService, usergroupService, provider, streamer, pubSub, instrumentService, lockerLocker)
+	if err != nil {
+		return nil, err
+	}
+	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
+	branchStore := database.ProvideBranchStore(db)
+	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, pullReqReactionStore, pullReqAssigneeStore, membershipStore, checkStore, autoMergeStore, gitInterface, repoFinder, reporter3, migrator, pullreqService, listService, mergeService, protectionManager, streamer, dotrangeService, codeownersService, lockerLocker, settingsService, pullReq, labelService, milestoneService, instrumentService, usergroupService, branchStore, usergroupResolver)
+	automationStore := database.ProvideAutomationStore(db)
+	automationService, err := automation.ProvideService(ctx, config, eventsReaderFactory, reporter3, authorizer, repoFinder, spaceStore, automationStore, pullReqStore, pullReqActivityStore, pullReqReviewerStore, userGroupReviewerStore, userGroupStore, principalStore, labelService, mergeService, settingsService, streamer)
+	if err != nil {
+		return nil, err
+	}
+	reviewAnalyticsStore := database.ProvideReviewAnalyticsStore(db)
+	reviewanalyticsService := reviewanalytics.ProvideService(reviewAnalyticsStore, principalInfoCache)
+	repoController := repo.ProvideController(config, transactor, provider, authorizer, repoStore, linkedRepoStore, spaceStore, pipelineStore, principalStore, executionStore, ruleStore, checkStore, pullReqStore, settingsService, principalInfoCache, protectionManager, gitInterface, spaceFinder, repoFinder, jobRepository, jobReferenceSync, jobRepositoryLink, codeownersService, eventsReporter, indexer, resourceLimiter, lockerLocker, auditService, mutexManager, repoIdentifier, repoCheck, publicaccessService, labelService, instrumentService, userGroupStore, usergroupService, rulesService, streamer, lfsController, favoriteStore, signatureVerifyService, autolinkService, dotrangeService, connectorService, repoLangStore, pullreqController, milestoneService, mergeService, branchStore, automationService, reviewanalyticsService)
 	reposettingsController := reposettings.ProvideController(authorizer, repoFinder, settingsService, auditService)
 	stageStore := database.ProvideStageStore(db)
 	schedulerScheduler, err := scheduler.ProvideScheduler(stageStore, mutexManager)
@@ -388,7 +442,6 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	spaceIdentifier := check.ProvideSpaceIdentifierCheck()
 	secretStore := database.ProvideSecretStore(db)
 	connectorStore := database.ProvideConnectorStore(db, secretStore)
-	listService := pullreq.ProvideListService(transactor, gitInterface, authorizer, spaceStore, pullReqStore, checkStore, repoFinder, labelService, protectionManager)
 	repository, err := exporter.ProvideSpaceExporter(provider, gitInterface, repoStore, jobScheduler, executor, encrypter, streamer)
 	if err != nil {
 		return nil, err
@@ -397,7 +450,7 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	infraProviderResourceCache := cache.ProvideInfraProviderResourceCache(infraProviderResourceView)
 	gitspaceConfigStore := database.ProvideGitspaceConfigStore(db, principalInfoCache, infraProviderResourceCache, spaceIDCache)
 	gitspaceInstanceStore := database.ProvideGitspaceInstanceStore(db, spaceIDCache)
-	reporter3, err := events5.ProvideReporter(eventsSystem)
+	reporter4, err := events8.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -410,11 +463,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	dockerClientFactory := infraprovider.ProvideDockerClientFactory(dockerConfig)
-	reporter4, err := events6.ProvideReporter(eventsSystem)
+	reporter5, err := events9.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter4)
+	dockerProvider := infraprovider.ProvideDockerProvider(dockerConfig, dockerClientFactory, reporter5)
 	factory := infraprovider.ProvideFactory(dockerProvider)
 	cdeGatewayStore := database.ProvideCDEGatewayStore(db)
 	infraproviderService := infraprovider2.ProvideInfraProvider(transactor, gitspaceConfigStore, infraProviderResourceStore, infraProviderConfigStore, infraProviderTemplateStore, factory, spaceFinder, cdeGatewayStore)
@@ -436,11 +489,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	reporter5, err := events7.ProvideReporter(eventsSystem)
+	reporter6, err := events10.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	embeddedDockerOrchestrator := container.ProvideEmbeddedDockerOrchestrator(dockerClientFactory, statefulLogger, runargProvider, reporter5)
+	embeddedDockerOrchestrator := container.ProvideEmbeddedDockerOrchestrator(dockerClientFactory, statefulLogger, runargProvider, reporter6)
 	containerFactory := container.ProvideContainerOrchestratorFactory(embeddedDockerOrchestrator)
 	orchestratorConfig := server.ProvideGitspaceOrchestratorConfig(config)
 	vsCodeConfig := server.ProvideIDEVSCodeConfig(config)
@@ -461,25 +514,25 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, platformConnector, platformSecret, infraProvisioner, containerFactory, reporter3, orchestratorConfig, ideFactory, resolverFactory, gitspaceInstanceStore, gitspaceConfigStore, gitspacesettingsService, spaceStore, infraproviderService)
-	reporter6, err := events8.ProvideReporter(eventsSystem)
+	orchestratorOrchestrator := orchestrator.ProvideOrchestrator(scmSCM, platformConnector, platformSecret, infraProvisioner, containerFactory, reporter4, orchestratorConfig, ideFactory, resolverFactory, gitspaceInstanceStore, gitspaceConfigStore, gitspacesettingsService, spaceStore, infraproviderService)
+	reporter7, err := events11.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	tokenGenerator := tokengenerator.ProvideTokenGenerator()
-	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter3, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter6, ideFactory, spaceStore, tokenGenerator)
+	gitspaceService := gitspace.ProvideGitspace(transactor, gitspaceConfigStore, gitspaceInstanceStore, reporter4, gitspaceEventStore, spaceFinder, infraproviderService, orchestratorOrchestrator, scmSCM, config, reporter7, ideFactory, spaceStore, tokenGenerator)
 	usageMetricStore := database.ProvideUsageMetricStore(db)
 	resourceMover := space.ProvideNoopResourceMover()
 	spaceService, err := space.ProvideService(transactor, jobScheduler, executor, encrypter, repoStore, spaceStore, spacePathStore, ruleStore, resourceMover, spaceFinder, gitspaceService, infraproviderService, repoController)
 	if err != nil {
 		return nil, err
 	}
-	spaceController := space2.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repoFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, autolinkService, spaceService)
-	reporter7, err := events9.ProvideReporter(eventsSystem)
+	spaceController := space2.ProvideController(config, transactor, provider, streamer, spaceIdentifier, authorizer, spacePathStore, pipelineStore, secretStore, connectorStore, templateStore, spaceStore, repoStore, principalStore, repoController, membershipStore, listService, spaceFinder, repoFinder, jobRepository, repository, resourceLimiter, publicaccessService, auditService, gitspaceService, labelService, instrumentService, executionStore, rulesService, usageMetricStore, repoIdentifier, infraproviderService, favoriteStore, autolinkService, spaceService, milestoneService, automationService, reviewanalyticsService)
+	reporter8, err := events12.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pipelineController := pipeline.ProvideController(triggerStore, authorizer, pipelineStore, reporter7, repoFinder)
+	pipelineController := pipeline.ProvideController(triggerStore, authorizer, pipelineStore, reporter8, repoFinder)
 	secretController := secret2.ProvideController(encrypter, secretStore, authorizer, spaceFinder)
 	triggerController := trigger.ProvideController(authorizer, triggerStore, pipelineStore, repoFinder)
 	scmService := connector.ProvideSCMConnectorHandler(secretStore)
@@ -487,53 +540,38 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	connectorController := connector2.ProvideController(connectorStore, service2, authorizer, spaceFinder)
 	templateController := template.ProvideController(templateStore, authorizer, spaceFinder)
 	pluginController := plugin.ProvideController(pluginStore)
-	pullReqActivityStore := database.ProvidePullReqActivityStore(db, principalInfoCache)
-	codeCommentView := database.ProvideCodeCommentView(db)
-	pullReqReviewStore := database.ProvidePullReqReviewStore(db)
-	pullReqReviewerStore := database.ProvidePullReqReviewerStore(db, principalInfoCache)
-	userGroupReviewerStore := database.ProvideUserGroupReviewerStore(db, principalInfoCache, userGroupStore)
-	pullReqFileViewStore := database.ProvidePullReqFileViewStore(db)
-	autoMergeStore := database.ProvideAutoMergeStore(db)
-	reporter8, err := events10.ProvideReporter(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	migrator := codecomments.ProvideMigrator(gitInterface)
-	readerFactory, err := events11.ProvideReaderFactory(eventsSystem)
+	webhookConfig := server.ProvideWebhookConfig(config)
+	readerFactory3, err := events12.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	eventsReaderFactory, err := events10.ProvideReaderFactory(eventsSystem)
+	readerFactory4, err := events3.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	pullreqService, err := pullreq.ProvideService(ctx, config, readerFactory, eventsReaderFactory, reporter8, gitInterface, repoFinder, repoStore, pullReqStore, pullReqActivityStore, principalInfoCache, codeCommentView, migrator, pullReqFileViewStore, pubSub, provider, streamer)
+	readerFactory5, err := events4.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory2, err := events12.ProvideReaderFactory(eventsSystem)
+	webhookStore := database.ProvideWebhookStore(db)
+	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
+	urlProvider := webhook.ProvideURLProvider(ctx)
+	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
+	reporter9, err := events13.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	mergeService, err := merge.ProvideService(ctx, config, gitInterface, transactor, reporter8, readerFactory2, eventsReaderFactory, repoFinder, repoStore, pullReqStore, pullReqActivityStore, checkStore, pullReqReviewerStore, principalInfoCache, principalStore, autoMergeStore, protectionManager, codeownersService, usergroupService, provider, streamer, pubSub, instrumentService, lockerLocker)
+	signingKey, err := webhook.ProvideSigningKey(ctx, settingsService, encrypter)
 	if err != nil {
 		return nil, err
 	}
-	pullReq := migrate.ProvidePullReqImporter(provider, gitInterface, principalStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, labelStore, labelValueStore, pullReqLabelAssignmentStore, pullReqReviewerStore, pullReqReviewStore, repoFinder, transactor, mutexManager)
-	branchStore := database.ProvideBranchStore(db)
-	pullreqController := pullreq2.ProvideController(transactor, provider, authorizer, auditService, pullReqStore, pullReqActivityStore, codeCommentView, pullReqReviewStore, pullReqReviewerStore, repoStore, principalStore, userGroupStore, userGroupReviewerStore, principalInfoCache, pullReqFileViewStore, membershipStore, checkStore, autoMergeStore, gitInterface, repoFinder, reporter8, migrator, pullreqService, listService, mergeService, protectionManager, streamer, dotrangeService, codeownersService, lockerLocker, settingsService, pullReq, labelService, instrumentService, usergroupService, branchStore, usergroupResolver)
-	webhookConfig := server.ProvideWebhookConfig(config)
-	webhookStore := database.ProvideWebhookStore(db)
-	webhookExecutionStore := database.ProvideWebhookExecutionStore(db)
-	urlProvider := webhook.ProvideURLProvider(ctx)
-	secretService := secret3.ProvideSecretService(secretStore, encrypter, spaceFinder)
-	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore)
+	webhookService, err := webhook.ProvideService(ctx, webhookConfig, transactor, readerFactory, eventsReaderFactory, readerFactory3, readerFactory2, readerFactory4, readerFactory5, webhookStore, webhookExecutionStore, spaceStore, repoStore, pullReqStore, pullReqActivityStore, provider, principalStore, gitInterface, encrypter, labelStore, urlProvider, labelValueStore, auditService, streamer, secretService, spacePathStore, pipelineStore, executionStore, checkStore, ruleStore, jobScheduler, executor, reporter9, signingKey)
 	if err != nil {
 		return nil, err
 	}
 	preprocessor := webhook2.ProvidePreprocessor()
 	webhookController := webhook2.ProvideController(authorizer, spaceFinder, repoFinder, webhookService, encrypter, preprocessor)
-	reporter9, err := events11.ProvideReporter(eventsSystem)
+	reporter10, err := events6.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
@@ -549,16 +587,16 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter9, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService)
+	githookController := githook.ProvideController(authorizer, principalStore, repoStore, repoFinder, reporter10, eventsReporter, gitInterface, pullReqStore, provider, protectionManager, clientFactory, resourceLimiter, settingsService, preReceiveExtender, updateExtender, postReceiveExtender, streamer, lfsObjectStore, auditService, usergroupService, pullreqController)
 	serviceaccountController := serviceaccount.NewController(principalUID, authorizer, principalStore, spaceStore, repoStore, tokenStore)
 	principalController := principal.ProvideController(principalStore, authorizer)
 	usergroupController := usergroup2.ProvideController(userGroupStore, spaceStore, spaceFinder, authorizer, usergroupService)
 	v2 := check2.ProvideCheckSanitizers()
-	reporter10, err := events12.ProvideReporter(eventsSystem)
+	reporter11, err := events7.ProvideReporter(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	checkController := check2.ProvideController(transactor, authorizer, spaceStore, checkStore, spaceFinder, repoFinder, gitInterface, v2, streamer, reporter10)
+	checkController := check2.ProvideController(transactor, authorizer, spaceStore, checkStore, spaceFinder, repoFinder, gitInterface, v2, streamer, reporter11)
 	systemController := system.NewController(principalStore, config)
 	uploadController := upload.ProvideController(authorizer, repoFinder, blobStore, config)
 	searcher := keywordsearch.ProvideSearcher(localIndexSearcher)
@@ -570,6 +608,17 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	migrateWebhook := migrate.ProvideWebhookImporter(webhookConfig, transactor, webhookStore)
 	migrateLabel := migrate.ProvideLabelImporter(transactor, labelStore, labelValueStore, spaceStore)
 	migrateController := migrate2.ProvideController(authorizer, publicaccessService, gitInterface, provider, pullReq, rule, migrateWebhook, migrateLabel, resourceLimiter, auditService, repoIdentifier, transactor, spaceStore, repoStore, spaceFinder, repoFinder, eventsReporter)
+	notificationPreferenceStore := database.ProvideNotificationPreferenceStore(db)
+	notificationSubscriptionStore := database.ProvideNotificationSubscriptionStore(db)
+	notificationStore := database.ProvideNotificationStore(db)
+	notificationChannelStore := database.ProvideNotificationChannelStore(db)
+	notificationTemplateStore := database.ProvideNotificationTemplateStore(db)
+	notificationConfig := server.ProvideNotificationConfig(config)
+	templateRenderer, err := notification.ProvideTemplateRenderer(notificationTemplateStore, notificationConfig)
+	if err != nil {
+		return nil, err
+	}
+	notificationController := notification2.ProvideController(transactor, authorizer, spaceFinder, repoFinder, pullReqStore, notificationPreferenceStore, notificationSubscriptionStore, notificationStore, notificationChannelStore, notificationTemplateStore, streamer, encrypter, webhookConfig, templateRenderer)
 	openapiService := openapi.ProvideOpenAPIService()
 	blobRepository := database2.ProvideBlobDao(db, mediaTypesRepository)
 	storageDriver, err := api2.DefaultStorageProvider(ctx, config)
@@ -626,11 +675,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	accessor := dbtx.ProvideAccessor(accessorTx)
 	webhooksRepository := database2.ProvideWebhookDao(db)
 	webhooksExecutionRepository := database2.ProvideWebhookExecutionDao(db)
-	readerFactory3, err := artifact.ProvideReaderFactory(eventsSystem)
+	readerFactory6, err := artifact.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	service3, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory3, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder)
+	service3, err := webhook3.ProvideService(ctx, webhookConfig, transactor, readerFactory6, webhooksRepository, webhooksExecutionRepository, spaceStore, provider, principalStore, urlProvider, spacePathStore, secretService, registryRepository, encrypter, spaceFinder, signingKey)
 	if err != nil {
 		return nil, err
 	}
@@ -646,8 +695,8 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	packageWrapper := helpers.ProvidePackageWrapperProvider(interfacesRegistryHelper, registryFinder, registryHelper)
 	v3 := router.ProvideUntaggedImagesEnabled()
 	deletionPackageWrapper := deletion.ProvidePackageWrapper(packageWrapper)
-	reporter11 := artifact.ProvideArtifactReporterValue(artifactReporter)
-	reindexingService := reindexing.NewService(asyncprocessingReporter, reporter11)
+	reporter12 := artifact.ProvideArtifactReporterValue(artifactReporter)
+	reindexingService := reindexing.NewService(asyncprocessingReporter, reporter12)
 	deletionService := deletion.NewService(artifactRepository, imageRepository, manifestRepository, tagRepository, registryBlobRepository, fileManager, transactor, v3, deletionPackageWrapper, reindexingService, artifactReporter, provider)
 	apiHandler := router.APIHandlerProvider(registryRepository, upstreamProxyConfigRepository, fileManager, blobRepository, genericBlobRepository, tagRepository, manifestRepository, cleanupPolicyRepository, imageRepository, spaceFinder, transactor, accessor, authenticator, provider, authorizer, auditService, artifactRepository, webhooksRepository, webhooksExecutionRepository, service3, spacePathStore, artifactReporter, downloadStatRepository, config, registryBlobRepository, registryFinder, asyncprocessingReporter, registryHelper, spaceController, quarantineArtifactRepository, spaceStore, packageWrapper, cacheService, finder, v3, deletionService, storageService, app)
 	packageTagRepository := database2.ProvidePackageTagDao(db)
@@ -703,19 +752,15 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	huggingfaceHandler := huggingface3.ProvideHandler(huggingfaceController, packagesHandler)
 	handler4 := router.PackageHandlerProvider(packagesHandler, mavenHandler, genericHandler, pythonHandler, nugetHandler, npmHandler, rpmHandler, cargoHandler, gopackageHandler, huggingfaceHandler, spaceFinder, cacheService)
 	appRouter := router.AppRouterProvider(registryOCIHandler, apiHandler, handler2, handler3, handler4)
-	readerFactory4, err := events3.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
 	sender, err := usage.ProvideMediator(ctx, config, spaceFinder, repoFinder, usageMetricStore, readerFactory4)
 	if err != nil {
 		return nil, err
 	}
-	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, provider, openapiService, appRouter, sender, lfsController)
+	routerRouter := router2.ProvideRouter(ctx, config, authenticator, repoController, reposettingsController, executionController, logsController, spaceController, pipelineController, secretController, triggerController, connectorController, templateController, pluginController, pullreqController, webhookController, githookController, gitInterface, serviceaccountController, controller, principalController, usergroupController, checkController, systemController, uploadController, keywordsearchController, infraproviderController, gitspaceController, migrateController, notificationController, provider, openapiService, appRouter, sender, lfsController)
 	serverServer := server2.ProvideServer(config, routerRouter)
 	sshAuthService := publickey.ProvideSSHAuthService(publicKeyStore, principalInfoCache)
 	sshServer := ssh.ProvideServer(config, sshAuthService, repoController, lfsController)
-	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter7)
+	executionManager := manager.ProvideExecutionManager(config, executionStore, pipelineStore, provider, streamer, fileService, converterService, logStore, logStream, checkStore, repoStore, schedulerScheduler, secretStore, stageStore, stepStore, principalStore, publicaccessService, reporter8)
 	client := manager.ProvideExecutionClient(executionManager, provider, config)
 	resolverManager := resolver.ProvideResolver(config, pluginStore, templateStore, executionStore, repoStore)
 	runtimeRunner, err := runner.ProvideExecutionRunner(config, client, resolverManager)
@@ -732,15 +777,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	readerFactory5, err := events2.ProvideReaderFactory(eventsSystem)
+	readerFactory7, err := events2.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory6, err := events4.ProvideReaderFactory(eventsSystem)
-	if err != nil {
-		return nil, err
-	}
-	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory5, readerFactory4, eventsReaderFactory, readerFactory6, publicaccessService, spaceFinder, repoFinder)
+	submitter, err := metric.ProvideSubmitter(ctx, config, values, principalStore, principalInfoCache, pullReqStore, ruleStore, readerFactory7, readerFactory4, eventsReaderFactory, readerFactory5, publicaccessService, spaceFinder, repoFinder)
 	if err != nil {
 		return nil, err
 	}
@@ -761,10 +802,21 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
+	signer, err := emailreply.ProvideSigner(config)
+	if err != nil {
+		return nil, err
+	}
+	processor := emailreply.ProvideProcessor(signer, principalStore, pullReqActivityStore, pullReqStore, repoStore, pullreqController)
+	emailreplyServer := emailreply.ProvideServer(config, processor)
 	mailerMailer := mailer.ProvideMailClient(config)
-	notificationClient := notification.ProvideMailClient(mailerMailer)
-	notificationConfig := server.ProvideNotificationConfig(config)
-	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, notificationConfig, eventsReaderFactory, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, provider)
+	notificationClient := notification.ProvideMailClient(mailerMailer, templateRenderer)
+	chatClient := notification.ProvideChatClient(notificationChannelStore, spaceStore, encrypter, webhookConfig)
+	readerFactory8, err := events13.ProvideReaderFactory(eventsSystem)
+	if err != nil {
+		return nil, err
+	}
+	notificationDigestStore := database.ProvideNotificationDigestStore(db)
+	notificationService, err := notification.ProvideNotificationService(ctx, notificationClient, chatClient, notificationConfig, eventsReaderFactory, readerFactory8, pullReqStore, repoStore, principalInfoView, principalInfoCache, pullReqReviewerStore, pullReqActivityStore, spacePathStore, webhookStore, spaceStore, notificationPreferenceStore, notificationSubscriptionStore, notificationStore, notificationDigestStore, principalStore, authorizer, provider, streamer, jobScheduler, executor, signer)
 	if err != nil {
 		return nil, err
 	}
@@ -774,45 +826,45 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 		return nil, err
 	}
 	gitspaceeventConfig := server.ProvideGitspaceEventConfig(config)
-	readerFactory7, err := events5.ProvideReaderFactory(eventsSystem)
+	readerFactory9, err := events8.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory7, gitspaceEventStore)
+	gitspaceeventService, err := gitspaceevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, gitspaceEventStore)
 	if err != nil {
 		return nil, err
 	}
 	gitspacedeleteeventConfig := server.ProvideGitspaceDeleteEventConfig(config)
-	readerFactory8, err := events8.ProvideReaderFactory(eventsSystem)
+	readerFactory10, err := events11.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory8, gitspaceService)
+	gitspacedeleteeventService, err := gitspacedeleteevent.ProvideService(ctx, gitspacedeleteeventConfig, readerFactory10, gitspaceService)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory9, err := events6.ProvideReaderFactory(eventsSystem)
+	readerFactory11, err := events9.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory9, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceinfraeventService, err := gitspaceinfraevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, reporter4)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory10, err := events7.ProvideReaderFactory(eventsSystem)
+	readerFactory12, err := events10.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
-	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory10, orchestratorOrchestrator, gitspaceService, reporter3)
+	gitspaceoperationseventService, err := gitspaceoperationsevent.ProvideService(ctx, gitspaceeventConfig, readerFactory12, orchestratorOrchestrator, gitspaceService, reporter4)
 	if err != nil {
 		return nil, err
 	}
-	readerFactory11, err := events13.ProvideReaderFactory(eventsSystem)
+	readerFactory13, err := events14.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	aiTaskStore := database.ProvideAITaskStore(db)
-	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory11, orchestratorOrchestrator, gitspaceService, aiTaskStore)
+	aitaskeventService, err := aitaskevent.ProvideService(ctx, gitspaceeventConfig, readerFactory13, orchestratorOrchestrator, gitspaceService, aiTaskStore)
 	if err != nil {
 		return nil, err
 	}
@@ -832,12 +884,12 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	}
 	rpmHelper := asyncprocessing2.ProvideRpmHelper(fileManager, artifactRepository, upstreamProxyConfigRepository, spaceFinder, secretService, registryRepository)
 	gopackageRegistryHelper := gopackage3.LocalRegistryHelperProvider(fileManager, artifactRepository, spaceFinder, registryFinder)
-	readerFactory12, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
+	readerFactory14, err := asyncprocessing.ProvideReaderFactory(eventsSystem)
 	if err != nil {
 		return nil, err
 	}
 	asyncprocessingConfig := asyncprocessing2.ProvideRegistryPostProcessingConfig(config)
-	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory12, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
+	asyncprocessingService, err := asyncprocessing2.ProvideService(ctx, transactor, rpmHelper, registryHelper, gopackageRegistryHelper, lockerLocker, readerFactory14, asyncprocessingConfig, registryRepository, taskRepository, taskSourceRepository, taskEventRepository, eventsSystem, asyncprocessingReporter, packageWrapper)
 	if err != nil {
 		return nil, err
 	}
@@ -849,7 +901,11 @@ func initSystem(ctx context.Context, config *types.Config) (*server.System, erro
 	if err != nil {
 		return nil, err
 	}
-	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service3, branchService, asyncprocessingService, jobRpmRegistryIndex, languageAnalyzer)
+	slashcommandService, err := slashcommand.ProvideService(ctx, config, eventsReaderFactory, repoFinder, spaceStore, pullReqStore, pullReqActivityStore, principalStore, checkStore, labelService, pullreqController, repoController, executionController)
+	if err != nil {
+		return nil, err
+	}
+	servicesServices := services.ProvideServices(webhookService, pullreqService, triggerService, jobScheduler, collectorJob, sizeCalculator, repoService, cleanupService, emailreplyServer, notificationService, keywordsearchService, gitspaceServices, instrumentService, consumer, repositoryCount, service3, branchService, asyncprocessingService, jobRpmRegistryIndex, languageAnalyzer, slashcommandService)
 	listenAndServeServer := server.ProvideNoOpMetricServer()
 	serverSystem := server.NewSystem(bootstrapBootstrap, serverServer, sshServer, poller, resolverManager, servicesServices, listenAndServeServer)
 	return serverSystem, nil
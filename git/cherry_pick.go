@@ -0,0 +1,182 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+// CherryPickParams is input structure object for the cherry-pick operation.
+type CherryPickParams struct {
+	WriteParams
+
+	// BaseSHA is the commit on top of which the commits are applied.
+	BaseSHA sha.SHA
+
+	// CommitSHAs are the commits to cherry-pick, applied in the provided order.
+	CommitSHAs []sha.SHA
+
+	// RecordOrigin appends "(cherry picked from commit ...)" to the messages of the created commits.
+	RecordOrigin bool
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+
+	// Refs are updated to the resulting commit. If empty, no reference is updated.
+	Refs []RefUpdate
+}
+
+func (p *CherryPickParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.BaseSHA.IsEmpty() {
+		return errors.InvalidArgument("base commit SHA is mandatory")
+	}
+
+	if len(p.CommitSHAs) == 0 {
+		return errors.InvalidArgument("at least one commit to cherry-pick is required")
+	}
+
+	for _, ref := range p.Refs {
+		if ref.Name == "" {
+			return errors.InvalidArgument("ref name has to be provided")
+		}
+	}
+
+	return nil
+}
+
+// CherryPickOutput is result object of the cherry-pick operation.
+type CherryPickOutput struct {
+	// BaseSHA is the commit on top of which the commits have been applied.
+	BaseSHA sha.SHA
+	// CommitSHA is the SHA of the last created commit. It's empty in case of conflicts.
+	CommitSHA sha.SHA
+
+	// ConflictCommitSHA is the SHA of the commit that couldn't be applied because of the conflicts.
+	ConflictCommitSHA sha.SHA
+	ConflictFiles     []string
+}
+
+// CherryPick applies the changes introduced by the provided commits on top of the base commit.
+// The commits are applied one by one, each producing a new commit. Commits which changes
+// are already present are skipped. Merge commits can't be cherry-picked.
+func (s *Service) CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error) {
+	if err := params.Validate(); err != nil {
+		return CherryPickOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	for _, commitSHA := range params.CommitSHAs {
+		commit, err := api.GetCommit(ctx, repoPath, commitSHA)
+		if err != nil {
+			return CherryPickOutput{}, fmt.Errorf("failed to get commit %s: %w", commitSHA, err)
+		}
+
+		if len(commit.ParentSHAs) != 1 {
+			return CherryPickOutput{}, errors.InvalidArgumentf(
+				"commit %s can't be cherry-picked, only commits with a single parent are supported", commitSHA)
+		}
+	}
+
+	now := time.Now().UTC()
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: now}
+
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath)
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to create reference updater: %w", err)
+	}
+
+	var commitSHA, conflictSHA sha.SHA
+	var conflicts []string
+
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		commitSHA, conflictSHA, conflicts, err = merge.CherryPick(ctx, r,
+			&committer, params.BaseSHA, params.CommitSHAs, params.RecordOrigin)
+		if err != nil {
+			return fmt.Errorf("failed to cherry-pick commits: %w", err)
+		}
+
+		if len(conflicts) > 0 {
+			return refUpdater.Init(ctx, nil) // update nothing
+		}
+
+		if commitSHA.Equal(params.BaseSHA) {
+			return errors.InvalidArgument("the changes of the commits are already present")
+		}
+
+		refUpdates := make([]hook.ReferenceUpdate, len(params.Refs))
+		for i, ref := range params.Refs {
+			newValue := ref.New
+			if newValue.IsEmpty() { // replace all empty new values to the result of the cherry-pick
+				newValue = commitSHA
+			}
+
+			refUpdates[i] = hook.ReferenceUpdate{
+				Ref: ref.Name,
+				Old: ref.Old,
+				New: newValue,
+			}
+		}
+
+		err = refUpdater.Init(ctx, refUpdates)
+		if err != nil {
+			return fmt.Errorf("failed to init values of references (%v): %w", refUpdates, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return CherryPickOutput{}, fmt.Errorf("failed to cherry-pick commits onto %s in %q: %w",
+			params.BaseSHA, params.RepoUID, err)
+	}
+
+	if len(conflicts) > 0 {
+		return CherryPickOutput{
+			BaseSHA:           params.BaseSHA,
+			ConflictCommitSHA: conflictSHA,
+			ConflictFiles:     conflicts,
+		}, nil
+	}
+
+	return CherryPickOutput{
+		BaseSHA:   params.BaseSHA,
+		CommitSHA: commitSHA,
+	}, nil
+}
@@ -0,0 +1,187 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/merge"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_CherryPick(t *testing.T) {
+	skipWithoutMergeTree(t)
+
+	const branchRef = "refs/heads/picked"
+
+	tests := []struct {
+		name string
+		// setup returns the base commit and the commits to cherry-pick.
+		setup        func(r *testRepo) (sha.SHA, []sha.SHA)
+		recordOrigin bool
+		wantFiles    map[string]string
+		wantCommits  int
+		wantConflict []string
+	}{
+		{
+			name: "clean pick",
+			setup: func(r *testRepo) (sha.SHA, []sha.SHA) {
+				root := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "root")
+				base := r.commit(root, map[string]string{"a.txt": "a\n", "c.txt": "c\n"}, "add c")
+				pick := r.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b")
+				return base, []sha.SHA{pick}
+			},
+			recordOrigin: true,
+			wantFiles:    map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"},
+			wantCommits:  1,
+		},
+		{
+			name: "multiple commits",
+			setup: func(r *testRepo) (sha.SHA, []sha.SHA) {
+				root := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "root")
+				base := r.commit(root, map[string]string{"a.txt": "a\n", "c.txt": "c\n"}, "add c")
+				first := r.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b")
+				second := r.commit(first, map[string]string{"a.txt": "a2\n", "b.txt": "b\n"}, "change a")
+				return base, []sha.SHA{first, second}
+			},
+			wantFiles:   map[string]string{"a.txt": "a2", "b.txt": "b", "c.txt": "c"},
+			wantCommits: 2,
+		},
+		{
+			name: "conflict",
+			setup: func(r *testRepo) (sha.SHA, []sha.SHA) {
+				root := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "root")
+				base := r.commit(root, map[string]string{"a.txt": "base\n"}, "change a on base")
+				first := r.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b")
+				second := r.commit(first, map[string]string{"a.txt": "pick\n", "b.txt": "b\n"}, "change a")
+				return base, []sha.SHA{first, second}
+			},
+			wantConflict: []string{"a.txt"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, repo := newTestService(t)
+			ctx := context.Background()
+
+			baseSHA, commitSHAs := test.setup(repo)
+
+			out, err := s.CherryPick(ctx, &CherryPickParams{
+				WriteParams:  WriteParams{RepoUID: testRepoUID, Actor: testActor},
+				BaseSHA:      baseSHA,
+				CommitSHAs:   commitSHAs,
+				RecordOrigin: test.recordOrigin,
+				Refs:         []RefUpdate{{Name: branchRef, Old: sha.Nil}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, baseSHA, out.BaseSHA)
+
+			if test.wantConflict != nil {
+				assert.True(t, out.CommitSHA.IsEmpty())
+				assert.Equal(t, commitSHAs[len(commitSHAs)-1], out.ConflictCommitSHA)
+				assert.Equal(t, test.wantConflict, out.ConflictFiles)
+				assert.Empty(t, repo.run(nil, "for-each-ref", branchRef), "the branch must not be created")
+				return
+			}
+
+			require.Equal(t, out.CommitSHA, repo.ref(branchRef))
+			for name, content := range test.wantFiles {
+				assert.Equal(t, content, repo.file(out.CommitSHA, name), name)
+			}
+
+			commitSHA := out.CommitSHA
+			for i := 0; i < test.wantCommits; i++ {
+				commitSHA = repo.parent(commitSHA)
+			}
+			assert.Equal(t, baseSHA, commitSHA, "a commit per cherry-picked commit must be created")
+
+			message := repo.run(nil, "log", "-1", "--format=%B", out.CommitSHA.String())
+			if test.recordOrigin {
+				assert.Contains(t, message, "(cherry picked from commit "+commitSHAs[0].String()+")")
+			} else {
+				assert.NotContains(t, message, "cherry picked from commit")
+			}
+		})
+	}
+}
+
+func TestService_CherryPick_AlreadyPresent(t *testing.T) {
+	skipWithoutMergeTree(t)
+
+	s, repo := newTestService(t)
+
+	root := repo.commit(sha.None, map[string]string{"a.txt": "a\n"}, "root")
+	pick := repo.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b")
+	base := repo.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b again")
+
+	_, err := s.CherryPick(context.Background(), &CherryPickParams{
+		WriteParams: WriteParams{RepoUID: testRepoUID, Actor: testActor},
+		BaseSHA:     base,
+		CommitSHAs:  []sha.SHA{pick},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "already present")
+}
+
+func TestRebase(t *testing.T) {
+	skipWithoutMergeTree(t)
+
+	const branchRef = "refs/heads/rebased"
+
+	s, repo := newTestService(t)
+	ctx := context.Background()
+
+	root := repo.commit(sha.None, map[string]string{"a.txt": "a\n"}, "root")
+	target := repo.commit(root, map[string]string{"a.txt": "a\n", "c.txt": "c\n"}, "add c")
+	first := repo.commit(root, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "add b")
+	source := repo.commit(first, map[string]string{"a.txt": "a2\n", "b.txt": "b\n"}, "change a")
+
+	committer := &api.Signature{Identity: api.Identity{Name: "Committer", Email: testCommitterEmail}, When: time.Now()}
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, nil, repo.path)
+	require.NoError(t, err)
+
+	var rebasedSHA sha.SHA
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repo.path, func(r *sharedrepo.SharedRepo) error {
+		var conflicts []string
+		rebasedSHA, conflicts, err = merge.Rebase(ctx, r, merge.Params{
+			Committer:    committer,
+			MergeBaseSHA: root,
+			TargetSHA:    target,
+			SourceSHA:    source,
+		})
+		require.NoError(t, err)
+		require.Empty(t, conflicts)
+
+		return refUpdater.Init(ctx, []hook.ReferenceUpdate{{Ref: branchRef, Old: sha.Nil, New: rebasedSHA}})
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, rebasedSHA, repo.ref(branchRef))
+	assert.Equal(t, "a2", repo.file(rebasedSHA, "a.txt"))
+	assert.Equal(t, "b", repo.file(rebasedSHA, "b.txt"))
+	assert.Equal(t, "c", repo.file(rebasedSHA, "c.txt"))
+	assert.Equal(t, target, repo.parent(repo.parent(rebasedSHA)), "both source commits must be rebased")
+	assert.Equal(t, "change a", repo.run(nil, "log", "-1", "--format=%s", rebasedSHA.String()))
+}
@@ -196,6 +196,12 @@ var descriptions = map[string]builder{
 	},
 	"receive-pack": {
 		flags: 0,
+		options: func() []CmdOptionFunc {
+			return []CmdOptionFunc{
+				// required for clients to be able to send push options (git push -o <option>).
+				WithConfig("receive.advertisePushOptions", "true"),
+			}
+		},
 	},
 	"remote": {
 		// While git-remote(1)'s `add` subcommand does support `--end-of-options`,
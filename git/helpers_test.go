@@ -0,0 +1,188 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRepoUID        = "testrepo"
+	testCommitterEmail = "committer@example.com"
+)
+
+var testActor = Identity{Name: "Actor", Email: "actor@example.com"}
+
+// testRepo is a bare repository in the repos root of a test service.
+// Commits are created with plumbing commands, the files are stored in the root tree.
+type testRepo struct {
+	t    *testing.T
+	path string
+}
+
+func newTestService(t *testing.T) (*Service, *testRepo) {
+	t.Helper()
+
+	root := t.TempDir()
+	s := &Service{
+		reposRoot:         root + "/repos",
+		sharedRepoRoot:    root + "/shared",
+		hookClientFactory: noopHookClientFactory{},
+	}
+
+	require.NoError(t, os.MkdirAll(s.sharedRepoRoot, 0o700))
+
+	repo := &testRepo{t: t, path: getFullPathForRepo(s.reposRoot, testRepoUID)}
+	require.NoError(t, os.MkdirAll(repo.path, 0o700))
+	repo.run(nil, "init", "--bare")
+
+	return s, repo
+}
+
+func (r *testRepo) run(stdin []byte, name string, args ...string) string {
+	r.t.Helper()
+
+	cmd := command.New(name,
+		command.WithFlag(args...),
+		command.WithAuthor("Author", "author@example.com"),
+		command.WithCommitter("Committer", testCommitterEmail),
+	)
+
+	stdout := bytes.NewBuffer(nil)
+	opts := []command.RunOptionFunc{command.WithDir(r.path), command.WithStdout(stdout)}
+	if stdin != nil {
+		opts = append(opts, command.WithStdin(bytes.NewReader(stdin)))
+	}
+
+	require.NoError(r.t, cmd.Run(context.Background(), opts...))
+
+	return strings.TrimSpace(stdout.String())
+}
+
+// commit creates a commit with the files as the complete content of its tree.
+func (r *testRepo) commit(parent sha.SHA, files map[string]string, message string) sha.SHA {
+	r.t.Helper()
+	return r.commitWithExecutables(parent, files, nil, message)
+}
+
+// commitWithExecutables is like commit, but the files listed in executables get the executable file mode.
+func (r *testRepo) commitWithExecutables(
+	parent sha.SHA,
+	files map[string]string,
+	executables []string,
+	message string,
+) sha.SHA {
+	r.t.Helper()
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tree := strings.Builder{}
+	for _, name := range names {
+		blobSHA := r.run([]byte(files[name]), "hash-object", "-w", "--stdin")
+		mode := filePermissionDefault
+		if slices.Contains(executables, name) {
+			mode = filePermissionExecutable
+		}
+		tree.WriteString(mode + " blob " + blobSHA + "\t" + name + "\n")
+	}
+
+	treeSHA := r.run([]byte(tree.String()), "mktree")
+
+	args := []string{treeSHA, "-m", message}
+	if !parent.IsEmpty() {
+		args = append(args, "-p", parent.String())
+	}
+
+	return sha.Must(r.run(nil, "commit-tree", args...))
+}
+
+// file returns the content of the file in the commit.
+func (r *testRepo) file(commitSHA sha.SHA, name string) string {
+	r.t.Helper()
+	return r.run(nil, "show", commitSHA.String()+":"+name)
+}
+
+// mode returns the file mode of the file in the commit, or an empty string if the file doesn't exist.
+func (r *testRepo) mode(commitSHA sha.SHA, name string) string {
+	r.t.Helper()
+
+	fields := strings.Fields(r.run(nil, "ls-tree", commitSHA.String(), "--", name))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	return fields[0]
+}
+
+// ref returns the commit the reference points to.
+func (r *testRepo) ref(name string) sha.SHA {
+	r.t.Helper()
+	return sha.Must(r.run(nil, "rev-parse", name))
+}
+
+// parent returns the first parent of the commit.
+func (r *testRepo) parent(commitSHA sha.SHA) sha.SHA {
+	r.t.Helper()
+	return r.ref(commitSHA.String() + "^")
+}
+
+// skipWithoutMergeTree skips the test if the installed git doesn't support
+// 'merge-tree --write-tree --merge-base', used for merges, rebases and cherry-picks (git 2.40+).
+func skipWithoutMergeTree(t *testing.T) {
+	t.Helper()
+
+	out := bytes.NewBuffer(nil)
+	require.NoError(t, command.New("version").Run(context.Background(), command.WithStdout(out)))
+
+	// the output is in the form of "git version 2.39.5"
+	fields := strings.Fields(out.String())
+	require.GreaterOrEqual(t, len(fields), 3)
+
+	parts := strings.SplitN(fields[2], ".", 3)
+	require.GreaterOrEqual(t, len(parts), 2)
+
+	major, err := strconv.Atoi(parts[0])
+	require.NoError(t, err)
+	minor, err := strconv.Atoi(parts[1])
+	require.NoError(t, err)
+
+	if major < 2 || major == 2 && minor < 40 {
+		t.Skipf("git %s doesn't support merge-tree with an explicit merge base", fields[2])
+	}
+}
+
+// noopHookClientFactory creates hook clients that accept all reference updates.
+type noopHookClientFactory struct{}
+
+func (noopHookClientFactory) NewClient(map[string]string) (hook.Client, error) {
+	return hook.NewNoopClient(nil), nil
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,11 +56,17 @@ func (c *CLICore) PreReceive(ctx context.Context) error {
 		return fmt.Errorf("failed to read alternate object dirs from env: %w", err)
 	}
 
+	pushOptions, err := getPushOptionsFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to read push options from env: %w", err)
+	}
+
 	in := PreReceiveInput{
 		RefUpdates: refUpdates,
 		Environment: Environment{
 			AlternateObjectDirs: alternateObjDirs,
 		},
+		PushOptions: pushOptions,
 	}
 
 	out, err := c.client.PreReceive(ctx, in)
@@ -99,11 +106,17 @@ func (c *CLICore) PostReceive(ctx context.Context) error {
 		return fmt.Errorf("failed to read updated references from std in: %w", err)
 	}
 
+	pushOptions, err := getPushOptionsFromEnv()
+	if err != nil {
+		return fmt.Errorf("failed to read push options from env: %w", err)
+	}
+
 	in := PostReceiveInput{
 		RefUpdates: refUpdates,
 		Environment: Environment{
 			AlternateObjectDirs: nil, // all objects are in main objects folder at this point
 		},
+		PushOptions: pushOptions,
 	}
 
 	out, err := c.client.PostReceive(ctx, in)
@@ -206,3 +219,26 @@ func getAlternateObjectDirsFromEnv(refUpdates []ReferenceUpdate) ([]string, erro
 
 	return []string{tmpDir}, nil
 }
+
+// getPushOptionsFromEnv returns the push options provided by the client.
+// Git exposes them to the pre-receive and post-receive hooks via
+// GIT_PUSH_OPTION_COUNT and GIT_PUSH_OPTION_<i> environment variables.
+// For more details see https://git-scm.com/docs/githooks#pre-receive
+func getPushOptionsFromEnv() ([]string, error) {
+	countRaw, ok := os.LookupEnv(envNamePushOptionCount)
+	if !ok || countRaw == "" {
+		return nil, nil
+	}
+
+	count, err := strconv.Atoi(countRaw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value of environment variable %q: %w", envNamePushOptionCount, err)
+	}
+
+	pushOptions := make([]string, 0, count)
+	for i := range count {
+		pushOptions = append(pushOptions, os.Getenv(envNamePushOptionPrefix+strconv.Itoa(i)))
+	}
+
+	return pushOptions, nil
+}
@@ -25,6 +25,12 @@ import (
 const (
 	// envNamePayload defines the environment variable name used to send the payload to githook binary.
 	envNamePayload = "GIT_HOOK_PAYLOAD"
+
+	// envNamePushOptionCount defines the environment variable name git uses to provide the number of push options.
+	envNamePushOptionCount = "GIT_PUSH_OPTION_COUNT"
+
+	// envNamePushOptionPrefix defines the prefix of the environment variables git uses to provide the push options.
+	envNamePushOptionPrefix = "GIT_PUSH_OPTION_"
 )
 
 // GenerateEnvironmentVariables generates the environment variables that should be used when calling git
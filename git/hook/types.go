@@ -48,6 +48,9 @@ type PreReceiveInput struct {
 
 	// RefUpdates contains all references that are being updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// PushOptions contains the push options provided by the client (git push -o <option>).
+	PushOptions []string `json:"push_options,omitempty"`
 }
 
 // UpdateInput represents the input of the update git hook.
@@ -66,4 +69,7 @@ type PostReceiveInput struct {
 
 	// RefUpdates contains all references that got updated as part of the git operation.
 	RefUpdates []ReferenceUpdate `json:"ref_updates"`
+
+	// PushOptions contains the push options provided by the client (git push -o <option>).
+	PushOptions []string `json:"push_options,omitempty"`
 }
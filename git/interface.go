@@ -82,6 +82,10 @@ type Interface interface {
 		ctx context.Context,
 		params ProcessPreReceiveObjectsParams,
 	) (ProcessPreReceiveObjectsOutput, error)
+	ProcessRangeObjects(
+		ctx context.Context,
+		params ProcessRangeObjectsParams,
+	) (ProcessRangeObjectsOutput, error)
 
 	/*
 	 * Git Cli Service
@@ -111,6 +115,11 @@ type Interface interface {
 
 	Revert(ctx context.Context, in *RevertParams) (RevertOutput, error)
 
+	CherryPick(ctx context.Context, params *CherryPickParams) (CherryPickOutput, error)
+
+	MergeConflicts(ctx context.Context, params *MergeConflictsParams) (MergeConflictsOutput, error)
+	ResolveMergeConflicts(ctx context.Context, params *ResolveMergeConflictsParams) (ResolveMergeConflictsOutput, error)
+
 	/*
 	 * Blame services
 	 */
@@ -95,8 +95,6 @@ func mergeInternal(ctx context.Context,
 // Rebase merges two the commits (targetSHA and sourceSHA) using the Rebase method.
 // Commit author isn't used here - it's copied from every commit.
 // Commit message isn't used here
-//
-//nolint:gocognit // refactor if needed.
 func Rebase(
 	ctx context.Context,
 	s *sharedrepo.SharedRepo,
@@ -111,26 +109,64 @@ func Rebase(
 		return sha.None, nil, fmt.Errorf("failed to find commit list in rebase merge: %w", err)
 	}
 
+	mergeSHA, _, conflicts, err = pickCommits(ctx, s, params.Committer, targetSHA, sourceSHAs, false)
+	if err != nil {
+		return sha.None, nil, fmt.Errorf("failed to rebase commits: %w", err)
+	}
+
+	return mergeSHA, conflicts, nil
+}
+
+// CherryPick applies the changes introduced by each of the commits, in the provided order, on top of the targetSHA.
+// Commit author and message are copied from every commit. If recordOrigin is set, a line pointing
+// to the original commit is appended to the commit messages (same as 'git cherry-pick -x').
+// In case of conflicts, the SHA of the commit that couldn't be applied is returned along with the conflicting files.
+func CherryPick(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	committer *api.Signature,
+	targetSHA sha.SHA,
+	commitSHAs []sha.SHA,
+	recordOrigin bool,
+) (resultSHA sha.SHA, conflictSHA sha.SHA, conflicts []string, err error) {
+	return pickCommits(ctx, s, committer, targetSHA, commitSHAs, recordOrigin)
+}
+
+// pickCommits applies the changes introduced by each of the commits, one by one, on top of the targetSHA.
+// Commits that would be empty after being applied are dropped.
+//
+//nolint:gocognit // refactor if needed.
+func pickCommits(
+	ctx context.Context,
+	s *sharedrepo.SharedRepo,
+	committer *api.Signature,
+	targetSHA sha.SHA,
+	commitSHAs []sha.SHA,
+	recordOrigin bool,
+) (resultSHA sha.SHA, conflictSHA sha.SHA, conflicts []string, err error) {
 	lastCommitSHA := targetSHA
 	lastTreeSHA, err := s.GetTreeSHA(ctx, targetSHA.String())
 	if err != nil {
-		return sha.None, nil, fmt.Errorf("failed to get tree sha for target: %w", err)
+		return sha.None, sha.None, nil, fmt.Errorf("failed to get tree sha for target: %w", err)
 	}
 
-	for _, commitSHA := range sourceSHAs {
+	for _, commitSHA := range commitSHAs {
 		var treeSHA sha.SHA
 
 		commitInfo, err := api.GetCommit(ctx, s.Directory(), commitSHA)
 		if err != nil {
-			return sha.None, nil, fmt.Errorf("failed to get commit data in rebase merge: %w", err)
+			return sha.None, sha.None, nil, fmt.Errorf("failed to get commit data: %w", err)
 		}
 
-		// rebase merge preserves the commit author (and date) and the commit message, but changes the committer.
+		// the commit author (and date) and the commit message are preserved, but the committer is changed.
 		author := &commitInfo.Author
 		message := commitInfo.Title
 		if commitInfo.Message != "" {
 			message += "\n\n" + commitInfo.Message
 		}
+		if recordOrigin {
+			message += "\n\n(cherry picked from commit " + commitSHA.String() + ")"
+		}
 
 		var mergeTreeMergeBaseSHA sha.SHA
 		if len(commitInfo.ParentSHAs) > 0 {
@@ -138,19 +174,19 @@ func Rebase(
 			// See example usage of when --merge-base was introduced:
 			// https://github.com/git/git/commit/66265a693e8deb3ab86577eb7f69940410044081
 			//
-			// NOTE: CommitSHAsForRebase only returns non-merge commits.
+			// NOTE: Callers must provide only non-merge commits.
 			mergeTreeMergeBaseSHA = commitInfo.ParentSHAs[0]
 		}
 
 		treeSHA, conflicts, err = s.MergeTree(ctx, mergeTreeMergeBaseSHA, lastCommitSHA, commitSHA)
 		if err != nil {
-			return sha.None, nil, fmt.Errorf("failed to merge tree in rebase merge: %w", err)
+			return sha.None, sha.None, nil, fmt.Errorf("failed to merge tree: %w", err)
 		}
 		if len(conflicts) > 0 {
-			return sha.None, conflicts, nil
+			return sha.None, commitSHA, conflicts, nil
 		}
 
-		// Drop any commit which after being rebased would be empty.
+		// Drop any commit which after being applied would be empty.
 		// There's two cases in which that can happen:
 		// 1. Empty commit.
 		//    Github is dropping empty commits, so we'll do the same.
@@ -158,20 +194,18 @@ func Rebase(
 		//    Git's `git rebase` is dropping such commits on default (and so does Github)
 		//    https://git-scm.com/docs/git-rebase#Documentation/git-rebase.txt---emptydropkeepask
 		if treeSHA.Equal(lastTreeSHA) {
-			log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after rebase", commitSHA)
+			log.Ctx(ctx).Debug().Msgf("skipping commit %s as it's empty after being applied", commitSHA)
 			continue
 		}
 
-		lastCommitSHA, err = s.CommitTree(ctx, author, params.Committer, treeSHA, message, false, lastCommitSHA)
+		lastCommitSHA, err = s.CommitTree(ctx, author, committer, treeSHA, message, false, lastCommitSHA)
 		if err != nil {
-			return sha.None, nil, fmt.Errorf("failed to commit tree in rebase merge: %w", err)
+			return sha.None, sha.None, nil, fmt.Errorf("failed to commit tree: %w", err)
 		}
 		lastTreeSHA = treeSHA
 	}
 
-	mergeSHA = lastCommitSHA
-
-	return mergeSHA, nil, nil
+	return lastCommitSHA, sha.None, nil, nil
 }
 
 // FastForward points the is internal implementation of merge used for Merge and Squash methods.
@@ -0,0 +1,435 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/api"
+	"github.com/harness/gitness/git/enum"
+	"github.com/harness/gitness/git/hook"
+	"github.com/harness/gitness/git/parser"
+	"github.com/harness/gitness/git/sha"
+	"github.com/harness/gitness/git/sharedrepo"
+)
+
+const (
+	filePermissionExecutable = "100755"
+)
+
+// MergeConflictsParams is input structure object for the merge conflicts operation.
+type MergeConflictsParams struct {
+	ReadParams
+
+	// SourceSHA is the commit into which the TargetSHA gets merged (the source branch of a pull request).
+	SourceSHA sha.SHA
+	// TargetSHA is the commit which gets merged into the SourceSHA (the target branch of a pull request).
+	TargetSHA sha.SHA
+
+	// FileSizeLimit is the max number of bytes returned per file version. Larger files are truncated.
+	FileSizeLimit int64
+}
+
+func (p *MergeConflictsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceSHA.IsEmpty() {
+		return errors.InvalidArgument("source commit SHA is mandatory")
+	}
+
+	if p.TargetSHA.IsEmpty() {
+		return errors.InvalidArgument("target commit SHA is mandatory")
+	}
+
+	return nil
+}
+
+// MergeConflictFileVersion is a version of a conflicted file.
+type MergeConflictFileVersion struct {
+	SHA  sha.SHA
+	Mode string
+	// Size is the actual size of the file.
+	Size int64
+	// Content contains the (partial) content of the file, see MergeConflictsParams.FileSizeLimit.
+	Content []byte
+}
+
+// MergeConflictFile holds all versions of a conflicted file.
+// A version is nil if the file doesn't exist in the corresponding commit.
+type MergeConflictFile struct {
+	Path string
+
+	Base   *MergeConflictFileVersion
+	Source *MergeConflictFileVersion
+	Target *MergeConflictFileVersion
+
+	// Merged is the file with conflict markers, as produced by git merge.
+	Merged *MergeConflictFileVersion
+}
+
+type MergeConflictsOutput struct {
+	MergeBaseSHA sha.SHA
+	Files        []MergeConflictFile
+}
+
+// MergeConflicts returns the files that are in conflict when merging the target commit into the source commit.
+// For every file the content of the merge base, of both sides and the content with the conflict markers is returned.
+func (s *Service) MergeConflicts(ctx context.Context, params *MergeConflictsParams) (MergeConflictsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	mergeBaseSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "",
+		params.SourceSHA.String(), params.TargetSHA.String(), false)
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	var files []MergeConflictFile
+
+	// The operation is read-only, the shared repository is used to avoid writing of the merge tree to the repository.
+	err = sharedrepo.Run(ctx, nil, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		treeSHA, conflicts, err := r.MergeTree(ctx, mergeBaseSHA, params.SourceSHA, params.TargetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to merge tree: %w", err)
+		}
+
+		files = make([]MergeConflictFile, len(conflicts))
+		for i, path := range conflicts {
+			files[i].Path = path
+
+			versions := []struct {
+				rev     sha.SHA
+				version **MergeConflictFileVersion
+			}{
+				{rev: mergeBaseSHA, version: &files[i].Base},
+				{rev: params.SourceSHA, version: &files[i].Source},
+				{rev: params.TargetSHA, version: &files[i].Target},
+				{rev: treeSHA, version: &files[i].Merged},
+			}
+
+			for _, v := range versions {
+				*v.version, err = readMergeConflictFileVersion(ctx, r.Directory(), v.rev, path, params.FileSizeLimit)
+				if err != nil {
+					return fmt.Errorf("failed to read version %s of conflicted file %q: %w", v.rev, path, err)
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return MergeConflictsOutput{}, fmt.Errorf("failed to find merge conflicts of %s and %s in %q: %w",
+			params.SourceSHA, params.TargetSHA, params.RepoUID, err)
+	}
+
+	return MergeConflictsOutput{
+		MergeBaseSHA: mergeBaseSHA,
+		Files:        files,
+	}, nil
+}
+
+// readMergeConflictFileVersion returns the file at the path in the provided tree-ish, or nil if it doesn't exist.
+func readMergeConflictFileVersion(
+	ctx context.Context,
+	repoPath string,
+	treeish sha.SHA,
+	path string,
+	sizeLimit int64,
+) (*MergeConflictFileVersion, error) {
+	node, err := api.GetTreeNode(ctx, repoPath, treeish.String(), path, false)
+	if errors.IsNotFound(err) {
+		return nil, nil //nolint:nilnil // the file doesn't exist in this version
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree node: %w", err)
+	}
+
+	if node.NodeType != api.TreeNodeTypeBlob {
+		return nil, nil //nolint:nilnil // not a file (submodule or directory) - no content
+	}
+
+	blob, err := api.GetBlob(ctx, repoPath, nil, node.SHA, sizeLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	defer blob.Content.Close()
+
+	content, err := io.ReadAll(blob.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read blob content: %w", err)
+	}
+
+	mode := filePermissionDefault
+	if node.IsExecutable() {
+		mode = filePermissionExecutable
+	}
+
+	return &MergeConflictFileVersion{
+		SHA:     node.SHA,
+		Mode:    mode,
+		Size:    blob.Size,
+		Content: content,
+	}, nil
+}
+
+// ResolvedFile is the resolution of a conflicted file.
+type ResolvedFile struct {
+	Path    string
+	Content []byte
+	// Delete removes the file from the merge commit. Content is ignored.
+	Delete bool
+}
+
+// ResolveMergeConflictsParams is input structure object for the resolve merge conflicts operation.
+type ResolveMergeConflictsParams struct {
+	WriteParams
+
+	// SourceBranch is the branch into which the TargetSHA gets merged.
+	SourceBranch string
+	// SourceExpectedSHA is the expected latest commit of the SourceBranch.
+	SourceExpectedSHA sha.SHA
+	// TargetSHA is the commit which gets merged into the SourceBranch.
+	TargetSHA sha.SHA
+
+	// Files must contain the resolution of every conflicted file.
+	Files []ResolvedFile
+
+	Message string
+
+	// Committer overwrites the git committer used for committing the files
+	// (optional, default: actor)
+	Committer *Identity
+	// CommitterDate overwrites the git committer date used for committing the files
+	// (optional, default: current time on server)
+	CommitterDate *time.Time
+	// Author overwrites the git author used for committing the files
+	// (optional, default: committer)
+	Author *Identity
+	// AuthorDate overwrites the git author date used for committing the files
+	// (optional, default: committer date)
+	AuthorDate *time.Time
+}
+
+func (p *ResolveMergeConflictsParams) Validate() error {
+	if err := p.WriteParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.SourceBranch == "" {
+		return errors.InvalidArgument("source branch is mandatory")
+	}
+
+	if p.SourceExpectedSHA.IsEmpty() {
+		return errors.InvalidArgument("source branch expected SHA is mandatory")
+	}
+
+	if p.TargetSHA.IsEmpty() {
+		return errors.InvalidArgument("target commit SHA is mandatory")
+	}
+
+	if p.Message == "" {
+		return errors.InvalidArgument("commit message is empty")
+	}
+
+	if len(p.Files) == 0 {
+		return errors.InvalidArgument("resolved files are missing")
+	}
+
+	return nil
+}
+
+type ResolveMergeConflictsOutput struct {
+	// CommitSHA is the SHA of the created merge commit, the new head of the SourceBranch.
+	CommitSHA sha.SHA
+}
+
+// ResolveMergeConflicts merges the target commit into the source branch using the provided resolutions
+// of the conflicted files. All conflicted files must be resolved. The created merge commit has
+// the latest commit of the source branch as the first parent and the target commit as the second parent.
+//
+//nolint:gocognit // refactor if needed.
+func (s *Service) ResolveMergeConflicts(
+	ctx context.Context,
+	params *ResolveMergeConflictsParams,
+) (ResolveMergeConflictsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("params not valid: %w", err)
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	sourceRef, err := GetRefPath(params.SourceBranch, enum.RefTypeBranch)
+	if err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("failed to generate source branch ref name: %w", err)
+	}
+
+	sourceSHA, err := s.git.GetRef(ctx, repoPath, sourceRef)
+	if err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("failed to get source branch: %w", err)
+	}
+
+	if !sourceSHA.Equal(params.SourceExpectedSHA) {
+		return ResolveMergeConflictsOutput{}, errors.PreconditionFailedf(
+			"source branch %s is on SHA '%s' which doesn't match expected SHA '%s'.",
+			params.SourceBranch, sourceSHA, params.SourceExpectedSHA)
+	}
+
+	mergeBaseSHA, _, err := s.git.GetMergeBase(ctx, repoPath, "",
+		sourceSHA.String(), params.TargetSHA.String(), false)
+	if err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	if mergeBaseSHA.Equal(params.TargetSHA) {
+		return ResolveMergeConflictsOutput{}, errors.InvalidArgument(
+			"the source branch already contains the target commit")
+	}
+
+	// Set the author and the committer. The rules for setting these are the same as for the Merge method.
+
+	now := time.Now().UTC()
+
+	committer := api.Signature{Identity: api.Identity(params.Actor), When: now}
+
+	if params.Committer != nil {
+		committer.Identity = api.Identity(*params.Committer)
+	}
+	if params.CommitterDate != nil {
+		committer.When = *params.CommitterDate
+	}
+
+	author := committer
+
+	if params.Author != nil {
+		author.Identity = api.Identity(*params.Author)
+	}
+	if params.AuthorDate != nil {
+		author.When = *params.AuthorDate
+	}
+
+	message := parser.CleanUpWhitespace(params.Message)
+
+	refUpdater, err := hook.CreateRefUpdater(s.hookClientFactory, params.EnvVars, repoPath)
+	if err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("failed to create reference updater: %w", err)
+	}
+
+	var commitSHA sha.SHA
+
+	err = sharedrepo.Run(ctx, refUpdater, s.sharedRepoRoot, repoPath, func(r *sharedrepo.SharedRepo) error {
+		treeSHA, conflicts, err := r.MergeTree(ctx, mergeBaseSHA, sourceSHA, params.TargetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to merge tree: %w", err)
+		}
+
+		if len(conflicts) == 0 {
+			return errors.InvalidArgument("there are no conflicts to resolve")
+		}
+
+		resolved := make(map[string]ResolvedFile, len(params.Files))
+		for _, file := range params.Files {
+			resolved[file.Path] = file
+		}
+
+		for _, path := range conflicts {
+			if _, ok := resolved[path]; !ok {
+				return errors.InvalidArgumentf("conflicted file %q is not resolved", path)
+			}
+		}
+
+		if len(resolved) != len(conflicts) {
+			return errors.InvalidArgument("only the conflicted files can be resolved")
+		}
+
+		// The merged tree contains all non-conflicted changes,
+		// the conflicted files (with the conflict markers) are replaced with the resolved content.
+		if err := r.SetIndex(ctx, treeSHA); err != nil {
+			return fmt.Errorf("failed to set merged tree index: %w", err)
+		}
+
+		for _, path := range conflicts {
+			file := resolved[path]
+
+			if file.Delete {
+				if err := r.RemoveFilesFromIndex(ctx, path); err != nil {
+					return fmt.Errorf("failed to remove resolved file %q: %w", path, err)
+				}
+				continue
+			}
+
+			// The resolved file keeps the mode of the merged file, only the tree entry is needed for it.
+			mode := filePermissionDefault
+			node, err := api.GetTreeNode(ctx, r.Directory(), treeSHA.String(), path, false)
+			if err != nil && !errors.IsNotFound(err) {
+				return fmt.Errorf("failed to get merged file %q: %w", path, err)
+			}
+			if err == nil && node.IsExecutable() {
+				mode = filePermissionExecutable
+			}
+
+			objectSHA, err := r.WriteGitObject(ctx, bytes.NewReader(file.Content))
+			if err != nil {
+				return fmt.Errorf("failed to write resolved file %q: %w", path, err)
+			}
+
+			if err := r.AddObjectToIndex(ctx, mode, objectSHA, path); err != nil {
+				return fmt.Errorf("failed to add resolved file %q: %w", path, err)
+			}
+		}
+
+		resolvedTreeSHA, err := r.WriteTree(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to write resolved tree: %w", err)
+		}
+
+		commitSHA, err = r.CommitTree(ctx, &author, &committer, resolvedTreeSHA, message, false,
+			sourceSHA, params.TargetSHA)
+		if err != nil {
+			return fmt.Errorf("failed to create merge commit: %w", err)
+		}
+
+		err = refUpdater.Init(ctx, []hook.ReferenceUpdate{
+			{
+				Ref: sourceRef,
+				Old: sourceSHA,
+				New: commitSHA,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to init value of the source branch reference %s: %w", sourceRef, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return ResolveMergeConflictsOutput{}, fmt.Errorf("failed to resolve merge conflicts of %q in %q: %w",
+			params.SourceBranch, params.RepoUID, err)
+	}
+
+	return ResolveMergeConflictsOutput{
+		CommitSHA: commitSHA,
+	}, nil
+}
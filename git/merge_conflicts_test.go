@@ -0,0 +1,293 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"testing"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mergeConflictsSetup creates the merge base, the source and the target commit of a merge.
+type mergeConflictsSetup func(r *testRepo) (base, source, target sha.SHA)
+
+func setupContentConflict(r *testRepo) (sha.SHA, sha.SHA, sha.SHA) {
+	base := r.commitWithExecutables(sha.None, map[string]string{"a.sh": "base\n", "b.txt": "b\n"},
+		[]string{"a.sh"}, "base")
+	source := r.commitWithExecutables(base, map[string]string{"a.sh": "source\n", "b.txt": "b\n"},
+		[]string{"a.sh"}, "source")
+	target := r.commitWithExecutables(base, map[string]string{"a.sh": "target\n", "b.txt": "b2\n"},
+		[]string{"a.sh"}, "target")
+	return base, source, target
+}
+
+func setupAddAddConflict(r *testRepo) (sha.SHA, sha.SHA, sha.SHA) {
+	base := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "base")
+	source := r.commit(base, map[string]string{"a.txt": "a\n", "new.txt": "source\n"}, "source")
+	target := r.commit(base, map[string]string{"a.txt": "a\n", "new.txt": "target\n"}, "target")
+	return base, source, target
+}
+
+func setupDeleteModifyConflict(r *testRepo) (sha.SHA, sha.SHA, sha.SHA) {
+	base := r.commit(sha.None, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "base")
+	source := r.commit(base, map[string]string{"b.txt": "b\n"}, "source")
+	target := r.commit(base, map[string]string{"a.txt": "target\n", "b.txt": "b\n"}, "target")
+	return base, source, target
+}
+
+func TestService_MergeConflicts(t *testing.T) {
+	skipWithoutMergeTree(t)
+
+	version := func(content string) *MergeConflictFileVersion {
+		return &MergeConflictFileVersion{Mode: filePermissionDefault, Size: int64(len(content)), Content: []byte(content)}
+	}
+	executable := func(content string) *MergeConflictFileVersion {
+		v := version(content)
+		v.Mode = filePermissionExecutable
+		return v
+	}
+
+	tests := []struct {
+		name          string
+		setup         mergeConflictsSetup
+		fileSizeLimit int64
+		want          []MergeConflictFile
+	}{
+		{
+			name:  "content",
+			setup: setupContentConflict,
+			want: []MergeConflictFile{{
+				Path:   "a.sh",
+				Base:   executable("base\n"),
+				Source: executable("source\n"),
+				Target: executable("target\n"),
+			}},
+		},
+		{
+			name:          "content truncated",
+			setup:         setupContentConflict,
+			fileSizeLimit: 3,
+			want: []MergeConflictFile{{
+				Path:   "a.sh",
+				Base:   &MergeConflictFileVersion{Mode: filePermissionExecutable, Size: 5, Content: []byte("bas")},
+				Source: &MergeConflictFileVersion{Mode: filePermissionExecutable, Size: 7, Content: []byte("sou")},
+				Target: &MergeConflictFileVersion{Mode: filePermissionExecutable, Size: 7, Content: []byte("tar")},
+			}},
+		},
+		{
+			name:  "add/add",
+			setup: setupAddAddConflict,
+			want: []MergeConflictFile{{
+				Path:   "new.txt",
+				Source: version("source\n"),
+				Target: version("target\n"),
+			}},
+		},
+		{
+			name:  "delete/modify",
+			setup: setupDeleteModifyConflict,
+			want: []MergeConflictFile{{
+				Path:   "a.txt",
+				Base:   version("a\n"),
+				Target: version("target\n"),
+			}},
+		},
+		{
+			name: "no conflicts",
+			setup: func(r *testRepo) (sha.SHA, sha.SHA, sha.SHA) {
+				base := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "base")
+				source := r.commit(base, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "source")
+				target := r.commit(base, map[string]string{"a.txt": "a2\n"}, "target")
+				return base, source, target
+			},
+			want: []MergeConflictFile{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, repo := newTestService(t)
+			base, source, target := test.setup(repo)
+
+			fileSizeLimit := test.fileSizeLimit
+			if fileSizeLimit == 0 {
+				fileSizeLimit = 1024
+			}
+
+			out, err := s.MergeConflicts(context.Background(), &MergeConflictsParams{
+				ReadParams:    ReadParams{RepoUID: testRepoUID},
+				SourceSHA:     source,
+				TargetSHA:     target,
+				FileSizeLimit: fileSizeLimit,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, base, out.MergeBaseSHA)
+			require.Len(t, out.Files, len(test.want))
+
+			for i, want := range test.want {
+				got := out.Files[i]
+				assert.Equal(t, want.Path, got.Path)
+
+				for _, v := range []struct {
+					name      string
+					want, got *MergeConflictFileVersion
+				}{
+					{name: "base", want: want.Base, got: got.Base},
+					{name: "source", want: want.Source, got: got.Source},
+					{name: "target", want: want.Target, got: got.Target},
+				} {
+					if v.want == nil {
+						assert.Nil(t, v.got, v.name)
+						continue
+					}
+
+					require.NotNil(t, v.got, v.name)
+					assert.False(t, v.got.SHA.IsEmpty(), v.name)
+					assert.Equal(t, v.want.Mode, v.got.Mode, v.name)
+					assert.Equal(t, v.want.Size, v.got.Size, v.name)
+					assert.Equal(t, string(v.want.Content), string(v.got.Content), v.name)
+				}
+
+				require.NotNil(t, got.Merged)
+			}
+		})
+	}
+}
+
+func TestService_ResolveMergeConflicts(t *testing.T) {
+	skipWithoutMergeTree(t)
+
+	const sourceBranch = "source"
+
+	tests := []struct {
+		name  string
+		setup mergeConflictsSetup
+		files []ResolvedFile
+		// wantFiles maps the file name to the expected mode and content, an empty mode means the file is deleted.
+		wantFiles map[string][2]string
+		wantErr   func(error) bool
+	}{
+		{
+			name:  "content keeps the file mode",
+			setup: setupContentConflict,
+			files: []ResolvedFile{{Path: "a.sh", Content: []byte("resolved\n")}},
+			wantFiles: map[string][2]string{
+				"a.sh":  {filePermissionExecutable, "resolved"},
+				"b.txt": {filePermissionDefault, "b2"},
+			},
+		},
+		{
+			name:      "add/add",
+			setup:     setupAddAddConflict,
+			files:     []ResolvedFile{{Path: "new.txt", Content: []byte("both\n")}},
+			wantFiles: map[string][2]string{"new.txt": {filePermissionDefault, "both"}},
+		},
+		{
+			name:      "delete/modify deleted",
+			setup:     setupDeleteModifyConflict,
+			files:     []ResolvedFile{{Path: "a.txt", Delete: true}},
+			wantFiles: map[string][2]string{"a.txt": {"", ""}, "b.txt": {filePermissionDefault, "b"}},
+		},
+		{
+			name:      "delete/modify kept",
+			setup:     setupDeleteModifyConflict,
+			files:     []ResolvedFile{{Path: "a.txt", Content: []byte("kept\n")}},
+			wantFiles: map[string][2]string{"a.txt": {filePermissionDefault, "kept"}},
+		},
+		{
+			name:    "conflict not resolved",
+			setup:   setupAddAddConflict,
+			files:   []ResolvedFile{{Path: "a.txt", Content: []byte("a\n")}},
+			wantErr: errors.IsInvalidArgument,
+		},
+		{
+			name:  "non-conflicted file resolved",
+			setup: setupAddAddConflict,
+			files: []ResolvedFile{
+				{Path: "new.txt", Content: []byte("both\n")},
+				{Path: "a.txt", Content: []byte("a2\n")},
+			},
+			wantErr: errors.IsInvalidArgument,
+		},
+		{
+			name: "no conflicts",
+			setup: func(r *testRepo) (sha.SHA, sha.SHA, sha.SHA) {
+				base := r.commit(sha.None, map[string]string{"a.txt": "a\n"}, "base")
+				source := r.commit(base, map[string]string{"a.txt": "a\n", "b.txt": "b\n"}, "source")
+				target := r.commit(base, map[string]string{"a.txt": "a2\n"}, "target")
+				return base, source, target
+			},
+			files:   []ResolvedFile{{Path: "a.txt", Content: []byte("a2\n")}},
+			wantErr: errors.IsInvalidArgument,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, repo := newTestService(t)
+			_, source, target := test.setup(repo)
+			repo.run(nil, "update-ref", "refs/heads/"+sourceBranch, source.String())
+
+			out, err := s.ResolveMergeConflicts(context.Background(), &ResolveMergeConflictsParams{
+				WriteParams:       WriteParams{RepoUID: testRepoUID, Actor: testActor},
+				SourceBranch:      sourceBranch,
+				SourceExpectedSHA: source,
+				TargetSHA:         target,
+				Files:             test.files,
+				Message:           "Resolve conflicts",
+			})
+			if test.wantErr != nil {
+				require.Error(t, err)
+				assert.True(t, test.wantErr(err), err.Error())
+				assert.Equal(t, source, repo.ref("refs/heads/"+sourceBranch), "the branch must not be updated")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, out.CommitSHA, repo.ref("refs/heads/"+sourceBranch))
+			assert.Equal(t, source, repo.parent(out.CommitSHA))
+			assert.Equal(t, target, repo.ref(out.CommitSHA.String()+"^2"))
+
+			for name, want := range test.wantFiles {
+				assert.Equal(t, want[0], repo.mode(out.CommitSHA, name), name)
+				if want[0] != "" {
+					assert.Equal(t, want[1], repo.file(out.CommitSHA, name), name)
+				}
+			}
+		})
+	}
+}
+
+func TestService_ResolveMergeConflicts_SourceMoved(t *testing.T) {
+	s, repo := newTestService(t)
+	_, source, target := setupAddAddConflict(repo)
+	repo.run(nil, "update-ref", "refs/heads/source", source.String())
+
+	_, err := s.ResolveMergeConflicts(context.Background(), &ResolveMergeConflictsParams{
+		WriteParams:       WriteParams{RepoUID: testRepoUID, Actor: testActor},
+		SourceBranch:      "source",
+		SourceExpectedSHA: target,
+		TargetSHA:         target,
+		Files:             []ResolvedFile{{Path: "new.txt", Content: []byte("both\n")}},
+		Message:           "Resolve conflicts",
+	})
+	require.Error(t, err)
+	assert.True(t, errors.IsPreconditionFailed(err), err.Error())
+}
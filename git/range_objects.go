@@ -0,0 +1,152 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/git/command"
+	"github.com/harness/gitness/git/parser"
+)
+
+type ProcessRangeObjectsParams struct {
+	ReadParams
+	// BaseRev is optional, the objects reachable from it are excluded.
+	// If empty, all objects reachable from Rev are processed.
+	BaseRev string
+	Rev     string
+
+	FindOversizeFilesParams     *FindOversizeFilesParams
+	FindCommitterMismatchParams *FindCommitterMismatchParams
+}
+
+func (p *ProcessRangeObjectsParams) Validate() error {
+	if err := p.ReadParams.Validate(); err != nil {
+		return err
+	}
+
+	if p.Rev == "" {
+		return errors.InvalidArgument("rev cannot be empty")
+	}
+
+	return nil
+}
+
+type ProcessRangeObjectsOutput struct {
+	FindOversizeFilesOutput     *FindOversizeFilesOutput
+	FindCommitterMismatchOutput *FindCommitterMismatchOutput
+}
+
+// ProcessRangeObjects runs the same object checks as ProcessPreReceiveObjects,
+// but on the objects introduced by a range of already stored commits rather than on a quarantined push.
+func (s *Service) ProcessRangeObjects(
+	ctx context.Context,
+	params ProcessRangeObjectsParams,
+) (ProcessRangeObjectsOutput, error) {
+	if err := params.Validate(); err != nil {
+		return ProcessRangeObjectsOutput{}, err
+	}
+
+	if params.FindOversizeFilesParams == nil && params.FindCommitterMismatchParams == nil {
+		return ProcessRangeObjectsOutput{}, nil
+	}
+
+	repoPath := getFullPathForRepo(s.reposRoot, params.RepoUID)
+
+	objects, err := listRangeObjects(ctx, repoPath, params.AlternateObjectDirs, params.BaseRev, params.Rev)
+	if err != nil {
+		return ProcessRangeObjectsOutput{}, err
+	}
+
+	// sort objects in descending order by Size (largest to smallest)
+	slices.SortFunc(objects, func(a, b parser.BatchCheckObject) int {
+		return int(b.Size - a.Size)
+	})
+
+	var output ProcessRangeObjectsOutput
+
+	if params.FindOversizeFilesParams != nil {
+		output.FindOversizeFilesOutput = findOversizeFiles(objects, params.FindOversizeFilesParams)
+	}
+
+	if params.FindCommitterMismatchParams != nil {
+		out, err := findCommitterMismatch(
+			ctx,
+			objects,
+			repoPath,
+			params.AlternateObjectDirs,
+			params.FindCommitterMismatchParams,
+		)
+		if err != nil {
+			return ProcessRangeObjectsOutput{}, err
+		}
+		output.FindCommitterMismatchOutput = out
+	}
+
+	return output, nil
+}
+
+// listRangeObjects returns type and size of all objects reachable from rev, but not from baseRev.
+func listRangeObjects(
+	ctx context.Context,
+	repoPath string,
+	alternateObjectDirs []string,
+	baseRev string,
+	rev string,
+) ([]parser.BatchCheckObject, error) {
+	revList := command.New("rev-list",
+		command.WithFlag("--objects"),
+		command.WithFlag("--no-object-names"),
+		command.WithArg(rev),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+	if baseRev != "" {
+		revList.Add(command.WithArg("^" + baseRev))
+	}
+
+	shas := bytes.NewBuffer(nil)
+	if err := revList.Run(ctx, command.WithDir(repoPath), command.WithStdout(shas)); err != nil {
+		return nil, fmt.Errorf("failed to list objects of the commit range: %w", err)
+	}
+
+	catFile := command.New("cat-file",
+		command.WithFlag("--batch-check"),
+		command.WithAlternateObjectDirs(alternateObjectDirs...),
+	)
+
+	buffer := bytes.NewBuffer(nil)
+	err := catFile.Run(ctx,
+		command.WithDir(repoPath),
+		command.WithStdin(shas),
+		command.WithStdout(buffer),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to cat-file batch check objects of the commit range: %w", err)
+	}
+
+	// the output lines contain no object names, so they can be parsed as NUL separated entries.
+	buffer = bytes.NewBuffer(bytes.ReplaceAll(buffer.Bytes(), []byte{'\n'}, []byte{0}))
+
+	objects, err := parser.CatFileBatchCheckAllObjects(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse output of cat-file batch check: %w", err)
+	}
+
+	return objects, nil
+}
@@ -0,0 +1,101 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package git
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/git/sha"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_ProcessRangeObjects(t *testing.T) {
+	s, repo := newTestService(t)
+
+	base := repo.commit(sha.None, map[string]string{"big.txt": strings.Repeat("a", 500)}, "base")
+	head := repo.commit(base, map[string]string{
+		"big.txt":   strings.Repeat("a", 500),
+		"small.txt": "small",
+		"large.txt": strings.Repeat("b", 200),
+	}, "head")
+
+	tests := []struct {
+		name             string
+		baseRev          string
+		sizeLimits       []int64
+		principalEmail   string
+		expOversize      map[int64]int64
+		expMismatchTotal int64
+	}{
+		{
+			name:        "only objects of the range",
+			baseRev:     base.String(),
+			sizeLimits:  []int64{100},
+			expOversize: map[int64]int64{100: 1},
+		},
+		{
+			name:        "all objects without base",
+			sizeLimits:  []int64{100, 300},
+			expOversize: map[int64]int64{100: 1, 300: 1},
+		},
+		{
+			name:             "committer mismatch",
+			principalEmail:   "someone@example.com",
+			expMismatchTotal: 2,
+		},
+		{
+			name:           "committer match",
+			principalEmail: testCommitterEmail,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			params := ProcessRangeObjectsParams{
+				ReadParams: ReadParams{RepoUID: testRepoUID},
+				BaseRev:    test.baseRev,
+				Rev:        head.String(),
+			}
+			if test.sizeLimits != nil {
+				params.FindOversizeFilesParams = &FindOversizeFilesParams{
+					SizeLimit:  test.sizeLimits[0],
+					SizeLimits: test.sizeLimits,
+				}
+			}
+			if test.principalEmail != "" {
+				params.FindCommitterMismatchParams = &FindCommitterMismatchParams{
+					PrincipalEmail: test.principalEmail,
+				}
+			}
+
+			out, err := s.ProcessRangeObjects(context.Background(), params)
+			require.NoError(t, err)
+
+			if test.sizeLimits != nil {
+				require.NotNil(t, out.FindOversizeFilesOutput)
+				assert.Equal(t, test.expOversize, out.FindOversizeFilesOutput.TotalsPerLimit)
+			}
+
+			if test.principalEmail != "" {
+				require.NotNil(t, out.FindCommitterMismatchOutput)
+				assert.Equal(t, test.expMismatchTotal, out.FindCommitterMismatchOutput.Total)
+			}
+		})
+	}
+}
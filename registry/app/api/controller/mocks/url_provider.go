@@ -177,6 +177,24 @@ func (_m *Provider) GenerateUIRepoURL(ctx context.Context, repoPath string) stri
 	return r0
 }
 
+// GenerateUISpaceURL provides a mock function with given fields: ctx, spacePath
+func (_m *Provider) GenerateUISpaceURL(ctx context.Context, spacePath string) string {
+	ret := _m.Called(ctx, spacePath)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GenerateUISpaceURL")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, spacePath)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // GetAPIHostname provides a mock function with given fields: ctx
 func (_m *Provider) GetAPIHostname(ctx context.Context) string {
 	ret := _m.Called(ctx)
@@ -522,6 +522,9 @@ func (m *mockURLProvider) GenerateGITCloneSSHURL(_ context.Context, _ string) st
 	return ""
 }
 func (m *mockURLProvider) GenerateUIRepoURL(_ context.Context, _ string) string { return "" }
+func (m *mockURLProvider) GenerateUISpaceURL(_ context.Context, _ string) string {
+	return ""
+}
 func (m *mockURLProvider) GenerateUIPRURL(_ context.Context, _ string, _ int64) string {
 	return ""
 }
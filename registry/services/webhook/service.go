@@ -68,6 +68,7 @@ func NewService(
 	registryRepository registrystore.RegistryRepository,
 	encrypter encrypt.Encrypter,
 	spaceFinder refcache.SpaceFinder,
+	signingKey *gitnesswebhook.SigningKey,
 ) (*Service, error) {
 	if err := config.Prepare(); err != nil {
 		return nil, fmt.Errorf("provided webhook service config is invalid: %w", err)
@@ -77,7 +78,8 @@ func NewService(
 		webhookExecutionStore: webhookExecutionStore,
 	}
 	executor := gitnesswebhook.NewWebhookExecutor(config, webhookURLProvider, encrypter, spacePathStore,
-		secretService, principalStore, webhookExecutorStore, gitnesswebhook.ArtifactRegistryTrigger)
+		secretService, principalStore, webhookExecutorStore, signingKey,
+		gitnesswebhook.ArtifactRegistryTrigger)
 
 	service := &Service{
 		WebhookExecutor:    executor,
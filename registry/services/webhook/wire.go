@@ -53,6 +53,7 @@ func ProvideService(
 	registryRepository registrystore.RegistryRepository,
 	encrypter encrypt.Encrypter,
 	spaceFinder refcache.SpaceFinder,
+	signingKey *gitnesswebhook.SigningKey,
 ) (*Service, error) {
 	gob.Register(&artifact.DockerArtifact{})
 	gob.Register(&artifact.HelmArtifact{})
@@ -72,5 +73,6 @@ func ProvideService(
 		registryRepository,
 		encrypter,
 		spaceFinder,
+		signingKey,
 	)
 }
@@ -0,0 +1,239 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+
+	"github.com/bmatcuk/doublestar/v4"
+)
+
+const maxAutomationNameLength = 256
+
+// Automation performs an action on pull requests of a repository, or of all repositories in a space,
+// when the pull request changes in a way described by the trigger.
+type Automation struct {
+	ID          int64             `json:"id"`
+	SpaceID     *int64            `json:"space_id,omitempty"`
+	RepoID      *int64            `json:"repo_id,omitempty"`
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Disabled    bool              `json:"disabled"`
+	Trigger     AutomationTrigger `json:"trigger"`
+	Action      AutomationAction  `json:"action"`
+	Created     int64             `json:"created"`
+	Updated     int64             `json:"updated"`
+	CreatedBy   int64             `json:"created_by"`
+	UpdatedBy   int64             `json:"updated_by"`
+}
+
+// AutomationTrigger describes when an automation runs.
+// Patterns are used by the files_changed trigger, a pattern without a slash is matched against the file name.
+// LabelID (and optionally ValueID) are used by the label_assigned trigger.
+type AutomationTrigger struct {
+	Type     enum.AutomationTriggerType `json:"type"`
+	Patterns []string                   `json:"patterns,omitempty"`
+	LabelID  *int64                     `json:"label_id,omitempty"`
+	ValueID  *int64                     `json:"value_id,omitempty"`
+}
+
+// AutomationAction describes what an automation does.
+// LabelID and ValueID are used by the assign_label action, the reviewer IDs by the request_review action
+// and the merge method by the enable_auto_merge action.
+type AutomationAction struct {
+	Type                 enum.AutomationActionType `json:"type"`
+	LabelID              *int64                    `json:"label_id,omitempty"`
+	ValueID              *int64                    `json:"value_id,omitempty"`
+	ReviewerIDs          []int64                   `json:"reviewer_ids,omitempty"`
+	UserGroupReviewerIDs []int64                   `json:"user_group_reviewer_ids,omitempty"`
+	MergeMethod          enum.MergeMethod          `json:"merge_method,omitempty"`
+	DeleteSourceBranch   bool                      `json:"delete_source_branch,omitempty"`
+}
+
+// AutomationFilter stores automation query parameters.
+type AutomationFilter struct {
+	ListQueryFilter
+	Inherited bool `json:"inherited,omitempty"`
+
+	// internal use only
+	TriggerType     enum.AutomationTriggerType `json:"-"`
+	ExcludeDisabled bool                       `json:"-"`
+}
+
+type CreateAutomationInput struct {
+	Name        string            `json:"name"`
+	Description string            `json:"description"`
+	Disabled    bool              `json:"disabled"`
+	Trigger     AutomationTrigger `json:"trigger"`
+	Action      AutomationAction  `json:"action"`
+}
+
+func (in *CreateAutomationInput) Sanitize() error {
+	if err := sanitizeAutomationName(&in.Name); err != nil {
+		return err
+	}
+
+	sanitizeDescription(&in.Description)
+
+	if err := in.Trigger.Sanitize(); err != nil {
+		return err
+	}
+
+	return in.Action.Sanitize()
+}
+
+type UpdateAutomationInput struct {
+	Name        *string            `json:"name,omitempty"`
+	Description *string            `json:"description,omitempty"`
+	Disabled    *bool              `json:"disabled,omitempty"`
+	Trigger     *AutomationTrigger `json:"trigger,omitempty"`
+	Action      *AutomationAction  `json:"action,omitempty"`
+}
+
+func (in *UpdateAutomationInput) Sanitize() error {
+	if in.Name != nil {
+		if err := sanitizeAutomationName(in.Name); err != nil {
+			return err
+		}
+	}
+
+	sanitizeDescription(in.Description)
+
+	if in.Trigger != nil {
+		if err := in.Trigger.Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	if in.Action != nil {
+		if err := in.Action.Sanitize(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t *AutomationTrigger) Sanitize() error {
+	var ok bool
+	if t.Type, ok = t.Type.Sanitize(); !ok || t.Type == "" {
+		return errors.InvalidArgumentf("Automation trigger type must be one of: %v",
+			enum.AutomationTriggerType("").Enum())
+	}
+
+	switch t.Type {
+	case enum.AutomationTriggerFilesChanged:
+		if len(t.Patterns) == 0 {
+			return errors.InvalidArgument("At least one file path pattern is required")
+		}
+
+		for i := range t.Patterns {
+			t.Patterns[i] = strings.TrimSpace(t.Patterns[i])
+			if t.Patterns[i] == "" || !doublestar.ValidatePattern(t.Patterns[i]) {
+				return errors.InvalidArgumentf("Invalid file path pattern: %q", t.Patterns[i])
+			}
+		}
+
+		t.LabelID, t.ValueID = nil, nil
+	case enum.AutomationTriggerLabelAssigned:
+		if t.LabelID == nil || *t.LabelID <= 0 {
+			return errors.InvalidArgument("Label ID is required")
+		}
+
+		if t.ValueID != nil && *t.ValueID <= 0 {
+			return errors.InvalidArgument("Label value ID must be a positive integer")
+		}
+
+		t.Patterns = nil
+	}
+
+	return nil
+}
+
+// MatchesFile returns true if the file path matches any of the trigger's file path patterns.
+func (t *AutomationTrigger) MatchesFile(filePath string) bool {
+	for _, pattern := range t.Patterns {
+		target := filePath
+		if !strings.Contains(pattern, "/") {
+			target = path.Base(filePath)
+		}
+
+		if ok, _ := doublestar.Match(pattern, target); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (a *AutomationAction) Sanitize() error {
+	var ok bool
+	if a.Type, ok = a.Type.Sanitize(); !ok || a.Type == "" {
+		return errors.InvalidArgumentf("Automation action type must be one of: %v",
+			enum.AutomationActionType("").Enum())
+	}
+
+	switch a.Type {
+	case enum.AutomationActionAssignLabel:
+		if a.LabelID == nil || *a.LabelID <= 0 {
+			return errors.InvalidArgument("Label ID is required")
+		}
+
+		if a.ValueID != nil && *a.ValueID <= 0 {
+			return errors.InvalidArgument("Label value ID must be a positive integer")
+		}
+
+		a.ReviewerIDs, a.UserGroupReviewerIDs, a.MergeMethod, a.DeleteSourceBranch = nil, nil, "", false
+	case enum.AutomationActionRequestReview:
+		if len(a.ReviewerIDs) == 0 && len(a.UserGroupReviewerIDs) == 0 {
+			return errors.InvalidArgument("At least one reviewer or user group reviewer is required")
+		}
+
+		for _, id := range slices.Concat(a.ReviewerIDs, a.UserGroupReviewerIDs) {
+			if id <= 0 {
+				return errors.InvalidArgument("Reviewer IDs must be positive integers")
+			}
+		}
+
+		a.LabelID, a.ValueID, a.MergeMethod, a.DeleteSourceBranch = nil, nil, "", false
+	case enum.AutomationActionEnableAutoMerge:
+		if a.MergeMethod, ok = a.MergeMethod.Sanitize(); !ok || a.MergeMethod == "" {
+			return errors.InvalidArgumentf("Unsupported merge method: %q", a.MergeMethod)
+		}
+
+		a.LabelID, a.ValueID, a.ReviewerIDs, a.UserGroupReviewerIDs = nil, nil, nil, nil
+	}
+
+	return nil
+}
+
+func sanitizeAutomationName(name *string) error {
+	*name = strings.TrimSpace(*name)
+
+	if *name == "" {
+		return errors.InvalidArgument("Automation name must be provided")
+	}
+
+	if len(*name) > maxAutomationNameLength {
+		return errors.InvalidArgumentf("Automation name can have at most %d characters", maxAutomationNameLength)
+	}
+
+	return nil
+}
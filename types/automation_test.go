@@ -0,0 +1,125 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCreateAutomationInput_Sanitize(t *testing.T) {
+	labelID := int64(1)
+
+	tests := []struct {
+		name    string
+		in      CreateAutomationInput
+		wantErr bool
+	}{
+		{
+			name: "files changed adds label",
+			in: CreateAutomationInput{
+				Name:    "docs",
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerFilesChanged, Patterns: []string{"docs/**"}},
+				Action:  AutomationAction{Type: enum.AutomationActionAssignLabel, LabelID: &labelID},
+			},
+		},
+		{
+			name: "label assigned enables auto merge",
+			in: CreateAutomationInput{
+				Name:    "automerge",
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerLabelAssigned, LabelID: &labelID},
+				Action:  AutomationAction{Type: enum.AutomationActionEnableAutoMerge, MergeMethod: enum.MergeMethodSquash},
+			},
+		},
+		{
+			name: "missing name",
+			in: CreateAutomationInput{
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerLabelAssigned, LabelID: &labelID},
+				Action:  AutomationAction{Type: enum.AutomationActionRequestReview, ReviewerIDs: []int64{2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing trigger type",
+			in: CreateAutomationInput{
+				Name:   "x",
+				Action: AutomationAction{Type: enum.AutomationActionRequestReview, ReviewerIDs: []int64{2}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid pattern",
+			in: CreateAutomationInput{
+				Name:    "x",
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerFilesChanged, Patterns: []string{"docs/["}},
+				Action:  AutomationAction{Type: enum.AutomationActionAssignLabel, LabelID: &labelID},
+			},
+			wantErr: true,
+		},
+		{
+			name: "request review without reviewers",
+			in: CreateAutomationInput{
+				Name:    "x",
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerLabelAssigned, LabelID: &labelID},
+				Action:  AutomationAction{Type: enum.AutomationActionRequestReview},
+			},
+			wantErr: true,
+		},
+		{
+			name: "auto merge without method",
+			in: CreateAutomationInput{
+				Name:    "x",
+				Trigger: AutomationTrigger{Type: enum.AutomationTriggerLabelAssigned, LabelID: &labelID},
+				Action:  AutomationAction{Type: enum.AutomationActionEnableAutoMerge},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.Sanitize()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error: %t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestAutomationTrigger_MatchesFile(t *testing.T) {
+	trigger := AutomationTrigger{
+		Type:     enum.AutomationTriggerFilesChanged,
+		Patterns: []string{"docs/**", "*.md"},
+	}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: "docs/guide/index.html", want: true},
+		{path: "app/README.md", want: true},
+		{path: "app/main.go", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.path, func(t *testing.T) {
+			if got := trigger.MatchesFile(test.path); got != test.want {
+				t.Errorf("expected %t, got %t", test.want, got)
+			}
+		})
+	}
+}
@@ -21,17 +21,28 @@ import (
 )
 
 type CodeOwnerEvaluation struct {
-	EvaluationEntries []CodeOwnerEvaluationEntry `json:"evaluation_entries"`
-	FileSha           string                     `json:"file_sha"`
+	EvaluationEntries []CodeOwnerEvaluationEntry   `json:"evaluation_entries"`
+	Sections          []CodeOwnerSectionEvaluation `json:"sections,omitempty"`
+	FileSha           string                       `json:"file_sha"`
 }
 
 type CodeOwnerEvaluationEntry struct {
 	LineNumber                int64                      `json:"line_number"`
 	Pattern                   string                     `json:"pattern"`
+	Section                   string                     `json:"section,omitempty"`
 	OwnerEvaluations          []OwnerEvaluation          `json:"owner_evaluations"`
 	UserGroupOwnerEvaluations []UserGroupOwnerEvaluation `json:"user_group_owner_evaluations"`
 }
 
+// CodeOwnerSectionEvaluation holds the approval state of a named CODEOWNERS section.
+type CodeOwnerSectionEvaluation struct {
+	Name              string            `json:"name"`
+	Optional          bool              `json:"optional"`
+	RequiredApprovals int               `json:"required_approvals"`
+	Approvers         []OwnerEvaluation `json:"approvers"`
+	ChangeRequested   bool              `json:"change_requested"`
+}
+
 type UserGroupOwnerEvaluation struct {
 	ID          string            `json:"id"`
 	Name        string            `json:"name"`
@@ -343,6 +343,16 @@ type Config struct {
 		// RetentionTime is the duration after which webhook executions will be purged from the DB.
 		RetentionTime  time.Duration `envconfig:"GITNESS_WEBHOOK_RETENTION_TIME" default:"168h"` // 7 days
 		InternalSecret string        `envconfig:"GITNESS_WEBHOOK_INTERNAL_SECRET"`
+
+		// RetryMaxAttempts is the maximum number of redeliveries of a webhook execution that failed with a
+		// retriable error. Redeliveries are postponed using an exponential backoff with jitter.
+		RetryMaxAttempts int           `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_ATTEMPTS" default:"5"`
+		RetryBaseDelay   time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_BASE_DELAY" default:"30s"`
+		RetryMaxDelay    time.Duration `envconfig:"GITNESS_WEBHOOK_RETRY_MAX_DELAY" default:"1h"`
+
+		// AutoDisableThreshold is the number of consecutive failed deliveries after which a webhook is disabled.
+		// A value of 0 disables the auto-disabling of webhooks.
+		AutoDisableThreshold int `envconfig:"GITNESS_WEBHOOK_AUTO_DISABLE_THRESHOLD" default:"50"`
 	}
 
 	Trigger struct {
@@ -393,9 +403,36 @@ type Config struct {
 		Insecure bool   `envconfig:"GITNESS_SMTP_INSECURE"`
 	}
 
+	EmailReply struct {
+		// Enabled allows users to reply to pull request comment notifications via email.
+		Enabled bool `envconfig:"GITNESS_EMAIL_REPLY_ENABLED" default:"false"`
+		// Address is the address receiving the replies. The signed reply token is added as sub-address,
+		// e.g. replies to "reply@example.com" are sent to "reply+<token>@example.com".
+		Address string `envconfig:"GITNESS_EMAIL_REPLY_ADDRESS"`
+		// Secret is used to sign the reply tokens.
+		Secret string `envconfig:"GITNESS_EMAIL_REPLY_SECRET"`
+		// TokenValidity is how long the reply token of a notification email is accepted after it was sent.
+		TokenValidity time.Duration `envconfig:"GITNESS_EMAIL_REPLY_TOKEN_VALIDITY" default:"720h"` // 30 days
+		// ListenAddress is the address of the SMTP listener receiving the replies.
+		ListenAddress string `envconfig:"GITNESS_EMAIL_REPLY_LISTEN_ADDRESS" default:":2525"`
+		// MaxMessageSize is the maximum size of an accepted reply in bytes.
+		MaxMessageSize int64 `envconfig:"GITNESS_EMAIL_REPLY_MAX_MESSAGE_SIZE" default:"10485760"` // 10 MiB
+		// MaxConnections is the maximum number of SMTP connections served concurrently.
+		MaxConnections int `envconfig:"GITNESS_EMAIL_REPLY_MAX_CONNECTIONS" default:"100"`
+	}
+
 	Notification struct {
 		MaxRetries  int `envconfig:"GITNESS_NOTIFICATION_MAX_RETRIES" default:"3"`
 		Concurrency int `envconfig:"GITNESS_NOTIFICATION_CONCURRENCY" default:"4"`
+
+		// DigestDailyCron is the cron schedule of the daily notification digest emails.
+		DigestDailyCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_DAILY_CRON" default:"0 8 * * *"`
+		// DigestWeeklyCron is the cron schedule of the weekly notification digest emails.
+		DigestWeeklyCron string `envconfig:"GITNESS_NOTIFICATION_DIGEST_WEEKLY_CRON" default:"0 8 * * 1"`
+
+		// TemplatesDir is a directory with templates overriding the built-in templates of notification emails.
+		// Templates are named after the email: <name>.subject, and <name>.html or <name>.txt for the body.
+		TemplatesDir string `envconfig:"GITNESS_NOTIFICATION_TEMPLATES_DIR"`
 	}
 
 	KeywordSearch struct {
@@ -0,0 +1,63 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// AutomationTriggerType defines the pull request change that triggers an automation.
+type AutomationTriggerType string
+
+func (AutomationTriggerType) Enum() []any { return toInterfaceSlice(automationTriggerTypes) }
+func (t AutomationTriggerType) Sanitize() (AutomationTriggerType, bool) {
+	return Sanitize(t, GetAllAutomationTriggerTypes)
+}
+func GetAllAutomationTriggerTypes() ([]AutomationTriggerType, AutomationTriggerType) {
+	return automationTriggerTypes, ""
+}
+
+// AutomationTriggerType enumeration.
+const (
+	// AutomationTriggerFilesChanged triggers when a pull request changes files matching the patterns.
+	AutomationTriggerFilesChanged AutomationTriggerType = "files_changed"
+	// AutomationTriggerLabelAssigned triggers when the label is assigned to a pull request.
+	AutomationTriggerLabelAssigned AutomationTriggerType = "label_assigned"
+)
+
+var automationTriggerTypes = sortEnum([]AutomationTriggerType{
+	AutomationTriggerFilesChanged,
+	AutomationTriggerLabelAssigned,
+})
+
+// AutomationActionType defines what an automation does with the pull request.
+type AutomationActionType string
+
+func (AutomationActionType) Enum() []any { return toInterfaceSlice(automationActionTypes) }
+func (t AutomationActionType) Sanitize() (AutomationActionType, bool) {
+	return Sanitize(t, GetAllAutomationActionTypes)
+}
+func GetAllAutomationActionTypes() ([]AutomationActionType, AutomationActionType) {
+	return automationActionTypes, ""
+}
+
+// AutomationActionType enumeration.
+const (
+	AutomationActionAssignLabel     AutomationActionType = "assign_label"
+	AutomationActionRequestReview   AutomationActionType = "request_review"
+	AutomationActionEnableAutoMerge AutomationActionType = "enable_auto_merge"
+)
+
+var automationActionTypes = sortEnum([]AutomationActionType{
+	AutomationActionAssignLabel,
+	AutomationActionRequestReview,
+	AutomationActionEnableAutoMerge,
+})
@@ -0,0 +1,61 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// MilestoneState defines the state of a milestone.
+type MilestoneState string
+
+func (MilestoneState) Enum() []any { return toInterfaceSlice(milestoneStates) }
+func (s MilestoneState) Sanitize() (MilestoneState, bool) {
+	return Sanitize(s, GetAllMilestoneStates)
+}
+func GetAllMilestoneStates() ([]MilestoneState, MilestoneState) { return milestoneStates, "" }
+
+// MilestoneState enumeration.
+const (
+	MilestoneStateOpen   MilestoneState = "open"
+	MilestoneStateClosed MilestoneState = "closed"
+)
+
+var milestoneStates = sortEnum([]MilestoneState{
+	MilestoneStateOpen,
+	MilestoneStateClosed,
+})
+
+// MilestoneSort defines milestone attribute that can be used for sorting.
+type MilestoneSort string
+
+func (MilestoneSort) Enum() []any { return toInterfaceSlice(milestoneSorts) }
+func (s MilestoneSort) Sanitize() (MilestoneSort, bool) {
+	return Sanitize(s, GetAllMilestoneSorts)
+}
+func GetAllMilestoneSorts() ([]MilestoneSort, MilestoneSort) {
+	return milestoneSorts, MilestoneSortDueDate
+}
+
+// MilestoneSort enumeration.
+const (
+	MilestoneSortDueDate MilestoneSort = "due_date"
+	MilestoneSortTitle   MilestoneSort = "title"
+	MilestoneSortCreated MilestoneSort = "created"
+	MilestoneSortUpdated MilestoneSort = "updated"
+)
+
+var milestoneSorts = sortEnum([]MilestoneSort{
+	MilestoneSortDueDate,
+	MilestoneSortTitle,
+	MilestoneSortCreated,
+	MilestoneSortUpdated,
+})
@@ -0,0 +1,255 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enum
+
+// NotificationEvent defines the types of events users can configure the notification delivery for.
+type NotificationEvent string
+
+func (NotificationEvent) Enum() []any { return toInterfaceSlice(notificationEvents) }
+func (e NotificationEvent) Sanitize() (NotificationEvent, bool) {
+	return Sanitize(e, GetAllNotificationEvents)
+}
+func GetAllNotificationEvents() ([]NotificationEvent, NotificationEvent) {
+	return notificationEvents, ""
+}
+
+// NotificationEvent enumeration.
+const (
+	// NotificationEventPullReqCreated is sent to watchers when a pull request gets created.
+	NotificationEventPullReqCreated NotificationEvent = "pullreq_created"
+	// NotificationEventReviewerAdded is sent when a review of the user is requested.
+	NotificationEventReviewerAdded NotificationEvent = "reviewer_added"
+	// NotificationEventCommentMention is sent when the user is mentioned in a comment.
+	NotificationEventCommentMention NotificationEvent = "comment_mention"
+	// NotificationEventCommentCreated is sent when a comment is added to a pull request
+	// the user authored, participates in or watches.
+	NotificationEventCommentCreated NotificationEvent = "comment_created"
+	// NotificationEventReviewSubmitted is sent when a review is submitted for a pull request of the user.
+	NotificationEventReviewSubmitted NotificationEvent = "review_submitted"
+	// NotificationEventPullReqBranchUpdated is sent to reviewers when the source branch of a pull request is updated.
+	NotificationEventPullReqBranchUpdated NotificationEvent = "pullreq_branch_updated"
+	// NotificationEventPullReqStateChanged is sent when a pull request gets merged, closed or reopened.
+	NotificationEventPullReqStateChanged NotificationEvent = "pullreq_state_changed"
+	// NotificationEventWebhookDisabled is sent when a webhook created by the user gets disabled automatically.
+	NotificationEventWebhookDisabled NotificationEvent = "webhook_disabled"
+)
+
+var notificationEvents = sortEnum([]NotificationEvent{
+	NotificationEventPullReqCreated,
+	NotificationEventReviewerAdded,
+	NotificationEventCommentMention,
+	NotificationEventCommentCreated,
+	NotificationEventReviewSubmitted,
+	NotificationEventPullReqBranchUpdated,
+	NotificationEventPullReqStateChanged,
+	NotificationEventWebhookDisabled,
+})
+
+// SupportsChannels returns true if notifications of the event can be posted to notification channels of spaces.
+// Mentions are addressed to individual users and disabled webhooks aren't related to pull requests.
+func (e NotificationEvent) SupportsChannels() bool {
+	return e != NotificationEventCommentMention && e != NotificationEventWebhookDisabled
+}
+
+// NotificationDelivery defines how notifications of an event are delivered to a user.
+type NotificationDelivery string
+
+func (NotificationDelivery) Enum() []any { return toInterfaceSlice(notificationDeliveries) }
+func (d NotificationDelivery) Sanitize() (NotificationDelivery, bool) {
+	return Sanitize(d, GetAllNotificationDeliveries)
+}
+func GetAllNotificationDeliveries() ([]NotificationDelivery, NotificationDelivery) {
+	return notificationDeliveries, NotificationDeliveryEmail
+}
+
+// IsDigest returns true if the notifications are batched into a periodic digest email.
+func (d NotificationDelivery) IsDigest() bool {
+	return d == NotificationDeliveryDailyDigest || d == NotificationDeliveryWeeklyDigest
+}
+
+// NotificationDelivery enumeration.
+const (
+	NotificationDeliveryEmail        NotificationDelivery = "email"
+	NotificationDeliveryInApp        NotificationDelivery = "in_app"
+	NotificationDeliveryDailyDigest  NotificationDelivery = "daily_digest"
+	NotificationDeliveryWeeklyDigest NotificationDelivery = "weekly_digest"
+	NotificationDeliveryNone         NotificationDelivery = "none"
+)
+
+var notificationDeliveries = sortEnum([]NotificationDelivery{
+	NotificationDeliveryEmail,
+	NotificationDeliveryInApp,
+	NotificationDeliveryDailyDigest,
+	NotificationDeliveryWeeklyDigest,
+	NotificationDeliveryNone,
+})
+
+// NotificationSubscriptionState defines the subscription state of a user for a space, repository or pull request.
+type NotificationSubscriptionState string
+
+func (NotificationSubscriptionState) Enum() []any {
+	return toInterfaceSlice(notificationSubscriptionStates)
+}
+func (s NotificationSubscriptionState) Sanitize() (NotificationSubscriptionState, bool) {
+	return Sanitize(s, GetAllNotificationSubscriptionStates)
+}
+func GetAllNotificationSubscriptionStates() ([]NotificationSubscriptionState, NotificationSubscriptionState) {
+	return notificationSubscriptionStates, NotificationSubscriptionStateWatching
+}
+
+// NotificationSubscriptionState enumeration.
+const (
+	// NotificationSubscriptionStateWatching subscribes the user to all pull request activity of the resource.
+	NotificationSubscriptionStateWatching NotificationSubscriptionState = "watching"
+	// NotificationSubscriptionStateMuted suppresses all notifications of the resource, except mentions.
+	NotificationSubscriptionStateMuted NotificationSubscriptionState = "muted"
+)
+
+var notificationSubscriptionStates = sortEnum([]NotificationSubscriptionState{
+	NotificationSubscriptionStateWatching,
+	NotificationSubscriptionStateMuted,
+})
+
+// NotificationResourceType defines the types of resources users can subscribe to.
+type NotificationResourceType string
+
+func (NotificationResourceType) Enum() []any { return toInterfaceSlice(notificationResourceTypes) }
+func (t NotificationResourceType) Sanitize() (NotificationResourceType, bool) {
+	return Sanitize(t, GetAllNotificationResourceTypes)
+}
+func GetAllNotificationResourceTypes() ([]NotificationResourceType, NotificationResourceType) {
+	return notificationResourceTypes, ""
+}
+
+// NotificationResourceType enumeration.
+const (
+	NotificationResourceTypeSpace   NotificationResourceType = "space"
+	NotificationResourceTypeRepo    NotificationResourceType = "repo"
+	NotificationResourceTypePullReq NotificationResourceType = "pullreq"
+)
+
+var notificationResourceTypes = sortEnum([]NotificationResourceType{
+	NotificationResourceTypeSpace,
+	NotificationResourceTypeRepo,
+	NotificationResourceTypePullReq,
+})
+
+// NotificationChannelType defines the chat platforms notification channels of a space can post to.
+type NotificationChannelType string
+
+func (NotificationChannelType) Enum() []any { return toInterfaceSlice(notificationChannelTypes) }
+func (t NotificationChannelType) Sanitize() (NotificationChannelType, bool) {
+	return Sanitize(t, GetAllNotificationChannelTypes)
+}
+func GetAllNotificationChannelTypes() ([]NotificationChannelType, NotificationChannelType) {
+	return notificationChannelTypes, NotificationChannelTypeGeneric
+}
+
+// NotificationChannelType enumeration.
+const (
+	NotificationChannelTypeSlack      NotificationChannelType = "slack"
+	NotificationChannelTypeTeams      NotificationChannelType = "teams"
+	NotificationChannelTypeMattermost NotificationChannelType = "mattermost"
+	// NotificationChannelTypeGeneric posts a plain JSON document to any incoming webhook.
+	NotificationChannelTypeGeneric NotificationChannelType = "generic"
+)
+
+var notificationChannelTypes = sortEnum([]NotificationChannelType{
+	NotificationChannelTypeSlack,
+	NotificationChannelTypeTeams,
+	NotificationChannelTypeMattermost,
+	NotificationChannelTypeGeneric,
+})
+
+// NotificationTemplate defines the notification emails with a customizable subject and body.
+type NotificationTemplate string
+
+func (NotificationTemplate) Enum() []any { return toInterfaceSlice(notificationTemplates) }
+func (t NotificationTemplate) Sanitize() (NotificationTemplate, bool) {
+	return Sanitize(t, GetAllNotificationTemplates)
+}
+func GetAllNotificationTemplates() ([]NotificationTemplate, NotificationTemplate) {
+	return notificationTemplates, ""
+}
+
+// NotificationTemplate enumeration.
+const (
+	NotificationTemplatePullReqCreated       NotificationTemplate = "pullreq_created"
+	NotificationTemplateReviewerAdded        NotificationTemplate = "reviewer_added"
+	NotificationTemplateCommentPRAuthor      NotificationTemplate = "comment_pr_author"
+	NotificationTemplateCommentMentions      NotificationTemplate = "comment_mentions"
+	NotificationTemplateCommentParticipants  NotificationTemplate = "comment_participants"
+	NotificationTemplatePullReqBranchUpdated NotificationTemplate = "pullreq_branch_updated"
+	NotificationTemplateReviewSubmitted      NotificationTemplate = "review_submitted"
+	NotificationTemplatePullReqStateChanged  NotificationTemplate = "pullreq_state_changed"
+	NotificationTemplateWebhookDisabled      NotificationTemplate = "webhook_disabled"
+	NotificationTemplateDigest               NotificationTemplate = "digest"
+)
+
+var notificationTemplates = sortEnum([]NotificationTemplate{
+	NotificationTemplatePullReqCreated,
+	NotificationTemplateReviewerAdded,
+	NotificationTemplateCommentPRAuthor,
+	NotificationTemplateCommentMentions,
+	NotificationTemplateCommentParticipants,
+	NotificationTemplatePullReqBranchUpdated,
+	NotificationTemplateReviewSubmitted,
+	NotificationTemplatePullReqStateChanged,
+	NotificationTemplateWebhookDisabled,
+	NotificationTemplateDigest,
+})
+
+// NotificationTemplateSource defines where the template of a notification email is loaded from.
+type NotificationTemplateSource string
+
+// NotificationTemplateSource enumeration.
+const (
+	// NotificationTemplateSourceBuiltin is the template compiled into the binary.
+	NotificationTemplateSourceBuiltin NotificationTemplateSource = "builtin"
+	// NotificationTemplateSourceDirectory is a template loaded from the configured templates directory.
+	NotificationTemplateSourceDirectory NotificationTemplateSource = "directory"
+	// NotificationTemplateSourceCustom is a template saved by an admin, it overrides all other templates.
+	NotificationTemplateSourceCustom NotificationTemplateSource = "custom"
+)
+
+// NotificationContentType defines the format of the body of notification emails.
+type NotificationContentType string
+
+func (NotificationContentType) Enum() []any { return toInterfaceSlice(notificationContentTypes) }
+func (t NotificationContentType) Sanitize() (NotificationContentType, bool) {
+	return Sanitize(t, GetAllNotificationContentTypes)
+}
+func GetAllNotificationContentTypes() ([]NotificationContentType, NotificationContentType) {
+	return notificationContentTypes, NotificationContentTypeHTML
+}
+
+// MIMEType returns the MIME type of email bodies with the content type.
+func (t NotificationContentType) MIMEType() string {
+	if t == NotificationContentTypeText {
+		return "text/plain"
+	}
+	return "text/html"
+}
+
+// NotificationContentType enumeration.
+const (
+	NotificationContentTypeHTML NotificationContentType = "html"
+	NotificationContentTypeText NotificationContentType = "text"
+)
+
+var notificationContentTypes = sortEnum([]NotificationContentType{
+	NotificationContentTypeHTML,
+	NotificationContentTypeText,
+})
@@ -58,6 +58,27 @@ var pullReqSubStates = sortEnum([]PullReqSubState{
 	PullReqSubStateAutoMerge,
 })
 
+// PullReqFlow defines how the source of a pull request is provided.
+type PullReqFlow string
+
+func (PullReqFlow) Enum() []any                        { return toInterfaceSlice(pullReqFlows) }
+func (f PullReqFlow) Sanitize() (PullReqFlow, bool)    { return Sanitize(f, GetAllPullReqFlows) }
+func GetAllPullReqFlows() ([]PullReqFlow, PullReqFlow) { return pullReqFlows, PullReqFlowGithub }
+
+// PullReqFlow enumeration.
+const (
+	// PullReqFlowGithub is used for pull requests created from a source branch.
+	PullReqFlowGithub PullReqFlow = "github"
+	// PullReqFlowAGit is used for pull requests created by pushing to refs/for/<target-branch>.
+	// Such pull requests don't have a source branch, the source commit is kept only in the pull request head ref.
+	PullReqFlowAGit PullReqFlow = "agit"
+)
+
+var pullReqFlows = sortEnum([]PullReqFlow{
+	PullReqFlowGithub,
+	PullReqFlowAGit,
+})
+
 // PullReqSort defines pull request attribute that can be used for sorting.
 type PullReqSort string
 
@@ -115,6 +136,11 @@ const (
 	PullReqActivityTypeLabelModify                     PullReqActivityType = "label-modify"
 	PullReqActivityTypeNonUniqueMergeBase              PullReqActivityType = "non-unique-merge-base"
 	PullReqActivityTypeAutoMergeUnsupportedMergeMethod PullReqActivityType = "auto-merge-unsupported-merge-method"
+	PullReqActivityTypeAssigneeAdd                     PullReqActivityType = "assignee-add"
+	PullReqActivityTypeAssigneeDelete                  PullReqActivityType = "assignee-delete"
+	PullReqActivityTypeMilestoneChange                 PullReqActivityType = "milestone-change"
+	PullReqActivityTypeCommand                         PullReqActivityType = "command"
+	PullReqActivityTypeStackRebaseConflict             PullReqActivityType = "stack-rebase-conflict"
 )
 
 var pullReqActivityTypes = sortEnum([]PullReqActivityType{
@@ -135,6 +161,11 @@ var pullReqActivityTypes = sortEnum([]PullReqActivityType{
 	PullReqActivityTypeLabelModify,
 	PullReqActivityTypeNonUniqueMergeBase,
 	PullReqActivityTypeAutoMergeUnsupportedMergeMethod,
+	PullReqActivityTypeAssigneeAdd,
+	PullReqActivityTypeAssigneeDelete,
+	PullReqActivityTypeMilestoneChange,
+	PullReqActivityTypeCommand,
+	PullReqActivityTypeStackRebaseConflict,
 })
 
 // PullReqActivityKind defines kind of pull request activity system message.
@@ -309,3 +340,41 @@ var LabelActivityTypes = sortEnum([]PullReqLabelActivityType{
 	LabelActivityReassign,
 	LabelActivityNoop,
 })
+
+// PullReqReaction defines an emoji reaction to a pull request description or a comment.
+type PullReqReaction string
+
+func (PullReqReaction) Enum() []any {
+	return toInterfaceSlice(pullReqReactions)
+}
+
+func (r PullReqReaction) Sanitize() (PullReqReaction, bool) {
+	return Sanitize(r, GetAllPullReqReactions)
+}
+
+func GetAllPullReqReactions() ([]PullReqReaction, PullReqReaction) {
+	return pullReqReactions, "" // No default value
+}
+
+// PullReqReaction enumeration.
+const (
+	PullReqReactionThumbsUp   PullReqReaction = "thumbs_up"
+	PullReqReactionThumbsDown PullReqReaction = "thumbs_down"
+	PullReqReactionLaugh      PullReqReaction = "laugh"
+	PullReqReactionHooray     PullReqReaction = "hooray"
+	PullReqReactionConfused   PullReqReaction = "confused"
+	PullReqReactionHeart      PullReqReaction = "heart"
+	PullReqReactionRocket     PullReqReaction = "rocket"
+	PullReqReactionEyes       PullReqReaction = "eyes"
+)
+
+var pullReqReactions = sortEnum([]PullReqReaction{
+	PullReqReactionThumbsUp,
+	PullReqReactionThumbsDown,
+	PullReqReactionLaugh,
+	PullReqReactionHooray,
+	PullReqReactionConfused,
+	PullReqReactionHeart,
+	PullReqReactionRocket,
+	PullReqReactionEyes,
+})
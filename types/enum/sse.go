@@ -46,6 +46,8 @@ const (
 	SSETypePullReqCommentStatusResolved    SSEType = "pullreq_comment_status_resolved"
 	SSETypePullReqCommentStatusReactivated SSEType = "pullreq_comment_status_reactivated"
 
+	SSETypePullReqReactionsUpdated SSEType = "pullreq_reactions_updated"
+
 	SSETypePullReqOpened         SSEType = "pullreq_opened"
 	SSETypePullReqClosed         SSEType = "pullreq_closed"
 	SSETypePullReqMarkedAsDraft  SSEType = "pullreq_marked_as_draft"
@@ -87,4 +89,8 @@ const (
 	SSETypeWebhookCreated SSEType = "webhook_created"
 	SSETypeWebhookUpdated SSEType = "webhook_updated"
 	SSETypeWebhookDeleted SSEType = "webhook_deleted"
+
+	// Notifications.
+
+	SSETypeNotificationCreated SSEType = "notification_created"
 )
@@ -147,6 +147,62 @@ var webhookTypes = sortEnum([]WebhookType{
 	WebhookTypeJira,
 })
 
+// WebhookFormat defines the different formats of webhook request bodies.
+type WebhookFormat string
+
+func (WebhookFormat) Enum() []any                       { return toInterfaceSlice(webhookFormats) }
+func (f WebhookFormat) Sanitize() (WebhookFormat, bool) { return Sanitize(f, GetAllWebhookFormats) }
+
+func GetAllWebhookFormats() ([]WebhookFormat, WebhookFormat) {
+	return webhookFormats, WebhookFormatNative
+}
+
+const (
+	// WebhookFormatNative describes a webhook that receives the native JSON payload.
+	WebhookFormatNative WebhookFormat = "native"
+
+	// WebhookFormatSlack describes a webhook that receives a Slack Block Kit message.
+	WebhookFormatSlack WebhookFormat = "slack"
+
+	// WebhookFormatTeams describes a webhook that receives a Microsoft Teams Adaptive Card message.
+	WebhookFormatTeams WebhookFormat = "teams"
+
+	// WebhookFormatDiscord describes a webhook that receives a Discord embed message.
+	WebhookFormatDiscord WebhookFormat = "discord"
+)
+
+var webhookFormats = sortEnum([]WebhookFormat{
+	WebhookFormatNative,
+	WebhookFormatSlack,
+	WebhookFormatTeams,
+	WebhookFormatDiscord,
+})
+
+// WebhookSignatureMode defines how webhook requests are signed.
+type WebhookSignatureMode string
+
+func (WebhookSignatureMode) Enum() []any { return toInterfaceSlice(webhookSignatureModes) }
+func (m WebhookSignatureMode) Sanitize() (WebhookSignatureMode, bool) {
+	return Sanitize(m, GetAllWebhookSignatureModes)
+}
+
+func GetAllWebhookSignatureModes() ([]WebhookSignatureMode, WebhookSignatureMode) {
+	return webhookSignatureModes, WebhookSignatureModeHMAC
+}
+
+const (
+	// WebhookSignatureModeHMAC describes a webhook whose requests are signed with the webhook secret (HMAC-SHA256).
+	WebhookSignatureModeHMAC WebhookSignatureMode = "hmac"
+
+	// WebhookSignatureModeEd25519 describes a webhook whose requests are signed with the Ed25519 key of the instance.
+	WebhookSignatureModeEd25519 WebhookSignatureMode = "ed25519"
+)
+
+var webhookSignatureModes = sortEnum([]WebhookSignatureMode{
+	WebhookSignatureModeHMAC,
+	WebhookSignatureModeEd25519,
+})
+
 // WebhookTrigger defines the different types of webhook triggers available.
 type WebhookTrigger string
 
@@ -196,6 +252,31 @@ const (
 	WebhookTriggerPullReqReviewSubmitted = "pullreq_review_submitted"
 	// WebhookTriggerPullReqTargetBranchChanged gets triggered when a pull request target branch is changed.
 	WebhookTriggerPullReqTargetBranchChanged = "pullreq_target_branch_changed"
+	// WebhookTriggerPullReqReactionCreated gets triggered when a reaction is added
+	// to a pull request description or to a pull request comment.
+	WebhookTriggerPullReqReactionCreated WebhookTrigger = "pullreq_reaction_created"
+
+	// WebhookTriggerExecutionStarted gets triggered when a pipeline execution starts running.
+	WebhookTriggerExecutionStarted WebhookTrigger = "execution_started"
+	// WebhookTriggerExecutionFinished gets triggered when a pipeline execution finishes.
+	WebhookTriggerExecutionFinished WebhookTrigger = "execution_finished"
+
+	// WebhookTriggerCheckReported gets triggered when a status check is reported for a commit.
+	WebhookTriggerCheckReported WebhookTrigger = "check_reported"
+
+	// WebhookTriggerRepoCreated gets triggered when a repository gets created.
+	WebhookTriggerRepoCreated WebhookTrigger = "repo_created"
+	// WebhookTriggerRepoDeleted gets triggered when a repository gets deleted.
+	WebhookTriggerRepoDeleted WebhookTrigger = "repo_deleted"
+	// WebhookTriggerRepoRenamed gets triggered when a repository gets renamed or moved.
+	WebhookTriggerRepoRenamed WebhookTrigger = "repo_renamed"
+	// WebhookTriggerRepoVisibilityChanged gets triggered when public access of a repository changes.
+	WebhookTriggerRepoVisibilityChanged WebhookTrigger = "repo_visibility_changed"
+
+	// WebhookTriggerRuleCreated gets triggered when a protection rule gets created.
+	WebhookTriggerRuleCreated WebhookTrigger = "rule_created"
+	// WebhookTriggerRuleUpdated gets triggered when a protection rule gets updated.
+	WebhookTriggerRuleUpdated WebhookTrigger = "rule_updated"
 
 	// WebhookTriggerArtifactCreated gets triggered when an artifact gets created.
 	WebhookTriggerArtifactCreated WebhookTrigger = "artifact_created"
@@ -222,6 +303,16 @@ var webhookTriggers = sortEnum([]WebhookTrigger{
 	WebhookTriggerPullReqLabelAssigned,
 	WebhookTriggerPullReqReviewSubmitted,
 	WebhookTriggerPullReqTargetBranchChanged,
+	WebhookTriggerPullReqReactionCreated,
+	WebhookTriggerExecutionStarted,
+	WebhookTriggerExecutionFinished,
+	WebhookTriggerCheckReported,
+	WebhookTriggerRepoCreated,
+	WebhookTriggerRepoDeleted,
+	WebhookTriggerRepoRenamed,
+	WebhookTriggerRepoVisibilityChanged,
+	WebhookTriggerRuleCreated,
+	WebhookTriggerRuleUpdated,
 	WebhookTriggerArtifactCreated,
 	WebhookTriggerArtifactDeleted,
 })
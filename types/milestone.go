@@ -0,0 +1,148 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+
+	"github.com/harness/gitness/errors"
+	"github.com/harness/gitness/types/enum"
+)
+
+const maxMilestoneTitleLength = 256
+
+// Milestone groups pull requests of a repository or of all repositories in a space.
+type Milestone struct {
+	ID          int64               `json:"id"`
+	SpaceID     *int64              `json:"space_id,omitempty"`
+	RepoID      *int64              `json:"repo_id,omitempty"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	State       enum.MilestoneState `json:"state"`
+	DueDate     *int64              `json:"due_date,omitempty"`
+	Closed      *int64              `json:"closed,omitempty"`
+	Created     int64               `json:"created"`
+	Updated     int64               `json:"updated"`
+	CreatedBy   int64               `json:"created_by"`
+	UpdatedBy   int64               `json:"updated_by"`
+
+	Progress MilestoneProgress `json:"progress"`
+}
+
+// MilestoneProgress holds the number of pull requests associated with a milestone, by pull request state.
+type MilestoneProgress struct {
+	Open   int64 `json:"open"`
+	Merged int64 `json:"merged"`
+	Closed int64 `json:"closed"`
+	Total  int64 `json:"total"`
+}
+
+// MilestoneInfo is the short version of a milestone, returned as part of a pull request.
+type MilestoneInfo struct {
+	ID      int64               `json:"id"`
+	Title   string              `json:"title"`
+	State   enum.MilestoneState `json:"state"`
+	DueDate *int64              `json:"due_date,omitempty"`
+}
+
+func (m *Milestone) ToMilestoneInfo() *MilestoneInfo {
+	return &MilestoneInfo{
+		ID:      m.ID,
+		Title:   m.Title,
+		State:   m.State,
+		DueDate: m.DueDate,
+	}
+}
+
+// MilestoneFilter stores milestone query parameters.
+type MilestoneFilter struct {
+	ListQueryFilter
+	States    []enum.MilestoneState `json:"state"`
+	Sort      enum.MilestoneSort    `json:"sort"`
+	Order     enum.Order            `json:"order"`
+	Inherited bool                  `json:"inherited,omitempty"`
+}
+
+type CreateMilestoneInput struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DueDate     *int64 `json:"due_date"`
+}
+
+func (in *CreateMilestoneInput) Sanitize() error {
+	if err := sanitizeMilestoneTitle(&in.Title); err != nil {
+		return err
+	}
+
+	sanitizeDescription(&in.Description)
+
+	return sanitizeMilestoneDueDate(in.DueDate)
+}
+
+type UpdateMilestoneInput struct {
+	Title       *string              `json:"title,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	State       *enum.MilestoneState `json:"state,omitempty"`
+	DueDate     *int64               `json:"due_date,omitempty"`
+
+	// RemoveDueDate clears the due date of the milestone.
+	RemoveDueDate bool `json:"remove_due_date,omitempty"`
+}
+
+func (in *UpdateMilestoneInput) Sanitize() error {
+	if in.Title != nil {
+		if err := sanitizeMilestoneTitle(in.Title); err != nil {
+			return err
+		}
+	}
+
+	sanitizeDescription(in.Description)
+
+	if in.State != nil {
+		state, ok := in.State.Sanitize()
+		if !ok {
+			return errors.InvalidArgumentf("Milestone state must be one of: %v", enum.MilestoneState("").Enum())
+		}
+		in.State = &state
+	}
+
+	if in.RemoveDueDate && in.DueDate != nil {
+		return errors.InvalidArgument("Due date can't be provided if it's being removed")
+	}
+
+	return sanitizeMilestoneDueDate(in.DueDate)
+}
+
+func sanitizeMilestoneTitle(title *string) error {
+	*title = strings.TrimSpace(*title)
+
+	if *title == "" {
+		return errors.InvalidArgument("Milestone title must be provided")
+	}
+
+	if len(*title) > maxMilestoneTitleLength {
+		return errors.InvalidArgumentf("Milestone title can have at most %d characters", maxMilestoneTitleLength)
+	}
+
+	return nil
+}
+
+func sanitizeMilestoneDueDate(dueDate *int64) error {
+	if dueDate != nil && *dueDate <= 0 {
+		return errors.InvalidArgument("Milestone due date must be a positive unix timestamp in milliseconds")
+	}
+
+	return nil
+}
@@ -0,0 +1,83 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/harness/gitness/types/enum"
+)
+
+func TestCreateMilestoneInput_Sanitize(t *testing.T) {
+	negative := int64(-1)
+
+	tests := []struct {
+		name      string
+		in        CreateMilestoneInput
+		wantErr   bool
+		wantTitle string
+	}{
+		{name: "trims title", in: CreateMilestoneInput{Title: "  v1.0  "}, wantTitle: "v1.0"},
+		{name: "empty title", in: CreateMilestoneInput{Title: "   "}, wantErr: true},
+		{name: "long title", in: CreateMilestoneInput{Title: strings.Repeat("a", 257)}, wantErr: true},
+		{name: "negative due date", in: CreateMilestoneInput{Title: "v1", DueDate: &negative}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.Sanitize()
+			if test.wantErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if in.Title != test.wantTitle {
+				t.Errorf("expected title %q, got %q", test.wantTitle, in.Title)
+			}
+		})
+	}
+}
+
+func TestUpdateMilestoneInput_Sanitize(t *testing.T) {
+	dueDate := int64(1000)
+	invalid := enum.MilestoneState("archived")
+	closed := enum.MilestoneState("closed")
+
+	tests := []struct {
+		name    string
+		in      UpdateMilestoneInput
+		wantErr bool
+	}{
+		{name: "valid state", in: UpdateMilestoneInput{State: &closed}},
+		{name: "invalid state", in: UpdateMilestoneInput{State: &invalid}, wantErr: true},
+		{name: "due date with remove", in: UpdateMilestoneInput{DueDate: &dueDate, RemoveDueDate: true}, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			in := test.in
+			err := in.Sanitize()
+			if (err != nil) != test.wantErr {
+				t.Errorf("expected error: %t, got: %v", test.wantErr, err)
+			}
+		})
+	}
+}
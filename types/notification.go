@@ -0,0 +1,178 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// NotificationPreference defines how the notifications of an event are delivered to a user.
+type NotificationPreference struct {
+	PrincipalID int64                     `json:"-"`
+	Event       enum.NotificationEvent    `json:"event"`
+	Delivery    enum.NotificationDelivery `json:"delivery"`
+	Updated     int64                     `json:"updated"`
+}
+
+// NotificationPreferenceInput is used to change the delivery of the notifications of an event.
+type NotificationPreferenceInput struct {
+	Event    enum.NotificationEvent    `json:"event"`
+	Delivery enum.NotificationDelivery `json:"delivery"`
+}
+
+// NotificationPreferencesUpdateInput is used to change the notification preferences of a user.
+type NotificationPreferencesUpdateInput struct {
+	Preferences []NotificationPreferenceInput `json:"preferences"`
+}
+
+// NotificationSubscription represents a user watching or muting a space, repository or pull request.
+type NotificationSubscription struct {
+	PrincipalID  int64                              `json:"-"`
+	ResourceType enum.NotificationResourceType      `json:"resource_type"`
+	ResourceID   int64                              `json:"resource_id"`
+	State        enum.NotificationSubscriptionState `json:"state"`
+	Created      int64                              `json:"created"`
+	Updated      int64                              `json:"updated"`
+}
+
+// NotificationSubscriptionInput is used to watch or mute a space, repository or pull request.
+type NotificationSubscriptionInput struct {
+	State enum.NotificationSubscriptionState `json:"state"`
+}
+
+// NotificationSubscriptionFilter stores notification subscription query parameters.
+type NotificationSubscriptionFilter struct {
+	Pagination
+	ResourceType enum.NotificationResourceType `json:"resource_type"`
+}
+
+// Notification represents a notification in the in-app notification inbox of a user.
+// EventID is the ID of the event the notification was created for, a user gets it at most once.
+type Notification struct {
+	ID          int64                  `json:"id"`
+	PrincipalID int64                  `json:"-"`
+	Event       enum.NotificationEvent `json:"event"`
+	EventID     string                 `json:"-"`
+	RepoID      *int64                 `json:"repo_id,omitempty"`
+	PullReqID   *int64                 `json:"pullreq_id,omitempty"`
+	ActorID     *int64                 `json:"actor_id,omitempty"`
+	Title       string                 `json:"title"`
+	URL         string                 `json:"url"`
+	Read        bool                   `json:"read"`
+	Created     int64                  `json:"created"`
+	Updated     int64                  `json:"updated"`
+}
+
+// NotificationFilter stores in-app notification query parameters.
+type NotificationFilter struct {
+	Pagination
+	UnreadOnly bool `json:"unread_only"`
+}
+
+// NotificationMarkReadInput is used to mark in-app notifications as read.
+type NotificationMarkReadInput struct {
+	IDs []int64 `json:"ids"`
+}
+
+// NotificationCount holds the number of unread in-app notifications.
+type NotificationCount struct {
+	Unread int64 `json:"unread"`
+}
+
+// NotificationDigestItem is a notification queued for the next digest email of a user.
+// EventID is the ID of the event the item was queued for, so a redelivered event doesn't list the same change
+// twice in a digest. Items are deleted once their digest is sent, hence it only deduplicates pending items.
+type NotificationDigestItem struct {
+	ID          int64                     `json:"id"`
+	PrincipalID int64                     `json:"principal_id"`
+	Delivery    enum.NotificationDelivery `json:"delivery"`
+	Event       enum.NotificationEvent    `json:"event"`
+	EventID     string                    `json:"-"`
+	RepoID      *int64                    `json:"repo_id,omitempty"`
+	PullReqID   *int64                    `json:"pullreq_id,omitempty"`
+	Subject     string                    `json:"subject"`
+	Title       string                    `json:"title"`
+	URL         string                    `json:"url"`
+	Created     int64                     `json:"created"`
+}
+
+// NotificationChannel is a chat channel of a space that receives the pull request notifications
+// of all repositories in the space and its subspaces.
+type NotificationChannel struct {
+	ID          int64                        `json:"id"`
+	SpaceID     int64                        `json:"space_id"`
+	Identifier  string                       `json:"identifier"`
+	DisplayName string                       `json:"display_name"`
+	Type        enum.NotificationChannelType `json:"type"`
+	// URL is the encrypted incoming webhook URL of the channel. It's never returned as it grants posting access.
+	URL     string                   `json:"-"`
+	Enabled bool                     `json:"enabled"`
+	Events  []enum.NotificationEvent `json:"events"`
+	// RepoPatterns restricts the channel to repositories with a matching path or identifier.
+	RepoPatterns []string `json:"repo_patterns"`
+	// BranchPatterns restricts the channel to pull requests with a matching target branch.
+	BranchPatterns []string `json:"branch_patterns"`
+	CreatedBy      int64    `json:"created_by"`
+	Created        int64    `json:"created"`
+	Updated        int64    `json:"updated"`
+}
+
+// NotificationChannelCreateInput is used to create a notification channel.
+type NotificationChannelCreateInput struct {
+	Identifier     string                       `json:"identifier"`
+	DisplayName    string                       `json:"display_name"`
+	Type           enum.NotificationChannelType `json:"type"`
+	URL            string                       `json:"url"`
+	Enabled        bool                         `json:"enabled"`
+	Events         []enum.NotificationEvent     `json:"events"`
+	RepoPatterns   []string                     `json:"repo_patterns"`
+	BranchPatterns []string                     `json:"branch_patterns"`
+}
+
+// NotificationChannelUpdateInput is used to update a notification channel.
+type NotificationChannelUpdateInput struct {
+	Identifier     *string                       `json:"identifier"`
+	DisplayName    *string                       `json:"display_name"`
+	Type           *enum.NotificationChannelType `json:"type"`
+	URL            *string                       `json:"url"`
+	Enabled        *bool                         `json:"enabled"`
+	Events         []enum.NotificationEvent      `json:"events"`
+	RepoPatterns   []string                      `json:"repo_patterns"`
+	BranchPatterns []string                      `json:"branch_patterns"`
+}
+
+// NotificationTemplate is the subject and body template of a notification email.
+type NotificationTemplate struct {
+	Name        enum.NotificationTemplate       `json:"name"`
+	Source      enum.NotificationTemplateSource `json:"source"`
+	Subject     string                          `json:"subject"`
+	Body        string                          `json:"body"`
+	ContentType enum.NotificationContentType    `json:"content_type"`
+	// UpdatedBy and Updated are only set for custom templates.
+	UpdatedBy *int64 `json:"updated_by,omitempty"`
+	Updated   int64  `json:"updated,omitempty"`
+}
+
+// NotificationTemplateInput is used to customize or preview the template of a notification email.
+type NotificationTemplateInput struct {
+	Subject     string                       `json:"subject"`
+	Body        string                       `json:"body"`
+	ContentType enum.NotificationContentType `json:"content_type"`
+}
+
+// NotificationEmail is a notification email rendered from its template.
+type NotificationEmail struct {
+	Subject     string                       `json:"subject"`
+	Body        string                       `json:"body"`
+	ContentType enum.NotificationContentType `json:"content_type"`
+}
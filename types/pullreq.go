@@ -43,14 +43,17 @@ type PullReq struct {
 	Title       string `json:"title"`
 	Description string `json:"description"`
 
-	SourceRepoID *int64 `json:"source_repo_id"`
-	SourceBranch string `json:"source_branch"`
-	SourceSHA    string `json:"source_sha"`
-	TargetRepoID int64  `json:"target_repo_id"`
-	TargetBranch string `json:"target_branch"`
+	SourceRepoID *int64           `json:"source_repo_id"`
+	SourceBranch string           `json:"source_branch"`
+	SourceSHA    string           `json:"source_sha"`
+	TargetRepoID int64            `json:"target_repo_id"`
+	TargetBranch string           `json:"target_branch"`
+	Flow         enum.PullReqFlow `json:"flow"`
 
 	ActivitySeq int64 `json:"-"` // not returned, because it's a server's internal field
 
+	MilestoneID *int64 `json:"milestone_id,omitempty"`
+
 	MergedBy                *int64            `json:"-"` // not returned, because the merger info is in the Merger field
 	Merged                  *int64            `json:"merged"`
 	MergeMethod             *enum.MergeMethod `json:"merge_method"`
@@ -72,6 +75,10 @@ type PullReq struct {
 	Labels       []*LabelPullReqAssignmentInfo `json:"labels,omitempty"`
 	CheckSummary *CheckCountSummary            `json:"check_summary,omitempty"`
 	Rules        []RuleInfo                    `json:"rules,omitempty"`
+	Stack        *PullReqStack                 `json:"stack,omitempty"`
+	Reactions    []PullReqReactionSummary      `json:"reactions,omitempty"`
+	Assignees    []PrincipalInfo               `json:"assignees,omitempty"`
+	Milestone    *MilestoneInfo                `json:"milestone,omitempty"`
 
 	SourceRepo *RepositoryCore `json:"source_repo,omitempty"`
 }
@@ -171,6 +178,8 @@ type PullReqFilter struct {
 	ReviewerID         int64                        `json:"reviewer_id"`
 	ReviewDecisions    []enum.PullReqReviewDecision `json:"review_decisions"`
 	MentionedID        int64                        `json:"mentioned_id"`
+	AssigneeID         []int64                      `json:"assignee_id"`
+	MilestoneID        []int64                      `json:"milestone_id"`
 	ExcludeDescription bool                         `json:"exclude_description"`
 	MergeCheckStatus   *enum.MergeCheckStatus       `json:"merge_check_status,omitempty"`
 	RebaseCheckStatus  *enum.MergeCheckStatus       `json:"rebase_check_status,omitempty"`
@@ -182,6 +191,7 @@ type PullReqFilter struct {
 	// internal use only
 	SpaceIDs        []int64
 	RepoIDBlacklist []int64
+	Flow            enum.PullReqFlow
 }
 
 type PullReqMetadataOptions struct {
@@ -224,6 +234,26 @@ type PullReqReviewer struct {
 	AddedBy  PrincipalInfo `json:"added_by"`
 }
 
+// PullReqAssignee holds a principal assigned to the pull request.
+type PullReqAssignee struct {
+	PullReqID   int64 `json:"-"`
+	PrincipalID int64 `json:"-"`
+
+	CreatedBy int64 `json:"-"`
+	Created   int64 `json:"created"`
+
+	Assignee PrincipalInfo `json:"assignee"`
+	AddedBy  PrincipalInfo `json:"added_by"`
+}
+
+// PullReqTemplate is a pull request description template stored in the repository.
+type PullReqTemplate struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Content   string `json:"content"`
+	IsDefault bool   `json:"is_default"`
+}
+
 type UserGroupReviewer struct {
 	PullReqID   int64 `json:"-"`
 	UserGroupID int64 `json:"-"`
@@ -298,6 +328,9 @@ type MergeResponse struct {
 	RequiresCommentResolution        bool `json:"requires_comment_resolution,omitempty"`
 	RequiresNoChangeRequests         bool `json:"requires_no_change_requests,omitempty"`
 	RequiresBypassMessage            bool `json:"requires_bypass_message,omitempty"`
+
+	// RuleWarnings are violations of rules configured to only warn, they don't block the merge.
+	RuleWarnings []RuleViolations `json:"rule_warnings,omitempty"`
 }
 
 type MergeViolations struct {
@@ -306,6 +339,58 @@ type MergeViolations struct {
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
 
+// MergeConflictFileContent is a version of a conflicted file.
+type MergeConflictFileContent struct {
+	SHA      string                   `json:"sha"`
+	Encoding enum.ContentEncodingType `json:"encoding"`
+	Data     string                   `json:"data"`
+	Size     int64                    `json:"size"`
+	DataSize int64                    `json:"data_size"`
+}
+
+// MergeConflictFile holds all versions of a conflicted file.
+// A version is nil if the file doesn't exist in the corresponding commit.
+type MergeConflictFile struct {
+	Path   string                    `json:"path"`
+	Base   *MergeConflictFileContent `json:"base"`
+	Source *MergeConflictFileContent `json:"source"`
+	Target *MergeConflictFileContent `json:"target"`
+	// Merged is the content of the file with the conflict markers.
+	Merged *MergeConflictFileContent `json:"merged"`
+}
+
+type PullReqMergeConflicts struct {
+	SourceSHA    string              `json:"source_sha"`
+	TargetSHA    string              `json:"target_sha"`
+	MergeBaseSHA string              `json:"merge_base_sha"`
+	Files        []MergeConflictFile `json:"files"`
+}
+
+type ResolveConflictsResponse struct {
+	SHA            string           `json:"sha,omitempty"`
+	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
+	DryRunRules    bool             `json:"dry_run_rules,omitempty"`
+}
+
+// PullReqStack describes the position of a pull request in a stack of pull requests.
+// A pull request is stacked on top of another pull request of the same repository if it targets its source branch.
+type PullReqStack struct {
+	// Ancestors are the open pull requests below the pull request, starting with the one it directly depends on.
+	Ancestors []PullReqStackEntry `json:"ancestors,omitempty"`
+	// Dependents are the open pull requests that target the source branch of the pull request.
+	Dependents []PullReqStackEntry `json:"dependents,omitempty"`
+}
+
+// PullReqStackEntry is a pull request in a stack of pull requests.
+type PullReqStackEntry struct {
+	Number       int64             `json:"number"`
+	Title        string            `json:"title"`
+	State        enum.PullReqState `json:"state"`
+	IsDraft      bool              `json:"is_draft"`
+	SourceBranch string            `json:"source_branch"`
+	TargetBranch string            `json:"target_branch"`
+}
+
 type PullReqRepo struct {
 	PullRequest *PullReq        `json:"pull_request"`
 	Repository  *RepositoryCore `json:"repository"`
@@ -59,6 +59,7 @@ type PullReqActivity struct {
 	// used only in response
 	Mentions      map[int64]*PrincipalInfo `json:"mentions,omitempty"`
 	GroupMentions map[int64]*UserGroupInfo `json:"user_group_mentions,omitempty"`
+	Reactions     []PullReqReactionSummary `json:"reactions,omitempty"`
 }
 
 func (a *PullReqActivity) IsValidCodeComment() bool {
@@ -251,3 +251,51 @@ type PullRequestActivityPayloadAutoMergeDisabled struct {
 func (a *PullRequestActivityPayloadAutoMergeDisabled) ActivityType() enum.PullReqActivityType {
 	return enum.PullReqActivityTypeAutoMergeUnsupportedMergeMethod
 }
+
+type PullRequestActivityPayloadAssigneeAdd struct {
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (a *PullRequestActivityPayloadAssigneeAdd) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAssigneeAdd
+}
+
+type PullRequestActivityPayloadAssigneeDelete struct {
+	PrincipalID int64 `json:"principal_id"`
+}
+
+func (a *PullRequestActivityPayloadAssigneeDelete) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeAssigneeDelete
+}
+
+type PullRequestActivityPayloadMilestoneChange struct {
+	Old *MilestoneInfo `json:"old,omitempty"`
+	New *MilestoneInfo `json:"new,omitempty"`
+}
+
+func (a *PullRequestActivityPayloadMilestoneChange) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeMilestoneChange
+}
+
+// PullRequestActivityPayloadCommand is the system response to a slash command found in a pull request comment.
+type PullRequestActivityPayloadCommand struct {
+	CommentID int64  `json:"comment_id"`
+	Command   string `json:"command"`
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+}
+
+func (a *PullRequestActivityPayloadCommand) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeCommand
+}
+
+// PullRequestActivityPayloadStackRebaseConflict is written when a stacked pull request, retargeted after
+// the pull request it depended on got merged, can't be rebased onto its new target branch because of conflicts.
+type PullRequestActivityPayloadStackRebaseConflict struct {
+	TargetBranch  string   `json:"target_branch"`
+	ConflictFiles []string `json:"conflict_files"`
+}
+
+func (a *PullRequestActivityPayloadStackRebaseConflict) ActivityType() enum.PullReqActivityType {
+	return enum.PullReqActivityTypeStackRebaseConflict
+}
@@ -0,0 +1,45 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import "github.com/harness/gitness/types/enum"
+
+// PullReqReaction represents an emoji reaction of a principal
+// to a pull request description or to a pull request comment.
+type PullReqReaction struct {
+	ID        int64 `json:"-"`
+	PullReqID int64 `json:"-"`
+	// ActivityID is the ID of the comment, it's nil for reactions to the pull request description.
+	ActivityID  *int64               `json:"-"`
+	PrincipalID int64                `json:"-"`
+	Reaction    enum.PullReqReaction `json:"reaction"`
+	Created     int64                `json:"created"`
+}
+
+// PullReqReactionSummary holds the aggregated count of a single reaction.
+type PullReqReactionSummary struct {
+	Reaction enum.PullReqReaction `json:"reaction"`
+	Count    int                  `json:"count"`
+	// Reacted is true if the current principal is among the principals who reacted.
+	Reacted bool `json:"reacted"`
+}
+
+// PullReqReactions holds the reactions to a pull request description or to a pull request comment.
+type PullReqReactions struct {
+	PullReqNumber int64 `json:"pullreq_number"`
+	// ActivityID is the ID of the comment, it's nil for reactions to the pull request description.
+	ActivityID *int64                   `json:"activity_id,omitempty"`
+	Reactions  []PullReqReactionSummary `json:"reactions"`
+}
@@ -0,0 +1,93 @@
+// Copyright 2023 Harness, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+// ReviewAnalyticsFilter selects the pull requests included in the review analytics.
+type ReviewAnalyticsFilter struct {
+	CreatedFilter
+	LabelID []int64 `json:"label_id"`
+	ValueID []int64 `json:"value_id"`
+
+	// internal use only
+	RepoID   int64   `json:"-"`
+	SpaceIDs []int64 `json:"-"`
+}
+
+// PullReqReviewStats holds review timestamps and size of a single pull request.
+type PullReqReviewStats struct {
+	PullReqID     int64
+	Created       int64
+	Merged        *int64
+	FirstReview   *int64
+	FirstApproval *int64
+	ReviewRounds  int64
+	Additions     *int64
+	Deletions     *int64
+}
+
+// ReviewerStats holds review activity of a single reviewer.
+type ReviewerStats struct {
+	PrincipalID     int64
+	Reviews         int64
+	Approvals       int64
+	ChangeRequests  int64
+	PendingRequests int64
+}
+
+// ReviewAnalytics holds pull request review metrics.
+type ReviewAnalytics struct {
+	PullReqCount      int64               `json:"pullreq_count"`
+	MergedCount       int64               `json:"merged_count"`
+	TimeToFirstReview DurationStats       `json:"time_to_first_review"`
+	TimeToApproval    DurationStats       `json:"time_to_approval"`
+	TimeToMerge       DurationStats       `json:"time_to_merge"`
+	ReviewRounds      CountStats          `json:"review_rounds"`
+	ReviewerLoad      []ReviewerLoad      `json:"reviewer_load"`
+	SizeDistribution  []PullReqSizeBucket `json:"size_distribution"`
+}
+
+// DurationStats summarizes durations, in milliseconds, measured over Count pull requests.
+type DurationStats struct {
+	Count   int64 `json:"count"`
+	Average int64 `json:"average"`
+	Median  int64 `json:"median"`
+	P90     int64 `json:"p90"`
+}
+
+// CountStats summarizes counts measured over Count pull requests.
+type CountStats struct {
+	Count   int64   `json:"count"`
+	Average float64 `json:"average"`
+	Median  int64   `json:"median"`
+	Max     int64   `json:"max"`
+}
+
+// ReviewerLoad holds review activity of a reviewer.
+type ReviewerLoad struct {
+	Reviewer        *PrincipalInfo `json:"reviewer"`
+	Reviews         int64          `json:"reviews"`
+	Approvals       int64          `json:"approvals"`
+	ChangeRequests  int64          `json:"change_requests"`
+	PendingRequests int64          `json:"pending_requests"`
+}
+
+// PullReqSizeBucket holds the number of pull requests with at most MaxLines changed lines
+// and more changed lines than the previous bucket. MaxLines of the last bucket is 0, meaning no upper limit.
+// Pull requests without known size are not counted.
+type PullReqSizeBucket struct {
+	Name     string `json:"name"`
+	MaxLines int64  `json:"max_lines"`
+	Count    int64  `json:"count"`
+}
@@ -225,6 +225,32 @@ type DryRunRulesOutput struct {
 	RuleViolations []RuleViolations `json:"rule_violations,omitempty"`
 }
 
+// RuleSimulation holds the outcome of evaluating a rule against the recent activity of a repository.
+type RuleSimulation struct {
+	PullReqs []RuleSimulationPullReq `json:"pullreqs"`
+	Pushes   []RuleSimulationPush    `json:"pushes"`
+}
+
+// RuleSimulationPullReq holds the outcome of evaluating a rule against a merged pull request.
+type RuleSimulationPullReq struct {
+	Number     int64            `json:"number"`
+	Title      string           `json:"title"`
+	Merged     *int64           `json:"merged"`
+	Merger     *PrincipalInfo   `json:"merger"`
+	Blocked    bool             `json:"blocked"`
+	Violations []RuleViolations `json:"violations,omitempty"`
+}
+
+// RuleSimulationPush holds the outcome of evaluating a rule against a recent branch or tag push.
+type RuleSimulationPush struct {
+	Ref        string           `json:"ref"`
+	SHA        string           `json:"sha"`
+	Pushed     int64            `json:"pushed,omitempty"`
+	Pusher     *PrincipalInfo   `json:"pusher,omitempty"`
+	Blocked    bool             `json:"blocked"`
+	Violations []RuleViolations `json:"violations,omitempty"`
+}
+
 type RuleParentInfo struct {
 	Type enum.RuleParent
 	ID   int64
@@ -45,7 +45,10 @@ type Webhook struct {
 	Enabled               bool                         `json:"enabled" yaml:"enabled"`
 	Insecure              bool                         `json:"insecure" yaml:"insecure"`
 	Triggers              []enum.WebhookTrigger        `json:"triggers" yaml:"triggers"`
+	Format                enum.WebhookFormat           `json:"format" yaml:"format"`
+	SignatureMode         enum.WebhookSignatureMode    `json:"signature_mode" yaml:"signature_mode"`
 	LatestExecutionResult *enum.WebhookExecutionResult `json:"latest_execution_result,omitempty" yaml:"-"`
+	ConsecutiveFailures   int64                        `json:"consecutive_failures" yaml:"-"`
 	ExtraHeaders          []ExtraHeader                `json:"extra_headers,omitempty" yaml:"-"`
 }
 
@@ -99,14 +102,16 @@ type WebhookCreateInput struct {
 	UID        string `json:"uid" deprecated:"true"`
 	Identifier string `json:"identifier"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
-	DisplayName  string                `json:"display_name"`
-	Description  string                `json:"description"`
-	URL          string                `json:"url"`
-	Secret       string                `json:"secret"`
-	Enabled      bool                  `json:"enabled"`
-	Insecure     bool                  `json:"insecure"`
-	Triggers     []enum.WebhookTrigger `json:"triggers"`
-	ExtraHeaders []ExtraHeader         `json:"extra_headers,omitempty"`
+	DisplayName   string                    `json:"display_name"`
+	Description   string                    `json:"description"`
+	URL           string                    `json:"url"`
+	Secret        string                    `json:"secret"`
+	Enabled       bool                      `json:"enabled"`
+	Insecure      bool                      `json:"insecure"`
+	Triggers      []enum.WebhookTrigger     `json:"triggers"`
+	Format        enum.WebhookFormat        `json:"format"`
+	SignatureMode enum.WebhookSignatureMode `json:"signature_mode"`
+	ExtraHeaders  []ExtraHeader             `json:"extra_headers,omitempty"`
 }
 
 type WebhookSignatureMetadata struct {
@@ -119,14 +124,16 @@ type WebhookUpdateInput struct {
 	UID        *string `json:"uid" deprecated:"true"`
 	Identifier *string `json:"identifier"`
 	// TODO [CODE-1364]: Remove once UID/Identifier migration is completed.
-	DisplayName  *string               `json:"display_name"`
-	Description  *string               `json:"description"`
-	URL          *string               `json:"url"`
-	Secret       *string               `json:"secret"`
-	Enabled      *bool                 `json:"enabled"`
-	Insecure     *bool                 `json:"insecure"`
-	Triggers     []enum.WebhookTrigger `json:"triggers"`
-	ExtraHeaders []ExtraHeader         `json:"extra_headers,omitempty"`
+	DisplayName   *string                    `json:"display_name"`
+	Description   *string                    `json:"description"`
+	URL           *string                    `json:"url"`
+	Secret        *string                    `json:"secret"`
+	Enabled       *bool                      `json:"enabled"`
+	Insecure      *bool                      `json:"insecure"`
+	Triggers      []enum.WebhookTrigger      `json:"triggers"`
+	Format        *enum.WebhookFormat        `json:"format"`
+	SignatureMode *enum.WebhookSignatureMode `json:"signature_mode"`
+	ExtraHeaders  []ExtraHeader              `json:"extra_headers,omitempty"`
 }
 
 // WebhookExecution represents a single execution of a webhook.
@@ -170,6 +177,24 @@ type WebhookFilter struct {
 	SkipInternal bool             `json:"-"`
 }
 
+// WebhookRedeliverInput is used to redeliver the failed executions of a webhook.
+type WebhookRedeliverInput struct {
+	// Since is the time (unix millis) from which on failed executions are redelivered.
+	Since int64 `json:"since"`
+}
+
+// WebhookRedeliverOutput contains the number of queued redeliveries.
+type WebhookRedeliverOutput struct {
+	Count int `json:"count"`
+}
+
+// WebhookSigningKey describes the public key used to verify webhook requests signed with the ed25519 signature mode.
+type WebhookSigningKey struct {
+	Algorithm    string `json:"algorithm"`
+	PublicKey    string `json:"public_key"`
+	PublicKeyPEM string `json:"public_key_pem"`
+}
+
 // WebhookExecutionFilter stores WebhookExecution query parameters for listing.
 type WebhookExecutionFilter struct {
 	Page int `json:"page"`
@@ -200,7 +225,10 @@ type WebhookCore struct {
 	Enabled               bool
 	Insecure              bool
 	Triggers              []enum.WebhookTrigger
+	Format                enum.WebhookFormat
+	SignatureMode         enum.WebhookSignatureMode
 	LatestExecutionResult *enum.WebhookExecutionResult
+	ConsecutiveFailures   int64
 	SecretIdentifier      string
 	SecretSpaceID         int64
 	ExtraHeaders          []ExtraHeader